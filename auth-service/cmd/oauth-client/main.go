@@ -0,0 +1,77 @@
+// oauth-client регистрирует OAuth клиента для доступа к /oauth/introspect и /oauth/revoke,
+// для входа через OpenID Connect или для получения токенов сервиса (client_credentials). Секрет выводится один раз, в базе хранится только его хеш.
+//
+//	go run ./cmd/oauth-client -client-id api-gateway -name "API Gateway" -scopes "token:introspect token:revoke"
+//	go run ./cmd/oauth-client -client-id photo-app -name "Photo App" -scopes "" -redirect-uris "https://photo.example.com/callback"
+//	go run ./cmd/oauth-client -client-id photo-spa -scopes "" -redirect-uris "https://photo.example.com/callback" -public
+//	go run ./cmd/oauth-client -client-id post-service -scopes "auth:validate auth:roles.read" -audiences auth-service
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"social-network/auth-service/internal/config"
+	"social-network/auth-service/internal/domain"
+	database "social-network/auth-service/internal/infrastructure/db"
+	"social-network/auth-service/internal/infrastructure/postgres"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/logger"
+)
+
+func main() {
+	clientID := flag.String("client-id", "", "уникальный идентификатор клиента")
+	name := flag.String("name", "", "название клиента")
+	scopes := flag.String("scopes", service.ScopeTokenIntrospect, "разрешенные scope через пробел или запятую")
+	redirectURIs := flag.String("redirect-uris", "", "адреса возврата OpenID Connect через пробел или запятую")
+	audiences := flag.String("audiences", "", "сервисы, для которых клиент получает токены через client_credentials")
+	public := flag.Bool("public", false, "публичный клиент без секрета (SPA, мобильное приложение)")
+	skipConsent := flag.Bool("skip-consent", false, "не спрашивать согласие пользователя (только для собственных приложений)")
+	flag.Parse()
+
+	if *clientID == "" {
+		log.Fatal("-client-id is required")
+	}
+	if *name == "" {
+		*name = *clientID
+	}
+
+	cfg := config.Load()
+	db, err := database.NewDatabase(&cfg.Database, logger.NewCustomLogger(cfg.Logger.ServiceName, "error", nil))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var secret, secretHash string
+	if !*public {
+		secret, secretHash = service.GenerateClientSecret()
+	}
+	client := domain.NewOAuthClient(*clientID, secretHash, *name, service.NormalizeScopes(*scopes))
+	client.SetRedirectURIs(service.NormalizeScopes(*redirectURIs))
+	client.SetAudiences(service.NormalizeScopes(*audiences))
+	client.SetPublic(*public)
+	client.SetSkipConsent(*skipConsent)
+
+	if err := postgres.NewOAuthClientRepository(db.GetPool()).Create(ctx, client); err != nil {
+		log.Fatalf("Failed to create OAuth client: %v", err)
+	}
+
+	fmt.Printf("client_id:     %s\n", client.ClientID())
+	if !client.IsPublic() {
+		fmt.Printf("client_secret: %s\n", secret)
+	}
+	fmt.Printf("scopes:        %v\n", client.Scopes())
+	if len(client.RedirectURIs()) > 0 {
+		fmt.Printf("redirect_uris: %v\n", client.RedirectURIs())
+	}
+	if len(client.Audiences()) > 0 {
+		fmt.Printf("audiences:     %v\n", client.Audiences())
+	}
+}
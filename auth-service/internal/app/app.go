@@ -7,6 +7,9 @@ import (
 	"os/signal"
 	"social-network/auth-service/internal/config"
 	database "social-network/auth-service/internal/infrastructure/db"
+	"social-network/auth-service/internal/infrastructure/keys"
+	"social-network/auth-service/internal/infrastructure/mailer"
+	"social-network/auth-service/internal/infrastructure/outbox"
 	"social-network/auth-service/internal/service"
 	"social-network/auth-service/pkg/logger"
 	"sync"
@@ -27,11 +30,24 @@ type App struct {
 	httpServer *httpTransport.Server
 	grpcServer *grpcTransport.Server
 	database   *database.Database
+	keySet     *keys.KeySet
+	relay      *outbox.Relay
+	mailer     *mailer.AsyncMailer
 
 	// Сервисы
-	authService       *service.AuthService
-	jwtService        *service.JWTService
-	validationService *service.ValidationService
+	authService          *service.AuthService
+	emailService         *service.EmailService
+	jwtService           *service.JWTService
+	tokenRevocation      *service.TokenRevocationService
+	oauthService         *service.OAuthService
+	oidcService          *service.OIDCService
+	passkeyService       *service.PasskeyService
+	socialLoginService   *service.SocialLoginService
+	personalAccessTokens *service.PersonalAccessTokenService
+	roleService          *service.RoleService
+	impersonation        *service.ImpersonationService
+	auditService         *service.AuditService
+	validationService    *service.ValidationService
 
 	// Контекст для graceful shutdown
 	ctx    context.Context
@@ -113,6 +129,33 @@ func (a *App) Run() error {
 		}
 	}()
 
+	// Следим за ротацией ключей подписи
+	go a.keySet.Watch(a.ctx, a.config.JWT.KeyReloadInterval)
+
+	// Публикуем доменные события из outbox
+	go a.relay.Run(a.ctx)
+
+	// Отправляем письма из очереди
+	go a.mailer.Run(a.ctx)
+
+	// Удаляем отзывы access токенов, которые истекли сами
+	go a.tokenRevocation.RunCleanup(a.ctx, a.config.Revocation.CleanupInterval)
+
+	// Удаляем незавершенные церемонии WebAuthn
+	go a.passkeyService.RunCleanup(a.ctx, a.config.WebAuthn.CleanupInterval)
+
+	// Удаляем истекшие коды авторизации OpenID Connect
+	go a.oidcService.RunCleanup(a.ctx, a.config.OIDC.CleanupInterval)
+
+	// Удаляем незавершенные входы через внешних провайдеров
+	go a.socialLoginService.RunCleanup(a.ctx, a.config.Social.CleanupInterval)
+
+	// Снимаем временные роли с истекшим сроком
+	go a.authService.RunRoleExpiry(a.ctx, a.config.Roles.ExpiryInterval)
+
+	// Снимаем временные блокировки пользователей с истекшим сроком
+	go a.authService.RunSuspensionExpiry(a.ctx, a.config.Users.SuspensionCheckInterval)
+
 	a.logger.Info("All servers started successfully")
 
 	// Ожидаем сигнал завершения или ошибку
@@ -188,19 +231,58 @@ func (a *App) initDatabase() error {
 }
 
 func (a *App) initServices() error {
+	// Ключи подписи access токенов
+	keySet, err := keys.NewKeySet(
+		a.config.JWT.KeysDir,
+		a.config.JWT.SigningKeyID,
+		a.config.JWT.KeyGracePeriod,
+		a.logger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+	a.keySet = keySet
+
 	// JWT сервис
 	a.jwtService = service.NewJWTService(
-		[]byte(a.config.JWT.AccessSecret),
+		a.keySet,
 		[]byte(a.config.JWT.RefreshSecret),
 		a.config.JWT.Issuer,
 	)
 
-	// Сервис валидации
-	a.validationService = service.NewValidationService()
-
 	// Сервис аутентификации с использованием builder
 	builder := NewBuilder(a).WithDatabase(a.database.GetPool())
+
+	// Сервис валидации с правилами пароля
+	a.validationService, err = builder.BuildValidationService()
+	if err != nil {
+		return fmt.Errorf("failed to initialize password policy: %w", err)
+	}
+
+	// Отправка писем
+	a.mailer, err = builder.BuildMailer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize mailer: %w", err)
+	}
+	a.emailService, err = builder.BuildEmailService(a.mailer)
+	if err != nil {
+		return fmt.Errorf("failed to initialize email service: %w", err)
+	}
+
+	a.tokenRevocation = builder.BuildTokenRevocationService()
+	a.auditService = builder.BuildAuditService()
 	a.authService = builder.BuildAuthService()
+	a.oauthService = builder.BuildOAuthService()
+	a.oidcService = builder.BuildOIDCService()
+	a.passkeyService, err = builder.BuildPasskeyService()
+	if err != nil {
+		return fmt.Errorf("failed to initialize passkey service: %w", err)
+	}
+	a.socialLoginService = builder.BuildSocialLoginService()
+	a.personalAccessTokens = builder.BuildPersonalAccessTokenService()
+	a.roleService = builder.BuildRoleService()
+	a.impersonation = builder.BuildImpersonationService()
+	a.relay = builder.BuildOutboxRelay()
 
 	a.logger.Info("Services initialized")
 	return nil
@@ -212,6 +294,15 @@ func (a *App) initTransports() error {
 		a.config,
 		a.authService,
 		a.jwtService,
+		a.tokenRevocation,
+		a.oauthService,
+		a.oidcService,
+		a.passkeyService,
+		a.socialLoginService,
+		a.personalAccessTokens,
+		a.roleService,
+		a.impersonation,
+		a.auditService,
 		a.validationService,
 		a.logger,
 		a.zapLogger,
@@ -222,6 +313,11 @@ func (a *App) initTransports() error {
 		a.config,
 		a.authService,
 		a.jwtService,
+		a.tokenRevocation,
+		a.passkeyService,
+		a.personalAccessTokens,
+		a.impersonation,
+		a.auditService,
 		a.validationService,
 		a.logger,
 	)
@@ -1,9 +1,19 @@
 package app
 
 import (
+	"fmt"
+	"net/http"
+	"social-network/auth-service/internal/infrastructure/mailer"
+	"social-network/auth-service/internal/infrastructure/memory"
+	"social-network/auth-service/internal/infrastructure/outbox"
 	"social-network/auth-service/internal/infrastructure/postgres"
+	"social-network/auth-service/internal/infrastructure/pwned"
 	"social-network/auth-service/internal/repository"
 	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/oidc"
+	"social-network/auth-service/pkg/password"
+	"social-network/auth-service/pkg/webauthn"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -35,6 +45,9 @@ func (b *Builder) BuildRepositories() (
 	repository.RefreshTokenRepository,
 	repository.EmailVerificationRepository,
 	repository.PasswordResetRepository,
+	repository.MagicLinkRepository,
+	repository.UserMFARepository,
+	repository.MFARecoveryCodeRepository,
 ) {
 	userRepo := postgres.NewUserRepository(b.db)
 	userAuthRepo := postgres.NewUserAuthRepository(b.db)
@@ -42,21 +55,283 @@ func (b *Builder) BuildRepositories() (
 	refreshTokenRepo := postgres.NewRefreshTokenRepository(b.db)
 	emailVerificationRepo := postgres.NewEmailVerificationRepository(b.db)
 	passwordResetRepo := postgres.NewPasswordResetRepository(b.db)
+	magicLinkRepo := postgres.NewMagicLinkRepository(b.db)
+	userMFARepo := postgres.NewUserMFARepository(b.db)
+	mfaRecoveryCodeRepo := postgres.NewMFARecoveryCodeRepository(b.db)
 
-	return userRepo, userAuthRepo, userRoleRepo, refreshTokenRepo, emailVerificationRepo, passwordResetRepo
+	return userRepo, userAuthRepo, userRoleRepo, refreshTokenRepo, emailVerificationRepo, passwordResetRepo, magicLinkRepo, userMFARepo, mfaRecoveryCodeRepo
 }
 
 // BuildAuthService создает сервис аутентификации
 func (b *Builder) BuildAuthService() *service.AuthService {
-	userRepo, userAuthRepo, userRoleRepo, refreshTokenRepo, emailVerificationRepo, passwordResetRepo := b.BuildRepositories()
+	userRepo, userAuthRepo, userRoleRepo, refreshTokenRepo, emailVerificationRepo, passwordResetRepo, magicLinkRepo, userMFARepo, mfaRecoveryCodeRepo := b.BuildRepositories()
 
 	return service.NewAuthService(
 		userRepo,
 		userAuthRepo,
 		userRoleRepo,
+		postgres.NewRoleRepository(b.db),
+		postgres.NewRoleChangeRepository(b.db),
 		refreshTokenRepo,
 		emailVerificationRepo,
 		passwordResetRepo,
+		magicLinkRepo,
+		userMFARepo,
+		mfaRecoveryCodeRepo,
+		postgres.NewTxManager(b.db),
+		b.app.emailService,
+		b.app.tokenRevocation,
+		b.app.auditService,
+		b.BuildPasswordHasher(),
+		b.app.validationService,
+		b.BuildLoginThrottle(),
+		b.app.config.MFA.TOTPIssuer,
 		b.app.logger,
 	)
 }
+
+// BuildOAuthService создает сервис introspection и revocation для OAuth клиентов
+func (b *Builder) BuildOAuthService() *service.OAuthService {
+	return service.NewOAuthService(
+		postgres.NewOAuthClientRepository(b.db),
+		b.app.authService,
+		b.app.jwtService,
+		b.app.tokenRevocation,
+		b.app.config.JWT.Issuer,
+		b.app.logger,
+	)
+}
+
+// BuildOIDCService создает сервер авторизации OpenID Connect
+func (b *Builder) BuildOIDCService() *service.OIDCService {
+	return service.NewOIDCService(
+		postgres.NewOAuthClientRepository(b.db),
+		postgres.NewAuthorizationCodeRepository(b.db),
+		postgres.NewOAuthConsentRepository(b.db),
+		b.app.authService,
+		b.app.jwtService,
+		b.app.tokenRevocation,
+		b.app.config.OIDC.LoginURL,
+		b.app.logger,
+	)
+}
+
+// BuildSocialLoginService создает сервис входа через внешних провайдеров OpenID Connect
+func (b *Builder) BuildSocialLoginService() *service.SocialLoginService {
+	cfg := b.app.config.Social
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	providers := make([]*oidc.Provider, 0, len(cfg.Providers))
+	for _, provider := range cfg.Providers {
+		providers = append(providers, oidc.NewProvider(oidc.Config{
+			Name:         provider.Name,
+			DisplayName:  provider.DisplayName,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		}, httpClient))
+	}
+
+	return service.NewSocialLoginService(
+		postgres.NewUserRepository(b.db),
+		postgres.NewUserAuthRepository(b.db),
+		postgres.NewUserIdentityRepository(b.db),
+		postgres.NewSocialLoginStateRepository(b.db),
+		postgres.NewWebAuthnCredentialRepository(b.db),
+		b.app.authService,
+		b.app.auditService,
+		oidc.NewRegistry(providers...),
+		cfg.StateTTL,
+		b.app.logger,
+	)
+}
+
+// BuildTokenRevocationService создает список отзыва access токенов с выбранным хранилищем
+func (b *Builder) BuildTokenRevocationService() *service.TokenRevocationService {
+	var repo repository.TokenRevocationRepository
+	switch b.app.config.Revocation.Store {
+	case "memory":
+		repo = memory.NewTokenRevocationRepository()
+	default:
+		repo = postgres.NewTokenRevocationRepository(b.db)
+	}
+
+	return service.NewTokenRevocationService(repo, b.app.jwtService, b.app.logger)
+}
+
+// BuildPasswordHasher создает хешер паролей: новые хеши создаются выбранным алгоритмом,
+// хеши другого алгоритма проверяются и заменяются при следующем входе
+func (b *Builder) BuildPasswordHasher() *password.Hasher {
+	cfg := b.app.config.Password
+
+	params := password.DefaultArgon2idParams()
+	params.Memory = uint32(cfg.Argon2Memory)
+	params.Iterations = uint32(cfg.Argon2Iterations)
+	params.Parallelism = uint8(cfg.Argon2Parallelism)
+
+	argon2id := password.NewArgon2id(params)
+	bcrypt := password.NewBcrypt(cfg.BcryptCost)
+
+	if cfg.Algorithm == "bcrypt" {
+		return password.NewHasher(bcrypt, argon2id)
+	}
+	return password.NewHasher(argon2id, bcrypt)
+}
+
+// BuildValidationService создает сервис валидации с правилами пароля из конфигурации.
+// Проверка по базе утечек включается, только если задан каталог с диапазонами хешей.
+func (b *Builder) BuildValidationService() (*service.ValidationService, error) {
+	cfg := b.app.config.Password
+
+	rules := []service.PasswordRule{
+		service.NewStrengthRule(cfg.MinStrengthScore),
+	}
+
+	if cfg.BreachedListDir != "" {
+		store, err := pwned.NewRangeStore(cfg.BreachedListDir)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, service.NewBreachedRule(store))
+	}
+
+	if cfg.HistorySize > 0 {
+		rules = append(rules, service.NewHistoryRule(
+			postgres.NewPasswordHistoryRepository(b.db),
+			b.BuildPasswordHasher(),
+			cfg.HistorySize,
+		))
+	}
+
+	return service.NewValidationService(rules...), nil
+}
+
+// BuildLoginThrottle создает защиту от перебора паролей с выбранным хранилищем счетчиков
+func (b *Builder) BuildLoginThrottle() *service.LoginThrottle {
+	cfg := b.app.config.Lockout
+
+	var repo repository.LoginAttemptRepository
+	switch cfg.Store {
+	case "memory":
+		repo = memory.NewLoginAttemptRepository()
+	default:
+		repo = postgres.NewLoginAttemptRepository(b.db)
+	}
+
+	return service.NewLoginThrottle(repo, service.LockoutPolicy{
+		MaxAccountFailures: cfg.MaxAccountFailures,
+		MaxIPFailures:      cfg.MaxIPFailures,
+		BaseLockout:        cfg.BaseDuration,
+		MaxLockout:         cfg.MaxDuration,
+		Window:             cfg.Window,
+	}, b.app.logger)
+}
+
+// BuildOutboxRelay создает relay доменных событий с выбранным publisher
+func (b *Builder) BuildOutboxRelay() *outbox.Relay {
+	cfg := b.app.config.Outbox
+
+	var publisher outbox.Publisher
+	switch cfg.Publisher {
+	case "memory":
+		publisher = outbox.NewInMemoryPublisher()
+	default:
+		publisher = outbox.NewLogPublisher(b.app.logger)
+	}
+
+	return outbox.NewRelay(b.db, publisher, cfg.PollInterval, cfg.BatchSize, b.app.logger)
+}
+
+// BuildMailer создает асинхронный mailer поверх выбранного способа доставки
+func (b *Builder) BuildMailer() (*mailer.AsyncMailer, error) {
+	cfg := b.app.config.Mail
+
+	var delivery mailer.Mailer
+	switch cfg.Driver {
+	case "smtp":
+		delivery = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "file":
+		fileMailer, err := mailer.NewFileMailer(cfg.FileDir, cfg.From)
+		if err != nil {
+			return nil, err
+		}
+		delivery = fileMailer
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+
+	return mailer.NewAsyncMailer(delivery, cfg.QueueSize, cfg.MaxAttempts, cfg.RetryBaseDelay, b.app.logger), nil
+}
+
+// BuildEmailService создает сервис отправки писем
+func (b *Builder) BuildEmailService(m mailer.Mailer) (*service.EmailService, error) {
+	cfg := b.app.config.Mail
+
+	templates, err := mailer.NewTemplates(cfg.DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewEmailService(m, templates, cfg.AppBaseURL, cfg.ResendCooldown, b.app.logger), nil
+}
+
+// BuildPersonalAccessTokenService создает сервис персональных токенов для ботов и интеграций
+func (b *Builder) BuildPersonalAccessTokenService() *service.PersonalAccessTokenService {
+	return service.NewPersonalAccessTokenService(
+		postgres.NewPersonalAccessTokenRepository(b.db),
+		b.app.authService,
+		b.app.config.PAT.MaxPerUser,
+		b.app.config.PAT.MaxLifetime,
+		b.app.logger,
+	)
+}
+
+// BuildImpersonationService создает сервис входа администратора от имени пользователя
+func (b *Builder) BuildImpersonationService() *service.ImpersonationService {
+	return service.NewImpersonationService(b.app.authService, b.app.jwtService, b.app.auditService, b.app.logger)
+}
+
+// BuildAuditService создает журнал аудита событий безопасности
+func (b *Builder) BuildAuditService() *service.AuditService {
+	return service.NewAuditService(postgres.NewAuditEventRepository(b.db), b.app.logger)
+}
+
+// BuildRoleService создает сервис управления ролями и их правами
+func (b *Builder) BuildRoleService() *service.RoleService {
+	return service.NewRoleService(
+		postgres.NewRoleRepository(b.db),
+		postgres.NewUserRoleRepository(b.db),
+		b.app.logger,
+	)
+}
+
+// BuildPasskeyService создает сервис регистрации и входа по passkeys (WebAuthn)
+func (b *Builder) BuildPasskeyService() (*service.PasskeyService, error) {
+	cfg := b.app.config.WebAuthn
+
+	relyingParty, err := webauthn.NewRelyingParty(webauthn.Config{
+		RPID:             cfg.RPID,
+		RPName:           cfg.RPName,
+		Origins:          cfg.Origins,
+		Timeout:          cfg.Timeout,
+		UserVerification: cfg.UserVerification,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewPasskeyService(
+		postgres.NewUserRepository(b.db),
+		postgres.NewUserAuthRepository(b.db),
+		postgres.NewWebAuthnCredentialRepository(b.db),
+		postgres.NewWebAuthnSessionRepository(b.db),
+		postgres.NewUserIdentityRepository(b.db),
+		relyingParty,
+		b.BuildPasswordHasher(),
+		b.app.authService,
+		b.app.auditService,
+		b.app.logger,
+	), nil
+}
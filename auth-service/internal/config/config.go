@@ -4,16 +4,29 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Logger   LoggerConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	JWT        JWTConfig
+	MFA        MFAConfig
+	WebAuthn   WebAuthnConfig
+	OIDC       OIDCConfig
+	Social     SocialLoginConfig
+	PAT        PersonalAccessTokenConfig
+	Roles      RolesConfig
+	Users      UsersConfig
+	Password   PasswordConfig
+	Lockout    LockoutConfig
+	Revocation RevocationConfig
+	Outbox     OutboxConfig
+	Mail       MailConfig
+	Logger     LoggerConfig
 }
 
 type ServerConfig struct {
@@ -35,6 +48,10 @@ type GRPCConfig struct {
 	ConnectionTimeout time.Duration
 	KeepaliveTime     time.Duration
 	KeepaliveTimeout  time.Duration
+	// ServiceAudience - audience, который должен быть в токене вызывающего сервиса
+	ServiceAudience string
+	// RequireServiceAuth отклоняет вызовы без токена сервиса; false оставляет их на время перехода
+	RequireServiceAuth bool
 }
 
 type DatabaseConfig struct {
@@ -53,9 +70,110 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	AccessSecret  string
-	RefreshSecret string
-	Issuer        string
+	RefreshSecret     string
+	Issuer            string
+	KeysDir           string
+	SigningKeyID      string
+	KeyGracePeriod    time.Duration
+	KeyReloadInterval time.Duration
+}
+
+type MFAConfig struct {
+	TOTPIssuer string
+}
+
+type WebAuthnConfig struct {
+	RPID             string
+	RPName           string
+	Origins          []string
+	Timeout          time.Duration
+	UserVerification string
+	CleanupInterval  time.Duration
+}
+
+type OIDCConfig struct {
+	PublicURL       string
+	LoginURL        string
+	CleanupInterval time.Duration
+}
+
+type SocialLoginConfig struct {
+	Providers       []SocialProviderConfig
+	StateTTL        time.Duration
+	CleanupInterval time.Duration
+}
+
+type SocialProviderConfig struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// PersonalAccessTokenConfig - MaxLifetime 0 разрешает бессрочные токены
+type PersonalAccessTokenConfig struct {
+	MaxPerUser  int
+	MaxLifetime time.Duration
+}
+
+// RolesConfig - ExpiryInterval задает, как часто снимаются временные роли с истекшим сроком
+type RolesConfig struct {
+	ExpiryInterval time.Duration
+}
+
+// UsersConfig - SuspensionCheckInterval задает, как часто снимаются блокировки с истекшим сроком
+type UsersConfig struct {
+	SuspensionCheckInterval time.Duration
+}
+
+type PasswordConfig struct {
+	Algorithm         string
+	Argon2Memory      int
+	Argon2Iterations  int
+	Argon2Parallelism int
+	BcryptCost        int
+	MinStrengthScore  int
+	BreachedListDir   string
+	HistorySize       int
+}
+
+type LockoutConfig struct {
+	Store              string
+	MaxAccountFailures int
+	MaxIPFailures      int
+	BaseDuration       time.Duration
+	MaxDuration        time.Duration
+	Window             time.Duration
+}
+
+type RevocationConfig struct {
+	Store           string
+	CleanupInterval time.Duration
+}
+
+type OutboxConfig struct {
+	Publisher    string
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+type MailConfig struct {
+	Driver         string
+	From           string
+	FileDir        string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
+	AppBaseURL     string
+	DefaultLocale  string
+	QueueSize      int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	ResendCooldown time.Duration
 }
 
 type LoggerConfig struct {
@@ -78,12 +196,14 @@ func Load() *Config {
 				IdleTimeout:  getDurationEnv("HTTP_IDLE_TIMEOUT", 60*time.Second),
 			},
 			GRPC: GRPCConfig{
-				Port:              getEnv("GRPC_PORT", "9090"),
-				MaxReceiveSize:    getIntEnv("GRPC_MAX_RECEIVE_SIZE", 4*1024*1024),
-				MaxSendSize:       getIntEnv("GRPC_MAX_SEND_SIZE", 4*1024*1024),
-				ConnectionTimeout: getDurationEnv("GRPC_CONNECTION_TIMEOUT", 5*time.Second),
-				KeepaliveTime:     getDurationEnv("GRPC_KEEPALIVE_TIME", 30*time.Second),
-				KeepaliveTimeout:  getDurationEnv("GRPC_KEEPALIVE_TIMEOUT", 5*time.Second),
+				Port:               getEnv("GRPC_PORT", "9090"),
+				MaxReceiveSize:     getIntEnv("GRPC_MAX_RECEIVE_SIZE", 4*1024*1024),
+				MaxSendSize:        getIntEnv("GRPC_MAX_SEND_SIZE", 4*1024*1024),
+				ConnectionTimeout:  getDurationEnv("GRPC_CONNECTION_TIMEOUT", 5*time.Second),
+				KeepaliveTime:      getDurationEnv("GRPC_KEEPALIVE_TIME", 30*time.Second),
+				KeepaliveTimeout:   getDurationEnv("GRPC_KEEPALIVE_TIMEOUT", 5*time.Second),
+				ServiceAudience:    getEnv("GRPC_SERVICE_AUDIENCE", "auth-service"),
+				RequireServiceAuth: getBoolEnv("GRPC_REQUIRE_SERVICE_AUTH", true),
 			},
 		},
 		Database: DatabaseConfig{
@@ -101,9 +221,85 @@ func Load() *Config {
 			ConnectTimeout:    getDurationEnv("DB_CONNECT_TIMEOUT", 10*time.Second),
 		},
 		JWT: JWTConfig{
-			AccessSecret:  getEnv("JWT_ACCESS_SECRET", "your-access-secret-key"),
-			RefreshSecret: getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key"),
-			Issuer:        getEnv("JWT_ISSUER", "auth-service"),
+			RefreshSecret:     getEnv("JWT_REFRESH_SECRET", "your-refresh-secret-key"),
+			Issuer:            getEnv("JWT_ISSUER", "auth-service"),
+			KeysDir:           getEnv("JWT_KEYS_DIR", ""),
+			SigningKeyID:      getEnv("JWT_SIGNING_KEY_ID", ""),
+			KeyGracePeriod:    getDurationEnv("JWT_KEY_GRACE_PERIOD", time.Hour),
+			KeyReloadInterval: getDurationEnv("JWT_KEY_RELOAD_INTERVAL", time.Minute),
+		},
+		MFA: MFAConfig{
+			TOTPIssuer: getEnv("MFA_TOTP_ISSUER", "Social Network"),
+		},
+		WebAuthn: WebAuthnConfig{
+			RPID:             getEnv("WEBAUTHN_RP_ID", "localhost"),
+			RPName:           getEnv("WEBAUTHN_RP_NAME", "Social Network"),
+			Origins:          getListEnv("WEBAUTHN_RP_ORIGINS", []string{"http://localhost:3000"}),
+			Timeout:          getDurationEnv("WEBAUTHN_TIMEOUT", 5*time.Minute),
+			UserVerification: getEnv("WEBAUTHN_USER_VERIFICATION", "preferred"),
+			CleanupInterval:  getDurationEnv("WEBAUTHN_SESSION_CLEANUP_INTERVAL", 10*time.Minute),
+		},
+		OIDC: OIDCConfig{
+			PublicURL:       getEnv("OIDC_PUBLIC_URL", "http://localhost:8080"),
+			LoginURL:        getEnv("OIDC_LOGIN_URL", "http://localhost:3000/oauth/login"),
+			CleanupInterval: getDurationEnv("OIDC_CODE_CLEANUP_INTERVAL", 10*time.Minute),
+		},
+		Social: SocialLoginConfig{
+			Providers:       loadSocialProviders(),
+			StateTTL:        getDurationEnv("SOCIAL_LOGIN_STATE_TTL", 10*time.Minute),
+			CleanupInterval: getDurationEnv("SOCIAL_LOGIN_CLEANUP_INTERVAL", 10*time.Minute),
+		},
+		PAT: PersonalAccessTokenConfig{
+			MaxPerUser:  getIntEnv("PAT_MAX_PER_USER", 50),
+			MaxLifetime: getDurationEnv("PAT_MAX_LIFETIME", 0),
+		},
+		Roles: RolesConfig{
+			ExpiryInterval: getDurationEnv("ROLE_EXPIRY_INTERVAL", time.Minute),
+		},
+		Users: UsersConfig{
+			SuspensionCheckInterval: getDurationEnv("USER_SUSPENSION_CHECK_INTERVAL", time.Minute),
+		},
+		Password: PasswordConfig{
+			Algorithm:         getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+			Argon2Memory:      getIntEnv("PASSWORD_ARGON2_MEMORY_KIB", 64*1024),
+			Argon2Iterations:  getIntEnv("PASSWORD_ARGON2_ITERATIONS", 3),
+			Argon2Parallelism: getIntEnv("PASSWORD_ARGON2_PARALLELISM", 2),
+			BcryptCost:        getIntEnv("PASSWORD_BCRYPT_COST", 12),
+			MinStrengthScore:  getIntEnv("PASSWORD_MIN_STRENGTH_SCORE", 3),
+			BreachedListDir:   getEnv("PASSWORD_BREACHED_LIST_DIR", ""),
+			HistorySize:       getIntEnv("PASSWORD_HISTORY_SIZE", 5),
+		},
+		Lockout: LockoutConfig{
+			Store:              getEnv("LOGIN_ATTEMPTS_STORE", "postgres"),
+			MaxAccountFailures: getIntEnv("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+			MaxIPFailures:      getIntEnv("LOGIN_MAX_IP_FAILURES", 50),
+			BaseDuration:       getDurationEnv("LOGIN_LOCKOUT_BASE", 30*time.Second),
+			MaxDuration:        getDurationEnv("LOGIN_LOCKOUT_MAX", 15*time.Minute),
+			Window:             getDurationEnv("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		},
+		Revocation: RevocationConfig{
+			Store:           getEnv("TOKEN_REVOCATION_STORE", "postgres"),
+			CleanupInterval: getDurationEnv("TOKEN_REVOCATION_CLEANUP_INTERVAL", 10*time.Minute),
+		},
+		Outbox: OutboxConfig{
+			Publisher:    getEnv("OUTBOX_PUBLISHER", "log"),
+			PollInterval: getDurationEnv("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:    getIntEnv("OUTBOX_BATCH_SIZE", 100),
+		},
+		Mail: MailConfig{
+			Driver:         getEnv("MAIL_DRIVER", "file"),
+			From:           getEnv("MAIL_FROM", "Social Network <no-reply@social-network.local>"),
+			FileDir:        getEnv("MAIL_FILE_DIR", "mail-outbox"),
+			SMTPHost:       getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:       getEnv("SMTP_PORT", "587"),
+			SMTPUsername:   getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
+			AppBaseURL:     getEnv("APP_BASE_URL", "http://localhost:3000"),
+			DefaultLocale:  getEnv("MAIL_DEFAULT_LOCALE", "en"),
+			QueueSize:      getIntEnv("MAIL_QUEUE_SIZE", 1000),
+			MaxAttempts:    getIntEnv("MAIL_MAX_ATTEMPTS", 5),
+			RetryBaseDelay: getDurationEnv("MAIL_RETRY_BASE_DELAY", 5*time.Second),
+			ResendCooldown: getDurationEnv("EMAIL_VERIFICATION_RESEND_COOLDOWN", time.Minute),
 		},
 		Logger: LoggerConfig{
 			Level:       getEnv("LOG_LEVEL", "info"),
@@ -136,3 +332,61 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// knownSocialIssuers - issuer известных провайдеров, которые можно не указывать в конфигурации
+var knownSocialIssuers = map[string]string{
+	"google": "https://accounts.google.com",
+}
+
+// loadSocialProviders читает провайдеров из SOCIAL_LOGIN_PROVIDERS (например, google,vk) и
+// параметры каждого из SOCIAL_LOGIN_<NAME>_*. Провайдеры без client ID пропускаются.
+func loadSocialProviders() []SocialProviderConfig {
+	redirectBaseURL := strings.TrimSuffix(getEnv("SOCIAL_LOGIN_REDIRECT_BASE_URL", "http://localhost:3000/auth/social/callback"), "/")
+
+	var providers []SocialProviderConfig
+	for _, name := range getListEnv("SOCIAL_LOGIN_PROVIDERS", nil) {
+		name = strings.ToLower(name)
+		prefix := "SOCIAL_LOGIN_" + strings.ToUpper(name) + "_"
+
+		provider := SocialProviderConfig{
+			Name:         name,
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:       getEnv(prefix+"ISSUER", knownSocialIssuers[name]),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", redirectBaseURL+"/"+name),
+			Scopes:       getListEnv(prefix+"SCOPES", nil),
+		}
+		if provider.ClientID == "" || provider.Issuer == "" {
+			log.Printf("Social login provider %q is skipped: issuer and client ID are required", name)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// getListEnv читает список значений, разделенных запятыми
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
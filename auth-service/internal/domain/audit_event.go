@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventType string
+
+// Типы событий журнала аудита
+const (
+	AuditLoginSucceeded         AuditEventType = "login.succeeded"
+	AuditLoginMFARequired       AuditEventType = "login.mfa_required"
+	AuditLoginFailed            AuditEventType = "login.failed"
+	AuditLogout                 AuditEventType = "logout"
+	AuditPasswordChanged        AuditEventType = "password.changed"
+	AuditPasswordResetRequested AuditEventType = "password.reset_requested"
+	AuditPasswordReset          AuditEventType = "password.reset"
+	AuditPasswordRemoved        AuditEventType = "password.removed"
+	AuditPasskeyRegistered      AuditEventType = "passkey.registered"
+	AuditPasskeyDeleted         AuditEventType = "passkey.deleted"
+	AuditEmailVerified          AuditEventType = "email.verified"
+	AuditMFAEnabled             AuditEventType = "mfa.enabled"
+	AuditMFADisabled            AuditEventType = "mfa.disabled"
+	AuditRoleGranted            AuditEventType = "role.granted"
+	AuditRoleRevoked            AuditEventType = "role.revoked"
+	AuditRoleExpired            AuditEventType = "role.expired"
+	AuditSessionRevoked         AuditEventType = "session.revoked"
+	AuditTokensRevoked          AuditEventType = "tokens.revoked"
+	AuditRefreshTokenReused     AuditEventType = "refresh_token.reused"
+	AuditUserSuspended          AuditEventType = "user.suspended"
+	AuditUserReactivated        AuditEventType = "user.reactivated"
+	AuditImpersonationStarted   AuditEventType = "impersonation.started"
+)
+
+// AuditEvent - запись журнала аудита. Журнал только дополняется: записи не изменяются
+// и не удаляются, в том числе вместе с пользователем.
+type AuditEvent struct {
+	id        uuid.UUID
+	eventType AuditEventType
+	actorID   *uuid.UUID // кто выполнил действие; nil для действий системы и неизвестных пользователей
+	targetID  *uuid.UUID // над чьим аккаунтом выполнено действие
+	ipAddress string
+	userAgent string
+	requestID string
+	details   map[string]string
+	createdAt time.Time
+}
+
+// Constructor
+func NewAuditEvent(eventType AuditEventType, actorID, targetID *uuid.UUID, details map[string]string) *AuditEvent {
+	return &AuditEvent{
+		id:        uuid.New(),
+		eventType: eventType,
+		actorID:   actorID,
+		targetID:  targetID,
+		details:   details,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (e *AuditEvent) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *AuditEvent) Type() AuditEventType {
+	return e.eventType
+}
+
+func (e *AuditEvent) ActorID() *uuid.UUID {
+	return e.actorID
+}
+
+func (e *AuditEvent) TargetID() *uuid.UUID {
+	return e.targetID
+}
+
+func (e *AuditEvent) IPAddress() string {
+	return e.ipAddress
+}
+
+func (e *AuditEvent) UserAgent() string {
+	return e.userAgent
+}
+
+func (e *AuditEvent) RequestID() string {
+	return e.requestID
+}
+
+func (e *AuditEvent) Details() map[string]string {
+	return e.details
+}
+
+func (e *AuditEvent) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// Setters
+func (e *AuditEvent) SetID(id uuid.UUID) {
+	e.id = id
+}
+
+func (e *AuditEvent) SetClient(ipAddress, userAgent, requestID string) {
+	e.ipAddress = ipAddress
+	e.userAgent = userAgent
+	e.requestID = requestID
+}
+
+func (e *AuditEvent) SetCreatedAt(createdAt time.Time) {
+	e.createdAt = createdAt
+}
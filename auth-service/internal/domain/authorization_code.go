@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CodeChallengeMethodS256 - единственный поддерживаемый метод PKCE (RFC 7636)
+const CodeChallengeMethodS256 = "S256"
+
+// AuthorizationCode - одноразовый код авторизации OAuth 2.0, который клиент обменивает
+// на токены. Хранится только хеш кода; обмен требует code_verifier от PKCE.
+type AuthorizationCode struct {
+	id                  uuid.UUID
+	codeHash            string
+	clientID            string
+	userID              uuid.UUID
+	redirectURI         string
+	scopes              []string
+	nonce               string
+	codeChallenge       string
+	codeChallengeMethod string
+	expiresAt           time.Time
+	isUsed              bool
+	createdAt           time.Time
+}
+
+// Constructor
+func NewAuthorizationCode(codeHash, clientID string, userID uuid.UUID, redirectURI string, scopes []string, expiresAt time.Time) *AuthorizationCode {
+	return &AuthorizationCode{
+		id:          uuid.New(),
+		codeHash:    codeHash,
+		clientID:    clientID,
+		userID:      userID,
+		redirectURI: redirectURI,
+		scopes:      scopes,
+		expiresAt:   expiresAt,
+		isUsed:      false,
+		createdAt:   time.Now(),
+	}
+}
+
+// Getters
+func (c *AuthorizationCode) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *AuthorizationCode) CodeHash() string {
+	return c.codeHash
+}
+
+func (c *AuthorizationCode) ClientID() string {
+	return c.clientID
+}
+
+func (c *AuthorizationCode) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *AuthorizationCode) RedirectURI() string {
+	return c.redirectURI
+}
+
+func (c *AuthorizationCode) Scopes() []string {
+	return c.scopes
+}
+
+func (c *AuthorizationCode) Nonce() string {
+	return c.nonce
+}
+
+func (c *AuthorizationCode) CodeChallenge() string {
+	return c.codeChallenge
+}
+
+func (c *AuthorizationCode) CodeChallengeMethod() string {
+	return c.codeChallengeMethod
+}
+
+func (c *AuthorizationCode) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+func (c *AuthorizationCode) IsUsed() bool {
+	return c.isUsed
+}
+
+func (c *AuthorizationCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Setters
+func (c *AuthorizationCode) SetID(id uuid.UUID) {
+	c.id = id
+}
+
+func (c *AuthorizationCode) SetNonce(nonce string) {
+	c.nonce = nonce
+}
+
+func (c *AuthorizationCode) SetCodeChallenge(challenge, method string) {
+	c.codeChallenge = challenge
+	c.codeChallengeMethod = method
+}
+
+func (c *AuthorizationCode) SetUsed(used bool) {
+	c.isUsed = used
+}
+
+func (c *AuthorizationCode) SetCreatedAt(createdAt time.Time) {
+	c.createdAt = createdAt
+}
+
+// Business methods
+func (c *AuthorizationCode) IsExpired() bool {
+	return time.Now().After(c.expiresAt)
+}
+
+func (c *AuthorizationCode) IsValid() bool {
+	return !c.isUsed && !c.IsExpired()
+}
+
+// VerifyCodeVerifier проверяет code_verifier: BASE64URL(SHA256(verifier)) должен совпасть
+// с code_challenge из запроса авторизации
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if c.codeChallengeMethod != CodeChallengeMethodS256 || verifier == "" {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(digest[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(c.codeChallenge)) == 1
+}
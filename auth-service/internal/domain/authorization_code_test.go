@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Пример из приложения B RFC 7636
+const (
+	rfc7636Verifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfc7636Challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestAuthorizationCode_VerifyCodeVerifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		method    string
+		verifier  string
+		want      bool
+	}{
+		{name: "rfc 7636 example", challenge: rfc7636Challenge, method: CodeChallengeMethodS256, verifier: rfc7636Verifier, want: true},
+		{name: "other verifier", challenge: rfc7636Challenge, method: CodeChallengeMethodS256, verifier: rfc7636Verifier[:42] + "Y", want: false},
+		{name: "empty verifier", challenge: rfc7636Challenge, method: CodeChallengeMethodS256, verifier: "", want: false},
+		{name: "challenge sent as verifier", challenge: rfc7636Challenge, method: CodeChallengeMethodS256, verifier: rfc7636Challenge, want: false},
+		{name: "plain method is not supported", challenge: rfc7636Verifier, method: "plain", verifier: rfc7636Verifier, want: false},
+		{name: "code without challenge", challenge: "", method: "", verifier: rfc7636Verifier, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := NewAuthorizationCode("hash", "client", uuid.New(), "https://client.example/callback", []string{"openid"}, time.Now().Add(time.Minute))
+			code.SetCodeChallenge(tt.challenge, tt.method)
+
+			if got := code.VerifyCodeVerifier(tt.verifier); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCode_IsValid(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		used      bool
+		want      bool
+	}{
+		{name: "fresh", expiresIn: time.Minute, want: true},
+		{name: "expired", expiresIn: -time.Second, want: false},
+		{name: "already exchanged", expiresIn: time.Minute, used: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := NewAuthorizationCode("hash", "client", uuid.New(), "https://client.example/callback", []string{"openid"}, time.Now().Add(tt.expiresIn))
+			code.SetUsed(tt.used)
+
+			if got := code.IsValid(); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package domain
+
+// ClientInfo описывает устройство и адрес, с которых пришел запрос.
+// Это value object без собственной идентичности, поэтому поля открыты.
+type ClientInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
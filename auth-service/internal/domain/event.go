@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы доменных событий, публикуемых другим сервисам
+const (
+	EventUserRegistered    = "auth.user.registered"
+	EventUserEmailVerified = "auth.user.email_verified"
+	EventPasswordChanged   = "auth.user.password_changed"
+	EventRoleAssigned      = "auth.user.role_assigned"
+	EventRoleRevoked       = "auth.user.role_revoked"
+	EventRoleExpired       = "auth.user.role_expired"
+	EventUserLoggedOut     = "auth.user.logged_out"
+	EventUserSuspended     = "auth.user.suspended"
+	EventUserReactivated   = "auth.user.reactivated"
+)
+
+// Типы агрегатов, к которым относятся события
+const (
+	AggregateUser = "user"
+)
+
+// Event - доменное событие. Сохраняется в outbox в одной транзакции с изменением
+// агрегата и публикуется асинхронно, поэтому потребители должны быть идемпотентны по ID.
+type Event struct {
+	id            uuid.UUID
+	eventType     string
+	aggregateType string
+	aggregateID   uuid.UUID
+	payload       any
+	occurredAt    time.Time
+}
+
+// Constructor
+func NewEvent(eventType, aggregateType string, aggregateID uuid.UUID, payload any) *Event {
+	return &Event{
+		id:            uuid.New(),
+		eventType:     eventType,
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		payload:       payload,
+		occurredAt:    time.Now(),
+	}
+}
+
+// Getters
+func (e *Event) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *Event) Type() string {
+	return e.eventType
+}
+
+func (e *Event) AggregateType() string {
+	return e.aggregateType
+}
+
+func (e *Event) AggregateID() uuid.UUID {
+	return e.aggregateID
+}
+
+func (e *Event) Payload() any {
+	return e.payload
+}
+
+func (e *Event) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// eventRecorder встраивается в агрегаты и накапливает события до сохранения
+type eventRecorder struct {
+	events []*Event
+}
+
+func (r *eventRecorder) record(event *Event) {
+	r.events = append(r.events, event)
+}
+
+// PendingEvents возвращает события, еще не сохраненные в outbox
+func (r *eventRecorder) PendingEvents() []*Event {
+	return r.events
+}
+
+// ClearEvents вызывается репозиторием после успешного сохранения событий
+func (r *eventRecorder) ClearEvents() {
+	r.events = nil
+}
+
+// Payloads событий
+
+type UserRegisteredPayload struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+}
+
+type UserEmailVerifiedPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+}
+
+type PasswordChangedPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Reason string    `json:"reason"`
+}
+
+type RolePayload struct {
+	UserID uuid.UUID    `json:"user_id"`
+	Role   UserRoleType `json:"role"`
+}
+
+type UserLoggedOutPayload struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"session_id"`
+}
+
+type UserSuspendedPayload struct {
+	UserID uuid.UUID  `json:"user_id"`
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+type UserReactivatedPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+}
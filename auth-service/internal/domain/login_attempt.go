@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// LoginAttempt - счетчик неудачных попыток входа по ключу (аккаунт или IP адрес клиента)
+type LoginAttempt struct {
+	key          string
+	failedCount  int
+	lastFailedAt time.Time
+	lockedUntil  *time.Time
+}
+
+// Constructor
+func NewLoginAttempt(key string) *LoginAttempt {
+	return &LoginAttempt{
+		key: key,
+	}
+}
+
+// Getters
+func (la *LoginAttempt) Key() string {
+	return la.key
+}
+
+func (la *LoginAttempt) FailedCount() int {
+	return la.failedCount
+}
+
+func (la *LoginAttempt) LastFailedAt() time.Time {
+	return la.lastFailedAt
+}
+
+func (la *LoginAttempt) LockedUntil() *time.Time {
+	return la.lockedUntil
+}
+
+// Setters
+func (la *LoginAttempt) SetFailedCount(failedCount int) {
+	la.failedCount = failedCount
+}
+
+func (la *LoginAttempt) SetLastFailedAt(lastFailedAt time.Time) {
+	la.lastFailedAt = lastFailedAt
+}
+
+func (la *LoginAttempt) SetLockedUntil(lockedUntil *time.Time) {
+	la.lockedUntil = lockedUntil
+}
+
+// Business methods
+func (la *LoginAttempt) IsLocked(now time.Time) bool {
+	return la.lockedUntil != nil && now.Before(*la.lockedUntil)
+}
+
+// RetryAfter возвращает время до снятия блокировки
+func (la *LoginAttempt) RetryAfter(now time.Time) time.Duration {
+	if !la.IsLocked(now) {
+		return 0
+	}
+	return la.lockedUntil.Sub(now)
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MagicLink struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	token     string
+	expiresAt time.Time
+	isUsed    bool
+	createdAt time.Time
+}
+
+// Constructor
+func NewMagicLink(userID uuid.UUID, token string, expiresAt time.Time) *MagicLink {
+	return &MagicLink{
+		id:        uuid.New(),
+		userID:    userID,
+		token:     token,
+		expiresAt: expiresAt,
+		isUsed:    false,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (ml *MagicLink) ID() uuid.UUID {
+	return ml.id
+}
+
+func (ml *MagicLink) UserID() uuid.UUID {
+	return ml.userID
+}
+
+func (ml *MagicLink) Token() string {
+	return ml.token
+}
+
+func (ml *MagicLink) ExpiresAt() time.Time {
+	return ml.expiresAt
+}
+
+func (ml *MagicLink) IsUsed() bool {
+	return ml.isUsed
+}
+
+func (ml *MagicLink) CreatedAt() time.Time {
+	return ml.createdAt
+}
+
+// Setters
+func (ml *MagicLink) SetUsed(used bool) {
+	ml.isUsed = used
+}
+
+func (ml *MagicLink) SetID(id uuid.UUID) {
+	ml.id = id
+}
+
+func (ml *MagicLink) SetUserID(userID uuid.UUID) {
+	ml.userID = userID
+}
+
+func (ml *MagicLink) SetToken(token string) {
+	ml.token = token
+}
+
+func (ml *MagicLink) SetExpiresAt(expiresAt time.Time) {
+	ml.expiresAt = expiresAt
+}
+
+func (ml *MagicLink) SetCreatedAt(createdAt time.Time) {
+	ml.createdAt = createdAt
+}
+
+// Business methods
+func (ml *MagicLink) IsExpired() bool {
+	return time.Now().After(ml.expiresAt)
+}
+
+func (ml *MagicLink) IsValid() bool {
+	return !ml.isUsed && !ml.IsExpired()
+}
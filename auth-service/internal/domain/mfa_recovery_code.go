@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFARecoveryCode - одноразовый код восстановления доступа при потере устройства с TOTP.
+// Хранится только хеш кода.
+type MFARecoveryCode struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	codeHash  string
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// Constructor
+func NewMFARecoveryCode(userID uuid.UUID, codeHash string) *MFARecoveryCode {
+	return &MFARecoveryCode{
+		id:        uuid.New(),
+		userID:    userID,
+		codeHash:  codeHash,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (c *MFARecoveryCode) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *MFARecoveryCode) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *MFARecoveryCode) CodeHash() string {
+	return c.codeHash
+}
+
+func (c *MFARecoveryCode) UsedAt() *time.Time {
+	return c.usedAt
+}
+
+func (c *MFARecoveryCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Setters
+func (c *MFARecoveryCode) SetID(id uuid.UUID) {
+	c.id = id
+}
+
+func (c *MFARecoveryCode) SetUsedAt(usedAt *time.Time) {
+	c.usedAt = usedAt
+}
+
+func (c *MFARecoveryCode) SetCreatedAt(createdAt time.Time) {
+	c.createdAt = createdAt
+}
+
+// Business methods
+func (c *MFARecoveryCode) IsUsed() bool {
+	return c.usedAt != nil
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthClient - зарегистрированный клиент: доверенный сервис, который аутентифицируется
+// по client_id и client_secret (например, API gateway с introspection), или приложение,
+// входящее через OpenID Connect по redirect_uris. Секрет хранится только в виде SHA-256 хеша;
+// у публичных клиентов (SPA, мобильные приложения) секрета нет.
+type OAuthClient struct {
+	id           uuid.UUID
+	clientID     string
+	secretHash   string
+	name         string
+	scopes       []string
+	redirectURIs []string
+	audiences    []string
+	isPublic     bool
+	skipConsent  bool
+	isActive     bool
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// Constructor
+func NewOAuthClient(clientID, secretHash, name string, scopes []string) *OAuthClient {
+	now := time.Now()
+	return &OAuthClient{
+		id:           uuid.New(),
+		clientID:     clientID,
+		secretHash:   secretHash,
+		name:         name,
+		scopes:       scopes,
+		redirectURIs: []string{},
+		audiences:    []string{},
+		isActive:     true,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+// Getters
+func (c *OAuthClient) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *OAuthClient) ClientID() string {
+	return c.clientID
+}
+
+func (c *OAuthClient) SecretHash() string {
+	return c.secretHash
+}
+
+func (c *OAuthClient) Name() string {
+	return c.name
+}
+
+func (c *OAuthClient) Scopes() []string {
+	return c.scopes
+}
+
+func (c *OAuthClient) RedirectURIs() []string {
+	return c.redirectURIs
+}
+
+// Audiences возвращает сервисы, для вызова которых клиент может получить токен по client credentials
+func (c *OAuthClient) Audiences() []string {
+	return c.audiences
+}
+
+func (c *OAuthClient) IsPublic() bool {
+	return c.isPublic
+}
+
+func (c *OAuthClient) SkipConsent() bool {
+	return c.skipConsent
+}
+
+func (c *OAuthClient) IsActive() bool {
+	return c.isActive
+}
+
+func (c *OAuthClient) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *OAuthClient) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Setters
+func (c *OAuthClient) SetID(id uuid.UUID) {
+	c.id = id
+}
+
+func (c *OAuthClient) SetSecretHash(secretHash string) {
+	c.secretHash = secretHash
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetName(name string) {
+	c.name = name
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetScopes(scopes []string) {
+	c.scopes = scopes
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetRedirectURIs(redirectURIs []string) {
+	if redirectURIs == nil {
+		redirectURIs = []string{}
+	}
+	c.redirectURIs = redirectURIs
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetAudiences(audiences []string) {
+	if audiences == nil {
+		audiences = []string{}
+	}
+	c.audiences = audiences
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetPublic(public bool) {
+	c.isPublic = public
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetSkipConsent(skipConsent bool) {
+	c.skipConsent = skipConsent
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetActive(active bool) {
+	c.isActive = active
+	c.updatedAt = time.Now()
+}
+
+func (c *OAuthClient) SetCreatedAt(createdAt time.Time) {
+	c.createdAt = createdAt
+}
+
+func (c *OAuthClient) SetUpdatedAt(updatedAt time.Time) {
+	c.updatedAt = updatedAt
+}
+
+// Business methods
+
+// HasScope проверяет, выдан ли клиенту scope
+func (c *OAuthClient) HasScope(scope string) bool {
+	for _, s := range c.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRedirectURI проверяет redirect_uri на точное совпадение с зарегистрированным
+func (c *OAuthClient) HasRedirectURI(redirectURI string) bool {
+	for _, uri := range c.redirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAudience проверяет, может ли клиент получить токен для вызова сервиса
+func (c *OAuthClient) HasAudience(audience string) bool {
+	for _, a := range c.audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// CanUseClientCredentials сообщает, может ли клиент получать токены сервиса: нужен секрет
+// и хотя бы один разрешенный audience
+func (c *OAuthClient) CanUseClientCredentials() bool {
+	return !c.isPublic && len(c.audiences) > 0
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthConsent - согласие пользователя на выдачу клиенту перечисленных scope.
+// Пока согласие покрывает запрошенные scope, экран согласия не показывается повторно.
+type OAuthConsent struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	clientID  string
+	scopes    []string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// Constructor
+func NewOAuthConsent(userID uuid.UUID, clientID string, scopes []string) *OAuthConsent {
+	now := time.Now()
+	return &OAuthConsent{
+		id:        uuid.New(),
+		userID:    userID,
+		clientID:  clientID,
+		scopes:    scopes,
+		createdAt: now,
+		updatedAt: now,
+	}
+}
+
+// Getters
+func (c *OAuthConsent) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *OAuthConsent) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *OAuthConsent) ClientID() string {
+	return c.clientID
+}
+
+func (c *OAuthConsent) Scopes() []string {
+	return c.scopes
+}
+
+func (c *OAuthConsent) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *OAuthConsent) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Setters
+func (c *OAuthConsent) SetID(id uuid.UUID) {
+	c.id = id
+}
+
+func (c *OAuthConsent) SetCreatedAt(createdAt time.Time) {
+	c.createdAt = createdAt
+}
+
+func (c *OAuthConsent) SetUpdatedAt(updatedAt time.Time) {
+	c.updatedAt = updatedAt
+}
+
+// Business methods
+
+// Covers проверяет, что пользователь уже согласился на все запрошенные scope
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Grant добавляет scope к ранее выданному согласию
+func (c *OAuthConsent) Grant(scopes []string) {
+	for _, scope := range scopes {
+		if !slices.Contains(c.scopes, scope) {
+			c.scopes = append(c.scopes, scope)
+		}
+	}
+	c.updatedAt = time.Now()
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistory - хеш одного из прежних паролей пользователя. Нужен, чтобы
+// не давать вернуться к недавно использованным паролям.
+type PasswordHistory struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	passwordHash string
+	createdAt    time.Time
+}
+
+// Constructor
+func NewPasswordHistory(userID uuid.UUID, passwordHash string) *PasswordHistory {
+	return &PasswordHistory{
+		id:           uuid.New(),
+		userID:       userID,
+		passwordHash: passwordHash,
+		createdAt:    time.Now(),
+	}
+}
+
+// Getters
+func (h *PasswordHistory) ID() uuid.UUID {
+	return h.id
+}
+
+func (h *PasswordHistory) UserID() uuid.UUID {
+	return h.userID
+}
+
+func (h *PasswordHistory) PasswordHash() string {
+	return h.passwordHash
+}
+
+func (h *PasswordHistory) CreatedAt() time.Time {
+	return h.createdAt
+}
+
+// Setters
+func (h *PasswordHistory) SetID(id uuid.UUID) {
+	h.id = id
+}
+
+func (h *PasswordHistory) SetCreatedAt(createdAt time.Time) {
+	h.createdAt = createdAt
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Permission - право на действие в формате <ресурс>.<действие>. Права проверяет не только
+// auth-service: другие сервисы получают их в access токене и заводят свои (comments.delete).
+type Permission string
+
+// Права, которые проверяет auth-service, и права модерации, выданные ролям по умолчанию
+const (
+	PermissionRolesRead        Permission = "roles.read"
+	PermissionRolesManage      Permission = "roles.manage"
+	PermissionRolesAssign      Permission = "roles.assign"
+	PermissionUsersRead        Permission = "users.read"
+	PermissionAuditRead        Permission = "audit.read"
+	PermissionUsersImpersonate Permission = "users.impersonate"
+
+	PermissionPostsDelete    Permission = "posts.delete"
+	PermissionCommentsDelete Permission = "comments.delete"
+	PermissionUsersBan       Permission = "users.ban"
+)
+
+// KnownPermissions - права, о которых знает auth-service. Роли могут содержать и другие
+// права в том же формате.
+var KnownPermissions = []Permission{
+	PermissionRolesRead,
+	PermissionRolesManage,
+	PermissionRolesAssign,
+	PermissionUsersRead,
+	PermissionAuditRead,
+	PermissionUsersImpersonate,
+	PermissionPostsDelete,
+	PermissionCommentsDelete,
+	PermissionUsersBan,
+}
+
+var permissionPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+
+// maxPermissionLength ограничивает длину права, чтобы токен оставался компактным
+const maxPermissionLength = 64
+
+// IsValid проверяет формат права
+func (p Permission) IsValid() bool {
+	return len(p) <= maxPermissionLength && permissionPattern.MatchString(string(p))
+}
+
+// NormalizePermissions убирает повторы и сортирует права, чтобы одинаковые наборы
+// выглядели в токене одинаково
+func NormalizePermissions(permissions []Permission) []Permission {
+	result := slices.Clone(permissions)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// JoinPermissions записывает права через пробел, как scope в OAuth
+func JoinPermissions(permissions []Permission) string {
+	parts := make([]string, len(permissions))
+	for i, permission := range permissions {
+		parts[i] = string(permission)
+	}
+	return strings.Join(parts, " ")
+}
+
+// SplitPermissions разбирает права, записанные через пробел
+func SplitPermissions(value string) []Permission {
+	fields := strings.Fields(value)
+	permissions := make([]Permission, len(fields))
+	for i, field := range fields {
+		permissions[i] = Permission(field)
+	}
+	return permissions
+}
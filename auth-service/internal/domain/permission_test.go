@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPermission_IsValid(t *testing.T) {
+	tests := []struct {
+		permission Permission
+		want       bool
+	}{
+		{PermissionRolesManage, true},
+		{"comments.delete", true},
+		{"posts.media.upload", true},
+		{"user_notes.read", true},
+		{"roles", false},
+		{"Roles.Manage", false},
+		{"roles.", false},
+		{".manage", false},
+		{"roles manage", false},
+		{"1roles.read", false},
+		{Permission("a." + strings.Repeat("b", maxPermissionLength)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.permission), func(t *testing.T) {
+			if got := tt.permission.IsValid(); got != tt.want {
+				t.Fatalf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePermissions(t *testing.T) {
+	got := NormalizePermissions([]Permission{PermissionUsersRead, PermissionPostsDelete, PermissionUsersRead})
+	want := []Permission{PermissionPostsDelete, PermissionUsersRead}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("NormalizePermissions() = %v, want %v", got, want)
+	}
+	if joined := JoinPermissions(got); !slices.Equal(SplitPermissions(joined), want) {
+		t.Fatalf("permissions must survive join and split, got %q", joined)
+	}
+}
+
+func TestIsValidRoleName(t *testing.T) {
+	for name, want := range map[UserRoleType]bool{
+		RoleAdmin:      true,
+		"support-team": true,
+		"tier_2":       true,
+		"Support":      false,
+		"support team": false,
+		"":             false,
+	} {
+		if got := IsValidRoleName(name); got != want {
+			t.Fatalf("IsValidRoleName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessToken - долгоживущий токен пользователя для ботов и интеграций. Сам токен
+// показывается один раз при создании; хранится SHA-256 хеш и видимый префикс, по которому
+// пользователь узнает токен в списке.
+type PersonalAccessToken struct {
+	id         uuid.UUID
+	userID     uuid.UUID
+	name       string
+	prefix     string
+	tokenHash  string
+	scopes     []string
+	expiresAt  *time.Time
+	lastUsedAt *time.Time
+	lastUsedIP string
+	revokedAt  *time.Time
+	createdAt  time.Time
+}
+
+// Constructor
+func NewPersonalAccessToken(userID uuid.UUID, name, prefix, tokenHash string, scopes []string, expiresAt *time.Time) *PersonalAccessToken {
+	return &PersonalAccessToken{
+		id:        uuid.New(),
+		userID:    userID,
+		name:      name,
+		prefix:    prefix,
+		tokenHash: tokenHash,
+		scopes:    scopes,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (t *PersonalAccessToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *PersonalAccessToken) UserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *PersonalAccessToken) Name() string {
+	return t.name
+}
+
+func (t *PersonalAccessToken) Prefix() string {
+	return t.prefix
+}
+
+func (t *PersonalAccessToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *PersonalAccessToken) Scopes() []string {
+	return t.scopes
+}
+
+// ExpiresAt возвращает nil для бессрочного токена
+func (t *PersonalAccessToken) ExpiresAt() *time.Time {
+	return t.expiresAt
+}
+
+func (t *PersonalAccessToken) LastUsedAt() *time.Time {
+	return t.lastUsedAt
+}
+
+func (t *PersonalAccessToken) LastUsedIP() string {
+	return t.lastUsedIP
+}
+
+func (t *PersonalAccessToken) RevokedAt() *time.Time {
+	return t.revokedAt
+}
+
+func (t *PersonalAccessToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// Setters
+func (t *PersonalAccessToken) SetID(id uuid.UUID) {
+	t.id = id
+}
+
+func (t *PersonalAccessToken) SetLastUsed(lastUsedAt *time.Time, lastUsedIP string) {
+	t.lastUsedAt = lastUsedAt
+	t.lastUsedIP = lastUsedIP
+}
+
+func (t *PersonalAccessToken) SetRevokedAt(revokedAt *time.Time) {
+	t.revokedAt = revokedAt
+}
+
+func (t *PersonalAccessToken) SetCreatedAt(createdAt time.Time) {
+	t.createdAt = createdAt
+}
+
+// Business methods
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.expiresAt != nil && time.Now().After(*t.expiresAt)
+}
+
+func (t *PersonalAccessToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+// IsValid проверяет, что токен не отозван и не истек
+func (t *PersonalAccessToken) IsValid() bool {
+	return !t.IsRevoked() && !t.IsExpired()
+}
+
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	return slices.Contains(t.scopes, scope)
+}
+
+// RecordUse фиксирует использование токена. Пустой адрес не затирает последний известный.
+func (t *PersonalAccessToken) RecordUse(ipAddress string) {
+	now := time.Now()
+	t.lastUsedAt = &now
+	if ipAddress != "" {
+		t.lastUsedIP = ipAddress
+	}
+}
@@ -7,23 +7,38 @@ import (
 )
 
 type RefreshToken struct {
-	id        uuid.UUID
-	userID    uuid.UUID
-	token     string
-	expiresAt time.Time
-	isRevoked bool
-	createdAt time.Time
+	id         uuid.UUID
+	userID     uuid.UUID
+	familyID   uuid.UUID  // Rotation chain the token belongs to
+	parentID   *uuid.UUID // Token this one was rotated from (nil for the first token in a family)
+	replacedBy *uuid.UUID // Token this one was rotated into (nil until rotated)
+	token      string
+	deviceName string
+	userAgent  string
+	ipAddress  string
+	clientID   string // OAuth клиент, которому выдан токен (пусто для собственного входа)
+	scope      string // Scope, выданный клиенту
+	expiresAt  time.Time
+	isRevoked  bool
+	revokedAt  *time.Time
+	lastUsedAt *time.Time
+	createdAt  time.Time
+	eventRecorder
 }
 
 // Constructor
 func NewRefreshToken(userID uuid.UUID, token string, expiresAt time.Time) *RefreshToken {
+	id := uuid.New()
+	now := time.Now()
 	return &RefreshToken{
-		id:        uuid.New(),
-		userID:    userID,
-		token:     token,
-		expiresAt: expiresAt,
-		isRevoked: false,
-		createdAt: time.Now(),
+		id:         id,
+		userID:     userID,
+		familyID:   id, // Первый токен открывает новое семейство
+		token:      token,
+		expiresAt:  expiresAt,
+		isRevoked:  false,
+		lastUsedAt: &now,
+		createdAt:  now,
 	}
 }
 
@@ -36,10 +51,42 @@ func (rt *RefreshToken) UserID() uuid.UUID {
 	return rt.userID
 }
 
+func (rt *RefreshToken) FamilyID() uuid.UUID {
+	return rt.familyID
+}
+
+func (rt *RefreshToken) ParentID() *uuid.UUID {
+	return rt.parentID
+}
+
+func (rt *RefreshToken) ReplacedBy() *uuid.UUID {
+	return rt.replacedBy
+}
+
 func (rt *RefreshToken) Token() string {
 	return rt.token
 }
 
+func (rt *RefreshToken) DeviceName() string {
+	return rt.deviceName
+}
+
+func (rt *RefreshToken) UserAgent() string {
+	return rt.userAgent
+}
+
+func (rt *RefreshToken) IPAddress() string {
+	return rt.ipAddress
+}
+
+func (rt *RefreshToken) ClientID() string {
+	return rt.clientID
+}
+
+func (rt *RefreshToken) Scope() string {
+	return rt.scope
+}
+
 func (rt *RefreshToken) ExpiresAt() time.Time {
 	return rt.expiresAt
 }
@@ -48,6 +95,14 @@ func (rt *RefreshToken) IsRevoked() bool {
 	return rt.isRevoked
 }
 
+func (rt *RefreshToken) RevokedAt() *time.Time {
+	return rt.revokedAt
+}
+
+func (rt *RefreshToken) LastUsedAt() *time.Time {
+	return rt.lastUsedAt
+}
+
 func (rt *RefreshToken) CreatedAt() time.Time {
 	return rt.createdAt
 }
@@ -55,6 +110,10 @@ func (rt *RefreshToken) CreatedAt() time.Time {
 // Setters
 func (rt *RefreshToken) SetRevoked(revoked bool) {
 	rt.isRevoked = revoked
+	if revoked && rt.revokedAt == nil {
+		now := time.Now()
+		rt.revokedAt = &now
+	}
 }
 
 func (rt *RefreshToken) SetID(id uuid.UUID) {
@@ -65,14 +124,50 @@ func (rt *RefreshToken) SetUserID(userID uuid.UUID) {
 	rt.userID = userID
 }
 
+func (rt *RefreshToken) SetFamilyID(familyID uuid.UUID) {
+	rt.familyID = familyID
+}
+
+func (rt *RefreshToken) SetParentID(parentID *uuid.UUID) {
+	rt.parentID = parentID
+}
+
+func (rt *RefreshToken) SetReplacedBy(replacedBy *uuid.UUID) {
+	rt.replacedBy = replacedBy
+}
+
 func (rt *RefreshToken) SetToken(token string) {
 	rt.token = token
 }
 
+// SetClientInfo запоминает устройство и адрес клиента, которому выдан токен.
+// Пустое имя устройства не затирает ранее сохраненное.
+func (rt *RefreshToken) SetClientInfo(client ClientInfo) {
+	if client.DeviceName != "" {
+		rt.deviceName = client.DeviceName
+	}
+	rt.userAgent = client.UserAgent
+	rt.ipAddress = client.IPAddress
+}
+
+// SetOAuthClient привязывает токен к OAuth клиенту и выданному ему scope
+func (rt *RefreshToken) SetOAuthClient(clientID, scope string) {
+	rt.clientID = clientID
+	rt.scope = scope
+}
+
 func (rt *RefreshToken) SetExpiresAt(expiresAt time.Time) {
 	rt.expiresAt = expiresAt
 }
 
+func (rt *RefreshToken) SetRevokedAt(revokedAt *time.Time) {
+	rt.revokedAt = revokedAt
+}
+
+func (rt *RefreshToken) SetLastUsedAt(lastUsedAt *time.Time) {
+	rt.lastUsedAt = lastUsedAt
+}
+
 func (rt *RefreshToken) SetCreatedAt(createdAt time.Time) {
 	rt.createdAt = createdAt
 }
@@ -85,3 +180,51 @@ func (rt *RefreshToken) IsExpired() bool {
 func (rt *RefreshToken) IsValid() bool {
 	return !rt.isRevoked && !rt.IsExpired()
 }
+
+// IsRotated сообщает, что токен уже был обменян на следующий в цепочке
+func (rt *RefreshToken) IsRotated() bool {
+	return rt.replacedBy != nil
+}
+
+// SessionID идентифицирует сессию устройства: она живет, пока живет семейство токенов
+func (rt *RefreshToken) SessionID() uuid.UUID {
+	return rt.familyID
+}
+
+// MarkLoggedOut фиксирует завершение сессии пользователем
+func (rt *RefreshToken) MarkLoggedOut() {
+	rt.record(NewEvent(EventUserLoggedOut, AggregateUser, rt.userID, UserLoggedOutPayload{
+		UserID:    rt.userID,
+		SessionID: rt.familyID,
+	}))
+}
+
+// Rotate создает следующий токен того же семейства и помечает текущий как замененный.
+// Данные устройства и OAuth клиента переносятся в новый токен, время использования обновляется у обоих.
+func (rt *RefreshToken) Rotate(token string, expiresAt time.Time) *RefreshToken {
+	now := time.Now()
+	parentID := rt.id
+	next := &RefreshToken{
+		id:         uuid.New(),
+		userID:     rt.userID,
+		familyID:   rt.familyID,
+		parentID:   &parentID,
+		token:      token,
+		deviceName: rt.deviceName,
+		userAgent:  rt.userAgent,
+		ipAddress:  rt.ipAddress,
+		clientID:   rt.clientID,
+		scope:      rt.scope,
+		expiresAt:  expiresAt,
+		isRevoked:  false,
+		lastUsedAt: &now,
+		createdAt:  now,
+	}
+
+	nextID := next.id
+	rt.replacedBy = &nextID
+	rt.lastUsedAt = &now
+	rt.SetRevoked(true)
+
+	return next
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// Role - роль как набор прав. Пользователю назначается роль по имени (UserRole), а права
+// берутся из определения роли при выпуске токена. Системные роли (user, moderator, admin)
+// создаются миграцией; их права можно менять, но удалить их нельзя.
+type Role struct {
+	id          uuid.UUID
+	name        UserRoleType
+	description string
+	permissions []Permission
+	isSystem    bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// Constructor
+func NewRole(name UserRoleType, description string, permissions []Permission) *Role {
+	now := time.Now()
+	return &Role{
+		id:          uuid.New(),
+		name:        name,
+		description: description,
+		permissions: NormalizePermissions(permissions),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+}
+
+// Getters
+func (r *Role) ID() uuid.UUID {
+	return r.id
+}
+
+func (r *Role) Name() UserRoleType {
+	return r.name
+}
+
+func (r *Role) Description() string {
+	return r.description
+}
+
+func (r *Role) Permissions() []Permission {
+	return r.permissions
+}
+
+func (r *Role) IsSystem() bool {
+	return r.isSystem
+}
+
+func (r *Role) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *Role) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Setters
+func (r *Role) SetID(id uuid.UUID) {
+	r.id = id
+}
+
+func (r *Role) SetSystem(isSystem bool) {
+	r.isSystem = isSystem
+}
+
+func (r *Role) SetCreatedAt(createdAt time.Time) {
+	r.createdAt = createdAt
+}
+
+func (r *Role) SetUpdatedAt(updatedAt time.Time) {
+	r.updatedAt = updatedAt
+}
+
+// Business methods
+
+// Update меняет описание и права роли
+func (r *Role) Update(description string, permissions []Permission) {
+	r.description = description
+	r.permissions = NormalizePermissions(permissions)
+	r.updatedAt = time.Now()
+}
+
+func (r *Role) HasPermission(permission Permission) bool {
+	return slices.Contains(r.permissions, permission)
+}
+
+// IsGrantableBy проверяет, что у назначающего есть все права роли: нельзя выдать
+// другому больше, чем есть у тебя самого
+func (r *Role) IsGrantableBy(permissions []Permission) bool {
+	for _, permission := range r.permissions {
+		if !slices.Contains(permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidRoleName проверяет имя роли: строчные латинские буквы, цифры, - и _
+func IsValidRoleName(name UserRoleType) bool {
+	return roleNamePattern.MatchString(string(name))
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleChangeAction string
+
+const (
+	RoleChangeGranted RoleChangeAction = "granted"
+	RoleChangeRevoked RoleChangeAction = "revoked"
+	RoleChangeExpired RoleChangeAction = "expired"
+)
+
+// RoleChange - запись истории назначения ролей. Хранится отдельно от UserRole, чтобы история
+// переживала повторные назначения и удаление определения роли.
+type RoleChange struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	role      UserRoleType
+	action    RoleChangeAction
+	actorID   *uuid.UUID // nil для изменений, сделанных системой (истечение срока)
+	reason    string
+	expiresAt *time.Time
+	createdAt time.Time
+}
+
+// Constructor
+func NewRoleChange(userID uuid.UUID, role UserRoleType, action RoleChangeAction, actorID *uuid.UUID, reason string, expiresAt *time.Time) *RoleChange {
+	return &RoleChange{
+		id:        uuid.New(),
+		userID:    userID,
+		role:      role,
+		action:    action,
+		actorID:   actorID,
+		reason:    reason,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (rc *RoleChange) ID() uuid.UUID {
+	return rc.id
+}
+
+func (rc *RoleChange) UserID() uuid.UUID {
+	return rc.userID
+}
+
+func (rc *RoleChange) Role() UserRoleType {
+	return rc.role
+}
+
+func (rc *RoleChange) Action() RoleChangeAction {
+	return rc.action
+}
+
+func (rc *RoleChange) ActorID() *uuid.UUID {
+	return rc.actorID
+}
+
+func (rc *RoleChange) Reason() string {
+	return rc.reason
+}
+
+func (rc *RoleChange) ExpiresAt() *time.Time {
+	return rc.expiresAt
+}
+
+func (rc *RoleChange) CreatedAt() time.Time {
+	return rc.createdAt
+}
+
+// Setters
+func (rc *RoleChange) SetID(id uuid.UUID) {
+	rc.id = id
+}
+
+func (rc *RoleChange) SetCreatedAt(createdAt time.Time) {
+	rc.createdAt = createdAt
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRole_IsGrantableBy(t *testing.T) {
+	role := NewRole(RoleModerator, "", []Permission{PermissionPostsDelete, PermissionCommentsDelete})
+
+	tests := []struct {
+		name        string
+		permissions []Permission
+		want        bool
+	}{
+		{"all permissions", []Permission{PermissionCommentsDelete, PermissionPostsDelete, PermissionUsersBan}, true},
+		{"missing permission", []Permission{PermissionPostsDelete}, false},
+		{"no permissions", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := role.IsGrantableBy(tt.permissions); got != tt.want {
+				t.Fatalf("IsGrantableBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !NewRole(RoleUser, "", nil).IsGrantableBy(nil) {
+		t.Fatalf("role without permissions must be grantable by anyone")
+	}
+}
+
+func TestUserRole_IsEffective(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	actorID := uuid.New()
+
+	revoked := NewUserRole(uuid.New(), RoleModerator)
+	revoked.Revoke()
+
+	tests := []struct {
+		name     string
+		userRole *UserRole
+		want     bool
+	}{
+		{"permanent role", NewUserRole(uuid.New(), RoleModerator), true},
+		{"temporary role", NewRoleGrant(uuid.New(), RoleModerator, actorID, "", &future), true},
+		{"expired role", NewRoleGrant(uuid.New(), RoleModerator, actorID, "", &past), false},
+		{"revoked role", revoked, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.userRole.IsEffective(); got != tt.want {
+				t.Fatalf("IsEffective() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
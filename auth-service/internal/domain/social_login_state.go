@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Назначение входа через внешнего провайдера
+const (
+	SocialLoginPurposeLogin = "login"
+	SocialLoginPurposeLink  = "link"
+)
+
+// SocialLoginState хранит state, nonce и PKCE code_verifier между перенаправлением
+// к провайдеру и возвратом от него. Хранится только хеш state; запись одноразовая.
+type SocialLoginState struct {
+	id           uuid.UUID
+	stateHash    string
+	provider     string
+	purpose      string
+	userID       *uuid.UUID
+	nonce        string
+	codeVerifier string
+	expiresAt    time.Time
+	createdAt    time.Time
+}
+
+// Constructor
+func NewSocialLoginState(stateHash, provider, purpose string, userID *uuid.UUID, nonce, codeVerifier string, expiresAt time.Time) *SocialLoginState {
+	return &SocialLoginState{
+		id:           uuid.New(),
+		stateHash:    stateHash,
+		provider:     provider,
+		purpose:      purpose,
+		userID:       userID,
+		nonce:        nonce,
+		codeVerifier: codeVerifier,
+		expiresAt:    expiresAt,
+		createdAt:    time.Now(),
+	}
+}
+
+// Getters
+func (s *SocialLoginState) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *SocialLoginState) StateHash() string {
+	return s.stateHash
+}
+
+func (s *SocialLoginState) Provider() string {
+	return s.provider
+}
+
+func (s *SocialLoginState) Purpose() string {
+	return s.purpose
+}
+
+// UserID возвращает пользователя, начавшего привязку; при входе пользователь неизвестен
+func (s *SocialLoginState) UserID() *uuid.UUID {
+	return s.userID
+}
+
+func (s *SocialLoginState) Nonce() string {
+	return s.nonce
+}
+
+func (s *SocialLoginState) CodeVerifier() string {
+	return s.codeVerifier
+}
+
+func (s *SocialLoginState) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *SocialLoginState) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Setters
+func (s *SocialLoginState) SetID(id uuid.UUID) {
+	s.id = id
+}
+
+func (s *SocialLoginState) SetCreatedAt(createdAt time.Time) {
+	s.createdAt = createdAt
+}
+
+// Business methods
+func (s *SocialLoginState) IsExpired() bool {
+	return time.Now().After(s.expiresAt)
+}
+
+// BelongsTo проверяет, что привязка начата этим пользователем
+func (s *SocialLoginState) BelongsTo(userID uuid.UUID) bool {
+	return s.userID != nil && *s.userID == userID
+}
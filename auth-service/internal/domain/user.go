@@ -13,8 +13,18 @@ type User struct {
 	displayName string
 	isVerified  bool // Indicates if the user's email is verified
 	isActive    bool // Indicates if the user account is active / may be suspended
+	suspension  *Suspension
 	createdAt   time.Time
 	updatedAt   time.Time
+	eventRecorder
+}
+
+// Suspension - блокировка аккаунта администратором. Until равен nil для бессрочной блокировки.
+type Suspension struct {
+	SuspendedBy *uuid.UUID // nil, если администратор удален
+	Reason      string
+	SuspendedAt time.Time
+	Until       *time.Time
 }
 
 func NewUser(email, username, displayName string) *User {
@@ -55,6 +65,12 @@ func (u *User) IsActive() bool {
 	return u.isActive
 }
 
+// Suspension возвращает текущую блокировку или nil. Аккаунт, отключенный до появления
+// блокировок, неактивен и без нее.
+func (u *User) Suspension() *Suspension {
+	return u.suspension
+}
+
 func (u *User) CreatedAt() time.Time {
 	return u.createdAt
 }
@@ -89,6 +105,10 @@ func (u *User) SetActive(active bool) {
 	u.updatedAt = time.Now()
 }
 
+func (u *User) SetSuspension(suspension *Suspension) {
+	u.suspension = suspension
+}
+
 func (u *User) SetID(id uuid.UUID) {
 	u.id = id
 }
@@ -100,3 +120,53 @@ func (u *User) SetCreatedAt(createdAt time.Time) {
 func (u *User) SetUpdatedAt(updatedAt time.Time) {
 	u.updatedAt = updatedAt
 }
+
+// Business methods
+
+// MarkRegistered фиксирует событие регистрации нового пользователя
+func (u *User) MarkRegistered() {
+	u.record(NewEvent(EventUserRegistered, AggregateUser, u.id, UserRegisteredPayload{
+		UserID:      u.id,
+		Email:       u.email,
+		Username:    u.username,
+		DisplayName: u.displayName,
+	}))
+}
+
+// VerifyEmail подтверждает email пользователя
+func (u *User) VerifyEmail() {
+	u.isVerified = true
+	u.updatedAt = time.Now()
+	u.record(NewEvent(EventUserEmailVerified, AggregateUser, u.id, UserEmailVerifiedPayload{
+		UserID: u.id,
+		Email:  u.email,
+	}))
+}
+
+// Suspend блокирует аккаунт. Сессии пользователя отзывает сервис.
+func (u *User) Suspend(actorID uuid.UUID, reason string, until *time.Time) {
+	now := time.Now()
+	u.isActive = false
+	u.suspension = &Suspension{
+		SuspendedBy: &actorID,
+		Reason:      reason,
+		SuspendedAt: now,
+		Until:       until,
+	}
+	u.updatedAt = now
+	u.record(NewEvent(EventUserSuspended, AggregateUser, u.id, UserSuspendedPayload{
+		UserID: u.id,
+		Reason: reason,
+		Until:  until,
+	}))
+}
+
+// Reactivate снимает блокировку аккаунта
+func (u *User) Reactivate() {
+	u.isActive = true
+	u.suspension = nil
+	u.updatedAt = time.Now()
+	u.record(NewEvent(EventUserReactivated, AggregateUser, u.id, UserReactivatedPayload{
+		UserID: u.id,
+	}))
+}
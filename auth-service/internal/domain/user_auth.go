@@ -6,6 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// Причины смены пароля в событии EventPasswordChanged
+const (
+	PasswordChangeReasonChange  = "change"
+	PasswordChangeReasonReset   = "reset"
+	PasswordChangeReasonRemoved = "removed"
+)
+
 type UserAuth struct {
 	id           uuid.UUID
 	userID       uuid.UUID
@@ -13,6 +20,7 @@ type UserAuth struct {
 	lastLoginAt  *time.Time
 	createdAt    time.Time
 	updatedAt    time.Time
+	eventRecorder
 }
 
 // Constructor
@@ -78,3 +86,21 @@ func (ua *UserAuth) SetCreatedAt(createdAt time.Time) {
 func (ua *UserAuth) SetUpdatedAt(updatedAt time.Time) {
 	ua.updatedAt = updatedAt
 }
+
+// Business methods
+
+// HasPassword сообщает, задан ли пароль. Аккаунт, который входит только через passkeys,
+// хранит пустой хеш.
+func (ua *UserAuth) HasPassword() bool {
+	return ua.passwordHash != ""
+}
+
+// ChangePassword устанавливает новый хеш пароля и фиксирует событие смены пароля
+func (ua *UserAuth) ChangePassword(passwordHash, reason string) {
+	ua.passwordHash = passwordHash
+	ua.updatedAt = time.Now()
+	ua.record(NewEvent(EventPasswordChanged, AggregateUser, ua.userID, PasswordChangedPayload{
+		UserID: ua.userID,
+		Reason: reason,
+	}))
+}
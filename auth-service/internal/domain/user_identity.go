@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity связывает аккаунт с учетной записью внешнего провайдера OpenID Connect.
+// Учетная запись определяется парой provider + subject (claim sub провайдера).
+type UserIdentity struct {
+	id          uuid.UUID
+	userID      uuid.UUID
+	provider    string
+	subject     string
+	email       string
+	lastLoginAt *time.Time
+	createdAt   time.Time
+}
+
+// Constructor
+func NewUserIdentity(userID uuid.UUID, provider, subject, email string) *UserIdentity {
+	return &UserIdentity{
+		id:        uuid.New(),
+		userID:    userID,
+		provider:  provider,
+		subject:   subject,
+		email:     email,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (i *UserIdentity) ID() uuid.UUID {
+	return i.id
+}
+
+func (i *UserIdentity) UserID() uuid.UUID {
+	return i.userID
+}
+
+func (i *UserIdentity) Provider() string {
+	return i.provider
+}
+
+func (i *UserIdentity) Subject() string {
+	return i.subject
+}
+
+// Email возвращает адрес, который провайдер сообщил при последнем входе
+func (i *UserIdentity) Email() string {
+	return i.email
+}
+
+func (i *UserIdentity) LastLoginAt() *time.Time {
+	return i.lastLoginAt
+}
+
+func (i *UserIdentity) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// Setters
+func (i *UserIdentity) SetID(id uuid.UUID) {
+	i.id = id
+}
+
+func (i *UserIdentity) SetLastLoginAt(lastLoginAt *time.Time) {
+	i.lastLoginAt = lastLoginAt
+}
+
+func (i *UserIdentity) SetCreatedAt(createdAt time.Time) {
+	i.createdAt = createdAt
+}
+
+// Business methods
+
+// RecordLogin фиксирует вход через провайдера и обновляет сообщенный им email
+func (i *UserIdentity) RecordLogin(email string) {
+	now := time.Now()
+	i.lastLoginAt = &now
+	if email != "" {
+		i.email = email
+	}
+}
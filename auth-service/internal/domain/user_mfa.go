@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA хранит TOTP секрет пользователя. Запись создается при начале
+// подключения 2FA и включается только после подтверждения первым кодом.
+type UserMFA struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	secret       string
+	isEnabled    bool
+	lastUsedStep int64
+	enabledAt    *time.Time
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// Constructor
+func NewUserMFA(userID uuid.UUID, secret string) *UserMFA {
+	now := time.Now()
+	return &UserMFA{
+		id:        uuid.New(),
+		userID:    userID,
+		secret:    secret,
+		isEnabled: false,
+		createdAt: now,
+		updatedAt: now,
+	}
+}
+
+// Getters
+func (m *UserMFA) ID() uuid.UUID {
+	return m.id
+}
+
+func (m *UserMFA) UserID() uuid.UUID {
+	return m.userID
+}
+
+func (m *UserMFA) Secret() string {
+	return m.secret
+}
+
+func (m *UserMFA) IsEnabled() bool {
+	return m.isEnabled
+}
+
+func (m *UserMFA) LastUsedStep() int64 {
+	return m.lastUsedStep
+}
+
+func (m *UserMFA) EnabledAt() *time.Time {
+	return m.enabledAt
+}
+
+func (m *UserMFA) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *UserMFA) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// Setters
+func (m *UserMFA) SetID(id uuid.UUID) {
+	m.id = id
+}
+
+func (m *UserMFA) SetUserID(userID uuid.UUID) {
+	m.userID = userID
+}
+
+func (m *UserMFA) SetSecret(secret string) {
+	m.secret = secret
+	m.updatedAt = time.Now()
+}
+
+func (m *UserMFA) SetEnabled(enabled bool) {
+	m.isEnabled = enabled
+}
+
+func (m *UserMFA) SetLastUsedStep(step int64) {
+	m.lastUsedStep = step
+}
+
+func (m *UserMFA) SetEnabledAt(enabledAt *time.Time) {
+	m.enabledAt = enabledAt
+}
+
+func (m *UserMFA) SetCreatedAt(createdAt time.Time) {
+	m.createdAt = createdAt
+}
+
+func (m *UserMFA) SetUpdatedAt(updatedAt time.Time) {
+	m.updatedAt = updatedAt
+}
+
+// Business methods
+
+// Enable включает 2FA после подтверждения кодом из интервала step
+func (m *UserMFA) Enable(step int64) {
+	now := time.Now()
+	m.isEnabled = true
+	m.enabledAt = &now
+	m.lastUsedStep = step
+	m.updatedAt = now
+}
+
+// CanUseStep проверяет, что код из интервала step еще не использовался
+func (m *UserMFA) CanUseStep(step int64) bool {
+	return step > m.lastUsedStep
+}
@@ -21,7 +21,11 @@ type UserRole struct {
 	userID    uuid.UUID
 	role      UserRoleType
 	grantedAt time.Time
+	grantedBy *uuid.UUID // nil для ролей, выданных системой (регистрация, миграции)
+	reason    string
+	expiresAt *time.Time // nil для бессрочных ролей
 	isActive  bool
+	eventRecorder
 }
 
 // Constructor
@@ -35,6 +39,16 @@ func NewUserRole(userID uuid.UUID, role UserRoleType) *UserRole {
 	}
 }
 
+// NewRoleGrant создает роль, выданную администратором. Временная роль (expiresAt != nil)
+// деактивируется фоновой задачей после истечения срока.
+func NewRoleGrant(userID uuid.UUID, role UserRoleType, grantedBy uuid.UUID, reason string, expiresAt *time.Time) *UserRole {
+	userRole := NewUserRole(userID, role)
+	userRole.grantedBy = &grantedBy
+	userRole.reason = reason
+	userRole.expiresAt = expiresAt
+	return userRole
+}
+
 // Getters
 func (ur *UserRole) ID() uuid.UUID {
 	return ur.id
@@ -52,6 +66,18 @@ func (ur *UserRole) GrantedAt() time.Time {
 	return ur.grantedAt
 }
 
+func (ur *UserRole) GrantedBy() *uuid.UUID {
+	return ur.grantedBy
+}
+
+func (ur *UserRole) Reason() string {
+	return ur.reason
+}
+
+func (ur *UserRole) ExpiresAt() *time.Time {
+	return ur.expiresAt
+}
+
 func (ur *UserRole) IsActive() bool {
 	return ur.isActive
 }
@@ -77,7 +103,57 @@ func (ur *UserRole) SetGrantedAt(grantedAt time.Time) {
 	ur.grantedAt = grantedAt
 }
 
+func (ur *UserRole) SetGrantedBy(grantedBy *uuid.UUID) {
+	ur.grantedBy = grantedBy
+}
+
+func (ur *UserRole) SetReason(reason string) {
+	ur.reason = reason
+}
+
+func (ur *UserRole) SetExpiresAt(expiresAt *time.Time) {
+	ur.expiresAt = expiresAt
+}
+
 // Business methods
+
+// MarkAssigned фиксирует событие назначения роли
+func (ur *UserRole) MarkAssigned() {
+	ur.record(NewEvent(EventRoleAssigned, AggregateUser, ur.userID, RolePayload{
+		UserID: ur.userID,
+		Role:   ur.role,
+	}))
+}
+
+// Revoke деактивирует роль и фиксирует событие отзыва
+func (ur *UserRole) Revoke() {
+	ur.isActive = false
+	ur.record(NewEvent(EventRoleRevoked, AggregateUser, ur.userID, RolePayload{
+		UserID: ur.userID,
+		Role:   ur.role,
+	}))
+}
+
+// Expire деактивирует временную роль по истечении срока
+func (ur *UserRole) Expire() {
+	ur.isActive = false
+	ur.record(NewEvent(EventRoleExpired, AggregateUser, ur.userID, RolePayload{
+		UserID: ur.userID,
+		Role:   ur.role,
+	}))
+}
+
+// IsExpired сообщает, истек ли срок временной роли
+func (ur *UserRole) IsExpired() bool {
+	return ur.expiresAt != nil && time.Now().After(*ur.expiresAt)
+}
+
+// IsEffective сообщает, дает ли роль права прямо сейчас. Истекшая роль может оставаться
+// активной до запуска фоновой задачи, но прав уже не дает.
+func (ur *UserRole) IsEffective() bool {
+	return ur.isActive && !ur.IsExpired()
+}
+
 func (ur *UserRole) IsAdmin() bool {
 	return ur.role == RoleAdmin && ur.isActive
 }
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebAuthnCredential - passkey пользователя: открытый ключ аутентификатора и счетчик подписей.
+// Закрытый ключ никогда не покидает аутентификатор.
+type WebAuthnCredential struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	credentialID []byte
+	publicKey    []byte
+	algorithm    int64
+	signCount    uint32
+	transports   []string
+	aaguid       []byte
+	name         string
+	cloneWarning bool
+	lastUsedAt   *time.Time
+	createdAt    time.Time
+}
+
+// Constructor
+func NewWebAuthnCredential(userID uuid.UUID, credentialID, publicKey []byte, algorithm int64, signCount uint32) *WebAuthnCredential {
+	return &WebAuthnCredential{
+		id:           uuid.New(),
+		userID:       userID,
+		credentialID: credentialID,
+		publicKey:    publicKey,
+		algorithm:    algorithm,
+		signCount:    signCount,
+		transports:   []string{},
+		createdAt:    time.Now(),
+	}
+}
+
+// Getters
+func (c *WebAuthnCredential) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *WebAuthnCredential) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *WebAuthnCredential) CredentialID() []byte {
+	return c.credentialID
+}
+
+func (c *WebAuthnCredential) PublicKey() []byte {
+	return c.publicKey
+}
+
+func (c *WebAuthnCredential) Algorithm() int64 {
+	return c.algorithm
+}
+
+func (c *WebAuthnCredential) SignCount() uint32 {
+	return c.signCount
+}
+
+func (c *WebAuthnCredential) Transports() []string {
+	return c.transports
+}
+
+func (c *WebAuthnCredential) AAGUID() []byte {
+	return c.aaguid
+}
+
+func (c *WebAuthnCredential) Name() string {
+	return c.name
+}
+
+func (c *WebAuthnCredential) CloneWarning() bool {
+	return c.cloneWarning
+}
+
+func (c *WebAuthnCredential) LastUsedAt() *time.Time {
+	return c.lastUsedAt
+}
+
+func (c *WebAuthnCredential) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Setters
+func (c *WebAuthnCredential) SetID(id uuid.UUID) {
+	c.id = id
+}
+
+func (c *WebAuthnCredential) SetSignCount(signCount uint32) {
+	c.signCount = signCount
+}
+
+func (c *WebAuthnCredential) SetTransports(transports []string) {
+	if transports == nil {
+		transports = []string{}
+	}
+	c.transports = transports
+}
+
+func (c *WebAuthnCredential) SetAAGUID(aaguid []byte) {
+	c.aaguid = aaguid
+}
+
+func (c *WebAuthnCredential) SetName(name string) {
+	c.name = name
+}
+
+func (c *WebAuthnCredential) SetCloneWarning(cloneWarning bool) {
+	c.cloneWarning = cloneWarning
+}
+
+func (c *WebAuthnCredential) SetLastUsedAt(lastUsedAt *time.Time) {
+	c.lastUsedAt = lastUsedAt
+}
+
+func (c *WebAuthnCredential) SetCreatedAt(createdAt time.Time) {
+	c.createdAt = createdAt
+}
+
+// Business methods
+
+// IsSignCountValid проверяет счетчик подписей из нового входа. Аутентификатор со счетчиком
+// обязан его увеличивать; значение не больше сохраненного означает, что ключ мог быть
+// скопирован. Нулевой счетчик с обеих сторон означает, что аутентификатор его не ведет.
+func (c *WebAuthnCredential) IsSignCountValid(signCount uint32) bool {
+	if signCount == 0 && c.signCount == 0 {
+		return true
+	}
+	return signCount > c.signCount
+}
+
+// RecordUse фиксирует успешный вход этим ключом
+func (c *WebAuthnCredential) RecordUse(signCount uint32) {
+	now := time.Now()
+	c.signCount = signCount
+	c.lastUsedAt = &now
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Виды церемоний WebAuthn
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"
+)
+
+// WebAuthnSession хранит challenge, выданный на шаге begin, до шага finish.
+// Сессия одноразовая: при завершении церемонии она удаляется.
+type WebAuthnSession struct {
+	id        uuid.UUID
+	userID    *uuid.UUID
+	challenge []byte
+	ceremony  string
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// Constructor
+func NewWebAuthnSession(userID *uuid.UUID, challenge []byte, ceremony string, expiresAt time.Time) *WebAuthnSession {
+	return &WebAuthnSession{
+		id:        uuid.New(),
+		userID:    userID,
+		challenge: challenge,
+		ceremony:  ceremony,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}
+}
+
+// Getters
+func (s *WebAuthnSession) ID() uuid.UUID {
+	return s.id
+}
+
+// UserID возвращает владельца сессии регистрации; у сессии входа пользователь неизвестен
+func (s *WebAuthnSession) UserID() *uuid.UUID {
+	return s.userID
+}
+
+func (s *WebAuthnSession) Challenge() []byte {
+	return s.challenge
+}
+
+func (s *WebAuthnSession) Ceremony() string {
+	return s.ceremony
+}
+
+func (s *WebAuthnSession) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *WebAuthnSession) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Setters
+func (s *WebAuthnSession) SetID(id uuid.UUID) {
+	s.id = id
+}
+
+func (s *WebAuthnSession) SetCreatedAt(createdAt time.Time) {
+	s.createdAt = createdAt
+}
+
+// Business methods
+func (s *WebAuthnSession) IsExpired() bool {
+	return time.Now().After(s.expiresAt)
+}
+
+// BelongsTo проверяет, что сессия регистрации начата этим пользователем
+func (s *WebAuthnSession) BelongsTo(userID uuid.UUID) bool {
+	return s.userID != nil && *s.userID == userID
+}
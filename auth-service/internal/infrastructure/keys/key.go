@@ -0,0 +1,180 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Поддерживаемые алгоритмы подписи
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	// ErrUnsupportedKey is returned when the PEM block holds a key of an unsupported type
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	// ErrInvalidPEM is returned when the file does not contain a PEM block
+	ErrInvalidPEM = errors.New("invalid PEM data")
+	// ErrNoSigningKey is returned when the key set has no private key to sign with
+	ErrNoSigningKey = errors.New("no signing key available")
+	// ErrKeyNotFound is returned when the key with the requested kid is unknown
+	ErrKeyNotFound = errors.New("key not found")
+)
+
+// Key представляет ключ подписи access токенов
+type Key struct {
+	id         string
+	algorithm  string
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+	retiredAt  *time.Time
+}
+
+// ID возвращает идентификатор ключа (kid)
+func (k *Key) ID() string {
+	return k.id
+}
+
+// Algorithm возвращает JWS алгоритм ключа
+func (k *Key) Algorithm() string {
+	return k.algorithm
+}
+
+// PrivateKey возвращает закрытый ключ, nil для ключей только для проверки
+func (k *Key) PrivateKey() crypto.Signer {
+	return k.privateKey
+}
+
+// PublicKey возвращает открытый ключ
+func (k *Key) PublicKey() crypto.PublicKey {
+	return k.publicKey
+}
+
+// RetiredAt возвращает время вывода ключа из оборота
+func (k *Key) RetiredAt() *time.Time {
+	return k.retiredAt
+}
+
+// CanSign проверяет, можно ли подписывать этим ключом
+func (k *Key) CanSign() bool {
+	return k.privateKey != nil && k.retiredAt == nil
+}
+
+// JWK представляет открытый ключ в формате RFC 7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKSet представляет набор открытых ключей
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK возвращает открытую часть ключа в формате JWK
+func (k *Key) JWK() JWK {
+	jwk := JWK{
+		KeyID:     k.id,
+		Use:       "sig",
+		Algorithm: k.algorithm,
+	}
+
+	switch pub := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// GenerateEd25519Key создает новый Ed25519 ключ в памяти
+func GenerateEd25519Key(id string) (*Key, error) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+	}
+
+	return &Key{
+		id:         id,
+		algorithm:  AlgorithmEdDSA,
+		privateKey: priv,
+		publicKey:  pub,
+	}, nil
+}
+
+// ParsePEM разбирает PEM файл с закрытым или открытым ключом
+func ParsePEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS8 private key: %w", err)
+		}
+		return newPrivateKey(id, parsed)
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS1 private key: %w", err)
+		}
+		return newPrivateKey(id, parsed)
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return newPublicKey(id, parsed)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+}
+
+func newPrivateKey(id string, parsed any) (*Key, error) {
+	switch priv := parsed.(type) {
+	case *rsa.PrivateKey:
+		if priv.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("%w: RSA key must be at least 2048 bits", ErrUnsupportedKey)
+		}
+		return &Key{id: id, algorithm: AlgorithmRS256, privateKey: priv, publicKey: &priv.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &Key{id: id, algorithm: AlgorithmEdDSA, privateKey: priv, publicKey: priv.Public()}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, parsed)
+	}
+}
+
+func newPublicKey(id string, parsed any) (*Key, error) {
+	switch pub := parsed.(type) {
+	case *rsa.PublicKey:
+		return &Key{id: id, algorithm: AlgorithmRS256, publicKey: pub}, nil
+	case ed25519.PublicKey:
+		return &Key{id: id, algorithm: AlgorithmEdDSA, publicKey: pub}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, parsed)
+	}
+}
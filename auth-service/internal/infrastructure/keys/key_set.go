@@ -0,0 +1,247 @@
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"social-network/auth-service/pkg/logger"
+)
+
+const keyFileExtension = ".pem"
+
+// KeySet хранит ключи подписи access токенов, загруженные из каталога с PEM файлами.
+//
+// Идентификатор ключа (kid) совпадает с именем файла без расширения. Подписывает
+// ключ, заданный в конфигурации, либо ключ с наибольшим kid, поэтому файлы удобно
+// называть по дате выпуска. Ключ, удаленный из каталога, продолжает проверять
+// подписи в течение grace period, чтобы уже выданные токены не стали невалидными.
+type KeySet struct {
+	mu           sync.RWMutex
+	dir          string
+	signingKeyID string
+	gracePeriod  time.Duration
+	keys         map[string]*Key
+	signer       *Key
+	logger       logger.Logger
+}
+
+// NewKeySet загружает ключи из каталога. Если каталог не задан, создается
+// временный Ed25519 ключ, который живет только до перезапуска сервиса.
+func NewKeySet(dir, signingKeyID string, gracePeriod time.Duration, log logger.Logger) (*KeySet, error) {
+	ks := &KeySet{
+		dir:          dir,
+		signingKeyID: signingKeyID,
+		gracePeriod:  gracePeriod,
+		keys:         make(map[string]*Key),
+		logger:       log,
+	}
+
+	if dir == "" {
+		key, err := GenerateEd25519Key(ephemeralKeyID())
+		if err != nil {
+			return nil, err
+		}
+		ks.keys[key.ID()] = key
+		ks.signer = key
+
+		log.Warn("JWT keys directory is not configured, using ephemeral signing key",
+			logger.String("kid", key.ID()),
+		)
+		return ks, nil
+	}
+
+	if err := ks.Reload(); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Reload перечитывает каталог с ключами. При ошибке текущий набор ключей не меняется.
+func (ks *KeySet) Reload() error {
+	if ks.dir == "" {
+		return nil
+	}
+
+	loaded, err := loadDir(ks.dir)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	now := time.Now()
+	for id, key := range ks.keys {
+		if _, ok := loaded[id]; ok {
+			continue
+		}
+
+		retiredAt := key.retiredAt
+		if retiredAt == nil {
+			retiredAt = &now
+		}
+		if now.Sub(*retiredAt) >= ks.gracePeriod {
+			ks.logger.Info("JWT key grace period expired, key removed", logger.String("kid", id))
+			continue
+		}
+
+		loaded[id] = &Key{
+			id:        key.id,
+			algorithm: key.algorithm,
+			publicKey: key.publicKey,
+			retiredAt: retiredAt,
+		}
+	}
+
+	signer, err := selectSigner(loaded, ks.signingKeyID)
+	if err != nil {
+		return err
+	}
+
+	if ks.signer == nil || ks.signer.ID() != signer.ID() {
+		ks.logger.Info("JWT signing key selected",
+			logger.String("kid", signer.ID()),
+			logger.String("alg", signer.Algorithm()),
+		)
+	}
+
+	ks.keys = loaded
+	ks.signer = signer
+	return nil
+}
+
+// Watch периодически перечитывает каталог с ключами до отмены контекста
+func (ks *KeySet) Watch(ctx context.Context, interval time.Duration) {
+	if ks.dir == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.Reload(); err != nil {
+				ks.logger.Error("Failed to reload JWT keys",
+					logger.String("dir", ks.dir),
+					logger.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// SigningKey возвращает текущий ключ подписи
+func (ks *KeySet) SigningKey() (*Key, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if ks.signer == nil {
+		return nil, ErrNoSigningKey
+	}
+	return ks.signer, nil
+}
+
+// VerificationKey возвращает ключ для проверки подписи по kid
+func (ks *KeySet) VerificationKey(id string) (*Key, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[id]
+	if !ok || ks.isExpired(key) {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// JWKS возвращает открытые ключи, которыми можно проверять выданные токены
+func (ks *KeySet) JWKS() JWKSet {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	ids := make([]string, 0, len(ks.keys))
+	for id, key := range ks.keys {
+		if !ks.isExpired(key) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		set.Keys = append(set.Keys, ks.keys[id].JWK())
+	}
+	return set
+}
+
+func (ks *KeySet) isExpired(key *Key) bool {
+	return key.retiredAt != nil && time.Since(*key.retiredAt) >= ks.gracePeriod
+}
+
+func loadDir(dir string) (map[string]*Key, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys directory: %w", err)
+	}
+
+	loaded := make(map[string]*Key)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExtension {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", entry.Name(), err)
+		}
+
+		id := strings.TrimSuffix(entry.Name(), keyFileExtension)
+		key, err := ParsePEM(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", entry.Name(), err)
+		}
+		loaded[id] = key
+	}
+
+	return loaded, nil
+}
+
+func selectSigner(keys map[string]*Key, signingKeyID string) (*Key, error) {
+	if signingKeyID != "" {
+		key, ok := keys[signingKeyID]
+		if !ok || !key.CanSign() {
+			return nil, fmt.Errorf("%w: private key %q not found", ErrNoSigningKey, signingKeyID)
+		}
+		return key, nil
+	}
+
+	var signer *Key
+	for id, key := range keys {
+		if key.CanSign() && (signer == nil || id > signer.ID()) {
+			signer = key
+		}
+	}
+
+	if signer == nil {
+		return nil, ErrNoSigningKey
+	}
+	return signer, nil
+}
+
+func ephemeralKeyID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "ephemeral-" + hex.EncodeToString(b)
+}
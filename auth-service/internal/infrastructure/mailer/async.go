@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"social-network/auth-service/pkg/logger"
+)
+
+const sendTimeout = 30 * time.Second
+
+type job struct {
+	msg     Message
+	attempt int
+}
+
+// AsyncMailer ставит письма в очередь и отправляет их в фоне через вложенный Mailer.
+// Неудачные отправки повторяются с экспоненциальной задержкой до maxAttempts раз.
+// Очередь хранится в памяти: письма, не отправленные до остановки сервиса, теряются.
+type AsyncMailer struct {
+	next        Mailer
+	queue       chan job
+	maxAttempts int
+	baseDelay   time.Duration
+	logger      logger.Logger
+}
+
+// NewAsyncMailer создает асинхронную обертку над mailer
+func NewAsyncMailer(next Mailer, queueSize, maxAttempts int, baseDelay time.Duration, log logger.Logger) *AsyncMailer {
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	return &AsyncMailer{
+		next:        next,
+		queue:       make(chan job, queueSize),
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		logger:      log,
+	}
+}
+
+// Send ставит письмо в очередь. Возвращает ErrQueueFull, если очередь переполнена.
+func (m *AsyncMailer) Send(ctx context.Context, msg Message) error {
+	return m.enqueue(job{msg: msg, attempt: 1})
+}
+
+// Run отправляет письма из очереди до отмены контекста
+func (m *AsyncMailer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			if pending := len(m.queue); pending > 0 {
+				m.logger.Warn("Mailer stopped with unsent messages", logger.Int("pending", pending))
+			}
+			return
+		case j := <-m.queue:
+			m.deliver(ctx, j)
+		}
+	}
+}
+
+func (m *AsyncMailer) deliver(ctx context.Context, j job) {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	err := m.next.Send(sendCtx, j.msg)
+	cancel()
+
+	if err == nil {
+		return
+	}
+
+	if j.attempt >= m.maxAttempts {
+		m.logger.Error("Failed to send email, giving up",
+			logger.String("subject", j.msg.Subject),
+			logger.Int("attempts", j.attempt),
+			logger.Error(err),
+		)
+		return
+	}
+
+	delay := m.retryDelay(j.attempt)
+	m.logger.Warn("Failed to send email, will retry",
+		logger.String("subject", j.msg.Subject),
+		logger.Int("attempt", j.attempt),
+		logger.Duration("retry_in", delay),
+		logger.Error(err),
+	)
+
+	j.attempt++
+	time.AfterFunc(delay, func() {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := m.enqueue(j); err != nil {
+			m.logger.Error("Failed to requeue email", logger.String("subject", j.msg.Subject), logger.Error(err))
+		}
+	})
+}
+
+func (m *AsyncMailer) enqueue(j job) error {
+	select {
+	case m.queue <- j:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (m *AsyncMailer) retryDelay(attempt int) time.Duration {
+	return time.Duration(float64(m.baseDelay) * math.Pow(2, float64(attempt-1)))
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer сохраняет письма в каталог в формате .eml вместо отправки.
+// Предназначен для локальной разработки: письма открываются любым почтовым клиентом.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer создает mailer, пишущий письма в каталог
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send сохраняет письмо в файл
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	_, data, err := buildMIME(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), hex.EncodeToString(b))
+
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o640)
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRecipient is returned when the recipient address cannot be parsed
+	ErrInvalidRecipient = errors.New("invalid recipient address")
+	// ErrQueueFull is returned when the async mailer cannot accept more messages
+	ErrQueueFull = errors.New("mail queue is full")
+	// ErrTemplateNotFound is returned when no template exists for the requested name
+	ErrTemplateNotFound = errors.New("mail template not found")
+)
+
+// Message представляет письмо с текстовой и HTML версиями
+type Message struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Mailer отправляет письма
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// buildMIME собирает письмо в формате multipart/alternative
+func buildMIME(from string, msg Message) (string, []byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidRecipient, err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	if err := writePart(parts, "text/plain; charset=utf-8", msg.TextBody); err != nil {
+		return "", nil, err
+	}
+	if msg.HTMLBody != "" {
+		if err := writePart(parts, "text/html; charset=utf-8", msg.HTMLBody); err != nil {
+			return "", nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", singleLine(msg.Subject))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID(from)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.key, h.value)
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+
+	return to.Address, buf.Bytes(), nil
+}
+
+func writePart(parts *multipart.Writer, contentType, content string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	w, err := parts.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func messageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,140 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Имена шаблонов писем
+const (
+	TemplateVerifyEmail   = "verify_email"
+	TemplatePasswordReset = "password_reset"
+	TemplateMagicLink     = "magic_link"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Templates хранит локализованные шаблоны писем. Для каждого письма и языка
+// есть файл <name>.txt с блоками "subject" и "text" и файл <name>.html.
+type Templates struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+var templateFuncs = map[string]any{
+	"plural": pluralRU,
+}
+
+// NewTemplates загружает встроенные шаблоны
+func NewTemplates(defaultLocale string) (*Templates, error) {
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+
+	err := fs.WalkDir(templateFS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		locale := path.Base(path.Dir(p))
+		name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+		key := locale + "/" + name
+
+		switch path.Ext(p) {
+		case ".txt":
+			tmpl, err := texttemplate.New(name).Funcs(templateFuncs).ParseFS(templateFS, p)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", p, err)
+			}
+			t.text[key] = tmpl
+		case ".html":
+			tmpl, err := htmltemplate.New(path.Base(p)).Funcs(templateFuncs).ParseFS(templateFS, p)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", p, err)
+			}
+			t.html[key] = tmpl
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.HasLocale(defaultLocale) {
+		return nil, fmt.Errorf("%w: no templates for default locale %q", ErrTemplateNotFound, defaultLocale)
+	}
+
+	return t, nil
+}
+
+// HasLocale проверяет, есть ли шаблоны для языка
+func (t *Templates) HasLocale(locale string) bool {
+	for key := range t.text {
+		if strings.HasPrefix(key, locale+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Render заполняет шаблон письма. Если для языка нет шаблона, используется язык по умолчанию.
+func (t *Templates) Render(locale, name string, data any) (Message, error) {
+	if _, ok := t.text[locale+"/"+name]; !ok {
+		locale = t.defaultLocale
+	}
+	key := locale + "/" + name
+
+	textTmpl, ok := t.text[key]
+	if !ok {
+		return Message{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, key)
+	}
+
+	var subject, text bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := textTmpl.ExecuteTemplate(&text, "text", data); err != nil {
+		return Message{}, err
+	}
+
+	msg := Message{
+		Subject:  strings.TrimSpace(subject.String()),
+		TextBody: strings.TrimSpace(text.String()) + "\n",
+	}
+
+	if htmlTmpl, ok := t.html[key]; ok {
+		var html bytes.Buffer
+		if err := htmlTmpl.Execute(&html, data); err != nil {
+			return Message{}, err
+		}
+		msg.HTMLBody = html.String()
+	}
+
+	return msg, nil
+}
+
+// pluralRU выбирает форму слова для числа по правилам русского языка
+func pluralRU(n int, one, few, many string) string {
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return many
+	}
+	switch n % 10 {
+	case 1:
+		return one
+	case 2, 3, 4:
+		return few
+	default:
+		return many
+	}
+}
@@ -0,0 +1,80 @@
+package mailer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	templates, err := NewTemplates("en")
+	if err != nil {
+		t.Fatalf("load templates: %v", err)
+	}
+
+	data := struct {
+		DisplayName string
+		Link        string
+		Hours       int
+	}{"<Alice>", "https://app.test/verify-email?token=abc", 24}
+
+	tests := []struct {
+		name        string
+		locale      string
+		wantSubject string
+		wantText    string
+	}{
+		{"english", "en", "Confirm your email address", "valid for 24 hours"},
+		{"russian with plural form", "ru", "Подтвердите адрес электронной почты", "действительна 24 часа"},
+		{"unknown locale falls back to default", "de", "Confirm your email address", "valid for 24 hours"},
+		{"empty locale falls back to default", "", "Confirm your email address", "valid for 24 hours"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := templates.Render(tt.locale, TemplateVerifyEmail, data)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			if msg.Subject != tt.wantSubject {
+				t.Fatalf("subject = %q, want %q", msg.Subject, tt.wantSubject)
+			}
+			if !strings.Contains(msg.TextBody, tt.wantText) || !strings.Contains(msg.TextBody, data.Link) {
+				t.Fatalf("unexpected text body:\n%s", msg.TextBody)
+			}
+			// В HTML версии пользовательские данные экранируются
+			if strings.Contains(msg.HTMLBody, "<Alice>") || !strings.Contains(msg.HTMLBody, "&lt;Alice&gt;") {
+				t.Fatalf("display name must be escaped in HTML body")
+			}
+		})
+	}
+}
+
+func TestTemplates_UnknownTemplate(t *testing.T) {
+	templates, err := NewTemplates("en")
+	if err != nil {
+		t.Fatalf("load templates: %v", err)
+	}
+
+	if _, err := templates.Render("en", "missing", nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", err)
+	}
+	if _, err := NewTemplates("xx"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound for missing default locale, got %v", err)
+	}
+}
+
+func TestPluralRU(t *testing.T) {
+	tests := map[int]string{
+		1: "час", 21: "час",
+		2: "часа", 4: "часа", 24: "часа",
+		5: "часов", 11: "часов", 12: "часов", 14: "часов", 111: "часов",
+	}
+
+	for n, want := range tests {
+		if got := pluralRU(n, "час", "часа", "часов"); got != want {
+			t.Errorf("pluralRU(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
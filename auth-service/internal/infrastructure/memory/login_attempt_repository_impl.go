@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"sync"
+	"time"
+)
+
+// loginAttemptRepositoryImpl хранит счетчики в памяти процесса. Подходит для одного
+// экземпляра сервиса и тестов; при нескольких репликах счетчики у каждой свои.
+type loginAttemptRepositoryImpl struct {
+	mu        sync.Mutex
+	attempts  map[string]*loginAttemptRecord
+	lastSweep time.Time
+}
+
+type loginAttemptRecord struct {
+	failedCount  int
+	lastFailedAt time.Time
+	lockedUntil  *time.Time
+}
+
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{
+		attempts:  make(map[string]*loginAttemptRecord),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *loginAttemptRepositoryImpl) GetByKey(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.attempts[key]
+	if !ok {
+		return nil, repository.ErrLoginAttemptNotFound
+	}
+
+	return record.toDomain(key), nil
+}
+
+func (r *loginAttemptRepositoryImpl) RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.sweep(now, window)
+
+	record, ok := r.attempts[key]
+	if !ok || now.Sub(record.lastFailedAt) > window {
+		record = &loginAttemptRecord{lockedUntil: recordLock(record)}
+		r.attempts[key] = record
+	}
+
+	record.failedCount++
+	record.lastFailedAt = now
+
+	return record.toDomain(key), nil
+}
+
+func (r *loginAttemptRepositoryImpl) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.attempts[key]
+	if !ok {
+		return repository.ErrLoginAttemptNotFound
+	}
+
+	record.lockedUntil = &until
+	return nil
+}
+
+func (r *loginAttemptRepositoryImpl) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// sweep удаляет устаревшие записи не чаще одного раза за window,
+// чтобы перебор с множества IP не раздувал память
+func (r *loginAttemptRepositoryImpl) sweep(now time.Time, window time.Duration) {
+	if now.Sub(r.lastSweep) < window {
+		return
+	}
+
+	for key, record := range r.attempts {
+		locked := record.lockedUntil != nil && now.Before(*record.lockedUntil)
+		if !locked && now.Sub(record.lastFailedAt) > window {
+			delete(r.attempts, key)
+		}
+	}
+	r.lastSweep = now
+}
+
+func (rec *loginAttemptRecord) toDomain(key string) *domain.LoginAttempt {
+	attempt := domain.NewLoginAttempt(key)
+	attempt.SetFailedCount(rec.failedCount)
+	attempt.SetLastFailedAt(rec.lastFailedAt)
+	if rec.lockedUntil != nil {
+		lockedUntil := *rec.lockedUntil
+		attempt.SetLockedUntil(&lockedUntil)
+	}
+	return attempt
+}
+
+func recordLock(record *loginAttemptRecord) *time.Time {
+	if record == nil {
+		return nil
+	}
+	return record.lockedUntil
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"social-network/auth-service/internal/repository"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// tokenRevocationSweepInterval - как часто запись удаляет истекшие отзывы
+const tokenRevocationSweepInterval = time.Minute
+
+// tokenRevocationRepositoryImpl хранит отзывы в памяти процесса с истечением по TTL.
+// Подходит для одного экземпляра сервиса: отзыв на одной реплике не виден другим.
+type tokenRevocationRepositoryImpl struct {
+	mu         sync.RWMutex
+	tokens     map[string]time.Time
+	users      map[uuid.UUID]userRevocation
+	sessions   map[uuid.UUID]time.Time
+	exemptions map[uuid.UUID]userRevocation
+	lastSweep  time.Time
+}
+
+type userRevocation struct {
+	revokedBefore time.Time
+	expiresAt     time.Time
+}
+
+func NewTokenRevocationRepository() repository.TokenRevocationRepository {
+	return &tokenRevocationRepositoryImpl{
+		tokens:     make(map[string]time.Time),
+		users:      make(map[uuid.UUID]userRevocation),
+		sessions:   make(map[uuid.UUID]time.Time),
+		exemptions: make(map[uuid.UUID]userRevocation),
+		lastSweep:  time.Now(),
+	}
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(time.Now())
+	r.tokens[jti] = expiresAt
+	return nil
+}
+
+func (r *tokenRevocationRepositoryImpl) ConsumeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.sweep(now)
+	if current, ok := r.tokens[jti]; ok && now.Before(current) {
+		return false, nil
+	}
+
+	r.tokens[jti] = expiresAt
+	return true, nil
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeUserTokens(ctx context.Context, userID uuid.UUID, issuedBefore, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(time.Now())
+
+	current, ok := r.users[userID]
+	if ok && current.revokedBefore.After(issuedBefore) {
+		issuedBefore = current.revokedBefore
+	}
+	if ok && current.expiresAt.After(expiresAt) {
+		expiresAt = current.expiresAt
+	}
+
+	r.users[userID] = userRevocation{revokedBefore: issuedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+func (r *tokenRevocationRepositoryImpl) ExemptSession(ctx context.Context, sessionID, userID uuid.UUID, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(now)
+	if marker, ok := r.users[userID]; ok && now.Before(marker.revokedBefore) {
+		r.exemptions[sessionID] = marker
+	}
+	return nil
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(time.Now())
+	if current, ok := r.sessions[sessionID]; !ok || expiresAt.After(current) {
+		r.sessions[sessionID] = expiresAt
+	}
+	return nil
+}
+
+func (r *tokenRevocationRepositoryImpl) IsRevoked(ctx context.Context, jti string, userID, sessionID uuid.UUID, issuedAt time.Time) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	if expiresAt, ok := r.tokens[jti]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+
+	if sessionID != uuid.Nil {
+		if expiresAt, ok := r.sessions[sessionID]; ok && now.Before(expiresAt) {
+			return true, nil
+		}
+	}
+
+	marker, ok := r.users[userID]
+	if ok && now.Before(marker.expiresAt) && issuedAt.Before(marker.revokedBefore) {
+		// Сессия, начатая после отзыва, не попадает под маркер, пока он не сдвинется
+		if exemption, exempt := r.exemptions[sessionID]; !exempt || !exemption.revokedBefore.Equal(marker.revokedBefore) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *tokenRevocationRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deleteExpired(time.Now()), nil
+}
+
+// sweep удаляет истекшие записи не чаще tokenRevocationSweepInterval
+func (r *tokenRevocationRepositoryImpl) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < tokenRevocationSweepInterval {
+		return
+	}
+	r.deleteExpired(now)
+}
+
+func (r *tokenRevocationRepositoryImpl) deleteExpired(now time.Time) int64 {
+	var deleted int64
+	for jti, expiresAt := range r.tokens {
+		if !now.Before(expiresAt) {
+			delete(r.tokens, jti)
+			deleted++
+		}
+	}
+	for userID, marker := range r.users {
+		if !now.Before(marker.expiresAt) {
+			delete(r.users, userID)
+			deleted++
+		}
+	}
+	for sessionID, expiresAt := range r.sessions {
+		if !now.Before(expiresAt) {
+			delete(r.sessions, sessionID)
+			deleted++
+		}
+	}
+	for sessionID, exemption := range r.exemptions {
+		if !now.Before(exemption.expiresAt) {
+			delete(r.exemptions, sessionID)
+			deleted++
+		}
+	}
+	r.lastSweep = now
+	return deleted
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Маркер хранится до конца жизни самого позднего токена и не сдвигается назад
+func TestTokenRevocationRepository_MarkerOnlyMovesForward(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTokenRevocationRepository()
+	userID := uuid.New()
+	now := time.Now().Truncate(time.Second)
+
+	if err := repo.RevokeUserTokens(ctx, userID, now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := repo.RevokeUserTokens(ctx, userID, now.Add(-time.Minute), now.Add(time.Minute)); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"before marker", now.Add(-time.Second), true},
+		{"at marker", now, false},
+		{"after marker", now.Add(time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := repo.IsRevoked(ctx, "", userID, uuid.Nil, tt.issuedAt)
+			if err != nil {
+				t.Fatalf("is revoked: %v", err)
+			}
+			if revoked != tt.revoked {
+				t.Fatalf("revoked = %v, want %v", revoked, tt.revoked)
+			}
+		})
+	}
+}
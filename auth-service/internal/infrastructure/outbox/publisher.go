@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Message представляет событие из outbox, передаваемое во внешний транспорт.
+// ID сохраняется между повторными попытками, поэтому потребители могут
+// отбрасывать дубликаты по нему.
+type Message struct {
+	ID            uuid.UUID       `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// Publisher доставляет сообщения потребителям. Доставка выполняется
+// как минимум один раз: реализация должна спокойно принимать повторы.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// LogPublisher пишет события в лог. Используется, пока брокер не подключен.
+type LogPublisher struct {
+	logger logger.Logger
+}
+
+// NewLogPublisher создает publisher, пишущий события в лог
+func NewLogPublisher(log logger.Logger) *LogPublisher {
+	return &LogPublisher{logger: log}
+}
+
+// Publish пишет событие в лог
+func (p *LogPublisher) Publish(ctx context.Context, msg Message) error {
+	p.logger.Info("Domain event published",
+		logger.String("event_id", msg.ID.String()),
+		logger.String("event_type", msg.Type),
+		logger.String("aggregate_type", msg.AggregateType),
+		logger.String("aggregate_id", msg.AggregateID.String()),
+		logger.String("payload", string(msg.Payload)),
+	)
+	return nil
+}
+
+// InMemoryPublisher хранит опубликованные события в памяти. Повторная
+// публикация сообщения с тем же ID игнорируется. Предназначен для тестов.
+type InMemoryPublisher struct {
+	mu       sync.Mutex
+	seen     map[uuid.UUID]struct{}
+	messages []Message
+}
+
+// NewInMemoryPublisher создает publisher, сохраняющий события в памяти
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{seen: make(map[uuid.UUID]struct{})}
+}
+
+// Publish сохраняет событие, если оно еще не было получено
+func (p *InMemoryPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.seen[msg.ID]; ok {
+		return nil
+	}
+
+	p.seen[msg.ID] = struct{}{}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию полученных событий в порядке публикации
+func (p *InMemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]Message, len(p.messages))
+	copy(messages, p.messages)
+	return messages
+}
+
+// Reset очищает полученные события
+func (p *InMemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seen = make(map[uuid.UUID]struct{})
+	p.messages = nil
+}
@@ -0,0 +1,210 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	defaultBatchSize = 100
+	retryBaseDelay   = time.Second
+	retryMaxDelay    = 10 * time.Minute
+	maxErrorLength   = 1000
+)
+
+// txBeginner открывает транзакцию, в которой relay выбирает и помечает события
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Relay периодически выбирает неопубликованные события из outbox и передает их Publisher.
+//
+// Строки блокируются через FOR UPDATE SKIP LOCKED, поэтому несколько экземпляров
+// сервиса могут работать одновременно, не публикуя одно событие параллельно.
+// Событие помечается опубликованным только после успешного Publish; при сбое
+// процесса до фиксации транзакции оно будет отправлено повторно.
+type Relay struct {
+	db        txBeginner
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+	logger    logger.Logger
+}
+
+// NewRelay создает relay для таблицы outbox
+func NewRelay(db *pgxpool.Pool, publisher Publisher, interval time.Duration, batchSize int, log logger.Logger) *Relay {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return &Relay{
+		db:        db,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log,
+	}
+}
+
+// Run публикует события до отмены контекста
+func (r *Relay) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain обрабатывает пачки, пока в outbox есть готовые к отправке события
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := r.ProcessBatch(ctx)
+		if err != nil {
+			r.logger.Error("Failed to process outbox batch", logger.Error(err))
+			return
+		}
+		if processed < r.batchSize {
+			return
+		}
+	}
+}
+
+// ProcessBatch публикует одну пачку событий и возвращает количество обработанных
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	messages, attempts, err := r.fetch(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		if err := r.publisher.Publish(ctx, msg); err != nil {
+			r.logger.Warn("Failed to publish outbox event",
+				logger.String("event_id", msg.ID.String()),
+				logger.String("event_type", msg.Type),
+				logger.Int("attempt", attempts[i]+1),
+				logger.Error(err),
+			)
+
+			if err := r.markFailed(ctx, tx, msg, attempts[i]+1, err); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if err := r.markPublished(ctx, tx, msg); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) fetch(ctx context.Context, tx pgx.Tx) ([]Message, []int, error) {
+	query := `
+        SELECT id, event_type, aggregate_type, aggregate_id, payload, occurred_at, attempts
+        FROM outbox
+        WHERE published_at IS NULL AND next_attempt_at <= NOW()
+        ORDER BY occurred_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `
+
+	rows, err := tx.Query(ctx, query, r.batchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var messages []Message
+	var attempts []int
+	for rows.Next() {
+		var msg Message
+		var attempt int
+		if err := rows.Scan(
+			&msg.ID,
+			&msg.Type,
+			&msg.AggregateType,
+			&msg.AggregateID,
+			&msg.Payload,
+			&msg.OccurredAt,
+			&attempt,
+		); err != nil {
+			return nil, nil, err
+		}
+		messages = append(messages, msg)
+		attempts = append(attempts, attempt)
+	}
+
+	return messages, attempts, rows.Err()
+}
+
+func (r *Relay) markPublished(ctx context.Context, tx pgx.Tx, msg Message) error {
+	query := `
+        UPDATE outbox
+        SET published_at = NOW(), last_error = NULL
+        WHERE id = $1
+    `
+
+	_, err := tx.Exec(ctx, query, msg.ID)
+	return err
+}
+
+func (r *Relay) markFailed(ctx context.Context, tx pgx.Tx, msg Message, attempt int, publishErr error) error {
+	query := `
+        UPDATE outbox
+        SET attempts = $2, last_error = $3, next_attempt_at = NOW() + make_interval(secs => $4)
+        WHERE id = $1
+    `
+
+	lastError := publishErr.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+
+	_, err := tx.Exec(ctx, query, msg.ID, attempt, lastError, RetryDelay(attempt).Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to record outbox publish failure: %w", err)
+	}
+	return nil
+}
+
+// RetryDelay возвращает паузу перед следующей попыткой: экспоненциальный рост
+// от одной секунды, но не больше десяти минут
+func RetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(retryBaseDelay) * math.Pow(2, float64(attempt-1))
+	if delay > float64(retryMaxDelay) {
+		return retryMaxDelay
+	}
+	return time.Duration(delay)
+}
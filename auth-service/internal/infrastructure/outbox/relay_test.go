@@ -0,0 +1,327 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// outboxRow - строка таблицы outbox
+type outboxRow struct {
+	msg       Message
+	attempts  int
+	published bool
+	lastError string
+	retryIn   float64
+}
+
+// fakeOutbox хранит таблицу outbox в памяти. Изменения транзакции применяются только при Commit.
+type fakeOutbox struct {
+	rows      []*outboxRow
+	commitErr error
+}
+
+func (db *fakeOutbox) Begin(ctx context.Context) (pgx.Tx, error) {
+	return &fakeTx{db: db}, nil
+}
+
+func (db *fakeOutbox) add(eventType string) *outboxRow {
+	row := &outboxRow{msg: Message{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: "user",
+		AggregateID:   uuid.New(),
+		Payload:       json.RawMessage(`{}`),
+		OccurredAt:    time.Now(),
+	}}
+	db.rows = append(db.rows, row)
+	return row
+}
+
+type fakeTx struct {
+	pgx.Tx
+	db      *fakeOutbox
+	pending []func()
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	limit := args[0].(int)
+
+	rows := &fakeRows{}
+	for _, row := range tx.db.rows {
+		if !row.published && len(rows.rows) < limit {
+			rows.rows = append(rows.rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	row := tx.db.find(args[0].(uuid.UUID))
+
+	if strings.Contains(sql, "published_at = NOW()") {
+		tx.pending = append(tx.pending, func() { row.published = true })
+	} else {
+		attempts, lastError, retryIn := args[1].(int), args[2].(string), args[3].(float64)
+		tx.pending = append(tx.pending, func() {
+			row.attempts = attempts
+			row.lastError = lastError
+			row.retryIn = retryIn
+		})
+	}
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	if tx.db.commitErr != nil {
+		return tx.db.commitErr
+	}
+	for _, apply := range tx.pending {
+		apply()
+	}
+	tx.pending = nil
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	tx.pending = nil
+	return nil
+}
+
+func (db *fakeOutbox) find(id uuid.UUID) *outboxRow {
+	for _, row := range db.rows {
+		if row.msg.ID == id {
+			return row
+		}
+	}
+	return nil
+}
+
+type fakeRows struct {
+	pgx.Rows
+	rows []*outboxRow
+	next int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.rows[r.next-1]
+	*dest[0].(*uuid.UUID) = row.msg.ID
+	*dest[1].(*string) = row.msg.Type
+	*dest[2].(*string) = row.msg.AggregateType
+	*dest[3].(*uuid.UUID) = row.msg.AggregateID
+	*dest[4].(*json.RawMessage) = row.msg.Payload
+	*dest[5].(*time.Time) = row.msg.OccurredAt
+	*dest[6].(*int) = row.attempts
+	return nil
+}
+
+func (r *fakeRows) Close() {}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+// failingPublisher отклоняет события указанных типов и передает остальные дальше
+type failingPublisher struct {
+	next  Publisher
+	types map[string]bool
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, msg Message) error {
+	if p.types[msg.Type] {
+		return errors.New("broker unavailable")
+	}
+	return p.next.Publish(ctx, msg)
+}
+
+func newTestRelay(db txBeginner, publisher Publisher, batchSize int) *Relay {
+	return &Relay{
+		db:        db,
+		publisher: publisher,
+		batchSize: batchSize,
+		logger:    logger.NewCustomLogger("auth-service-test", "error", io.Discard),
+	}
+}
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []string
+		failTypes     []string
+		batchSize     int
+		wantProcessed int
+		wantPublished []string
+	}{
+		{
+			name:          "publishes events in order",
+			events:        []string{"user.registered", "user.email_verified", "user.logged_out"},
+			batchSize:     10,
+			wantProcessed: 3,
+			wantPublished: []string{"user.registered", "user.email_verified", "user.logged_out"},
+		},
+		{
+			name:          "respects batch size",
+			events:        []string{"user.registered", "user.email_verified", "user.logged_out"},
+			batchSize:     2,
+			wantProcessed: 2,
+			wantPublished: []string{"user.registered", "user.email_verified"},
+		},
+		{
+			name:          "failed event does not block the rest",
+			events:        []string{"user.registered", "user.email_verified", "user.logged_out"},
+			failTypes:     []string{"user.email_verified"},
+			batchSize:     10,
+			wantProcessed: 3,
+			wantPublished: []string{"user.registered", "user.logged_out"},
+		},
+		{
+			name:          "empty outbox",
+			batchSize:     10,
+			wantProcessed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeOutbox{}
+			for _, eventType := range tt.events {
+				db.add(eventType)
+			}
+
+			publisher := NewInMemoryPublisher()
+			failing := &failingPublisher{next: publisher, types: map[string]bool{}}
+			for _, eventType := range tt.failTypes {
+				failing.types[eventType] = true
+			}
+
+			processed, err := newTestRelay(db, failing, tt.batchSize).ProcessBatch(context.Background())
+			if err != nil {
+				t.Fatalf("process batch: %v", err)
+			}
+			if processed != tt.wantProcessed {
+				t.Fatalf("expected %d processed, got %d", tt.wantProcessed, processed)
+			}
+
+			var published []string
+			for _, msg := range publisher.Messages() {
+				published = append(published, msg.Type)
+				if !db.find(msg.ID).published {
+					t.Fatalf("delivered event %s must be marked as published", msg.Type)
+				}
+			}
+			if strings.Join(published, ",") != strings.Join(tt.wantPublished, ",") {
+				t.Fatalf("expected published %v, got %v", tt.wantPublished, published)
+			}
+
+			for _, row := range db.rows {
+				if !failing.types[row.msg.Type] {
+					continue
+				}
+				if row.published || row.attempts != 1 || row.lastError != "broker unavailable" {
+					t.Fatalf("failed event must stay unpublished with the error recorded, got %+v", row)
+				}
+				if row.retryIn != RetryDelay(1).Seconds() {
+					t.Fatalf("expected retry in %v, got %vs", RetryDelay(1), row.retryIn)
+				}
+			}
+		})
+	}
+}
+
+func TestRelay_FailedAttemptsBackOff(t *testing.T) {
+	db := &fakeOutbox{}
+	row := db.add("user.registered")
+	relay := newTestRelay(db, &failingPublisher{types: map[string]bool{"user.registered": true}}, 10)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err := relay.ProcessBatch(context.Background()); err != nil {
+			t.Fatalf("process batch: %v", err)
+		}
+		if row.attempts != attempt || row.retryIn != RetryDelay(attempt).Seconds() {
+			t.Fatalf("attempt %d: got attempts=%d retry in %vs", attempt, row.attempts, row.retryIn)
+		}
+	}
+}
+
+func TestRelay_RedeliveryAfterFailedCommitIsDeduplicated(t *testing.T) {
+	db := &fakeOutbox{commitErr: errors.New("connection reset")}
+	db.add("user.registered")
+
+	publisher := NewInMemoryPublisher()
+	relay := newTestRelay(db, publisher, 10)
+
+	// Событие доставлено, но отметка о публикации потерялась вместе с транзакцией
+	if _, err := relay.ProcessBatch(context.Background()); err == nil {
+		t.Fatal("expected commit error")
+	}
+	if db.rows[0].published {
+		t.Fatal("event must stay unpublished after a failed commit")
+	}
+
+	db.commitErr = nil
+	if _, err := relay.ProcessBatch(context.Background()); err != nil {
+		t.Fatalf("process batch: %v", err)
+	}
+
+	if got := len(publisher.Messages()); got != 1 {
+		t.Fatalf("redelivered event must be deduplicated by ID, got %d messages", got)
+	}
+	if !db.rows[0].published {
+		t.Fatal("event must be marked as published")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: time.Second},
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 5, want: 16 * time.Second},
+		{attempt: 10, want: 512 * time.Second},
+		{attempt: 11, want: 10 * time.Minute},
+		{attempt: 100, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := RetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("RetryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestInMemoryPublisher_IgnoresDuplicates(t *testing.T) {
+	publisher := NewInMemoryPublisher()
+	msg := Message{ID: uuid.New(), Type: "user.registered"}
+
+	for i := 0; i < 3; i++ {
+		if err := publisher.Publish(context.Background(), msg); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if got := len(publisher.Messages()); got != 1 {
+		t.Fatalf("expected 1 message, got %d", got)
+	}
+
+	publisher.Reset()
+	if got := len(publisher.Messages()); got != 0 {
+		t.Fatalf("expected no messages after reset, got %d", got)
+	}
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type auditEventRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAuditEventRepository(db DBTX) repository.AuditEventRepository {
+	return &auditEventRepositoryImpl{db: db}
+}
+
+const auditEventColumns = `id, event_type, actor_id, target_id, ip_address, user_agent, request_id, details, created_at`
+
+func (r *auditEventRepositoryImpl) Create(ctx context.Context, event *domain.AuditEvent) error {
+	query := `
+        INSERT INTO audit_events (` + auditEventColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+
+	details := event.Details()
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		event.ID(),
+		string(event.Type()),
+		event.ActorID(),
+		event.TargetID(),
+		event.IPAddress(),
+		event.UserAgent(),
+		event.RequestID(),
+		detailsJSON,
+		event.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *auditEventRepositoryImpl) List(ctx context.Context, filter repository.AuditEventFilter) ([]*domain.AuditEvent, error) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.UserID != nil {
+		placeholder := arg(*filter.UserID)
+		conditions = append(conditions, "(actor_id = "+placeholder+" OR target_id = "+placeholder+")")
+	}
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, eventType := range filter.Types {
+			types[i] = string(eventType)
+		}
+		conditions = append(conditions, "event_type = ANY("+arg(types)+")")
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.To))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at, id) < ("+arg(filter.After.CreatedAt)+", "+arg(filter.After.ID)+")")
+	}
+
+	query := `SELECT ` + auditEventColumns + ` FROM audit_events`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ` + arg(filter.Limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		var id uuid.UUID
+		var eventType, ipAddress, userAgent, requestID string
+		var actorID, targetID *uuid.UUID
+		var detailsJSON []byte
+		var createdAt time.Time
+
+		if err := rows.Scan(&id, &eventType, &actorID, &targetID, &ipAddress, &userAgent, &requestID, &detailsJSON, &createdAt); err != nil {
+			return nil, err
+		}
+
+		var details map[string]string
+		if err := json.Unmarshal(detailsJSON, &details); err != nil {
+			return nil, err
+		}
+
+		event := domain.NewAuditEvent(domain.AuditEventType(eventType), actorID, targetID, details)
+		event.SetID(id)
+		event.SetClient(ipAddress, userAgent, requestID)
+		event.SetCreatedAt(createdAt)
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type authorizationCodeRepositoryImpl struct {
+	db DBTX
+}
+
+func NewAuthorizationCodeRepository(db DBTX) repository.AuthorizationCodeRepository {
+	return &authorizationCodeRepositoryImpl{db: db}
+}
+
+func (r *authorizationCodeRepositoryImpl) Create(ctx context.Context, code *domain.AuthorizationCode) error {
+	query := `
+        INSERT INTO oauth_authorization_codes (id, code_hash, client_id, user_id, redirect_uri, scopes, nonce,
+            code_challenge, code_challenge_method, expires_at, is_used, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		code.ID(),
+		code.CodeHash(),
+		code.ClientID(),
+		code.UserID(),
+		code.RedirectURI(),
+		code.Scopes(),
+		code.Nonce(),
+		code.CodeChallenge(),
+		code.CodeChallengeMethod(),
+		code.ExpiresAt(),
+		code.IsUsed(),
+		code.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *authorizationCodeRepositoryImpl) GetByCodeHash(ctx context.Context, codeHash string) (*domain.AuthorizationCode, error) {
+	query := `
+        SELECT id, code_hash, client_id, user_id, redirect_uri, scopes, nonce,
+            code_challenge, code_challenge_method, expires_at, is_used, created_at
+        FROM oauth_authorization_codes
+        WHERE code_hash = $1
+    `
+
+	var id, userID uuid.UUID
+	var hash, clientID, redirectURI, nonce, codeChallenge, codeChallengeMethod string
+	var scopes []string
+	var expiresAt, createdAt time.Time
+	var isUsed bool
+
+	err := r.db.QueryRow(ctx, query, codeHash).Scan(
+		&id, &hash, &clientID, &userID, &redirectURI, &scopes, &nonce,
+		&codeChallenge, &codeChallengeMethod, &expiresAt, &isUsed, &createdAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrAuthorizationCodeNotFound
+		}
+		return nil, err
+	}
+
+	code := domain.NewAuthorizationCode(hash, clientID, userID, redirectURI, scopes, expiresAt)
+	code.SetID(id)
+	code.SetNonce(nonce)
+	code.SetCodeChallenge(codeChallenge, codeChallengeMethod)
+	code.SetUsed(isUsed)
+	code.SetCreatedAt(createdAt)
+
+	return code, nil
+}
+
+func (r *authorizationCodeRepositoryImpl) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	// Условие на is_used и expires_at не дает обменять один код дважды при параллельных запросах
+	query := `
+        UPDATE oauth_authorization_codes
+        SET is_used = TRUE
+        WHERE id = $1 AND is_used = FALSE AND expires_at > NOW()
+    `
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrAuthorizationCodeInvalid
+	}
+
+	return nil
+}
+
+func (r *authorizationCodeRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM oauth_authorization_codes WHERE expires_at <= NOW()`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
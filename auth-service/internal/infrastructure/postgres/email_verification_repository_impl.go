@@ -8,24 +8,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type emailVerificationRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewEmailVerificationRepository(db *pgxpool.Pool) repository.EmailVerificationRepository {
+func NewEmailVerificationRepository(db DBTX) repository.EmailVerificationRepository {
 	return &emailVerificationRepositoryImpl{db: db}
 }
 
-func (r *emailVerificationRepositoryImpl) Create(verification *domain.EmailVerification) error {
+func (r *emailVerificationRepositoryImpl) Create(ctx context.Context, verification *domain.EmailVerification) error {
 	query := `
         INSERT INTO email_verifications (id, user_id, token, expires_at, is_used, created_at)
         VALUES ($1, $2, $3, $4, $5, $6)
     `
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err := r.db.Exec(ctx, query,
 		verification.ID(),
 		verification.UserID(),
 		verification.Token(),
@@ -37,14 +36,14 @@ func (r *emailVerificationRepositoryImpl) Create(verification *domain.EmailVerif
 	return err
 }
 
-func (r *emailVerificationRepositoryImpl) GetByToken(token string) (*domain.EmailVerification, error) {
+func (r *emailVerificationRepositoryImpl) GetByToken(ctx context.Context, token string) (*domain.EmailVerification, error) {
 	query := `
         SELECT id, user_id, token, expires_at, is_used, created_at
         FROM email_verifications
         WHERE token = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, token)
+	row := r.db.QueryRow(ctx, query, token)
 
 	var id, userID uuid.UUID
 	var tokenStr string
@@ -67,7 +66,7 @@ func (r *emailVerificationRepositoryImpl) GetByToken(token string) (*domain.Emai
 	return verification, nil
 }
 
-func (r *emailVerificationRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.EmailVerification, error) {
+func (r *emailVerificationRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error) {
 	query := `
         SELECT id, user_id, token, expires_at, is_used, created_at
         FROM email_verifications
@@ -76,7 +75,7 @@ func (r *emailVerificationRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain
         LIMIT 1
     `
 
-	row := r.db.QueryRow(context.Background(), query, userID)
+	row := r.db.QueryRow(ctx, query, userID)
 
 	var id, userId uuid.UUID
 	var token string
@@ -99,14 +98,14 @@ func (r *emailVerificationRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain
 	return verification, nil
 }
 
-func (r *emailVerificationRepositoryImpl) Update(verification *domain.EmailVerification) error {
+func (r *emailVerificationRepositoryImpl) Update(ctx context.Context, verification *domain.EmailVerification) error {
 	query := `
         UPDATE email_verifications 
         SET is_used = $2
         WHERE id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query, verification.ID(), verification.IsUsed())
+	result, err := r.db.Exec(ctx, query, verification.ID(), verification.IsUsed())
 	if err != nil {
 		return err
 	}
@@ -118,10 +117,10 @@ func (r *emailVerificationRepositoryImpl) Update(verification *domain.EmailVerif
 	return nil
 }
 
-func (r *emailVerificationRepositoryImpl) Delete(id uuid.UUID) error {
+func (r *emailVerificationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM email_verifications WHERE id = $1`
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
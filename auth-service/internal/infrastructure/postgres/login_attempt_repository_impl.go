@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type loginAttemptRepositoryImpl struct {
+	db DBTX
+}
+
+func NewLoginAttemptRepository(db DBTX) repository.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{db: db}
+}
+
+func (r *loginAttemptRepositoryImpl) GetByKey(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	query := `
+        SELECT key, failed_count, last_failed_at, locked_until
+        FROM login_attempts
+        WHERE key = $1
+    `
+
+	attempt, err := scanLoginAttempt(r.db.QueryRow(ctx, query, key))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrLoginAttemptNotFound
+		}
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+func (r *loginAttemptRepositoryImpl) RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	// Счетчик сбрасывается, если с последней неудачи прошло больше window
+	query := `
+        INSERT INTO login_attempts (key, failed_count, last_failed_at, updated_at)
+        VALUES ($1, 1, NOW(), NOW())
+        ON CONFLICT (key) DO UPDATE SET
+            failed_count = CASE
+                WHEN login_attempts.last_failed_at < NOW() - make_interval(secs => $2) THEN 1
+                ELSE login_attempts.failed_count + 1
+            END,
+            last_failed_at = NOW(),
+            updated_at = NOW()
+        RETURNING key, failed_count, last_failed_at, locked_until
+    `
+
+	return scanLoginAttempt(r.db.QueryRow(ctx, query, key, window.Seconds()))
+}
+
+func (r *loginAttemptRepositoryImpl) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+        UPDATE login_attempts
+        SET locked_until = $2, updated_at = NOW()
+        WHERE key = $1
+    `
+
+	result, err := r.db.Exec(ctx, query, key, until)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrLoginAttemptNotFound
+	}
+
+	return nil
+}
+
+func (r *loginAttemptRepositoryImpl) Reset(ctx context.Context, key string) error {
+	query := `DELETE FROM login_attempts WHERE key = $1`
+
+	_, err := r.db.Exec(ctx, query, key)
+	return err
+}
+
+func scanLoginAttempt(row pgx.Row) (*domain.LoginAttempt, error) {
+	var key string
+	var failedCount int
+	var lastFailedAt time.Time
+	var lockedUntil *time.Time
+
+	if err := row.Scan(&key, &failedCount, &lastFailedAt, &lockedUntil); err != nil {
+		return nil, err
+	}
+
+	attempt := domain.NewLoginAttempt(key)
+	attempt.SetFailedCount(failedCount)
+	attempt.SetLastFailedAt(lastFailedAt)
+	attempt.SetLockedUntil(lockedUntil)
+
+	return attempt, nil
+}
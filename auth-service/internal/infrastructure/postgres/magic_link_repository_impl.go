@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type magicLinkRepositoryImpl struct {
+	db DBTX
+}
+
+func NewMagicLinkRepository(db DBTX) repository.MagicLinkRepository {
+	return &magicLinkRepositoryImpl{db: db}
+}
+
+func (r *magicLinkRepositoryImpl) Create(ctx context.Context, link *domain.MagicLink) error {
+	query := `
+        INSERT INTO magic_links (id, user_id, token, expires_at, is_used, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		link.ID(),
+		link.UserID(),
+		link.Token(),
+		link.ExpiresAt(),
+		link.IsUsed(),
+		link.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *magicLinkRepositoryImpl) GetByToken(ctx context.Context, token string) (*domain.MagicLink, error) {
+	query := `
+        SELECT id, user_id, token, expires_at, is_used, created_at
+        FROM magic_links
+        WHERE token = $1
+    `
+
+	row := r.db.QueryRow(ctx, query, token)
+
+	var id, userID uuid.UUID
+	var tokenStr string
+	var expiresAt, createdAt time.Time
+	var isUsed bool
+
+	err := row.Scan(&id, &userID, &tokenStr, &expiresAt, &isUsed, &createdAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrMagicLinkNotFound
+		}
+		return nil, err
+	}
+
+	magicLink := domain.NewMagicLink(userID, tokenStr, expiresAt)
+	magicLink.SetID(id)
+	magicLink.SetUsed(isUsed)
+	magicLink.SetCreatedAt(createdAt)
+
+	return magicLink, nil
+}
+
+func (r *magicLinkRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.MagicLink, error) {
+	query := `
+        SELECT id, user_id, token, expires_at, is_used, created_at
+        FROM magic_links
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT 1
+    `
+
+	row := r.db.QueryRow(ctx, query, userID)
+
+	var id, userId uuid.UUID
+	var token string
+	var expiresAt, createdAt time.Time
+	var isUsed bool
+
+	err := row.Scan(&id, &userId, &token, &expiresAt, &isUsed, &createdAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrMagicLinkNotFound
+		}
+		return nil, err
+	}
+
+	magicLink := domain.NewMagicLink(userId, token, expiresAt)
+	magicLink.SetID(id)
+	magicLink.SetUsed(isUsed)
+	magicLink.SetCreatedAt(createdAt)
+
+	return magicLink, nil
+}
+
+func (r *magicLinkRepositoryImpl) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	// Условие на is_used и expires_at не дает обменять одну ссылку дважды при параллельных запросах
+	query := `
+        UPDATE magic_links 
+        SET is_used = TRUE
+        WHERE id = $1 AND is_used = FALSE AND expires_at > NOW()
+    `
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrMagicLinkInvalid
+	}
+
+	return nil
+}
+
+func (r *magicLinkRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM magic_links WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrMagicLinkNotFound
+	}
+
+	return nil
+}
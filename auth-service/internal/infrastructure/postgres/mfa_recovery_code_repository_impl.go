@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type mfaRecoveryCodeRepositoryImpl struct {
+	db DBTX
+}
+
+func NewMFARecoveryCodeRepository(db DBTX) repository.MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepositoryImpl{db: db}
+}
+
+func (r *mfaRecoveryCodeRepositoryImpl) ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*domain.MFARecoveryCode) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO mfa_recovery_codes (id, user_id, code_hash, used_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+
+	for _, code := range codes {
+		if _, err := tx.Exec(ctx, query,
+			code.ID(),
+			code.UserID(),
+			code.CodeHash(),
+			code.UsedAt(),
+			code.CreatedAt(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *mfaRecoveryCodeRepositoryImpl) Use(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `
+        UPDATE mfa_recovery_codes
+        SET used_at = NOW()
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    `
+
+	result, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrMFARecoveryCodeNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRecoveryCodeRepositoryImpl) CountUnused(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *mfaRecoveryCodeRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM mfa_recovery_codes WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type oauthClientRepositoryImpl struct {
+	db DBTX
+}
+
+func NewOAuthClientRepository(db DBTX) repository.OAuthClientRepository {
+	return &oauthClientRepositoryImpl{db: db}
+}
+
+const oauthClientColumns = `id, client_id, secret_hash, name, scopes, redirect_uris, audiences, is_public, skip_consent, is_active, created_at, updated_at`
+
+func (r *oauthClientRepositoryImpl) Create(ctx context.Context, client *domain.OAuthClient) error {
+	query := `
+        INSERT INTO oauth_clients (` + oauthClientColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		client.ID(),
+		client.ClientID(),
+		client.SecretHash(),
+		client.Name(),
+		client.Scopes(),
+		client.RedirectURIs(),
+		client.Audiences(),
+		client.IsPublic(),
+		client.SkipConsent(),
+		client.IsActive(),
+		client.CreatedAt(),
+		client.UpdatedAt(),
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return repository.ErrOAuthClientExists
+	}
+
+	return err
+}
+
+func (r *oauthClientRepositoryImpl) GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	query := `
+        SELECT ` + oauthClientColumns + `
+        FROM oauth_clients
+        WHERE client_id = $1
+    `
+
+	client, err := scanOAuthClient(r.db.QueryRow(ctx, query, clientID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrOAuthClientNotFound
+		}
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *oauthClientRepositoryImpl) List(ctx context.Context) ([]*domain.OAuthClient, error) {
+	query := `
+        SELECT ` + oauthClientColumns + `
+        FROM oauth_clients
+        ORDER BY created_at
+    `
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []*domain.OAuthClient
+	for rows.Next() {
+		client, err := scanOAuthClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
+func (r *oauthClientRepositoryImpl) Update(ctx context.Context, client *domain.OAuthClient) error {
+	query := `
+        UPDATE oauth_clients
+        SET secret_hash = $2, name = $3, scopes = $4, redirect_uris = $5, audiences = $6,
+            is_public = $7, skip_consent = $8, is_active = $9, updated_at = NOW()
+        WHERE id = $1
+    `
+
+	result, err := r.db.Exec(ctx, query,
+		client.ID(),
+		client.SecretHash(),
+		client.Name(),
+		client.Scopes(),
+		client.RedirectURIs(),
+		client.Audiences(),
+		client.IsPublic(),
+		client.SkipConsent(),
+		client.IsActive(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrOAuthClientNotFound
+	}
+
+	return nil
+}
+
+func (r *oauthClientRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM oauth_clients WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrOAuthClientNotFound
+	}
+
+	return nil
+}
+
+func scanOAuthClient(row pgx.Row) (*domain.OAuthClient, error) {
+	var id uuid.UUID
+	var clientID, secretHash, name string
+	var scopes, redirectURIs, audiences []string
+	var isPublic, skipConsent, isActive bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &clientID, &secretHash, &name, &scopes, &redirectURIs, &audiences, &isPublic, &skipConsent, &isActive, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	client := domain.NewOAuthClient(clientID, secretHash, name, scopes)
+	client.SetID(id)
+	client.SetRedirectURIs(redirectURIs)
+	client.SetAudiences(audiences)
+	client.SetPublic(isPublic)
+	client.SetSkipConsent(skipConsent)
+	client.SetActive(isActive)
+	client.SetCreatedAt(createdAt)
+	client.SetUpdatedAt(updatedAt)
+
+	return client, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type oauthConsentRepositoryImpl struct {
+	db DBTX
+}
+
+func NewOAuthConsentRepository(db DBTX) repository.OAuthConsentRepository {
+	return &oauthConsentRepositoryImpl{db: db}
+}
+
+func (r *oauthConsentRepositoryImpl) Get(ctx context.Context, userID uuid.UUID, clientID string) (*domain.OAuthConsent, error) {
+	query := `
+        SELECT id, user_id, client_id, scopes, created_at, updated_at
+        FROM oauth_consents
+        WHERE user_id = $1 AND client_id = $2
+    `
+
+	var id, consentUserID uuid.UUID
+	var consentClientID string
+	var scopes []string
+	var createdAt, updatedAt time.Time
+
+	err := r.db.QueryRow(ctx, query, userID, clientID).Scan(&id, &consentUserID, &consentClientID, &scopes, &createdAt, &updatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrOAuthConsentNotFound
+		}
+		return nil, err
+	}
+
+	consent := domain.NewOAuthConsent(consentUserID, consentClientID, scopes)
+	consent.SetID(id)
+	consent.SetCreatedAt(createdAt)
+	consent.SetUpdatedAt(updatedAt)
+
+	return consent, nil
+}
+
+func (r *oauthConsentRepositoryImpl) Save(ctx context.Context, consent *domain.OAuthConsent) error {
+	query := `
+        INSERT INTO oauth_consents (id, user_id, client_id, scopes, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (user_id, client_id)
+        DO UPDATE SET scopes = EXCLUDED.scopes, updated_at = EXCLUDED.updated_at
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		consent.ID(),
+		consent.UserID(),
+		consent.ClientID(),
+		consent.Scopes(),
+		consent.CreatedAt(),
+		consent.UpdatedAt(),
+	)
+
+	return err
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execer позволяет выполнять запросы как через пул, так и внутри транзакции
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// eventSource - агрегат, накапливающий доменные события до сохранения
+type eventSource interface {
+	PendingEvents() []*domain.Event
+	ClearEvents()
+}
+
+// execWithEvents выполняет запись агрегата и сохраняет его события в outbox
+// в одной транзакции. Без событий запись выполняется напрямую.
+func execWithEvents(ctx context.Context, db DBTX, source eventSource, write func(db execer) error) error {
+	events := source.PendingEvents()
+	if len(events) == 0 {
+		return write(db)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := write(tx); err != nil {
+		return err
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	source.ClearEvents()
+	return nil
+}
+
+func insertEvents(ctx context.Context, db execer, events []*domain.Event) error {
+	query := `
+        INSERT INTO outbox (id, event_type, aggregate_type, aggregate_id, payload, occurred_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (id) DO NOTHING
+    `
+
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload())
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event payload: %w", event.Type(), err)
+		}
+
+		if _, err := db.Exec(ctx, query,
+			event.ID(),
+			event.Type(),
+			event.AggregateType(),
+			event.AggregateID(),
+			payload,
+			event.OccurredAt(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type passwordHistoryRepositoryImpl struct {
+	db DBTX
+}
+
+func NewPasswordHistoryRepository(db DBTX) repository.PasswordHistoryRepository {
+	return &passwordHistoryRepositoryImpl{db: db}
+}
+
+func (r *passwordHistoryRepositoryImpl) Add(ctx context.Context, entry *domain.PasswordHistory, keep int) error {
+	query := `
+        INSERT INTO password_history (id, user_id, password_hash, created_at)
+        VALUES ($1, $2, $3, $4)
+    `
+
+	if _, err := r.db.Exec(ctx, query,
+		entry.ID(),
+		entry.UserID(),
+		entry.PasswordHash(),
+		entry.CreatedAt(),
+	); err != nil {
+		return err
+	}
+
+	// Старые записи за пределами keep больше не проверяются
+	trimQuery := `
+        DELETE FROM password_history
+        WHERE user_id = $1 AND id NOT IN (
+            SELECT id FROM password_history
+            WHERE user_id = $1
+            ORDER BY created_at DESC
+            LIMIT $2
+        )
+    `
+
+	_, err := r.db.Exec(ctx, trimQuery, entry.UserID(), keep)
+	return err
+}
+
+func (r *passwordHistoryRepositoryImpl) GetRecentByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.PasswordHistory, error) {
+	query := `
+        SELECT id, user_id, password_hash, created_at
+        FROM password_history
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.PasswordHistory
+	for rows.Next() {
+		var id, entryUserID uuid.UUID
+		var passwordHash string
+		var createdAt time.Time
+
+		if err := rows.Scan(&id, &entryUserID, &passwordHash, &createdAt); err != nil {
+			return nil, err
+		}
+
+		entry := domain.NewPasswordHistory(entryUserID, passwordHash)
+		entry.SetID(id)
+		entry.SetCreatedAt(createdAt)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
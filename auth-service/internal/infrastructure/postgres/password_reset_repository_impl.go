@@ -8,24 +8,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type passwordResetRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewPasswordResetRepository(db *pgxpool.Pool) repository.PasswordResetRepository {
+func NewPasswordResetRepository(db DBTX) repository.PasswordResetRepository {
 	return &passwordResetRepositoryImpl{db: db}
 }
 
-func (r *passwordResetRepositoryImpl) Create(reset *domain.PasswordReset) error {
+func (r *passwordResetRepositoryImpl) Create(ctx context.Context, reset *domain.PasswordReset) error {
 	query := `
         INSERT INTO password_resets (id, user_id, token, expires_at, is_used, created_at)
         VALUES ($1, $2, $3, $4, $5, $6)
     `
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err := r.db.Exec(ctx, query,
 		reset.ID(),
 		reset.UserID(),
 		reset.Token(),
@@ -37,14 +36,14 @@ func (r *passwordResetRepositoryImpl) Create(reset *domain.PasswordReset) error
 	return err
 }
 
-func (r *passwordResetRepositoryImpl) GetByToken(token string) (*domain.PasswordReset, error) {
+func (r *passwordResetRepositoryImpl) GetByToken(ctx context.Context, token string) (*domain.PasswordReset, error) {
 	query := `
         SELECT id, user_id, token, expires_at, is_used, created_at
         FROM password_resets
         WHERE token = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, token)
+	row := r.db.QueryRow(ctx, query, token)
 
 	var id, userID uuid.UUID
 	var tokenStr string
@@ -67,7 +66,7 @@ func (r *passwordResetRepositoryImpl) GetByToken(token string) (*domain.Password
 	return passwordReset, nil
 }
 
-func (r *passwordResetRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.PasswordReset, error) {
+func (r *passwordResetRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.PasswordReset, error) {
 	query := `
         SELECT id, user_id, token, expires_at, is_used, created_at
         FROM password_resets
@@ -76,7 +75,7 @@ func (r *passwordResetRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.Pas
         LIMIT 1
     `
 
-	row := r.db.QueryRow(context.Background(), query, userID)
+	row := r.db.QueryRow(ctx, query, userID)
 
 	var id, userId uuid.UUID
 	var token string
@@ -99,14 +98,14 @@ func (r *passwordResetRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.Pas
 	return passwordReset, nil
 }
 
-func (r *passwordResetRepositoryImpl) Update(reset *domain.PasswordReset) error {
+func (r *passwordResetRepositoryImpl) Update(ctx context.Context, reset *domain.PasswordReset) error {
 	query := `
         UPDATE password_resets 
         SET is_used = $2
         WHERE id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query, reset.ID(), reset.IsUsed())
+	result, err := r.db.Exec(ctx, query, reset.ID(), reset.IsUsed())
 	if err != nil {
 		return err
 	}
@@ -118,10 +117,10 @@ func (r *passwordResetRepositoryImpl) Update(reset *domain.PasswordReset) error
 	return nil
 }
 
-func (r *passwordResetRepositoryImpl) Delete(id uuid.UUID) error {
+func (r *passwordResetRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM password_resets WHERE id = $1`
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type personalAccessTokenRepositoryImpl struct {
+	db DBTX
+}
+
+func NewPersonalAccessTokenRepository(db DBTX) repository.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepositoryImpl{db: db}
+}
+
+const personalAccessTokenColumns = `id, user_id, name, prefix, token_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_at`
+
+func (r *personalAccessTokenRepositoryImpl) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	query := `
+        INSERT INTO personal_access_tokens (` + personalAccessTokenColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		token.ID(),
+		token.UserID(),
+		token.Name(),
+		token.Prefix(),
+		token.TokenHash(),
+		token.Scopes(),
+		token.ExpiresAt(),
+		token.LastUsedAt(),
+		token.LastUsedIP(),
+		token.RevokedAt(),
+		token.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *personalAccessTokenRepositoryImpl) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	query := `
+        SELECT ` + personalAccessTokenColumns + `
+        FROM personal_access_tokens
+        WHERE token_hash = $1
+    `
+
+	token, err := scanPersonalAccessToken(r.db.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrPersonalAccessTokenNotFound
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *personalAccessTokenRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	query := `
+        SELECT ` + personalAccessTokenColumns + `
+        FROM personal_access_tokens
+        WHERE user_id = $1 AND revoked_at IS NULL
+        ORDER BY created_at DESC
+    `
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.PersonalAccessToken
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *personalAccessTokenRepositoryImpl) CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM personal_access_tokens
+        WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+    `
+
+	var count int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *personalAccessTokenRepositoryImpl) UpdateLastUsed(ctx context.Context, token *domain.PersonalAccessToken) error {
+	query := `
+        UPDATE personal_access_tokens
+        SET last_used_at = $2, last_used_ip = $3
+        WHERE id = $1
+    `
+
+	_, err := r.db.Exec(ctx, query, token.ID(), token.LastUsedAt(), token.LastUsedIP())
+	return err
+}
+
+func (r *personalAccessTokenRepositoryImpl) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+        UPDATE personal_access_tokens
+        SET revoked_at = NOW()
+        WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+    `
+
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+
+	return nil
+}
+
+func scanPersonalAccessToken(row pgx.Row) (*domain.PersonalAccessToken, error) {
+	var id, userID uuid.UUID
+	var name, prefix, tokenHash, lastUsedIP string
+	var scopes []string
+	var expiresAt, lastUsedAt, revokedAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(&id, &userID, &name, &prefix, &tokenHash, &scopes, &expiresAt, &lastUsedAt, &lastUsedIP, &revokedAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token := domain.NewPersonalAccessToken(userID, name, prefix, tokenHash, scopes, expiresAt)
+	token.SetID(id)
+	token.SetLastUsed(lastUsedAt, lastUsedIP)
+	token.SetRevokedAt(revokedAt)
+	token.SetCreatedAt(createdAt)
+
+	return token, nil
+}
@@ -8,50 +8,32 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type refreshTokenRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewRefreshTokenRepository(db *pgxpool.Pool) repository.RefreshTokenRepository {
+func NewRefreshTokenRepository(db DBTX) repository.RefreshTokenRepository {
 	return &refreshTokenRepositoryImpl{db: db}
 }
 
-func (r *refreshTokenRepositoryImpl) Create(token *domain.RefreshToken) error {
-	query := `
-        INSERT INTO refresh_tokens (id, user_id, token, expires_at, is_revoked, created_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
-    `
+const refreshTokenColumns = `id, user_id, family_id, parent_id, replaced_by, token, device_name, user_agent, ip_address, client_id, scope, expires_at, is_revoked, revoked_at, last_used_at, created_at`
 
-	_, err := r.db.Exec(context.Background(), query,
-		token.ID(),
-		token.UserID(),
-		token.Token(),
-		token.ExpiresAt(),
-		token.IsRevoked(),
-		token.CreatedAt(),
-	)
-
-	return err
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.insert(ctx, r.db, token)
 }
 
-func (r *refreshTokenRepositoryImpl) GetByToken(tokenStr string) (*domain.RefreshToken, error) {
+func (r *refreshTokenRepositoryImpl) GetByToken(ctx context.Context, tokenStr string) (*domain.RefreshToken, error) {
 	query := `
-        SELECT id, user_id, token, expires_at, is_revoked, created_at
+        SELECT ` + refreshTokenColumns + `
         FROM refresh_tokens
         WHERE token = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, tokenStr)
+	row := r.db.QueryRow(ctx, query, tokenStr)
 
-	var id, userID uuid.UUID
-	var token string
-	var expiresAt, createdAt time.Time
-	var isRevoked bool
-
-	err := row.Scan(&id, &userID, &token, &expiresAt, &isRevoked, &createdAt)
+	refreshToken, err := scanRefreshToken(row)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, repository.ErrRefreshTokenNotFound
@@ -59,23 +41,18 @@ func (r *refreshTokenRepositoryImpl) GetByToken(tokenStr string) (*domain.Refres
 		return nil, err
 	}
 
-	refreshToken := domain.NewRefreshToken(userID, token, expiresAt)
-	refreshToken.SetID(id)
-	refreshToken.SetRevoked(isRevoked)
-	refreshToken.SetCreatedAt(createdAt)
-
 	return refreshToken, nil
 }
 
-func (r *refreshTokenRepositoryImpl) GetByUserID(userID uuid.UUID) ([]*domain.RefreshToken, error) {
+func (r *refreshTokenRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
 	query := `
-        SELECT id, user_id, token, expires_at, is_revoked, created_at
+        SELECT ` + refreshTokenColumns + `
         FROM refresh_tokens
         WHERE user_id = $1
         ORDER BY created_at DESC
     `
 
-	rows, err := r.db.Query(context.Background(), query, userID)
+	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,35 +61,59 @@ func (r *refreshTokenRepositoryImpl) GetByUserID(userID uuid.UUID) ([]*domain.Re
 	var tokens []*domain.RefreshToken
 
 	for rows.Next() {
-		var id, userId uuid.UUID
-		var token string
-		var expiresAt, createdAt time.Time
-		var isRevoked bool
-
-		err := rows.Scan(&id, &userId, &token, &expiresAt, &isRevoked, &createdAt)
+		refreshToken, err := scanRefreshToken(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		refreshToken := domain.NewRefreshToken(userId, token, expiresAt)
-		refreshToken.SetID(id)
-		refreshToken.SetRevoked(isRevoked)
-		refreshToken.SetCreatedAt(createdAt)
+		tokens = append(tokens, refreshToken)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *refreshTokenRepositoryImpl) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
+	query := `
+        SELECT ` + refreshTokenColumns + `
+        FROM refresh_tokens
+        WHERE user_id = $1 AND is_revoked = FALSE AND expires_at > NOW()
+        ORDER BY last_used_at DESC NULLS LAST, created_at DESC
+    `
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.RefreshToken
+
+	for rows.Next() {
+		refreshToken, err := scanRefreshToken(rows)
+		if err != nil {
+			return nil, err
+		}
 
 		tokens = append(tokens, refreshToken)
 	}
 
-	return tokens, nil
+	return tokens, rows.Err()
 }
 
-func (r *refreshTokenRepositoryImpl) Update(token *domain.RefreshToken) error {
+func (r *refreshTokenRepositoryImpl) Update(ctx context.Context, token *domain.RefreshToken) error {
 	query := `
         UPDATE refresh_tokens 
-        SET is_revoked = $2
+        SET is_revoked = $2, revoked_at = $3, replaced_by = $4, last_used_at = $5
         WHERE id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query, token.ID(), token.IsRevoked())
+	result, err := r.db.Exec(ctx, query,
+		token.ID(),
+		token.IsRevoked(),
+		token.RevokedAt(),
+		token.ReplacedBy(),
+		token.LastUsedAt(),
+	)
 	if err != nil {
 		return err
 	}
@@ -124,10 +125,10 @@ func (r *refreshTokenRepositoryImpl) Update(token *domain.RefreshToken) error {
 	return nil
 }
 
-func (r *refreshTokenRepositoryImpl) Delete(id uuid.UUID) error {
+func (r *refreshTokenRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM refresh_tokens WHERE id = $1`
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -139,9 +140,147 @@ func (r *refreshTokenRepositoryImpl) Delete(id uuid.UUID) error {
 	return nil
 }
 
-func (r *refreshTokenRepositoryImpl) DeleteByUserID(userID uuid.UUID) error {
+func (r *refreshTokenRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
 
-	_, err := r.db.Exec(context.Background(), query, userID)
+	_, err := r.db.Exec(ctx, query, userID)
 	return err
 }
+
+func (r *refreshTokenRepositoryImpl) Rotate(ctx context.Context, current, next *domain.RefreshToken) error {
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Условие по is_revoked/replaced_by защищает от одновременной ротации одного и того же токена
+	query := `
+        UPDATE refresh_tokens
+        SET is_revoked = TRUE, revoked_at = $2, replaced_by = $3, last_used_at = $4
+        WHERE id = $1 AND is_revoked = FALSE AND replaced_by IS NULL
+    `
+
+	result, err := tx.Exec(ctx, query, current.ID(), current.RevokedAt(), current.ReplacedBy(), current.LastUsedAt())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrRefreshTokenRevoked
+	}
+
+	if err := r.insert(ctx, tx, next); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, token *domain.RefreshToken) error {
+	query := `
+        UPDATE refresh_tokens
+        SET is_revoked = TRUE, revoked_at = NOW()
+        WHERE family_id = $1 AND is_revoked = FALSE
+    `
+
+	return execWithEvents(ctx, r.db, token, func(db execer) error {
+		_, err := db.Exec(ctx, query, token.FamilyID())
+		return err
+	})
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeAllExceptFamily(ctx context.Context, userID, familyID uuid.UUID) (int64, error) {
+	query := `
+        UPDATE refresh_tokens
+        SET is_revoked = TRUE, revoked_at = NOW()
+        WHERE user_id = $1 AND family_id <> $2 AND is_revoked = FALSE
+    `
+
+	result, err := r.db.Exec(ctx, query, userID, familyID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+        UPDATE refresh_tokens
+        SET is_revoked = TRUE, revoked_at = NOW()
+        WHERE user_id = $1 AND is_revoked = FALSE
+    `
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *refreshTokenRepositoryImpl) insert(ctx context.Context, db execer, token *domain.RefreshToken) error {
+	query := `
+        INSERT INTO refresh_tokens (` + refreshTokenColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+    `
+
+	_, err := db.Exec(ctx, query,
+		token.ID(),
+		token.UserID(),
+		token.FamilyID(),
+		token.ParentID(),
+		token.ReplacedBy(),
+		token.Token(),
+		token.DeviceName(),
+		token.UserAgent(),
+		token.IPAddress(),
+		token.ClientID(),
+		token.Scope(),
+		token.ExpiresAt(),
+		token.IsRevoked(),
+		token.RevokedAt(),
+		token.LastUsedAt(),
+		token.CreatedAt(),
+	)
+
+	return err
+}
+
+func scanRefreshToken(row pgx.Row) (*domain.RefreshToken, error) {
+	var id, userID, familyID uuid.UUID
+	var parentID, replacedBy *uuid.UUID
+	var token, deviceName, userAgent, ipAddress, clientID, scope string
+	var expiresAt, createdAt time.Time
+	var isRevoked bool
+	var revokedAt, lastUsedAt *time.Time
+
+	err := row.Scan(
+		&id, &userID, &familyID, &parentID, &replacedBy, &token,
+		&deviceName, &userAgent, &ipAddress, &clientID, &scope,
+		&expiresAt, &isRevoked, &revokedAt, &lastUsedAt, &createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken := domain.NewRefreshToken(userID, token, expiresAt)
+	refreshToken.SetID(id)
+	refreshToken.SetFamilyID(familyID)
+	refreshToken.SetParentID(parentID)
+	refreshToken.SetReplacedBy(replacedBy)
+	refreshToken.SetRevoked(isRevoked)
+	refreshToken.SetRevokedAt(revokedAt)
+	refreshToken.SetClientInfo(domain.ClientInfo{
+		DeviceName: deviceName,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+	})
+	refreshToken.SetOAuthClient(clientID, scope)
+	refreshToken.SetLastUsedAt(lastUsedAt)
+	refreshToken.SetCreatedAt(createdAt)
+
+	return refreshToken, nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type roleChangeRepositoryImpl struct {
+	db DBTX
+}
+
+func NewRoleChangeRepository(db DBTX) repository.RoleChangeRepository {
+	return &roleChangeRepositoryImpl{db: db}
+}
+
+func (r *roleChangeRepositoryImpl) Create(ctx context.Context, change *domain.RoleChange) error {
+	query := `
+        INSERT INTO role_changes (id, user_id, role, action, actor_id, reason, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		change.ID(),
+		change.UserID(),
+		string(change.Role()),
+		string(change.Action()),
+		change.ActorID(),
+		change.Reason(),
+		change.ExpiresAt(),
+		change.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *roleChangeRepositoryImpl) ListByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.RoleChange, error) {
+	query := `
+        SELECT id, user_id, role, action, actor_id, reason, expires_at, created_at
+        FROM role_changes
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*domain.RoleChange
+	for rows.Next() {
+		var id, changeUserID uuid.UUID
+		var role, action, reason string
+		var actorID *uuid.UUID
+		var expiresAt *time.Time
+		var createdAt time.Time
+
+		if err := rows.Scan(&id, &changeUserID, &role, &action, &actorID, &reason, &expiresAt, &createdAt); err != nil {
+			return nil, err
+		}
+
+		change := domain.NewRoleChange(changeUserID, domain.UserRoleType(role), domain.RoleChangeAction(action), actorID, reason, expiresAt)
+		change.SetID(id)
+		change.SetCreatedAt(createdAt)
+
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type roleRepositoryImpl struct {
+	db DBTX
+}
+
+func NewRoleRepository(db DBTX) repository.RoleRepository {
+	return &roleRepositoryImpl{db: db}
+}
+
+const roleColumns = `id, name, description, permissions, is_system, created_at, updated_at`
+
+func (r *roleRepositoryImpl) Create(ctx context.Context, role *domain.Role) error {
+	query := `
+        INSERT INTO roles (` + roleColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		role.ID(),
+		string(role.Name()),
+		role.Description(),
+		permissionStrings(role.Permissions()),
+		role.IsSystem(),
+		role.CreatedAt(),
+		role.UpdatedAt(),
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return repository.ErrRoleAlreadyExists
+	}
+
+	return err
+}
+
+func (r *roleRepositoryImpl) GetByName(ctx context.Context, name domain.UserRoleType) (*domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        WHERE name = $1
+    `
+
+	role, err := scanRole(r.db.QueryRow(ctx, query, string(name)))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrRoleNotFound
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r *roleRepositoryImpl) GetByNames(ctx context.Context, names []domain.UserRoleType) ([]*domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        WHERE name = ANY($1)
+        ORDER BY name
+    `
+
+	nameStrings := make([]string, len(names))
+	for i, name := range names {
+		nameStrings[i] = string(name)
+	}
+
+	return r.queryRoles(ctx, query, nameStrings)
+}
+
+func (r *roleRepositoryImpl) List(ctx context.Context) ([]*domain.Role, error) {
+	query := `
+        SELECT ` + roleColumns + `
+        FROM roles
+        ORDER BY is_system DESC, name
+    `
+
+	return r.queryRoles(ctx, query)
+}
+
+func (r *roleRepositoryImpl) Update(ctx context.Context, role *domain.Role) error {
+	query := `
+        UPDATE roles
+        SET description = $2, permissions = $3, updated_at = $4
+        WHERE name = $1
+    `
+
+	result, err := r.db.Exec(ctx, query,
+		string(role.Name()),
+		role.Description(),
+		permissionStrings(role.Permissions()),
+		role.UpdatedAt(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *roleRepositoryImpl) Delete(ctx context.Context, name domain.UserRoleType) error {
+	query := `DELETE FROM roles WHERE name = $1 AND NOT is_system`
+
+	result, err := r.db.Exec(ctx, query, string(name))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *roleRepositoryImpl) queryRoles(ctx context.Context, query string, args ...any) ([]*domain.Role, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*domain.Role
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+func scanRole(row pgx.Row) (*domain.Role, error) {
+	var id uuid.UUID
+	var name, description string
+	var permissions []string
+	var isSystem bool
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &description, &permissions, &isSystem, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	rolePermissions := make([]domain.Permission, len(permissions))
+	for i, permission := range permissions {
+		rolePermissions[i] = domain.Permission(permission)
+	}
+
+	role := domain.NewRole(domain.UserRoleType(name), description, rolePermissions)
+	role.SetID(id)
+	role.SetSystem(isSystem)
+	role.SetCreatedAt(createdAt)
+	role.SetUpdatedAt(updatedAt)
+
+	return role, nil
+}
+
+func permissionStrings(permissions []domain.Permission) []string {
+	result := make([]string, len(permissions))
+	for i, permission := range permissions {
+		result[i] = string(permission)
+	}
+	return result
+}
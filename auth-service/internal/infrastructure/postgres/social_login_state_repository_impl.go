@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type socialLoginStateRepositoryImpl struct {
+	db DBTX
+}
+
+func NewSocialLoginStateRepository(db DBTX) repository.SocialLoginStateRepository {
+	return &socialLoginStateRepositoryImpl{db: db}
+}
+
+func (r *socialLoginStateRepositoryImpl) Create(ctx context.Context, state *domain.SocialLoginState) error {
+	query := `
+        INSERT INTO social_login_states (id, state_hash, provider, purpose, user_id, nonce, code_verifier, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		state.ID(),
+		state.StateHash(),
+		state.Provider(),
+		state.Purpose(),
+		state.UserID(),
+		state.Nonce(),
+		state.CodeVerifier(),
+		state.ExpiresAt(),
+		state.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *socialLoginStateRepositoryImpl) Consume(ctx context.Context, stateHash, provider, purpose string) (*domain.SocialLoginState, error) {
+	query := `
+        DELETE FROM social_login_states
+        WHERE state_hash = $1 AND provider = $2 AND purpose = $3 AND expires_at > NOW()
+        RETURNING id, state_hash, provider, purpose, user_id, nonce, code_verifier, expires_at, created_at
+    `
+
+	var id uuid.UUID
+	var hash, providerName, purposeName, nonce, codeVerifier string
+	var userID *uuid.UUID
+	var expiresAt, createdAt time.Time
+
+	err := r.db.QueryRow(ctx, query, stateHash, provider, purpose).Scan(
+		&id, &hash, &providerName, &purposeName, &userID, &nonce, &codeVerifier, &expiresAt, &createdAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrSocialLoginStateNotFound
+		}
+		return nil, err
+	}
+
+	state := domain.NewSocialLoginState(hash, providerName, purposeName, userID, nonce, codeVerifier, expiresAt)
+	state.SetID(id)
+	state.SetCreatedAt(createdAt)
+
+	return state, nil
+}
+
+func (r *socialLoginStateRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM social_login_states WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
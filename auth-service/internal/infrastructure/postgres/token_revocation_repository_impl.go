@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type tokenRevocationRepositoryImpl struct {
+	db DBTX
+}
+
+func NewTokenRevocationRepository(db DBTX) repository.TokenRevocationRepository {
+	return &tokenRevocationRepositoryImpl{db: db}
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+        INSERT INTO revoked_access_tokens (jti, user_id, expires_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (jti) DO NOTHING
+    `
+
+	_, err := r.db.Exec(ctx, query, jti, userID, expiresAt)
+	return err
+}
+
+func (r *tokenRevocationRepositoryImpl) ConsumeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) (bool, error) {
+	query := `
+        INSERT INTO revoked_access_tokens (jti, user_id, expires_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (jti) DO NOTHING
+    `
+
+	tag, err := r.db.Exec(ctx, query, jti, userID, expiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeUserTokens(ctx context.Context, userID uuid.UUID, issuedBefore, expiresAt time.Time) error {
+	// Маркер только сдвигается вперед, чтобы параллельные отзывы не откатили друг друга
+	query := `
+        INSERT INTO user_token_revocations (user_id, revoked_before, expires_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id) DO UPDATE SET
+            revoked_before = GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before),
+            expires_at = GREATEST(user_token_revocations.expires_at, EXCLUDED.expires_at)
+    `
+
+	_, err := r.db.Exec(ctx, query, userID, issuedBefore, expiresAt)
+	return err
+}
+
+func (r *tokenRevocationRepositoryImpl) ExemptSession(ctx context.Context, sessionID, userID uuid.UUID, now time.Time) error {
+	// Исключение привязано к значению маркера: после следующего отзыва оно перестает совпадать
+	query := `
+        INSERT INTO session_revocation_exemptions (session_id, user_id, revoked_before, expires_at)
+        SELECT $1, user_id, revoked_before, expires_at
+        FROM user_token_revocations
+        WHERE user_id = $2 AND revoked_before > $3
+        ON CONFLICT (session_id) DO UPDATE SET
+            revoked_before = EXCLUDED.revoked_before,
+            expires_at = EXCLUDED.expires_at
+    `
+
+	_, err := r.db.Exec(ctx, query, sessionID, userID, now)
+	return err
+}
+
+func (r *tokenRevocationRepositoryImpl) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+        INSERT INTO revoked_sessions (session_id, user_id, expires_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (session_id) DO UPDATE SET
+            expires_at = GREATEST(revoked_sessions.expires_at, EXCLUDED.expires_at)
+    `
+
+	_, err := r.db.Exec(ctx, query, sessionID, userID, expiresAt)
+	return err
+}
+
+func (r *tokenRevocationRepositoryImpl) IsRevoked(ctx context.Context, jti string, userID, sessionID uuid.UUID, issuedAt time.Time) (bool, error) {
+	query := `
+        SELECT
+            EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti = $1)
+            OR EXISTS (
+                SELECT 1 FROM user_token_revocations u
+                WHERE u.user_id = $2 AND u.revoked_before > $3
+                  AND NOT EXISTS (
+                      SELECT 1 FROM session_revocation_exemptions e
+                      WHERE e.session_id = $4 AND e.revoked_before = u.revoked_before
+                  )
+            )
+            OR EXISTS (SELECT 1 FROM revoked_sessions WHERE session_id = $4)
+    `
+
+	var revoked bool
+	if err := r.db.QueryRow(ctx, query, jti, userID, issuedAt, sessionID).Scan(&revoked); err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func (r *tokenRevocationRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	tokens, err := r.db.Exec(ctx, `DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	users, err := r.db.Exec(ctx, `DELETE FROM user_token_revocations WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	sessions, err := r.db.Exec(ctx, `DELETE FROM revoked_sessions WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	exemptions, err := r.db.Exec(ctx, `DELETE FROM session_revocation_exemptions WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return tokens.RowsAffected() + users.RowsAffected() + sessions.RowsAffected() + exemptions.RowsAffected(), nil
+}
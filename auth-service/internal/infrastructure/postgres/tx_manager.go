@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBTX - общее подмножество методов *pgxpool.Pool и pgx.Tx. Репозитории работают
+// через него, поэтому одна и та же реализация используется и с пулом, и внутри транзакции.
+// Begin внутри транзакции создает savepoint.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txManagerImpl struct {
+	db *pgxpool.Pool
+}
+
+func NewTxManager(db *pgxpool.Pool) repository.TxManager {
+	return &txManagerImpl{db: db}
+}
+
+func (m *txManagerImpl) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return pgx.BeginFunc(ctx, m.db, func(tx pgx.Tx) error {
+		return fn(NewRepositories(tx))
+	})
+}
+
+// NewRepositories создает набор репозиториев поверх пула или транзакции
+func NewRepositories(db DBTX) repository.Repositories {
+	return repository.Repositories{
+		Users:              NewUserRepository(db),
+		UserAuth:           NewUserAuthRepository(db),
+		UserRoles:          NewUserRoleRepository(db),
+		RoleChanges:        NewRoleChangeRepository(db),
+		RefreshTokens:      NewRefreshTokenRepository(db),
+		EmailVerifications: NewEmailVerificationRepository(db),
+		PasswordResets:     NewPasswordResetRepository(db),
+		UserMFA:            NewUserMFARepository(db),
+		MFARecoveryCodes:   NewMFARecoveryCodeRepository(db),
+		PasswordHistory:    NewPasswordHistoryRepository(db),
+		UserIdentities:     NewUserIdentityRepository(db),
+	}
+}
@@ -8,24 +8,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type userAuthRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewUserAuthRepository(db *pgxpool.Pool) repository.UserAuthRepository {
+func NewUserAuthRepository(db DBTX) repository.UserAuthRepository {
 	return &userAuthRepositoryImpl{db: db}
 }
 
-func (r *userAuthRepositoryImpl) Create(userAuth *domain.UserAuth) error {
+func (r *userAuthRepositoryImpl) Create(ctx context.Context, userAuth *domain.UserAuth) error {
 	query := `
         INSERT INTO user_auth (id, user_id, password_hash, last_login_at, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
     `
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err := r.db.Exec(ctx, query,
 		userAuth.ID(),
 		userAuth.UserID(),
 		userAuth.PasswordHash(),
@@ -37,14 +36,14 @@ func (r *userAuthRepositoryImpl) Create(userAuth *domain.UserAuth) error {
 	return err
 }
 
-func (r *userAuthRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.UserAuth, error) {
+func (r *userAuthRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserAuth, error) {
 	query := `
-        SELECT id, user_id, password_hash, last_login_at, created_at, updated_at
+        SELECT id, user_id, COALESCE(password_hash, ''), last_login_at, created_at, updated_at
         FROM user_auth
         WHERE user_id = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, userID)
+	row := r.db.QueryRow(ctx, query, userID)
 
 	var id, userId uuid.UUID
 	var passwordHash string
@@ -68,20 +67,37 @@ func (r *userAuthRepositoryImpl) GetByUserID(userID uuid.UUID) (*domain.UserAuth
 	return userAuth, nil
 }
 
-func (r *userAuthRepositoryImpl) Update(userAuth *domain.UserAuth) error {
+func (r *userAuthRepositoryImpl) Update(ctx context.Context, userAuth *domain.UserAuth) error {
 	query := `
         UPDATE user_auth 
-        SET password_hash = $2, last_login_at = $3, updated_at = $4
+        SET password_hash = NULLIF($2, ''), last_login_at = $3, updated_at = $4
         WHERE user_id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query,
-		userAuth.UserID(),
-		userAuth.PasswordHash(),
-		userAuth.LastLoginAt(),
-		userAuth.UpdatedAt(),
-	)
+	return execWithEvents(ctx, r.db, userAuth, func(db execer) error {
+		result, err := db.Exec(ctx, query,
+			userAuth.UserID(),
+			userAuth.PasswordHash(),
+			userAuth.LastLoginAt(),
+			userAuth.UpdatedAt(),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return repository.ErrUserAuthNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *userAuthRepositoryImpl) UpdateLastLogin(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	query := `UPDATE user_auth SET last_login_at = $2 WHERE user_id = $1`
 
+	result, err := r.db.Exec(ctx, query, userID, at)
 	if err != nil {
 		return err
 	}
@@ -93,10 +109,25 @@ func (r *userAuthRepositoryImpl) Update(userAuth *domain.UserAuth) error {
 	return nil
 }
 
-func (r *userAuthRepositoryImpl) Delete(userID uuid.UUID) error {
+func (r *userAuthRepositoryImpl) ReplacePasswordHash(ctx context.Context, userID uuid.UUID, currentHash, newHash string) (bool, error) {
+	query := `
+        UPDATE user_auth
+        SET password_hash = $3, updated_at = NOW()
+        WHERE user_id = $1 AND password_hash = $2
+    `
+
+	result, err := r.db.Exec(ctx, query, userID, currentHash, newHash)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *userAuthRepositoryImpl) Delete(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM user_auth WHERE user_id = $1`
 
-	result, err := r.db.Exec(context.Background(), query, userID)
+	result, err := r.db.Exec(ctx, query, userID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type userIdentityRepositoryImpl struct {
+	db DBTX
+}
+
+func NewUserIdentityRepository(db DBTX) repository.UserIdentityRepository {
+	return &userIdentityRepositoryImpl{db: db}
+}
+
+const userIdentityColumns = `id, user_id, provider, subject, email, last_login_at, created_at`
+
+func (r *userIdentityRepositoryImpl) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+        INSERT INTO user_identities (` + userIdentityColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		identity.ID(),
+		identity.UserID(),
+		identity.Provider(),
+		identity.Subject(),
+		identity.Email(),
+		identity.LastLoginAt(),
+		identity.CreatedAt(),
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return repository.ErrUserIdentityExists
+	}
+
+	return err
+}
+
+func (r *userIdentityRepositoryImpl) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query := `
+        SELECT ` + userIdentityColumns + `
+        FROM user_identities
+        WHERE provider = $1 AND subject = $2
+    `
+
+	identity, err := scanUserIdentity(r.db.QueryRow(ctx, query, provider, subject))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrUserIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (r *userIdentityRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserIdentity, error) {
+	query := `
+        SELECT ` + userIdentityColumns + `
+        FROM user_identities
+        WHERE user_id = $1
+        ORDER BY created_at
+    `
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*domain.UserIdentity
+	for rows.Next() {
+		identity, err := scanUserIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func (r *userIdentityRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM user_identities WHERE user_id = $1`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *userIdentityRepositoryImpl) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+        UPDATE user_identities
+        SET email = $2, last_login_at = $3
+        WHERE id = $1
+    `
+
+	result, err := r.db.Exec(ctx, query, identity.ID(), identity.Email(), identity.LastLoginAt())
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrUserIdentityNotFound
+	}
+
+	return nil
+}
+
+func (r *userIdentityRepositoryImpl) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `DELETE FROM user_identities WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrUserIdentityNotFound
+	}
+
+	return nil
+}
+
+func scanUserIdentity(row pgx.Row) (*domain.UserIdentity, error) {
+	var id, userID uuid.UUID
+	var provider, subject, email string
+	var lastLoginAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(&id, &userID, &provider, &subject, &email, &lastLoginAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := domain.NewUserIdentity(userID, provider, subject, email)
+	identity.SetID(id)
+	identity.SetLastLoginAt(lastLoginAt)
+	identity.SetCreatedAt(createdAt)
+
+	return identity, nil
+}
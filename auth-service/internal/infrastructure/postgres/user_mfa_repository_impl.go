@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type userMFARepositoryImpl struct {
+	db DBTX
+}
+
+func NewUserMFARepository(db DBTX) repository.UserMFARepository {
+	return &userMFARepositoryImpl{db: db}
+}
+
+func (r *userMFARepositoryImpl) Create(ctx context.Context, mfa *domain.UserMFA) error {
+	query := `
+        INSERT INTO user_mfa (id, user_id, secret, is_enabled, last_used_step, enabled_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		mfa.ID(),
+		mfa.UserID(),
+		mfa.Secret(),
+		mfa.IsEnabled(),
+		mfa.LastUsedStep(),
+		mfa.EnabledAt(),
+		mfa.CreatedAt(),
+		mfa.UpdatedAt(),
+	)
+
+	return err
+}
+
+func (r *userMFARepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	query := `
+        SELECT id, user_id, secret, is_enabled, last_used_step, enabled_at, created_at, updated_at
+        FROM user_mfa
+        WHERE user_id = $1
+    `
+
+	row := r.db.QueryRow(ctx, query, userID)
+
+	var id, userId uuid.UUID
+	var secret string
+	var isEnabled bool
+	var lastUsedStep int64
+	var enabledAt *time.Time
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &userId, &secret, &isEnabled, &lastUsedStep, &enabledAt, &createdAt, &updatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrUserMFANotFound
+		}
+		return nil, err
+	}
+
+	mfa := domain.NewUserMFA(userId, secret)
+	mfa.SetID(id)
+	mfa.SetEnabled(isEnabled)
+	mfa.SetLastUsedStep(lastUsedStep)
+	mfa.SetEnabledAt(enabledAt)
+	mfa.SetCreatedAt(createdAt)
+	mfa.SetUpdatedAt(updatedAt)
+
+	return mfa, nil
+}
+
+func (r *userMFARepositoryImpl) Update(ctx context.Context, mfa *domain.UserMFA) error {
+	query := `
+        UPDATE user_mfa
+        SET secret = $2, is_enabled = $3, last_used_step = $4, enabled_at = $5, updated_at = NOW()
+        WHERE id = $1
+    `
+
+	result, err := r.db.Exec(ctx, query,
+		mfa.ID(),
+		mfa.Secret(),
+		mfa.IsEnabled(),
+		mfa.LastUsedStep(),
+		mfa.EnabledAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrUserMFANotFound
+	}
+
+	return nil
+}
+
+func (r *userMFARepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM user_mfa WHERE user_id = $1`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrUserMFANotFound
+	}
+
+	return nil
+}
+
+func (r *userMFARepositoryImpl) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	// Условие по last_used_step не дает принять один и тот же код дважды даже при параллельных запросах
+	query := `
+        UPDATE user_mfa
+        SET last_used_step = $2, updated_at = NOW()
+        WHERE user_id = $1 AND is_enabled = TRUE AND last_used_step < $2
+    `
+
+	result, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrTOTPStepAlreadyUsed
+	}
+
+	return nil
+}
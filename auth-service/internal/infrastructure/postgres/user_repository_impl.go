@@ -2,172 +2,181 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/repository"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"time"
 )
 
 type userRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewUserRepository(db *pgxpool.Pool) repository.UserRepository {
+func NewUserRepository(db DBTX) repository.UserRepository {
 	return &userRepositoryImpl{db: db}
 }
 
-func (r *userRepositoryImpl) Create(user *domain.User) error {
+const userColumns = `id, email, username, display_name, is_verified, is_active, suspended_by, suspension_reason, suspended_at, suspended_until, created_at, updated_at`
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы префикс искался буквально
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *userRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
 	query := `
         INSERT INTO users (id, email, username, display_name, is_verified, is_active, created_at, updated_at)
         VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
 
-	_, err := r.db.Exec(context.Background(), query,
-		user.ID(),
-		user.Email(),
-		user.Username(),
-		user.DisplayName(),
-		user.IsVerified(),
-		user.IsActive(),
-		user.CreatedAt(),
-		user.UpdatedAt(),
-	)
-
-	return err
+	return execWithEvents(ctx, r.db, user, func(db execer) error {
+		_, err := db.Exec(ctx, query,
+			user.ID(),
+			user.Email(),
+			user.Username(),
+			user.DisplayName(),
+			user.IsVerified(),
+			user.IsActive(),
+			user.CreatedAt(),
+			user.UpdatedAt(),
+		)
+		return err
+	})
 }
 
-func (r *userRepositoryImpl) GetByID(id uuid.UUID) (*domain.User, error) {
+func (r *userRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-        SELECT id, email, username, display_name, is_verified, is_active, created_at, updated_at
+        SELECT ` + userColumns + `
         FROM users
         WHERE id = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, id)
-
-	var userID uuid.UUID
-	var email, username, displayName string
-	var isVerified, isActive bool
-	var createdAt, updatedAt time.Time
-
-	err := row.Scan(&userID, &email, &username, &displayName, &isVerified, &isActive, &createdAt, &updatedAt)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, repository.ErrUserNotFound
-		}
-		return nil, err
-	}
-
-	user := domain.NewUser(email, username, displayName)
-	user.SetID(userID)
-	user.SetVerified(isVerified)
-	user.SetActive(isActive)
-	user.SetCreatedAt(createdAt)
-	user.SetUpdatedAt(updatedAt)
-
-	return user, nil
+	return r.getUser(ctx, query, id)
 }
 
-func (r *userRepositoryImpl) GetByEmail(email string) (*domain.User, error) {
+func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-        SELECT id, email, username, display_name, is_verified, is_active, created_at, updated_at
+        SELECT ` + userColumns + `
         FROM users
         WHERE email = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, email)
-
-	var userID uuid.UUID
-	var userEmail, username, displayName string
-	var isVerified, isActive bool
-	var createdAt, updatedAt time.Time
-
-	err := row.Scan(&userID, &userEmail, &username, &displayName, &isVerified, &isActive, &createdAt, &updatedAt)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, repository.ErrUserNotFound
-		}
-		return nil, err
-	}
-
-	user := domain.NewUser(userEmail, username, displayName)
-	user.SetID(userID)
-	user.SetVerified(isVerified)
-	user.SetActive(isActive)
-	user.SetCreatedAt(createdAt)
-	user.SetUpdatedAt(updatedAt)
-
-	return user, nil
+	return r.getUser(ctx, query, email)
 }
 
-func (r *userRepositoryImpl) GetByUsername(username string) (*domain.User, error) {
+func (r *userRepositoryImpl) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
-        SELECT id, email, username, display_name, is_verified, is_active, created_at, updated_at
+        SELECT ` + userColumns + `
         FROM users
         WHERE username = $1
     `
 
-	row := r.db.QueryRow(context.Background(), query, username)
+	return r.getUser(ctx, query, username)
+}
 
-	var userID uuid.UUID
-	var email, userName, displayName string
-	var isVerified, isActive bool
-	var createdAt, updatedAt time.Time
+func (r *userRepositoryImpl) List(ctx context.Context, filter repository.UserFilter) ([]*domain.User, error) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	err := row.Scan(&userID, &email, &userName, &displayName, &isVerified, &isActive, &createdAt, &updatedAt)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, repository.ErrUserNotFound
-		}
-		return nil, err
+	if filter.Email != "" {
+		conditions = append(conditions, "LOWER(email) = LOWER("+arg(filter.Email)+")")
+	}
+	if filter.UsernamePrefix != "" {
+		conditions = append(conditions, "LOWER(username) LIKE LOWER("+arg(likeEscaper.Replace(filter.UsernamePrefix)+"%")+")")
+	}
+	if filter.IsVerified != nil {
+		conditions = append(conditions, "is_verified = "+arg(*filter.IsVerified))
+	}
+	if filter.IsActive != nil {
+		conditions = append(conditions, "is_active = "+arg(*filter.IsActive))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at, id) < ("+arg(filter.After.CreatedAt)+", "+arg(filter.After.ID)+")")
 	}
 
-	user := domain.NewUser(email, userName, displayName)
-	user.SetID(userID)
-	user.SetVerified(isVerified)
-	user.SetActive(isActive)
-	user.SetCreatedAt(createdAt)
-	user.SetUpdatedAt(updatedAt)
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ` + arg(filter.Limit)
 
-	return user, nil
+	return r.queryUsers(ctx, query, args...)
+}
+
+func (r *userRepositoryImpl) ListExpiredSuspensions(ctx context.Context, limit int) ([]*domain.User, error) {
+	query := `
+        SELECT ` + userColumns + `
+        FROM users
+        WHERE is_active = FALSE AND suspended_until IS NOT NULL AND suspended_until <= NOW()
+        ORDER BY suspended_until
+        LIMIT $1
+    `
+
+	return r.queryUsers(ctx, query, limit)
 }
 
-func (r *userRepositoryImpl) Update(user *domain.User) error {
+func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
 	query := `
         UPDATE users 
-        SET email = $2, username = $3, display_name = $4, is_verified = $5, is_active = $6, updated_at = $7
+        SET email = $2, username = $3, display_name = $4, is_verified = $5, is_active = $6,
+            suspended_by = $7, suspension_reason = $8, suspended_at = $9, suspended_until = $10, updated_at = $11
         WHERE id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query,
-		user.ID(),
-		user.Email(),
-		user.Username(),
-		user.DisplayName(),
-		user.IsVerified(),
-		user.IsActive(),
-		user.UpdatedAt(),
-	)
-
-	if err != nil {
-		return err
+	var suspendedBy *uuid.UUID
+	var suspensionReason *string
+	var suspendedAt, suspendedUntil *time.Time
+	if suspension := user.Suspension(); suspension != nil {
+		suspendedBy = suspension.SuspendedBy
+		suspensionReason = &suspension.Reason
+		suspendedAt = &suspension.SuspendedAt
+		suspendedUntil = suspension.Until
 	}
 
-	if result.RowsAffected() == 0 {
-		return repository.ErrUserNotFound
-	}
+	return execWithEvents(ctx, r.db, user, func(db execer) error {
+		result, err := db.Exec(ctx, query,
+			user.ID(),
+			user.Email(),
+			user.Username(),
+			user.DisplayName(),
+			user.IsVerified(),
+			user.IsActive(),
+			suspendedBy,
+			suspensionReason,
+			suspendedAt,
+			suspendedUntil,
+			user.UpdatedAt(),
+		)
+
+		if err != nil {
+			return err
+		}
 
-	return nil
+		if result.RowsAffected() == 0 {
+			return repository.ErrUserNotFound
+		}
+
+		return nil
+	})
 }
 
-func (r *userRepositoryImpl) Delete(id uuid.UUID) error {
+func (r *userRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
 
-	result, err := r.db.Exec(context.Background(), query, id)
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -179,41 +188,87 @@ func (r *userRepositoryImpl) Delete(id uuid.UUID) error {
 	return nil
 }
 
-func (r *userRepositoryImpl) ExistsByEmail(email string) (bool, error) {
+func (r *userRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
 
 	var exists bool
-	err := r.db.QueryRow(context.Background(), query, email).Scan(&exists)
+	err := r.db.QueryRow(ctx, query, email).Scan(&exists)
 
 	return exists, err
 }
 
-func (r *userRepositoryImpl) ExistsByUsername(username string) (bool, error) {
+func (r *userRepositoryImpl) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)`
 
 	var exists bool
-	err := r.db.QueryRow(context.Background(), query, username).Scan(&exists)
+	err := r.db.QueryRow(ctx, query, username).Scan(&exists)
 
 	return exists, err
 }
 
-//  func scanUser(row pgx.Row) (*domain.User, error) {
-// 	var userID uuid.UUID
-// 	var email, username, displayName string
-// 	var isVerified, isActive bool
-// 	var createdAt, updatedAt time.Time
-
-// 	err := row.Scan(&userID, &email, &username, &displayName, &isVerified, &isActive, &createdAt, &updatedAt)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	user := domain.NewUser(email, username, displayName)
-// 	user.SetID(userID)
-// 	user.SetVerified(isVerified)
-// 	user.SetActive(isActive)
-// 	user.SetCreatedAt(createdAt)
-// 	user.SetUpdatedAt(updatedAt)
-
-// 	return user, nil
-// }
+func (r *userRepositoryImpl) getUser(ctx context.Context, query string, args ...any) (*domain.User, error) {
+	user, err := scanUser(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *userRepositoryImpl) queryUsers(ctx context.Context, query string, args ...any) ([]*domain.User, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var userID uuid.UUID
+	var email, username, displayName string
+	var isVerified, isActive bool
+	var suspendedBy *uuid.UUID
+	var suspensionReason *string
+	var suspendedAt, suspendedUntil *time.Time
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&userID, &email, &username, &displayName, &isVerified, &isActive,
+		&suspendedBy, &suspensionReason, &suspendedAt, &suspendedUntil, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	user := domain.NewUser(email, username, displayName)
+	user.SetID(userID)
+	user.SetVerified(isVerified)
+	user.SetActive(isActive)
+	if !isActive && suspendedAt != nil {
+		suspension := &domain.Suspension{
+			SuspendedBy: suspendedBy,
+			SuspendedAt: *suspendedAt,
+			Until:       suspendedUntil,
+		}
+		if suspensionReason != nil {
+			suspension.Reason = *suspensionReason
+		}
+		user.SetSuspension(suspension)
+	}
+	user.SetCreatedAt(createdAt)
+	user.SetUpdatedAt(updatedAt)
+
+	return user, nil
+}
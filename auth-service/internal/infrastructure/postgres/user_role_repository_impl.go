@@ -7,85 +7,104 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 )
 
 type userRoleRepositoryImpl struct {
-	db *pgxpool.Pool
+	db DBTX
 }
 
-func NewUserRoleRepository(db *pgxpool.Pool) repository.UserRoleRepository {
+func NewUserRoleRepository(db DBTX) repository.UserRoleRepository {
 	return &userRoleRepositoryImpl{db: db}
 }
 
-func (r *userRoleRepositoryImpl) Create(userRole *domain.UserRole) error {
+const userRoleColumns = `id, user_id, role, granted_at, granted_by, reason, expires_at, is_active`
+
+func (r *userRoleRepositoryImpl) Create(ctx context.Context, userRole *domain.UserRole) error {
 	query := `
-        INSERT INTO user_roles (id, user_id, role, granted_at, is_active)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO user_roles (` + userRoleColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
 
-	_, err := r.db.Exec(context.Background(), query,
-		userRole.ID(),
-		userRole.UserID(),
-		string(userRole.Role()),
-		userRole.GrantedAt(),
-		userRole.IsActive(),
-	)
-
-	return err
+	return execWithEvents(ctx, r.db, userRole, func(db execer) error {
+		_, err := db.Exec(ctx, query,
+			userRole.ID(),
+			userRole.UserID(),
+			string(userRole.Role()),
+			userRole.GrantedAt(),
+			userRole.GrantedBy(),
+			userRole.Reason(),
+			userRole.ExpiresAt(),
+			userRole.IsActive(),
+		)
+		return err
+	})
 }
 
-func (r *userRoleRepositoryImpl) GetByUserID(userID uuid.UUID) ([]*domain.UserRole, error) {
+func (r *userRoleRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserRole, error) {
 	query := `
-        SELECT id, user_id, role, granted_at, is_active
+        SELECT ` + userRoleColumns + `
         FROM user_roles
         WHERE user_id = $1
         ORDER BY granted_at DESC
     `
 
-	rows, err := r.db.Query(context.Background(), query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var userRoles []*domain.UserRole
+	return r.queryUserRoles(ctx, query, userID)
+}
 
-	for rows.Next() {
-		var id, userId uuid.UUID
-		var roleStr string
-		var grantedAt time.Time
-		var isActive bool
+func (r *userRoleRepositoryImpl) CountActiveByRole(ctx context.Context, role domain.UserRoleType) (int, error) {
+	query := `SELECT COUNT(*) FROM user_roles WHERE role = $1 AND is_active = TRUE`
 
-		err := rows.Scan(&id, &userId, &roleStr, &grantedAt, &isActive)
-		if err != nil {
-			return nil, err
-		}
+	var count int
+	if err := r.db.QueryRow(ctx, query, string(role)).Scan(&count); err != nil {
+		return 0, err
+	}
 
-		userRole := domain.NewUserRole(userId, domain.UserRoleType(roleStr))
-		userRole.SetID(id)
-		userRole.SetGrantedAt(grantedAt)
-		userRole.SetActive(isActive)
+	return count, nil
+}
 
-		userRoles = append(userRoles, userRole)
-	}
+func (r *userRoleRepositoryImpl) ListExpired(ctx context.Context, limit int) ([]*domain.UserRole, error) {
+	query := `
+        SELECT ` + userRoleColumns + `
+        FROM user_roles
+        WHERE is_active = TRUE AND expires_at IS NOT NULL AND expires_at <= NOW()
+        ORDER BY expires_at
+        LIMIT $1
+    `
 
-	return userRoles, nil
+	return r.queryUserRoles(ctx, query, limit)
 }
 
-func (r *userRoleRepositoryImpl) Update(userRole *domain.UserRole) error {
+func (r *userRoleRepositoryImpl) Update(ctx context.Context, userRole *domain.UserRole) error {
 	query := `
         UPDATE user_roles 
         SET role = $2, is_active = $3
         WHERE id = $1
     `
 
-	result, err := r.db.Exec(context.Background(), query,
-		userRole.ID(),
-		string(userRole.Role()),
-		userRole.IsActive(),
-	)
+	return execWithEvents(ctx, r.db, userRole, func(db execer) error {
+		result, err := db.Exec(ctx, query,
+			userRole.ID(),
+			string(userRole.Role()),
+			userRole.IsActive(),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return repository.ErrUserRoleNotFound
+		}
 
+		return nil
+	})
+}
+
+func (r *userRoleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM user_roles WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -97,17 +116,44 @@ func (r *userRoleRepositoryImpl) Update(userRole *domain.UserRole) error {
 	return nil
 }
 
-func (r *userRoleRepositoryImpl) Delete(id uuid.UUID) error {
-	query := `DELETE FROM user_roles WHERE id = $1`
-
-	result, err := r.db.Exec(context.Background(), query, id)
+func (r *userRoleRepositoryImpl) queryUserRoles(ctx context.Context, query string, args ...any) ([]*domain.UserRole, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return repository.ErrUserRoleNotFound
+	var userRoles []*domain.UserRole
+	for rows.Next() {
+		userRole, err := scanUserRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		userRoles = append(userRoles, userRole)
 	}
 
-	return nil
+	return userRoles, rows.Err()
+}
+
+func scanUserRole(row pgx.Row) (*domain.UserRole, error) {
+	var id, userID uuid.UUID
+	var roleStr, reason string
+	var grantedAt time.Time
+	var grantedBy *uuid.UUID
+	var expiresAt *time.Time
+	var isActive bool
+
+	if err := row.Scan(&id, &userID, &roleStr, &grantedAt, &grantedBy, &reason, &expiresAt, &isActive); err != nil {
+		return nil, err
+	}
+
+	userRole := domain.NewUserRole(userID, domain.UserRoleType(roleStr))
+	userRole.SetID(id)
+	userRole.SetGrantedAt(grantedAt)
+	userRole.SetGrantedBy(grantedBy)
+	userRole.SetReason(reason)
+	userRole.SetExpiresAt(expiresAt)
+	userRole.SetActive(isActive)
+
+	return userRole, nil
 }
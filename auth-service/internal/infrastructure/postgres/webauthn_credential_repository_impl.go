@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type webAuthnCredentialRepositoryImpl struct {
+	db DBTX
+}
+
+func NewWebAuthnCredentialRepository(db DBTX) repository.WebAuthnCredentialRepository {
+	return &webAuthnCredentialRepositoryImpl{db: db}
+}
+
+const webAuthnCredentialColumns = `id, user_id, credential_id, public_key, algorithm, sign_count, transports, aaguid, name, clone_warning, last_used_at, created_at`
+
+func (r *webAuthnCredentialRepositoryImpl) Create(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	query := `
+        INSERT INTO webauthn_credentials (` + webAuthnCredentialColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		credential.ID(),
+		credential.UserID(),
+		credential.CredentialID(),
+		credential.PublicKey(),
+		credential.Algorithm(),
+		int64(credential.SignCount()),
+		credential.Transports(),
+		credential.AAGUID(),
+		credential.Name(),
+		credential.CloneWarning(),
+		credential.LastUsedAt(),
+		credential.CreatedAt(),
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return repository.ErrWebAuthnCredentialExists
+	}
+
+	return err
+}
+
+func (r *webAuthnCredentialRepositoryImpl) GetByCredentialID(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error) {
+	query := `
+        SELECT ` + webAuthnCredentialColumns + `
+        FROM webauthn_credentials
+        WHERE credential_id = $1
+    `
+
+	credential, err := scanWebAuthnCredential(r.db.QueryRow(ctx, query, credentialID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrWebAuthnCredentialNotFound
+		}
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (r *webAuthnCredentialRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.WebAuthnCredential, error) {
+	query := `
+        SELECT ` + webAuthnCredentialColumns + `
+        FROM webauthn_credentials
+        WHERE user_id = $1
+        ORDER BY created_at
+    `
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credentials []*domain.WebAuthnCredential
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, rows.Err()
+}
+
+func (r *webAuthnCredentialRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM webauthn_credentials WHERE user_id = $1`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *webAuthnCredentialRepositoryImpl) Update(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	query := `
+        UPDATE webauthn_credentials
+        SET sign_count = $2, clone_warning = $3, last_used_at = $4, name = $5
+        WHERE id = $1
+    `
+
+	result, err := r.db.Exec(ctx, query,
+		credential.ID(),
+		int64(credential.SignCount()),
+		credential.CloneWarning(),
+		credential.LastUsedAt(),
+		credential.Name(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrWebAuthnCredentialNotFound
+	}
+
+	return nil
+}
+
+func (r *webAuthnCredentialRepositoryImpl) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return repository.ErrWebAuthnCredentialNotFound
+	}
+
+	return nil
+}
+
+func scanWebAuthnCredential(row pgx.Row) (*domain.WebAuthnCredential, error) {
+	var id, userID uuid.UUID
+	var credentialID, publicKey, aaguid []byte
+	var algorithm, signCount int64
+	var transports []string
+	var name string
+	var cloneWarning bool
+	var lastUsedAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(&id, &userID, &credentialID, &publicKey, &algorithm, &signCount, &transports, &aaguid, &name, &cloneWarning, &lastUsedAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := domain.NewWebAuthnCredential(userID, credentialID, publicKey, algorithm, uint32(signCount))
+	credential.SetID(id)
+	credential.SetTransports(transports)
+	credential.SetAAGUID(aaguid)
+	credential.SetName(name)
+	credential.SetCloneWarning(cloneWarning)
+	credential.SetLastUsedAt(lastUsedAt)
+	credential.SetCreatedAt(createdAt)
+
+	return credential, nil
+}
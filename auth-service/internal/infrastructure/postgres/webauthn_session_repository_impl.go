@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type webAuthnSessionRepositoryImpl struct {
+	db DBTX
+}
+
+func NewWebAuthnSessionRepository(db DBTX) repository.WebAuthnSessionRepository {
+	return &webAuthnSessionRepositoryImpl{db: db}
+}
+
+func (r *webAuthnSessionRepositoryImpl) Create(ctx context.Context, session *domain.WebAuthnSession) error {
+	query := `
+        INSERT INTO webauthn_sessions (id, user_id, challenge, ceremony, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+
+	_, err := r.db.Exec(ctx, query,
+		session.ID(),
+		session.UserID(),
+		session.Challenge(),
+		session.Ceremony(),
+		session.ExpiresAt(),
+		session.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *webAuthnSessionRepositoryImpl) Consume(ctx context.Context, id uuid.UUID, ceremony string) (*domain.WebAuthnSession, error) {
+	query := `
+        DELETE FROM webauthn_sessions
+        WHERE id = $1 AND ceremony = $2 AND expires_at > NOW()
+        RETURNING id, user_id, challenge, ceremony, expires_at, created_at
+    `
+
+	var sessionID uuid.UUID
+	var userID *uuid.UUID
+	var challenge []byte
+	var ceremonyStr string
+	var expiresAt, createdAt time.Time
+
+	err := r.db.QueryRow(ctx, query, id, ceremony).Scan(&sessionID, &userID, &challenge, &ceremonyStr, &expiresAt, &createdAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, repository.ErrWebAuthnSessionNotFound
+		}
+		return nil, err
+	}
+
+	session := domain.NewWebAuthnSession(userID, challenge, ceremonyStr, expiresAt)
+	session.SetID(sessionID)
+	session.SetCreatedAt(createdAt)
+
+	return session, nil
+}
+
+func (r *webAuthnSessionRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM webauthn_sessions WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,72 @@
+// Package pwned проверяет пароли по локальной копии базы утекших паролей в формате
+// k-anonymity диапазонов Have I Been Pwned: каталог с файлами <PREFIX>.txt, где PREFIX -
+// первые 5 hex символов SHA-1 пароля, а каждая строка файла имеет вид SUFFIX:COUNT.
+// Такой каталог создает официальный PwnedPasswordsDownloader, сеть при проверке не нужна.
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const prefixLength = 5
+
+// RangeStore ищет хеш пароля в файле его диапазона
+type RangeStore struct {
+	dir string
+}
+
+// NewRangeStore проверяет, что каталог существует, и создает хранилище
+func NewRangeStore(dir string) (*RangeStore, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("breached password list: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("breached password list: %s is not a directory", dir)
+	}
+
+	return &RangeStore{dir: dir}, nil
+}
+
+// IsBreached сообщает, встречался ли пароль в утечках. Строки с COUNT = 0 - это
+// заполнение, которое HIBP добавляет к ответам, и они не считаются совпадением.
+func (s *RangeStore) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	file, err := os.Open(filepath.Join(s.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		lineSuffix, countText, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+
+		count, err := strconv.Atoi(countText)
+		return err == nil && count > 0, nil
+	}
+
+	return false, scanner.Err()
+}
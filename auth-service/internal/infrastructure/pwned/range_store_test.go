@@ -0,0 +1,131 @@
+package pwned
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// SHA-1("password") = 5BAA6 1E4C9B93F3F0682250B6CF8331B7EE68FD8
+const (
+	passwordPrefix = "5BAA6"
+	passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+// newTestRangeStore создает каталог диапазонов с файлом для префикса "password"
+func newTestRangeStore(t *testing.T, lines ...string) *RangeStore {
+	t.Helper()
+
+	dir := t.TempDir()
+	if lines != nil {
+		content := strings.Join(lines, "\r\n") + "\r\n"
+		if err := os.WriteFile(filepath.Join(dir, passwordPrefix+".txt"), []byte(content), 0o600); err != nil {
+			t.Fatalf("write range file: %v", err)
+		}
+	}
+
+	store, err := NewRangeStore(dir)
+	if err != nil {
+		t.Fatalf("new range store: %v", err)
+	}
+	return store
+}
+
+func TestRangeStore_IsBreached(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		password string
+		want     bool
+	}{
+		{
+			name:     "suffix present",
+			lines:    []string{"003D68EB55068C33ACE09247EE4C639306B:3", passwordSuffix + ":10434004"},
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "suffix in lower case",
+			lines:    []string{strings.ToLower(passwordSuffix) + ":1"},
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "padding entry with zero count",
+			lines:    []string{passwordSuffix + ":0"},
+			password: "password",
+			want:     false,
+		},
+		{
+			name:     "suffix absent from range",
+			lines:    []string{"003D68EB55068C33ACE09247EE4C639306B:3"},
+			password: "password",
+			want:     false,
+		},
+		{
+			name:     "malformed lines are skipped",
+			lines:    []string{"garbage", "", passwordSuffix + ":7"},
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "malformed count",
+			lines:    []string{passwordSuffix + ":many"},
+			password: "password",
+			want:     false,
+		},
+		{
+			name:     "range file missing",
+			password: "password",
+			want:     false,
+		},
+		{
+			// Хеш другого пароля ищется в файле своего диапазона
+			name:     "other password",
+			lines:    []string{passwordSuffix + ":10434004"},
+			password: "correct horse battery staple",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestRangeStore(t, tt.lines...)
+
+			got, err := store.IsBreached(context.Background(), tt.password)
+			if err != nil {
+				t.Fatalf("is breached: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRangeStore_CanceledContext(t *testing.T) {
+	store := newTestRangeStore(t, passwordSuffix+":1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := store.IsBreached(ctx, "password"); err == nil {
+		t.Fatal("expected context error")
+	}
+}
+
+func TestNewRangeStore_InvalidDir(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "list.txt")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	for _, path := range []string{filepath.Join(dir, "missing"), file} {
+		if _, err := NewRangeStore(path); err == nil {
+			t.Fatalf("expected error for %s", path)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditCursor - позиция в журнале аудита: время и ID последней выданной записи
+type AuditCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// AuditEventFilter - условия выборки журнала аудита. Пустые поля выборку не ограничивают.
+type AuditEventFilter struct {
+	// UserID совпадает и с актором, и с целью события
+	UserID *uuid.UUID
+	Types  []domain.AuditEventType
+	From   *time.Time
+	To     *time.Time
+	// After - записи старше этой позиции; записи идут от новых к старым
+	After *AuditCursor
+	Limit int
+}
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *domain.AuditEvent) error
+	List(ctx context.Context, filter AuditEventFilter) ([]*domain.AuditEvent, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type AuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *domain.AuthorizationCode) error
+	GetByCodeHash(ctx context.Context, codeHash string) (*domain.AuthorizationCode, error)
+	// MarkUsed атомарно помечает код использованным. Если код уже обменян
+	// или истек, возвращает ErrAuthorizationCodeInvalid.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	// DeleteExpired удаляет истекшие коды и возвращает их число
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -1,15 +1,16 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 type EmailVerificationRepository interface {
-	Create(verification *domain.EmailVerification) error
-	GetByToken(token string) (*domain.EmailVerification, error)
-	GetByUserID(userID uuid.UUID) (*domain.EmailVerification, error)
-	Update(verification *domain.EmailVerification) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, verification *domain.EmailVerification) error
+	GetByToken(ctx context.Context, token string) (*domain.EmailVerification, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error)
+	Update(ctx context.Context, verification *domain.EmailVerification) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+	"time"
+)
+
+// LoginAttemptRepository хранит счетчики неудачных попыток входа.
+// Ключ - произвольная строка, например "account:<email>" или "ip:<address>".
+type LoginAttemptRepository interface {
+	GetByKey(ctx context.Context, key string) (*domain.LoginAttempt, error)
+
+	// RegisterFailure атомарно увеличивает счетчик. Если последняя неудача была раньше,
+	// чем window назад, счетчик начинается заново.
+	RegisterFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error)
+
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type MagicLinkRepository interface {
+	Create(ctx context.Context, link *domain.MagicLink) error
+	GetByToken(ctx context.Context, token string) (*domain.MagicLink, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.MagicLink, error)
+	// MarkUsed атомарно помечает ссылку использованной. Если ссылка уже использована
+	// или истекла, возвращает ErrMagicLinkInvalid.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
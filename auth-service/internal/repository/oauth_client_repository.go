@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *domain.OAuthClient) error
+	GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error)
+	List(ctx context.Context) ([]*domain.OAuthClient, error)
+	Update(ctx context.Context, client *domain.OAuthClient) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
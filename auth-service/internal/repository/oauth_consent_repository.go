@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type OAuthConsentRepository interface {
+	Get(ctx context.Context, userID uuid.UUID, clientID string) (*domain.OAuthConsent, error)
+	// Save создает согласие или обновляет scope существующего для той же пары пользователь-клиент
+	Save(ctx context.Context, consent *domain.OAuthConsent) error
+}
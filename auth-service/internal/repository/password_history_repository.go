@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type PasswordHistoryRepository interface {
+	// Add сохраняет хеш нового пароля и оставляет только keep последних записей пользователя
+	Add(ctx context.Context, entry *domain.PasswordHistory, keep int) error
+
+	// GetRecentByUserID возвращает не больше limit последних записей, начиная с самой новой
+	GetRecentByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.PasswordHistory, error)
+}
@@ -1,15 +1,16 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 type PasswordResetRepository interface {
-	Create(reset *domain.PasswordReset) error
-	GetByToken(token string) (*domain.PasswordReset, error)
-	GetByUserID(userID uuid.UUID) (*domain.PasswordReset, error)
-	Update(reset *domain.PasswordReset) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, reset *domain.PasswordReset) error
+	GetByToken(ctx context.Context, token string) (*domain.PasswordReset, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.PasswordReset, error)
+	Update(ctx context.Context, reset *domain.PasswordReset) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *domain.PersonalAccessToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error)
+	// GetByUserID возвращает неотозванные токены пользователя, включая истекшие
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error)
+	CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	UpdateLastUsed(ctx context.Context, token *domain.PersonalAccessToken) error
+	// Revoke отзывает токен, только если он принадлежит пользователю и еще не отозван
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -1,16 +1,30 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 type RefreshTokenRepository interface {
-	Create(token *domain.RefreshToken) error
-	GetByToken(token string) (*domain.RefreshToken, error)
-	GetByUserID(userID uuid.UUID) ([]*domain.RefreshToken, error)
-	Update(token *domain.RefreshToken) error
-	Delete(id uuid.UUID) error
-	DeleteByUserID(userID uuid.UUID) error
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	GetByToken(ctx context.Context, token string) (*domain.RefreshToken, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error)
+	// GetActiveByUserID возвращает неотозванные и неистекшие токены — по одному на активную сессию
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error)
+	Update(ctx context.Context, token *domain.RefreshToken) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// Rotate атомарно помечает current замененным и сохраняет next.
+	// Возвращает ErrRefreshTokenRevoked, если current уже был отозван или ротирован.
+	Rotate(ctx context.Context, current, next *domain.RefreshToken) error
+	// RevokeFamily отзывает все токены цепочки ротации, к которой относится token.
+	// События, накопленные token, сохраняются в outbox в той же транзакции.
+	RevokeFamily(ctx context.Context, token *domain.RefreshToken) error
+	// RevokeAllExceptFamily отзывает все токены пользователя, кроме указанной цепочки, и возвращает число отозванных
+	RevokeAllExceptFamily(ctx context.Context, userID, familyID uuid.UUID) (int64, error)
+	// RevokeAllByUserID отзывает все токены пользователя и возвращает число отозванных
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
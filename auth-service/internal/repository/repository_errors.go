@@ -38,6 +38,15 @@ var (
 	ErrInvalidRole = errors.New("invalid role type")
 )
 
+// Role Repository Errors
+var (
+	// ErrRoleNotFound is returned when a role definition cannot be found
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleAlreadyExists is returned when a role with the same name is already defined
+	ErrRoleAlreadyExists = errors.New("role already exists")
+)
+
 // Refresh Token Repository Errors
 var (
 	// ErrRefreshTokenNotFound is returned when a refresh token cannot be found
@@ -83,6 +92,84 @@ var (
 	ErrPasswordResetInvalid = errors.New("password reset token is invalid")
 )
 
+// Magic Link Repository Errors
+var (
+	// ErrMagicLinkNotFound is returned when a magic link record cannot be found
+	ErrMagicLinkNotFound = errors.New("magic link not found")
+
+	// ErrMagicLinkInvalid is returned when a magic link token is invalid (expired or used)
+	ErrMagicLinkInvalid = errors.New("magic link token is invalid")
+)
+
+// User MFA Repository Errors
+var (
+	// ErrUserMFANotFound is returned when the user has not started two-factor enrollment
+	ErrUserMFANotFound = errors.New("user mfa not found")
+
+	// ErrTOTPStepAlreadyUsed is returned when a TOTP code from the same time step is presented twice
+	ErrTOTPStepAlreadyUsed = errors.New("totp code has already been used")
+
+	// ErrMFARecoveryCodeNotFound is returned when a recovery code does not exist or has already been used
+	ErrMFARecoveryCodeNotFound = errors.New("mfa recovery code not found")
+)
+
+// Login Attempt Repository Errors
+var (
+	// ErrLoginAttemptNotFound is returned when there are no recorded failures for the key
+	ErrLoginAttemptNotFound = errors.New("login attempt not found")
+)
+
+// OAuth Client Repository Errors
+var (
+	// ErrOAuthClientNotFound is returned when no client is registered with the given client_id
+	ErrOAuthClientNotFound = errors.New("oauth client not found")
+
+	// ErrOAuthClientExists is returned when a client with the same client_id is already registered
+	ErrOAuthClientExists = errors.New("oauth client already exists")
+)
+
+// OIDC Repository Errors
+var (
+	// ErrAuthorizationCodeNotFound is returned when an authorization code cannot be found
+	ErrAuthorizationCodeNotFound = errors.New("authorization code not found")
+
+	// ErrAuthorizationCodeInvalid is returned when an authorization code is expired or has already been exchanged
+	ErrAuthorizationCodeInvalid = errors.New("authorization code is invalid")
+
+	// ErrOAuthConsentNotFound is returned when the user has not granted consent to the client
+	ErrOAuthConsentNotFound = errors.New("oauth consent not found")
+)
+
+// WebAuthn Repository Errors
+var (
+	// ErrWebAuthnCredentialNotFound is returned when a passkey cannot be found
+	ErrWebAuthnCredentialNotFound = errors.New("webauthn credential not found")
+
+	// ErrWebAuthnCredentialExists is returned when a passkey with the same credential ID is already registered
+	ErrWebAuthnCredentialExists = errors.New("webauthn credential already exists")
+
+	// ErrWebAuthnSessionNotFound is returned when a ceremony session does not exist, has expired or was already used
+	ErrWebAuthnSessionNotFound = errors.New("webauthn session not found")
+)
+
+// Social Login Repository Errors
+var (
+	// ErrUserIdentityNotFound is returned when an external identity link cannot be found
+	ErrUserIdentityNotFound = errors.New("user identity not found")
+
+	// ErrUserIdentityExists is returned when the external account is already linked or the user already has a link to the provider
+	ErrUserIdentityExists = errors.New("user identity already exists")
+
+	// ErrSocialLoginStateNotFound is returned when the state does not exist, has expired or was already used
+	ErrSocialLoginStateNotFound = errors.New("social login state not found")
+)
+
+// Personal Access Token Repository Errors
+var (
+	// ErrPersonalAccessTokenNotFound is returned when a personal access token cannot be found
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+)
+
 // Database Connection Errors
 var (
 	// ErrDatabaseConnection is returned when there's a problem connecting to the database
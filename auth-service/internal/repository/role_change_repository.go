@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type RoleChangeRepository interface {
+	Create(ctx context.Context, change *domain.RoleChange) error
+	// ListByUserID возвращает последние изменения ролей пользователя, новые первыми
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.RoleChange, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+)
+
+type RoleRepository interface {
+	Create(ctx context.Context, role *domain.Role) error
+	GetByName(ctx context.Context, name domain.UserRoleType) (*domain.Role, error)
+	GetByNames(ctx context.Context, names []domain.UserRoleType) ([]*domain.Role, error)
+	List(ctx context.Context) ([]*domain.Role, error)
+	Update(ctx context.Context, role *domain.Role) error
+	Delete(ctx context.Context, name domain.UserRoleType) error
+}
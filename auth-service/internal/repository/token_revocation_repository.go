@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocationRepository хранит отозванные access токены до истечения их срока.
+// Записи нужны только пока отозванные токены еще не истекли сами, поэтому у каждой
+// есть expiresAt, после которого ее можно удалить.
+type TokenRevocationRepository interface {
+	// RevokeToken отзывает один токен по jti
+	RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+
+	// RevokeUserTokens делает недействительными все токены пользователя, выданные раньше issuedBefore.
+	// Токены с iat не раньше issuedBefore остаются действительными.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, issuedBefore, expiresAt time.Time) error
+
+	// ExemptSession выводит сессию из-под маркера пользователя, если маркер еще покрывает момент now:
+	// сессия начата уже после отзыва. Более поздний маркер действует на сессию как обычно.
+	ExemptSession(ctx context.Context, sessionID, userID uuid.UUID, now time.Time) error
+
+	// ConsumeToken отзывает одноразовый токен по jti и сообщает, был ли он до этого действителен.
+	// Из параллельных вызовов для одного jti true получает только один.
+	ConsumeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) (bool, error)
+
+	// RevokeSession делает недействительными все токены завершенной сессии (claim sid)
+	RevokeSession(ctx context.Context, sessionID, userID uuid.UUID, expiresAt time.Time) error
+
+	// IsRevoked проверяет, отозван ли токен по jti, маркером пользователя или вместе с сессией.
+	// uuid.Nil в sessionID означает токен без сессии. Маркер не действует на сессию, выведенную
+	// из-под него через ExemptSession.
+	IsRevoked(ctx context.Context, jti string, userID, sessionID uuid.UUID, issuedAt time.Time) (bool, error)
+
+	// DeleteExpired удаляет записи, которые больше не влияют на проверку
+	DeleteExpired(ctx context.Context) (int64, error)
+}
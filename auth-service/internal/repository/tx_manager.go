@@ -0,0 +1,25 @@
+package repository
+
+import "context"
+
+// Repositories - набор репозиториев, работающих поверх одного соединения или транзакции
+type Repositories struct {
+	Users              UserRepository
+	UserAuth           UserAuthRepository
+	UserRoles          UserRoleRepository
+	RoleChanges        RoleChangeRepository
+	RefreshTokens      RefreshTokenRepository
+	EmailVerifications EmailVerificationRepository
+	PasswordResets     PasswordResetRepository
+	UserMFA            UserMFARepository
+	MFARecoveryCodes   MFARecoveryCodeRepository
+	PasswordHistory    PasswordHistoryRepository
+	UserIdentities     UserIdentityRepository
+}
+
+// TxManager выполняет несколько операций с репозиториями атомарно
+type TxManager interface {
+	// WithinTransaction вызывает fn с репозиториями, привязанными к новой транзакции.
+	// Транзакция фиксируется, если fn вернула nil, и откатывается при ошибке или панике.
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
+}
@@ -1,14 +1,21 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type UserAuthRepository interface {
-	Create(userAuth *domain.UserAuth) error
-	GetByUserID(userID uuid.UUID) (*domain.UserAuth, error)
-	Update(userAuth *domain.UserAuth) error
-	Delete(userID uuid.UUID) error
+	Create(ctx context.Context, userAuth *domain.UserAuth) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserAuth, error)
+	Update(ctx context.Context, userAuth *domain.UserAuth) error
+	// UpdateLastLogin меняет только время последнего входа, не затрагивая хеш пароля
+	UpdateLastLogin(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// ReplacePasswordHash заменяет хеш, только если он все еще равен currentHash. Возвращает false,
+	// если пароль успели сменить.
+	ReplacePasswordHash(ctx context.Context, userID uuid.UUID, currentHash, newHash string) (bool, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
 }
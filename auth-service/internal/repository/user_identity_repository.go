@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type UserIdentityRepository interface {
+	// Create возвращает ErrUserIdentityExists, если учетная запись провайдера уже привязана
+	// или у пользователя уже есть привязка к этому провайдеру
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserIdentity, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// Update сохраняет email и время последнего входа
+	Update(ctx context.Context, identity *domain.UserIdentity) error
+	// Delete удаляет привязку, только если она принадлежит пользователю
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type SocialLoginStateRepository interface {
+	Create(ctx context.Context, state *domain.SocialLoginState) error
+	// Consume атомарно удаляет и возвращает действующий state провайдера с указанным
+	// назначением, поэтому ответ провайдера нельзя принять дважды
+	Consume(ctx context.Context, stateHash, provider, purpose string) (*domain.SocialLoginState, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type UserMFARepository interface {
+	Create(ctx context.Context, mfa *domain.UserMFA) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error)
+	Update(ctx context.Context, mfa *domain.UserMFA) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// UseStep атомарно помечает интервал TOTP использованным.
+	// Возвращает ErrTOTPStepAlreadyUsed, если код из этого или более позднего интервала уже принимался.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+}
+
+type MFARecoveryCodeRepository interface {
+	// ReplaceForUser удаляет прежние коды пользователя и сохраняет новые
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*domain.MFARecoveryCode) error
+
+	// Use атомарно помечает неиспользованный код использованным.
+	// Возвращает ErrMFARecoveryCodeNotFound, если такого неиспользованного кода нет.
+	Use(ctx context.Context, userID uuid.UUID, codeHash string) error
+
+	CountUnused(ctx context.Context, userID uuid.UUID) (int, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -1,19 +1,45 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserCursor - позиция в списке пользователей: время регистрации и ID последнего выданного
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// UserFilter - условия поиска пользователей администратором. Пустые поля выборку не ограничивают.
+type UserFilter struct {
+	// Email сравнивается целиком без учета регистра
+	Email string
+	// UsernamePrefix - начало имени пользователя без учета регистра
+	UsernamePrefix string
+	IsVerified     *bool
+	IsActive       *bool
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	// After - пользователи, зарегистрированные раньше этой позиции; список идет от новых к старым
+	After *UserCursor
+	Limit int
+}
+
 type UserRepository interface {
-	Create(user *domain.User) error
-	GetByID(id uuid.UUID) (*domain.User, error)
-	GetByEmail(email string) (*domain.User, error)
-	GetByUsername(username string) (*domain.User, error)
-	Update(user *domain.User) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, user *domain.User) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	List(ctx context.Context, filter UserFilter) ([]*domain.User, error)
+	// ListExpiredSuspensions возвращает заблокированных пользователей, срок блокировки которых истек
+	ListExpiredSuspensions(ctx context.Context, limit int) ([]*domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	Delete(ctx context.Context, id uuid.UUID) error
 
-	ExistsByEmail(email string) (bool, error)
-	ExistsByUsername(username string) (bool, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	ExistsByUsername(ctx context.Context, username string) (bool, error)
 }
@@ -1,14 +1,18 @@
 package repository
 
 import (
+	"context"
 	"social-network/auth-service/internal/domain"
 
 	"github.com/google/uuid"
 )
 
 type UserRoleRepository interface {
-	Create(userRole *domain.UserRole) error
-	GetByUserID(userID uuid.UUID) ([]*domain.UserRole, error)
-	Update(userRole *domain.UserRole) error
-	Delete(id uuid.UUID) error
+	Create(ctx context.Context, userRole *domain.UserRole) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserRole, error)
+	CountActiveByRole(ctx context.Context, role domain.UserRoleType) (int, error)
+	// ListExpired возвращает активные временные роли, срок которых истек
+	ListExpired(ctx context.Context, limit int) ([]*domain.UserRole, error)
+	Update(ctx context.Context, userRole *domain.UserRole) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
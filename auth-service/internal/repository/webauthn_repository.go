@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"social-network/auth-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type WebAuthnCredentialRepository interface {
+	Create(ctx context.Context, credential *domain.WebAuthnCredential) error
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.WebAuthnCredential, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// Update сохраняет счетчик подписей, признак клонирования, время использования и имя
+	Update(ctx context.Context, credential *domain.WebAuthnCredential) error
+	// Delete удаляет ключ, только если он принадлежит пользователю
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type WebAuthnSessionRepository interface {
+	Create(ctx context.Context, session *domain.WebAuthnSession) error
+	// Consume атомарно удаляет и возвращает действующую сессию указанной церемонии,
+	// поэтому один challenge нельзя использовать дважды
+	Consume(ctx context.Context, id uuid.UUID, ceremony string) (*domain.WebAuthnSession, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+	auditExportBatchSize = 500
+)
+
+// AuditQuery - условия выборки журнала аудита. Cursor берется из NextCursor предыдущей страницы.
+type AuditQuery struct {
+	UserID *uuid.UUID
+	Types  []domain.AuditEventType
+	From   *time.Time
+	To     *time.Time
+	Cursor string
+	Limit  int
+}
+
+// AuditPage - страница журнала аудита. NextCursor пуст на последней странице.
+type AuditPage struct {
+	Events     []*domain.AuditEvent
+	NextCursor string
+}
+
+// AuditService ведет журнал аудита событий безопасности
+type AuditService struct {
+	auditRepo repository.AuditEventRepository
+	logger    logger.Logger
+}
+
+func NewAuditService(auditRepo repository.AuditEventRepository, logger logger.Logger) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// Record записывает событие с адресом, user-agent и ID запроса из контекста. Ошибка записи
+// только логируется, чтобы недоступность журнала не ломала вход и выход пользователей.
+func (s *AuditService) Record(ctx context.Context, eventType domain.AuditEventType, actorID, targetID *uuid.UUID, details map[string]string) {
+	// Действия по токену имперсонации записываются вместе с администратором
+	if impersonatorID := requestctx.ImpersonatorID(ctx); impersonatorID != "" {
+		details = maps.Clone(details)
+		if details == nil {
+			details = map[string]string{}
+		}
+		details["impersonated_by"] = impersonatorID
+	}
+
+	event := domain.NewAuditEvent(eventType, actorID, targetID, details)
+	event.SetClient(requestctx.ClientIP(ctx), requestctx.UserAgent(ctx), requestctx.RequestID(ctx))
+
+	// Запись не должна пропасть из-за отмены запроса клиентом
+	if err := s.auditRepo.Create(context.WithoutCancel(ctx), event); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to write audit event",
+			logger.String("event_type", string(eventType)),
+			logger.Error(err),
+		)
+	}
+}
+
+// RecordLoginSucceeded записывает успешную проверку фактора входа: пароля, ссылки из письма,
+// passkey, второго фактора или входа через внешнего провайдера
+func (s *AuditService) RecordLoginSucceeded(ctx context.Context, userID uuid.UUID, method string) {
+	s.Record(ctx, domain.AuditLoginSucceeded, &userID, &userID, map[string]string{
+		"method": method,
+	})
+}
+
+// RecordLoginMFARequired записывает верный первый фактор у пользователя с 2FA: вход еще
+// не состоялся и завершится событием login.succeeded после проверки кода
+func (s *AuditService) RecordLoginMFARequired(ctx context.Context, userID uuid.UUID, method string) {
+	s.Record(ctx, domain.AuditLoginMFARequired, &userID, &userID, map[string]string{
+		"method": method,
+	})
+}
+
+// RecordLoginFailed записывает неудачную попытку входа. userID равен nil, если аккаунт
+// не найден; тогда email помогает связать попытки между собой.
+func (s *AuditService) RecordLoginFailed(ctx context.Context, userID *uuid.UUID, method, reason, email string) {
+	details := map[string]string{"method": method, "reason": reason}
+	if email != "" {
+		details["email"] = email
+	}
+	s.Record(ctx, domain.AuditLoginFailed, nil, userID, details)
+}
+
+// ListEvents возвращает страницу журнала аудита, от новых записей к старым
+func (s *AuditService) ListEvents(ctx context.Context, query AuditQuery) (*AuditPage, error) {
+	filter, err := auditFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = query.Limit
+	if filter.Limit <= 0 || filter.Limit > maxAuditPageSize {
+		filter.Limit = defaultAuditPageSize
+	}
+	pageSize := filter.Limit
+
+	// Лишняя запись показывает, есть ли следующая страница
+	filter.Limit++
+	events, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AuditPage{Events: events}
+	if len(events) > pageSize {
+		page.Events = events[:pageSize]
+		page.NextCursor = encodeAuditCursor(page.Events[pageSize-1])
+	}
+
+	return page, nil
+}
+
+// ExportEvents передает write все записи журнала, подходящие под условия, от новых к старым.
+// Limit запроса не учитывается.
+func (s *AuditService) ExportEvents(ctx context.Context, query AuditQuery, write func(event *domain.AuditEvent) error) error {
+	filter, err := auditFilter(query)
+	if err != nil {
+		return err
+	}
+	filter.Limit = auditExportBatchSize
+
+	for {
+		events, err := s.auditRepo.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := write(event); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < auditExportBatchSize {
+			return nil
+		}
+
+		last := events[len(events)-1]
+		filter.After = &repository.AuditCursor{CreatedAt: last.CreatedAt(), ID: last.ID()}
+	}
+}
+
+// contextActor возвращает аутентифицированного пользователя запроса или nil
+func contextActor(ctx context.Context) *uuid.UUID {
+	userID, err := uuid.Parse(requestctx.UserID(ctx))
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+func auditFilter(query AuditQuery) (repository.AuditEventFilter, error) {
+	filter := repository.AuditEventFilter{
+		UserID: query.UserID,
+		Types:  query.Types,
+		From:   query.From,
+		To:     query.To,
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeAuditCursor(query.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+// encodeAuditCursor кодирует позицию записи журнала
+func encodeAuditCursor(event *domain.AuditEvent) string {
+	return encodeCursor(event.CreatedAt(), event.ID())
+}
+
+func decodeAuditCursor(cursor string) (*repository.AuditCursor, error) {
+	createdAt, id, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.AuditCursor{CreatedAt: createdAt, ID: id}, nil
+}
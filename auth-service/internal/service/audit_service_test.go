@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/pkg/requestctx"
+
+	"github.com/google/uuid"
+)
+
+// seedAuditEvents записывает события с шагом в секунду, старые первыми
+func seedAuditEvents(recorder *auditRecorder, events ...*domain.AuditEvent) {
+	start := time.Now().Add(-time.Hour)
+	for i, event := range events {
+		event.SetCreatedAt(start.Add(time.Duration(i) * time.Second))
+		recorder.events = append(recorder.events, event)
+	}
+}
+
+func TestAuditService_Record(t *testing.T) {
+	audit, recorder := newTestAuditService()
+	userID := uuid.New()
+
+	ctx := requestctx.WithRequestID(context.Background(), "req-1")
+	ctx = requestctx.WithClient(ctx, "192.0.2.1", "test-agent")
+	audit.RecordLoginFailed(ctx, &userID, "password", "invalid_credentials", "alice@example.com")
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("events = %d, want 1", len(recorder.events))
+	}
+	event := recorder.events[0]
+	if event.Type() != domain.AuditLoginFailed || event.ActorID() != nil || event.TargetID() == nil || *event.TargetID() != userID {
+		t.Fatalf("unexpected event: type %q, actor %v, target %v", event.Type(), event.ActorID(), event.TargetID())
+	}
+	if event.IPAddress() != "192.0.2.1" || event.UserAgent() != "test-agent" || event.RequestID() != "req-1" {
+		t.Fatalf("client info must be taken from the context, got %q %q %q", event.IPAddress(), event.UserAgent(), event.RequestID())
+	}
+	if details := event.Details(); details["reason"] != "invalid_credentials" || details["email"] != "alice@example.com" {
+		t.Fatalf("unexpected details: %v", details)
+	}
+}
+
+func TestAuditService_ListEvents(t *testing.T) {
+	ctx := context.Background()
+	audit, recorder := newTestAuditService()
+	alice, bob := uuid.New(), uuid.New()
+
+	seedAuditEvents(recorder,
+		domain.NewAuditEvent(domain.AuditLoginSucceeded, &alice, &alice, nil),
+		domain.NewAuditEvent(domain.AuditLoginSucceeded, &bob, &bob, nil),
+		domain.NewAuditEvent(domain.AuditRoleGranted, &bob, &alice, nil),
+		domain.NewAuditEvent(domain.AuditLogout, &alice, &alice, nil),
+		domain.NewAuditEvent(domain.AuditLoginFailed, nil, &alice, nil),
+	)
+
+	// Постраничный обход возвращает все записи без повторов, от новых к старым
+	var seen []*domain.AuditEvent
+	query := AuditQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("pagination does not terminate")
+		}
+		page, err := audit.ListEvents(ctx, query)
+		if err != nil {
+			t.Fatalf("ListEvents() error = %v", err)
+		}
+		seen = append(seen, page.Events...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if len(seen) != len(recorder.events) {
+		t.Fatalf("listed %d events, want %d", len(seen), len(recorder.events))
+	}
+	for i, event := range seen {
+		if want := recorder.events[len(recorder.events)-1-i]; event != want {
+			t.Fatalf("event %d = %q, want %q", i, event.Type(), want.Type())
+		}
+	}
+
+	// Фильтр по пользователю учитывает и актора, и цель события
+	page, err := audit.ListEvents(ctx, AuditQuery{UserID: &alice, Types: []domain.AuditEventType{domain.AuditRoleGranted, domain.AuditLoginSucceeded}})
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(page.Events) != 2 || page.Events[0].Type() != domain.AuditRoleGranted || page.NextCursor != "" {
+		t.Fatalf("unexpected filtered page: %d events, cursor %q", len(page.Events), page.NextCursor)
+	}
+
+	for _, cursor := range []string{"not base64!", "bm90LWEtY3Vyc29y", "MTIzX25vdC1hLXV1aWQ"} {
+		if _, err := audit.ListEvents(ctx, AuditQuery{Cursor: cursor}); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("cursor %q: expected ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+}
+
+func TestAuditService_ExportEvents(t *testing.T) {
+	ctx := context.Background()
+	audit, recorder := newTestAuditService()
+	userID := uuid.New()
+
+	seedAuditEvents(recorder,
+		domain.NewAuditEvent(domain.AuditLoginSucceeded, &userID, &userID, nil),
+		domain.NewAuditEvent(domain.AuditPasswordChanged, &userID, &userID, nil),
+		domain.NewAuditEvent(domain.AuditLogout, &userID, &userID, nil),
+	)
+
+	var exported []domain.AuditEventType
+	err := audit.ExportEvents(ctx, AuditQuery{Limit: 1}, func(event *domain.AuditEvent) error {
+		exported = append(exported, event.Type())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportEvents() error = %v", err)
+	}
+	if len(exported) != 3 || exported[0] != domain.AuditLogout || exported[2] != domain.AuditLoginSucceeded {
+		t.Fatalf("export must ignore the page limit and go from newest to oldest, got %v", exported)
+	}
+
+	errWrite := errors.New("client went away")
+	err = audit.ExportEvents(ctx, AuditQuery{}, func(event *domain.AuditEvent) error { return errWrite })
+	if !errors.Is(err, errWrite) {
+		t.Fatalf("expected write error, got %v", err)
+	}
+}
@@ -1,22 +1,62 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/repository"
 	"social-network/auth-service/pkg/helpers"
 	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/password"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserAccess - активные роли пользователя и права, которые они дают
+type UserAccess struct {
+	Roles       []domain.UserRoleType
+	Permissions []domain.Permission
+}
+
+// RoleGrant - назначение роли администратором. ExpiresAt задает срок временной роли.
+type RoleGrant struct {
+	UserID    uuid.UUID
+	Role      domain.UserRoleType
+	ActorID   uuid.UUID
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+const (
+	defaultRoleHistoryLimit = 50
+	maxRoleHistoryLimit     = 200
+	roleExpiryBatchSize     = 100
+)
+
 type AuthService struct {
 	userRepo              repository.UserRepository
 	userAuthRepo          repository.UserAuthRepository
 	userRoleRepo          repository.UserRoleRepository
+	roleRepo              repository.RoleRepository
+	roleChangeRepo        repository.RoleChangeRepository
 	refreshTokenRepo      repository.RefreshTokenRepository
 	emailVerificationRepo repository.EmailVerificationRepository
 	passwordResetRepo     repository.PasswordResetRepository
+	magicLinkRepo         repository.MagicLinkRepository
+	userMFARepo           repository.UserMFARepository
+	mfaRecoveryCodeRepo   repository.MFARecoveryCodeRepository
+	txManager             repository.TxManager
+	emailService          *EmailService
+	tokenRevocation       *TokenRevocationService
+	audit                 *AuditService
+	passwordHasher        *password.Hasher
+	validationService     *ValidationService
+	loginThrottle         *LoginThrottle
+	totpIssuer            string
 	logger                logger.Logger
 }
 
@@ -24,79 +64,134 @@ func NewAuthService(
 	userRepo repository.UserRepository,
 	userAuthRepo repository.UserAuthRepository,
 	userRoleRepo repository.UserRoleRepository,
+	roleRepo repository.RoleRepository,
+	roleChangeRepo repository.RoleChangeRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	emailVerificationRepo repository.EmailVerificationRepository,
 	passwordResetRepo repository.PasswordResetRepository,
+	magicLinkRepo repository.MagicLinkRepository,
+	userMFARepo repository.UserMFARepository,
+	mfaRecoveryCodeRepo repository.MFARecoveryCodeRepository,
+	txManager repository.TxManager,
+	emailService *EmailService,
+	tokenRevocation *TokenRevocationService,
+	audit *AuditService,
+	passwordHasher *password.Hasher,
+	validationService *ValidationService,
+	loginThrottle *LoginThrottle,
+	totpIssuer string,
 	logger logger.Logger,
 ) *AuthService {
 	return &AuthService{
 		userRepo:              userRepo,
 		userAuthRepo:          userAuthRepo,
 		userRoleRepo:          userRoleRepo,
+		roleRepo:              roleRepo,
+		roleChangeRepo:        roleChangeRepo,
 		refreshTokenRepo:      refreshTokenRepo,
 		emailVerificationRepo: emailVerificationRepo,
 		passwordResetRepo:     passwordResetRepo,
+		magicLinkRepo:         magicLinkRepo,
+		userMFARepo:           userMFARepo,
+		mfaRecoveryCodeRepo:   mfaRecoveryCodeRepo,
+		txManager:             txManager,
+		emailService:          emailService,
+		tokenRevocation:       tokenRevocation,
+		audit:                 audit,
+		passwordHasher:        passwordHasher,
+		validationService:     validationService,
+		loginThrottle:         loginThrottle,
+		totpIssuer:            totpIssuer,
 		logger:                logger,
 	}
 }
 
 // GetUserByID получает пользователя по ID
-func (s *AuthService) GetUserByID(userID uuid.UUID) (*domain.User, error) {
-	return s.userRepo.GetByID(userID)
+func (s *AuthService) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	return s.userRepo.GetByID(ctx, userID)
 }
 
 // RegisterUser создает нового пользователя
-func (s *AuthService) RegisterUser(email, username, displayName, password string) (*domain.User, error) {
-	s.logger.Info("Starting user registration",
+func (s *AuthService) RegisterUser(ctx context.Context, email, username, displayName, password string) (*domain.User, error) {
+	s.logger.WithContext(ctx).Info("Starting user registration",
 		logger.String("email", email),
 		logger.String("username", username),
 	)
 
 	// Проверяем существование пользователя
-	if exists, err := s.userRepo.ExistsByEmail(email); err != nil {
+	if exists, err := s.userRepo.ExistsByEmail(ctx, email); err != nil {
 		return nil, err
 	} else if exists {
 		return nil, repository.ErrUserEmailExists
 	}
 
-	if exists, err := s.userRepo.ExistsByUsername(username); err != nil {
+	if exists, err := s.userRepo.ExistsByUsername(ctx, username); err != nil {
 		return nil, err
 	} else if exists {
 		return nil, repository.ErrUserUsernameExists
 	}
 
-	// Создаем пользователя
-	user := domain.NewUser(email, username, displayName)
-	if err := s.userRepo.Create(user); err != nil {
-		return nil, err
-	}
+	// Пароль необязателен: аккаунт без пароля входит по ссылке из письма или через passkey
+	var hashedPassword string
+	var err error
+	if password != "" {
+		if err := s.validationService.ValidatePassword(ctx, PasswordCandidate{
+			Password:   password,
+			UserInputs: []string{email, username, displayName},
+		}); err != nil {
+			return nil, err
+		}
 
-	// Хешируем пароль и создаем auth запись
-	hashedPassword, err := helpers.HashPassword(password)
-	if err != nil {
-		return nil, err
+		// Хешируем пароль до начала транзакции
+		hashedPassword, err = s.passwordHasher.Hash(password)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	userAuth := domain.NewUserAuth(user.ID(), hashedPassword)
-	if err := s.userAuthRepo.Create(userAuth); err != nil {
-		return nil, err
-	}
+	user := domain.NewUser(email, username, displayName)
+	user.MarkRegistered()
+
+	var verification *domain.EmailVerification
 
-	// Назначаем базовую роль пользователя
-	userRole := domain.NewUserRole(user.ID(), domain.RoleUser)
-	if err := s.userRoleRepo.Create(userRole); err != nil {
+	// Пользователь, пароль, базовая роль и токен верификации создаются атомарно
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.Users.Create(ctx, user); err != nil {
+			return err
+		}
+
+		userAuth := domain.NewUserAuth(user.ID(), hashedPassword)
+		if err := repos.UserAuth.Create(ctx, userAuth); err != nil {
+			return err
+		}
+
+		if userAuth.HasPassword() {
+			if err := repos.PasswordHistory.Add(ctx, domain.NewPasswordHistory(user.ID(), hashedPassword), passwordHistoryLimit); err != nil {
+				return err
+			}
+		}
+
+		userRole := domain.NewUserRole(user.ID(), domain.RoleUser)
+		if err := repos.UserRoles.Create(ctx, userRole); err != nil {
+			return err
+		}
+
+		verification, err = s.createEmailVerification(ctx, repos.EmailVerifications, user.ID())
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Создаем токен для верификации email
-	if err := s.createEmailVerification(user.ID()); err != nil {
-		s.logger.Error("Failed to create email verification",
+	// Письмо не блокирует регистрацию: его можно запросить повторно
+	if err := s.emailService.SendVerificationEmail(ctx, user, verification.Token()); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to send verification email",
 			logger.String("user_id", user.ID().String()),
 			logger.Error(err),
 		)
 	}
 
-	s.logger.Info("User registered successfully",
+	s.logger.WithContext(ctx).Info("User registered successfully",
 		logger.String("user_id", user.ID().String()),
 		logger.String("username", username),
 	)
@@ -104,50 +199,161 @@ func (s *AuthService) RegisterUser(email, username, displayName, password string
 	return user, nil
 }
 
-// AuthenticateUser проверяет учетные данные пользователя
-func (s *AuthService) AuthenticateUser(email, password string) (*domain.User, error) {
+// RegisterExternalUser создает пользователя без пароля при первом входе через внешнего
+// провайдера и сразу привязывает его учетную запись. Email, подтвержденный провайдером,
+// считается подтвержденным; иначе пользователю отправляется обычное письмо верификации.
+func (s *AuthService) RegisterExternalUser(ctx context.Context, email, username, displayName string, emailVerified bool, provider, subject string) (*domain.User, *domain.UserIdentity, error) {
+	if exists, err := s.userRepo.ExistsByEmail(ctx, email); err != nil {
+		return nil, nil, err
+	} else if exists {
+		return nil, nil, repository.ErrUserEmailExists
+	}
+
+	user := domain.NewUser(email, username, displayName)
+	user.MarkRegistered()
+	if emailVerified {
+		user.VerifyEmail()
+	}
+
+	identity := domain.NewUserIdentity(user.ID(), provider, subject, email)
+	identity.RecordLogin(email)
+
+	var verification *domain.EmailVerification
+
+	err := s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.Users.Create(ctx, user); err != nil {
+			return err
+		}
+
+		if err := repos.UserAuth.Create(ctx, domain.NewUserAuth(user.ID(), "")); err != nil {
+			return err
+		}
+
+		if err := repos.UserRoles.Create(ctx, domain.NewUserRole(user.ID(), domain.RoleUser)); err != nil {
+			return err
+		}
+
+		if err := repos.UserIdentities.Create(ctx, identity); err != nil {
+			return err
+		}
+
+		if !emailVerified {
+			var err error
+			verification, err = s.createEmailVerification(ctx, repos.EmailVerifications, user.ID())
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if verification != nil {
+		if err := s.emailService.SendVerificationEmail(ctx, user, verification.Token()); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to send verification email",
+				logger.String("user_id", user.ID().String()),
+				logger.Error(err),
+			)
+		}
+	}
+
+	s.logger.WithContext(ctx).Info("User registered through external provider",
+		logger.String("user_id", user.ID().String()),
+		logger.String("provider", provider),
+	)
+
+	return user, identity, nil
+}
+
+// AuthenticateUser проверяет учетные данные пользователя. После серии неудачных
+// попыток по аккаунту или IP клиента возвращает *AccountLockedError.
+func (s *AuthService) AuthenticateUser(ctx context.Context, email, password string, client domain.ClientInfo) (*domain.User, error) {
+	// Ограничение попыток и аудит используют нормализованный email, чтобы варианты написания
+	// не обходили блокировку и в журнале не оставался ввод пользователя как есть.
+	// Пользователь ищется по email в том виде, в каком он сохранен при регистрации.
+	email = strings.TrimSpace(email)
+	accountEmail := normalizeEmail(email)
+
+	// Проверяем блокировку по аккаунту и IP
+	if err := s.loginThrottle.CheckLogin(ctx, accountEmail, client.IPAddress); err != nil {
+		s.audit.RecordLoginFailed(ctx, nil, "password", "locked", accountEmail)
+		return nil, err
+	}
+
 	// Получаем пользователя
-	user, err := s.userRepo.GetByEmail(email)
+	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
+		s.audit.RecordLoginFailed(ctx, nil, "password", "unknown_user", accountEmail)
+		if lockErr := s.loginThrottle.RegisterLoginFailure(ctx, accountEmail, client.IPAddress); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, repository.ErrUserNotFound
 	}
 
 	// Проверяем активность аккаунта
 	if !user.IsActive() {
+		userID := user.ID()
+		s.audit.RecordLoginFailed(ctx, &userID, "password", "inactive", accountEmail)
 		return nil, ErrUserInactive
 	}
 
 	// Получаем данные аутентификации
-	userAuth, err := s.userAuthRepo.GetByUserID(user.ID())
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, user.ID())
 	if err != nil {
 		return nil, repository.ErrUserAuthNotFound
 	}
 
-	// Проверяем пароль
-	if err := helpers.ComparePassword(userAuth.PasswordHash(), password); err != nil {
+	// Проверяем пароль. У аккаунта без пароля вход по паролю всегда неудачен.
+	if !userAuth.HasPassword() || s.passwordHasher.Verify(userAuth.PasswordHash(), password) != nil {
+		userID := user.ID()
+		s.audit.RecordLoginFailed(ctx, &userID, "password", "invalid_credentials", accountEmail)
+		if lockErr := s.loginThrottle.RegisterLoginFailure(ctx, accountEmail, client.IPAddress); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, ErrInvalidCredentials
 	}
 
-	// Обновляем время последнего входа
-	now := time.Now()
-	userAuth.SetLastLoginAt(&now)
-	if err := s.userAuthRepo.Update(userAuth); err != nil {
-		s.logger.Error("Failed to update last login time",
-			logger.String("user_id", user.ID().String()),
-			logger.Error(err),
-		)
+	mfaEnabled, err := s.IsMFAEnabled(ctx, user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	// Хеш со старым алгоритмом или параметрами заменяется, пока пароль известен
+	s.rehashPasswordIfNeeded(ctx, userAuth, password)
+
+	// С 2FA вход завершается в CompleteMFAChallenge: до проверки кода счетчик неудач
+	// аккаунта не сбрасывается и успешный вход не записывается
+	if mfaEnabled {
+		s.audit.RecordLoginMFARequired(ctx, user.ID(), "password")
+		return user, nil
 	}
 
+	s.loginThrottle.RegisterLoginSuccess(ctx, accountEmail)
+	s.audit.RecordLoginSucceeded(ctx, user.ID(), "password")
+	s.RecordLastLogin(ctx, user.ID())
+
 	return user, nil
 }
 
-// CreateRefreshToken создает refresh token для пользователя
-func (s *AuthService) CreateRefreshToken(userID uuid.UUID) (*domain.RefreshToken, error) {
+// CreateRefreshToken создает refresh token для пользователя, открывая новую сессию устройства
+func (s *AuthService) CreateRefreshToken(ctx context.Context, userID uuid.UUID, client domain.ClientInfo) (*domain.RefreshToken, error) {
+	return s.CreateClientRefreshToken(ctx, userID, "", "", client)
+}
+
+// CreateClientRefreshToken создает refresh token, выданный OAuth клиенту с указанным scope.
+// Обновить такой токен может только тот же клиент.
+func (s *AuthService) CreateClientRefreshToken(ctx context.Context, userID uuid.UUID, clientID, scope string, client domain.ClientInfo) (*domain.RefreshToken, error) {
 	token := helpers.GenerateSecureToken()
 	expiresAt := helpers.GetExpirationTime("refresh")
 
 	refreshToken := domain.NewRefreshToken(userID, token, expiresAt)
-	if err := s.refreshTokenRepo.Create(refreshToken); err != nil {
+	refreshToken.SetClientInfo(client)
+	refreshToken.SetOAuthClient(clientID, scope)
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRevocation.RegisterSession(ctx, userID, refreshToken.SessionID()); err != nil {
 		return nil, err
 	}
 
@@ -155,8 +361,8 @@ func (s *AuthService) CreateRefreshToken(userID uuid.UUID) (*domain.RefreshToken
 }
 
 // ValidateRefreshToken проверяет refresh token
-func (s *AuthService) ValidateRefreshToken(token string) (*domain.RefreshToken, error) {
-	refreshToken, err := s.refreshTokenRepo.GetByToken(token)
+func (s *AuthService) ValidateRefreshToken(ctx context.Context, token string) (*domain.RefreshToken, error) {
+	refreshToken, err := s.refreshTokenRepo.GetByToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
@@ -168,43 +374,144 @@ func (s *AuthService) ValidateRefreshToken(token string) (*domain.RefreshToken,
 	return refreshToken, nil
 }
 
-// RevokeRefreshToken отзывает refresh token
-func (s *AuthService) RevokeRefreshToken(token string) error {
-	refreshToken, err := s.refreshTokenRepo.GetByToken(token)
+// RotateRefreshToken обменивает refresh token на следующий в той же цепочке ротации.
+// Повторное предъявление уже ротированного токена считается признаком кражи:
+// всё семейство отзывается, а вызывающему возвращается ErrRefreshTokenReused.
+// clientID должен совпадать с клиентом, которому выдан токен (пустой для входа в само приложение).
+func (s *AuthService) RotateRefreshToken(ctx context.Context, token, clientID string, client domain.ClientInfo) (*domain.RefreshToken, error) {
+	current, err := s.refreshTokenRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.IsRotated() {
+		s.handleRefreshTokenReuse(ctx, current)
+		return nil, ErrRefreshTokenReused
+	}
+
+	if !current.IsValid() || current.ClientID() != clientID {
+		return nil, repository.ErrRefreshTokenInvalid
+	}
+
+	next := current.Rotate(helpers.GenerateSecureToken(), helpers.GetExpirationTime("refresh"))
+	next.SetClientInfo(client)
+	if err := s.refreshTokenRepo.Rotate(ctx, current, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenRevoked) {
+			// Токен успели ротировать параллельным запросом
+			s.handleRefreshTokenReuse(ctx, current)
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// RevokeRefreshToken отзывает refresh token вместе со всей его цепочкой ротации
+func (s *AuthService) RevokeRefreshToken(ctx context.Context, token string) error {
+	refreshToken, err := s.refreshTokenRepo.GetByToken(ctx, token)
 	if err != nil {
 		return err
 	}
 
-	refreshToken.SetRevoked(true)
-	return s.refreshTokenRepo.Update(refreshToken)
+	refreshToken.MarkLoggedOut()
+	return s.refreshTokenRepo.RevokeFamily(ctx, refreshToken)
+}
+
+// Logout завершает текущую сессию: отзывает цепочку refresh токенов и предъявленный access токен.
+// Отзыв одного не зависит от успеха другого: ошибки возвращаются вместе.
+func (s *AuthService) Logout(ctx context.Context, accessClaims *AccessTokenClaims, refreshToken string) error {
+	refreshErr := s.RevokeRefreshToken(ctx, refreshToken)
+	accessErr := s.tokenRevocation.RevokeAccessToken(ctx, accessClaims)
+
+	s.audit.Record(ctx, domain.AuditLogout, &accessClaims.UserID, &accessClaims.UserID, map[string]string{
+		"session_id": accessClaims.SessionID.String(),
+	})
+
+	return errors.Join(refreshErr, accessErr)
+}
+
+// RevokeAllUserTokens отзывает все refresh токены пользователя и уже выданные access токены
+func (s *AuthService) RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditTokensRevoked, contextActor(ctx), &userID, map[string]string{
+		"scope": "all",
+	})
+
+	return s.tokenRevocation.RevokeUserAccessTokens(ctx, userID)
 }
 
-// RevokeAllUserTokens отзывает все refresh токены пользователя
-func (s *AuthService) RevokeAllUserTokens(userID uuid.UUID) error {
-	tokens, err := s.refreshTokenRepo.GetByUserID(userID)
+// ListSessions возвращает активные сессии пользователя (по одному действующему токену на сессию)
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
+	return s.refreshTokenRepo.GetActiveByUserID(ctx, userID)
+}
+
+// RevokeSession завершает одну сессию пользователя
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	sessions, err := s.refreshTokenRepo.GetActiveByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	for _, token := range tokens {
-		if !token.IsRevoked() {
-			token.SetRevoked(true)
-			if err := s.refreshTokenRepo.Update(token); err != nil {
-				s.logger.Error("Failed to revoke refresh token",
-					logger.String("user_id", userID.String()),
-					logger.String("token", token.Token()),
-					logger.Error(err),
-				)
+	for _, session := range sessions {
+		if session.SessionID() == sessionID {
+			session.MarkLoggedOut()
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, session); err != nil {
+				return err
 			}
+
+			s.audit.Record(ctx, domain.AuditSessionRevoked, contextActor(ctx), &userID, map[string]string{
+				"session_id": sessionID.String(),
+			})
+			return s.tokenRevocation.RevokeSessionAccessTokens(ctx, userID, sessionID)
 		}
 	}
 
-	return nil
+	return ErrSessionNotFound
+}
+
+// RevokeOtherSessions завершает все сессии пользователя, кроме текущей, вместе с их access токенами
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uuid.UUID) (int64, error) {
+	sessions, err := s.refreshTokenRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := s.refreshTokenRepo.RevokeAllExceptFamily(ctx, userID, currentSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, session := range sessions {
+		if session.SessionID() == currentSessionID {
+			continue
+		}
+		if err := s.tokenRevocation.RevokeSessionAccessTokens(ctx, userID, session.SessionID()); err != nil {
+			return revoked, err
+		}
+	}
+
+	s.logger.WithContext(ctx).Info("Other sessions revoked",
+		logger.String("user_id", userID.String()),
+		logger.String("session_id", currentSessionID.String()),
+		logger.Int64("revoked", revoked),
+	)
+
+	s.audit.Record(ctx, domain.AuditTokensRevoked, contextActor(ctx), &userID, map[string]string{
+		"scope":      "other_sessions",
+		"session_id": currentSessionID.String(),
+		"count":      strconv.FormatInt(revoked, 10),
+	})
+
+	return revoked, nil
 }
 
 // VerifyEmail подтверждает email пользователя
-func (s *AuthService) VerifyEmail(token string) error {
-	verification, err := s.emailVerificationRepo.GetByToken(token)
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	verification, err := s.emailVerificationRepo.GetByToken(ctx, token)
 	if err != nil {
 		return err
 	}
@@ -214,7 +521,7 @@ func (s *AuthService) VerifyEmail(token string) error {
 	}
 
 	// Получаем пользователя
-	user, err := s.userRepo.GetByID(verification.UserID())
+	user, err := s.userRepo.GetByID(ctx, verification.UserID())
 	if err != nil {
 		return err
 	}
@@ -224,20 +531,30 @@ func (s *AuthService) VerifyEmail(token string) error {
 		return ErrEmailAlreadyVerified
 	}
 
-	// Верифицируем пользователя
-	user.SetVerified(true)
-	if err := s.userRepo.Update(user); err != nil {
+	// Верифицируем пользователя и помечаем токен использованным в одной транзакции
+	user.VerifyEmail()
+	verification.SetUsed(true)
+
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.Users.Update(ctx, user); err != nil {
+			return err
+		}
+		return repos.EmailVerifications.Update(ctx, verification)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Помечаем токен как использованный
-	verification.SetUsed(true)
-	return s.emailVerificationRepo.Update(verification)
+	userID := user.ID()
+	s.audit.Record(ctx, domain.AuditEmailVerified, &userID, &userID, map[string]string{
+		"email": user.Email(),
+	})
+	return nil
 }
 
 // InitiatePasswordReset создает токен для сброса пароля
-func (s *AuthService) InitiatePasswordReset(email string) error {
-	user, err := s.userRepo.GetByEmail(email)
+func (s *AuthService) InitiatePasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		// Не раскрываем информа��ию о существовании email
 		return nil
@@ -247,12 +564,52 @@ func (s *AuthService) InitiatePasswordReset(email string) error {
 		return nil
 	}
 
-	return s.createPasswordReset(user.ID())
+	reset, err := s.createPasswordReset(ctx, user.ID())
+	if err != nil {
+		return err
+	}
+
+	userID := user.ID()
+	s.audit.Record(ctx, domain.AuditPasswordResetRequested, nil, &userID, nil)
+
+	return s.emailService.SendPasswordResetEmail(ctx, user, reset.Token())
+}
+
+// ResendVerificationEmail повторно отправляет письмо подтверждения email. Для неизвестных
+// и уже подтвержденных адресов ничего не делает, чтобы не раскрывать их существование.
+// Повторное письмо раньше cooldown не отправляется, но ошибка не возвращается по той же причине.
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.IsVerified() || !user.IsActive() {
+		return nil
+	}
+
+	last, err := s.emailVerificationRepo.GetByUserID(ctx, user.ID())
+	if err != nil && !errors.Is(err, repository.ErrEmailVerificationNotFound) {
+		return err
+	}
+	if last != nil && time.Since(last.CreatedAt()) < s.emailService.ResendCooldown() {
+		return nil
+	}
+
+	verification, err := s.createEmailVerification(ctx, s.emailVerificationRepo, user.ID())
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendVerificationEmail(ctx, user, verification.Token())
 }
 
 // ResetPassword сбрасывает пароль пользователя
-func (s *AuthService) ResetPassword(token, newPassword string) error {
-	reset, err := s.passwordResetRepo.GetByToken(token)
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	reset, err := s.passwordResetRepo.GetByToken(ctx, token)
 	if err != nil {
 		return err
 	}
@@ -261,75 +618,181 @@ func (s *AuthService) ResetPassword(token, newPassword string) error {
 		return repository.ErrPasswordResetInvalid
 	}
 
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, reset.UserID())
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateNewPassword(ctx, userAuth, newPassword); err != nil {
+		return err
+	}
+
 	// Хешируем новый пароль
-	hashedPassword, err := helpers.HashPassword(newPassword)
+	hashedPassword, err := s.passwordHasher.Hash(newPassword)
 	if err != nil {
 		return err
 	}
 
-	// Обновляем пароль
-	userAuth, err := s.userAuthRepo.GetByUserID(reset.UserID())
+	userAuth.ChangePassword(hashedPassword, domain.PasswordChangeReasonReset)
+	reset.SetUsed(true)
+
+	// Новый пароль, использованный токен и отзыв сессий фиксируются вместе
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.UserAuth.Update(ctx, userAuth); err != nil {
+			return err
+		}
+
+		if err := repos.PasswordHistory.Add(ctx, domain.NewPasswordHistory(userAuth.UserID(), hashedPassword), passwordHistoryLimit); err != nil {
+			return err
+		}
+
+		if err := repos.PasswordResets.Update(ctx, reset); err != nil {
+			return err
+		}
+
+		_, err := repos.RefreshTokens.RevokeAllByUserID(ctx, reset.UserID())
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	userAuth.SetPasswordHash(hashedPassword)
-	if err := s.userAuthRepo.Update(userAuth); err != nil {
+	userID := reset.UserID()
+	s.audit.Record(ctx, domain.AuditPasswordReset, &userID, &userID, nil)
+
+	// Access токены, выданные со старым паролем, тоже перестают действовать
+	return s.tokenRevocation.RevokeUserAccessTokens(ctx, reset.UserID())
+}
+
+// RequestMagicLink отправляет одноразовую ссылку для входа без пароля. Как и при сбросе
+// пароля, для неизвестных и неактивных адресов ничего не делает. Повторное письмо раньше
+// cooldown не отправляется, но ошибка не возвращается, чтобы ответ не раскрывал аккаунт.
+func (s *AuthService) RequestMagicLink(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
 		return err
 	}
 
-	// Помечаем токен как использованный
-	reset.SetUsed(true)
-	if err := s.passwordResetRepo.Update(reset); err != nil {
+	if !user.IsActive() {
+		return nil
+	}
+
+	last, err := s.magicLinkRepo.GetByUserID(ctx, user.ID())
+	if err != nil && !errors.Is(err, repository.ErrMagicLinkNotFound) {
+		return err
+	}
+	if last != nil && time.Since(last.CreatedAt()) < s.emailService.ResendCooldown() {
+		return nil
+	}
+
+	link, err := s.createMagicLink(ctx, user.ID())
+	if err != nil {
 		return err
 	}
 
-	// Отзываем все refresh токены пользователя
-	return s.RevokeAllUserTokens(reset.UserID())
+	return s.emailService.SendMagicLinkEmail(ctx, user, link.Token())
 }
 
-// ChangePassword изменяет пароль пользователя
-func (s *AuthService) ChangePassword(userID uuid.UUID, currentPassword, newPassword string) error {
-	userAuth, err := s.userAuthRepo.GetByUserID(userID)
+// LoginWithMagicLink обменивает ссылку из письма на пользователя, как AuthenticateUser
+// для пароля. Ссылка погашается сразу, поэтому второй обмен той же ссылки не пройдет.
+func (s *AuthService) LoginWithMagicLink(ctx context.Context, token string) (*domain.User, error) {
+	link, err := s.magicLinkRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrMagicLinkNotFound) {
+			s.audit.RecordLoginFailed(ctx, nil, "magic_link", "invalid_link", "")
+			return nil, repository.ErrMagicLinkInvalid
+		}
+		return nil, err
+	}
+
+	linkUserID := link.UserID()
+	if !link.IsValid() {
+		s.audit.RecordLoginFailed(ctx, &linkUserID, "magic_link", "invalid_link", "")
+		return nil, repository.ErrMagicLinkInvalid
+	}
+
+	if err := s.magicLinkRepo.MarkUsed(ctx, link.ID()); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, link.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		s.audit.RecordLoginFailed(ctx, &linkUserID, "magic_link", "inactive", "")
+		return nil, ErrUserInactive
+	}
+
+	// Ссылка заменяет только пароль: с 2FA вход завершается в CompleteMFAChallenge
+	if err := s.RecordFirstFactorLogin(ctx, user.ID(), "magic_link"); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ChangePassword изменяет пароль пользователя. Если пароля еще нет, задает его.
+func (s *AuthService) ChangePassword(ctx context.Context, userID, currentSessionID uuid.UUID, currentPassword, newPassword string) error {
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	// Проверяем текущий пароль
-	if err := helpers.ComparePassword(userAuth.PasswordHash(), currentPassword); err != nil {
-		return ErrInvalidCurrentPassword
+	// Проверяем текущий пароль. Аккаунт без пароля задает первый пароль без него.
+	if userAuth.HasPassword() {
+		if err := s.passwordHasher.Verify(userAuth.PasswordHash(), currentPassword); err != nil {
+			return ErrInvalidCurrentPassword
+		}
+	}
+
+	if err := s.validateNewPassword(ctx, userAuth, newPassword); err != nil {
+		return err
 	}
 
 	// Хешируем новый пароль
-	hashedPassword, err := helpers.HashPassword(newPassword)
+	hashedPassword, err := s.passwordHasher.Hash(newPassword)
 	if err != nil {
 		return err
 	}
 
-	// Обновляем пароль
-	userAuth.SetPasswordHash(hashedPassword)
-	if err := s.userAuthRepo.Update(userAuth); err != nil {
+	// Обновляем пароль и историю паролей
+	userAuth.ChangePassword(hashedPassword, domain.PasswordChangeReasonChange)
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.UserAuth.Update(ctx, userAuth); err != nil {
+			return err
+		}
+
+		return repos.PasswordHistory.Add(ctx, domain.NewPasswordHistory(userID, hashedPassword), passwordHistoryLimit)
+	})
+	if err != nil {
 		return err
 	}
 
+	s.audit.Record(ctx, domain.AuditPasswordChanged, &userID, &userID, nil)
+
 	// Отзываем все refresh токены кроме текущего
-	return s.RevokeAllUserTokens(userID)
+	_, err = s.RevokeOtherSessions(ctx, userID, currentSessionID)
+	return err
 }
 
 // GetUserRoles возвращает роли пользователя
-func (s *AuthService) GetUserRoles(userID uuid.UUID) ([]*domain.UserRole, error) {
-	return s.userRoleRepo.GetByUserID(userID)
+func (s *AuthService) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*domain.UserRole, error) {
+	return s.userRoleRepo.GetByUserID(ctx, userID)
 }
 
 // HasRole проверяет наличие роли у пользователя
-func (s *AuthService) HasRole(userID uuid.UUID, role domain.UserRoleType) (bool, error) {
-	roles, err := s.userRoleRepo.GetByUserID(userID)
+func (s *AuthService) HasRole(ctx context.Context, userID uuid.UUID, role domain.UserRoleType) (bool, error) {
+	roles, err := s.userRoleRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return false, err
 	}
 
 	for _, userRole := range roles {
-		if userRole.Role() == role && userRole.IsActive() {
+		if userRole.Role() == role && userRole.IsEffective() {
 			return true, nil
 		}
 	}
@@ -337,74 +800,360 @@ func (s *AuthService) HasRole(userID uuid.UUID, role domain.UserRoleType) (bool,
 	return false, nil
 }
 
-// HasPermission проверяет, имеет ли пользователь необходимые права
-func (s *AuthService) HasPermission(userID uuid.UUID, requiredRole domain.UserRoleType) (bool, error) {
-	roles, err := s.userRoleRepo.GetByUserID(userID)
+// GetUserAccess возвращает активные роли пользователя и объединение их прав для access токена
+func (s *AuthService) GetUserAccess(ctx context.Context, userID uuid.UUID) (*UserAccess, error) {
+	userRoles, err := s.userRoleRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	for _, userRole := range roles {
-		if userRole.IsActive() && helpers.HasHigherRole(userRole.Role(), requiredRole) {
-			return true, nil
+	access := &UserAccess{Roles: make([]domain.UserRoleType, 0, len(userRoles))}
+	for _, userRole := range userRoles {
+		if userRole.IsEffective() {
+			access.Roles = append(access.Roles, userRole.Role())
 		}
 	}
+	if len(access.Roles) == 0 {
+		return access, nil
+	}
 
-	return false, nil
+	roles, err := s.roleRepo.GetByNames(ctx, access.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		access.Permissions = append(access.Permissions, role.Permissions()...)
+	}
+	access.Permissions = domain.NormalizePermissions(access.Permissions)
+
+	return access, nil
+}
+
+// HasPermission проверяет, дает ли одна из активных ролей пользователя право
+func (s *AuthService) HasPermission(ctx context.Context, userID uuid.UUID, permission domain.Permission) (bool, error) {
+	access, err := s.GetUserAccess(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(access.Permissions, permission), nil
 }
 
-// AssignRole назначает роль пользователю
-func (s *AuthService) AssignRole(userID uuid.UUID, role domain.UserRoleType) error {
-	// Проверяем валидность роли
-	if !helpers.IsValidRole(role) {
-		return repository.ErrInvalidRole
+// AssignRole назначает роль пользователю от имени администратора
+func (s *AuthService) AssignRole(ctx context.Context, grant RoleGrant) error {
+	if grant.ExpiresAt != nil && !grant.ExpiresAt.After(time.Now()) {
+		return ErrInvalidRoleExpiry
+	}
+
+	if err := s.checkCanAssignRole(ctx, grant.ActorID, grant.Role); err != nil {
+		return err
 	}
 
-	// Проверяем, нет ли уже такой роли
-	hasRole, err := s.HasRole(userID, role)
+	roles, err := s.userRoleRepo.GetByUserID(ctx, grant.UserID)
 	if err != nil {
 		return err
 	}
 
-	if hasRole {
-		return repository.ErrUserRoleAlreadyExists
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		for _, userRole := range roles {
+			if userRole.Role() != grant.Role || !userRole.IsActive() {
+				continue
+			}
+			if !userRole.IsExpired() {
+				return repository.ErrUserRoleAlreadyExists
+			}
+
+			// Истекшая роль еще не снята фоновой задачей: снимаем ее сейчас, чтобы выдать заново
+			if err := s.expireRole(ctx, repos, userRole); err != nil {
+				return err
+			}
+		}
+
+		userRole := domain.NewRoleGrant(grant.UserID, grant.Role, grant.ActorID, grant.Reason, grant.ExpiresAt)
+		userRole.MarkAssigned()
+		if err := repos.UserRoles.Create(ctx, userRole); err != nil {
+			return err
+		}
+
+		change := domain.NewRoleChange(grant.UserID, grant.Role, domain.RoleChangeGranted, &grant.ActorID, grant.Reason, grant.ExpiresAt)
+		return repos.RoleChanges.Create(ctx, change)
+	})
+	if err != nil {
+		return err
 	}
 
-	userRole := domain.NewUserRole(userID, role)
-	return s.userRoleRepo.Create(userRole)
+	details := map[string]string{"role": string(grant.Role), "reason": grant.Reason}
+	if grant.ExpiresAt != nil {
+		details["expires_at"] = grant.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	s.audit.Record(ctx, domain.AuditRoleGranted, &grant.ActorID, &grant.UserID, details)
+	return nil
 }
 
-// RevokeRole отзывает роль у пользователя
-func (s *AuthService) RevokeRole(userID uuid.UUID, role domain.UserRoleType) error {
-	roles, err := s.userRoleRepo.GetByUserID(userID)
+// RevokeRole отзывает роль у пользователя от имени администратора
+func (s *AuthService) RevokeRole(ctx context.Context, userID uuid.UUID, role domain.UserRoleType, actorID uuid.UUID, reason string) error {
+	if err := s.checkCanAssignRole(ctx, actorID, role); err != nil {
+		if errors.Is(err, repository.ErrInvalidRole) {
+			return repository.ErrUserRoleNotFound
+		}
+		return err
+	}
+
+	roles, err := s.userRoleRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
 	for _, userRole := range roles {
 		if userRole.Role() == role && userRole.IsActive() {
-			userRole.SetActive(false)
-			return s.userRoleRepo.Update(userRole)
+			err := s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+				userRole.Revoke()
+				if err := repos.UserRoles.Update(ctx, userRole); err != nil {
+					return err
+				}
+
+				change := domain.NewRoleChange(userID, role, domain.RoleChangeRevoked, &actorID, reason, nil)
+				return repos.RoleChanges.Create(ctx, change)
+			})
+			if err != nil {
+				return err
+			}
+
+			s.audit.Record(ctx, domain.AuditRoleRevoked, &actorID, &userID, map[string]string{
+				"role":   string(role),
+				"reason": reason,
+			})
+
+			// Выданные токены содержат отозванную роль, поэтому пользователю придется обновить их
+			return s.tokenRevocation.RevokeUserAccessTokens(ctx, userID)
 		}
 	}
 
 	return repository.ErrUserRoleNotFound
 }
 
+// GetRoleHistory возвращает последние изменения ролей пользователя
+func (s *AuthService) GetRoleHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.RoleChange, error) {
+	if limit <= 0 || limit > maxRoleHistoryLimit {
+		limit = defaultRoleHistoryLimit
+	}
+	return s.roleChangeRepo.ListByUserID(ctx, userID, limit)
+}
+
+// ExpireRoles снимает временные роли с истекшим сроком и возвращает их количество
+func (s *AuthService) ExpireRoles(ctx context.Context) (int, error) {
+	roles, err := s.userRoleRepo.ListExpired(ctx, roleExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, userRole := range roles {
+		err := s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+			return s.expireRole(ctx, repos, userRole)
+		})
+		if err != nil {
+			return expired, err
+		}
+		expired++
+
+		userID := userRole.UserID()
+		s.audit.Record(ctx, domain.AuditRoleExpired, nil, &userID, map[string]string{
+			"role": string(userRole.Role()),
+		})
+
+		if err := s.tokenRevocation.RevokeUserAccessTokens(ctx, userRole.UserID()); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to revoke access tokens after role expiry",
+				logger.String("user_id", userRole.UserID().String()),
+				logger.Error(err),
+			)
+		}
+	}
+
+	return expired, nil
+}
+
+// RunRoleExpiry периодически снимает временные роли с истекшим сроком
+func (s *AuthService) RunRoleExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireRoles(ctx)
+			if err != nil {
+				s.logger.Error("Failed to expire temporary roles", logger.Error(err))
+				continue
+			}
+			if expired > 0 {
+				s.logger.Info("Temporary roles expired", logger.Int("count", expired))
+			}
+		}
+	}
+}
+
 // Приватные методы
 
-func (s *AuthService) createEmailVerification(userID uuid.UUID) error {
+// checkCanAssignRole проверяет, что роль существует и у назначающего есть все ее права
+func (s *AuthService) checkCanAssignRole(ctx context.Context, actorID uuid.UUID, name domain.UserRoleType) error {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrRoleNotFound) {
+			return repository.ErrInvalidRole
+		}
+		return err
+	}
+
+	actor, err := s.GetUserAccess(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	if !role.IsGrantableBy(actor.Permissions) {
+		s.logger.WithContext(ctx).Warn("Role change denied: actor lacks role permissions",
+			logger.String("actor_id", actorID.String()),
+			logger.String("role", string(name)),
+		)
+		return ErrRoleNotGrantable
+	}
+
+	return nil
+}
+
+// expireRole снимает истекшую роль и записывает это в историю
+func (s *AuthService) expireRole(ctx context.Context, repos repository.Repositories, userRole *domain.UserRole) error {
+	userRole.Expire()
+	if err := repos.UserRoles.Update(ctx, userRole); err != nil {
+		return err
+	}
+
+	change := domain.NewRoleChange(userRole.UserID(), userRole.Role(), domain.RoleChangeExpired, nil, userRole.Reason(), userRole.ExpiresAt())
+	return repos.RoleChanges.Create(ctx, change)
+}
+
+func (s *AuthService) createEmailVerification(ctx context.Context, repo repository.EmailVerificationRepository, userID uuid.UUID) (*domain.EmailVerification, error) {
 	token := helpers.GenerateSecureToken()
 	expiresAt := helpers.GetExpirationTime("email_verification")
 
 	verification := domain.NewEmailVerification(userID, token, expiresAt)
-	return s.emailVerificationRepo.Create(verification)
+	if err := repo.Create(ctx, verification); err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
+
+// validateNewPassword прогоняет новый пароль через все правила с учетом данных пользователя
+// и его прежних паролей
+func (s *AuthService) validateNewPassword(ctx context.Context, userAuth *domain.UserAuth, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userAuth.UserID())
+	if err != nil {
+		return err
+	}
+
+	return s.validationService.ValidatePassword(ctx, PasswordCandidate{
+		Password:            newPassword,
+		UserID:              user.ID(),
+		CurrentPasswordHash: userAuth.PasswordHash(),
+		UserInputs:          []string{user.Email(), user.Username(), user.DisplayName()},
+	})
+}
+
+// rehashPasswordIfNeeded обновляет хеш пароля в userAuth, если он создан устаревшим
+// алгоритмом или параметрами. Сохраняется вместе со временем входа; ошибка хеширования
+// не мешает входу.
+func (s *AuthService) rehashPasswordIfNeeded(ctx context.Context, userAuth *domain.UserAuth, password string) {
+	if !s.passwordHasher.NeedsRehash(userAuth.PasswordHash()) {
+		return
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to rehash password",
+			logger.String("user_id", userAuth.UserID().String()),
+			logger.Error(err),
+		)
+		return
+	}
+
+	// Сброс пароля мог завершиться после того, как мы прочитали хеш: тогда старый пароль
+	// уже недействителен и записывать его новый хеш нельзя
+	replaced, err := s.userAuthRepo.ReplacePasswordHash(ctx, userAuth.UserID(), userAuth.PasswordHash(), hashedPassword)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to store upgraded password hash",
+			logger.String("user_id", userAuth.UserID().String()),
+			logger.Error(err),
+		)
+		return
+	}
+	if !replaced {
+		s.logger.WithContext(ctx).Info("Password hash upgrade skipped: password changed concurrently",
+			logger.String("user_id", userAuth.UserID().String()),
+		)
+		return
+	}
+
+	s.logger.WithContext(ctx).Info("Password hash upgraded", logger.String("user_id", userAuth.UserID().String()))
+}
+
+// RecordLastLogin обновляет время последнего входа. Общий для всех способов входа; ошибка
+// только логируется: вход уже состоялся.
+func (s *AuthService) RecordLastLogin(ctx context.Context, userID uuid.UUID) {
+	if err := s.userAuthRepo.UpdateLastLogin(ctx, userID, time.Now()); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update last login time",
+			logger.String("user_id", userID.String()),
+			logger.Error(err),
+		)
+	}
 }
 
-func (s *AuthService) createPasswordReset(userID uuid.UUID) error {
+// handleRefreshTokenReuse отзывает скомпрометированное семейство токенов и фиксирует событие безопасности
+func (s *AuthService) handleRefreshTokenReuse(ctx context.Context, token *domain.RefreshToken) {
+	// Отзыв семейства не должен прерываться из-за отмены запроса
+	ctx = context.WithoutCancel(ctx)
+
+	s.logger.WithContext(ctx).Warn("Security event: refresh token reuse detected",
+		logger.String("event", "refresh_token_reuse"),
+		logger.String("user_id", token.UserID().String()),
+		logger.String("family_id", token.FamilyID().String()),
+		logger.String("token_id", token.ID().String()),
+	)
+
+	userID := token.UserID()
+	s.audit.Record(ctx, domain.AuditRefreshTokenReused, nil, &userID, map[string]string{
+		"session_id": token.SessionID().String(),
+	})
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to revoke compromised refresh token family",
+			logger.String("user_id", token.UserID().String()),
+			logger.String("family_id", token.FamilyID().String()),
+			logger.Error(err),
+		)
+	}
+}
+
+func (s *AuthService) createPasswordReset(ctx context.Context, userID uuid.UUID) (*domain.PasswordReset, error) {
 	token := helpers.GenerateSecureToken()
 	expiresAt := helpers.GetExpirationTime("password_reset")
 
 	reset := domain.NewPasswordReset(userID, token, expiresAt)
-	return s.passwordResetRepo.Create(reset)
+	if err := s.passwordResetRepo.Create(ctx, reset); err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
+
+func (s *AuthService) createMagicLink(ctx context.Context, userID uuid.UUID) (*domain.MagicLink, error) {
+	token := helpers.GenerateSecureToken()
+	expiresAt := helpers.GetExpirationTime("magic_link")
+
+	link := domain.NewMagicLink(userID, token, expiresAt)
+	if err := s.magicLinkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+	return link, nil
 }
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultUserPageSize       = 50
+	maxUserPageSize           = 200
+	suspensionExpiryBatchSize = 100
+)
+
+// UserQuery - условия поиска пользователей администратором. Cursor берется из NextCursor
+// предыдущей страницы.
+type UserQuery struct {
+	Email          string
+	UsernamePrefix string
+	IsVerified     *bool
+	IsActive       *bool
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	Cursor         string
+	Limit          int
+}
+
+// UserPage - страница списка пользователей. NextCursor пуст на последней странице.
+type UserPage struct {
+	Users      []*domain.User
+	NextCursor string
+}
+
+// UserSuspension - блокировка пользователя администратором. Until задает срок временной блокировки.
+type UserSuspension struct {
+	UserID  uuid.UUID
+	ActorID uuid.UUID
+	Reason  string
+	Until   *time.Time
+}
+
+// ListUsers возвращает страницу пользователей, от новых к старым
+func (s *AuthService) ListUsers(ctx context.Context, query UserQuery) (*UserPage, error) {
+	filter := repository.UserFilter{
+		Email:          query.Email,
+		UsernamePrefix: query.UsernamePrefix,
+		IsVerified:     query.IsVerified,
+		IsActive:       query.IsActive,
+		CreatedFrom:    query.CreatedFrom,
+		CreatedTo:      query.CreatedTo,
+		Limit:          query.Limit,
+	}
+	if query.Cursor != "" {
+		createdAt, id, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &repository.UserCursor{CreatedAt: createdAt, ID: id}
+	}
+
+	if filter.Limit <= 0 || filter.Limit > maxUserPageSize {
+		filter.Limit = defaultUserPageSize
+	}
+	pageSize := filter.Limit
+
+	// Лишняя запись показывает, есть ли следующая страница
+	filter.Limit++
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &UserPage{Users: users}
+	if len(users) > pageSize {
+		page.Users = users[:pageSize]
+		last := page.Users[pageSize-1]
+		page.NextCursor = encodeCursor(last.CreatedAt(), last.ID())
+	}
+
+	return page, nil
+}
+
+// SuspendUser блокирует пользователя и завершает все его сессии. Нельзя заблокировать себя
+// и пользователя, у которого есть права, которых нет у администратора.
+func (s *AuthService) SuspendUser(ctx context.Context, suspension UserSuspension) (*domain.User, error) {
+	if suspension.Until != nil && !suspension.Until.After(time.Now()) {
+		return nil, ErrInvalidSuspensionEnd
+	}
+	if suspension.UserID == suspension.ActorID {
+		return nil, ErrCannotSuspendSelf
+	}
+
+	user, err := s.userRepo.GetByID(ctx, suspension.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, ErrUserAlreadySuspended
+	}
+
+	if err := s.checkActorOutranks(ctx, suspension.ActorID, suspension.UserID); err != nil {
+		return nil, err
+	}
+
+	var revoked int64
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		user.Suspend(suspension.ActorID, suspension.Reason, suspension.Until)
+		if err := repos.Users.Update(ctx, user); err != nil {
+			return err
+		}
+
+		revoked, err = repos.RefreshTokens.RevokeAllByUserID(ctx, user.ID())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("User suspended",
+		logger.String("user_id", user.ID().String()),
+		logger.String("actor_id", suspension.ActorID.String()),
+		logger.Int64("sessions_revoked", revoked),
+	)
+
+	details := map[string]string{
+		"reason":           suspension.Reason,
+		"sessions_revoked": strconv.FormatInt(revoked, 10),
+	}
+	if suspension.Until != nil {
+		details["until"] = suspension.Until.UTC().Format(time.RFC3339)
+	}
+	s.audit.Record(ctx, domain.AuditUserSuspended, &suspension.ActorID, &suspension.UserID, details)
+
+	// Refresh токены отозваны в транзакции, access токены отзываются отдельно
+	if err := s.tokenRevocation.RevokeUserAccessTokens(ctx, user.ID()); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ReactivateUser снимает блокировку с пользователя
+func (s *AuthService) ReactivateUser(ctx context.Context, userID, actorID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsActive() {
+		return nil, ErrUserNotSuspended
+	}
+
+	user.Reactivate()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("User reactivated",
+		logger.String("user_id", userID.String()),
+		logger.String("actor_id", actorID.String()),
+	)
+
+	s.audit.Record(ctx, domain.AuditUserReactivated, &actorID, &userID, nil)
+
+	return user, nil
+}
+
+// ExpireSuspensions снимает временные блокировки с истекшим сроком и возвращает их количество
+func (s *AuthService) ExpireSuspensions(ctx context.Context) (int, error) {
+	users, err := s.userRepo.ListExpiredSuspensions(ctx, suspensionExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reactivated := 0
+	for _, user := range users {
+		user.Reactivate()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return reactivated, err
+		}
+		reactivated++
+
+		userID := user.ID()
+		s.audit.Record(ctx, domain.AuditUserReactivated, nil, &userID, map[string]string{
+			"reason": "suspension_expired",
+		})
+	}
+
+	return reactivated, nil
+}
+
+// RunSuspensionExpiry периодически снимает временные блокировки с истекшим сроком
+func (s *AuthService) RunSuspensionExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reactivated, err := s.ExpireSuspensions(ctx)
+			if err != nil {
+				s.logger.Error("Failed to lift expired suspensions", logger.Error(err))
+				continue
+			}
+			if reactivated > 0 {
+				s.logger.Info("Expired suspensions lifted", logger.Int("count", reactivated))
+			}
+		}
+	}
+}
+
+// checkActorOutranks проверяет, что у администратора есть все права пользователя: модератор
+// не может заблокировать администратора или действовать от его имени
+func (s *AuthService) checkActorOutranks(ctx context.Context, actorID, userID uuid.UUID) error {
+	actor, err := s.GetUserAccess(ctx, actorID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.GetUserAccess(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range target.Permissions {
+		if !slices.Contains(actor.Permissions, permission) {
+			s.logger.WithContext(ctx).Warn("Admin action denied: user has permissions the actor lacks",
+				logger.String("actor_id", actorID.String()),
+				logger.String("user_id", userID.String()),
+			)
+			return ErrUserOutranksActor
+		}
+	}
+
+	return nil
+}
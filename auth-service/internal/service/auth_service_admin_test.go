@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type userAdminTest struct {
+	service   *AuthService
+	users     *userStore
+	tokens    *refreshTokenStore
+	audit     *auditRecorder
+	admin     *domain.User
+	moderator *domain.User
+	target    *domain.User
+}
+
+func newUserAdminTest(t *testing.T) *userAdminTest {
+	t.Helper()
+
+	at := &userAdminTest{
+		admin:     domain.NewUser("admin@example.com", "admin", "Admin"),
+		moderator: domain.NewUser("moderator@example.com", "moderator", "Moderator"),
+		target:    newTestUser(),
+		tokens:    newRefreshTokenStore(),
+	}
+	at.users = &userStore{users: map[uuid.UUID]*domain.User{}}
+	for _, user := range []*domain.User{at.admin, at.moderator, at.target} {
+		at.users.users[user.ID()] = user
+	}
+	userRoles := &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{
+		at.admin.ID():     {domain.NewUserRole(at.admin.ID(), domain.RoleAdmin)},
+		at.moderator.ID(): {domain.NewUserRole(at.moderator.ID(), domain.RoleModerator)},
+		at.target.ID():    {domain.NewUserRole(at.target.ID(), domain.RoleUser)},
+	}}
+
+	revocation, _ := newTestTokenRevocationService(t)
+	audit, recorder := newTestAuditService()
+	at.audit = recorder
+	at.service = &AuthService{
+		userRepo:        at.users,
+		userRoleRepo:    userRoles,
+		roleRepo:        newRoleStore(),
+		tokenRevocation: revocation,
+		audit:           audit,
+		txManager:       &inlineTxManager{repos: repository.Repositories{Users: at.users, RefreshTokens: at.tokens}},
+		logger:          newTestLogger(),
+	}
+	return at
+}
+
+func TestAuthService_SuspendUser(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		user    func(at *userAdminTest) *domain.User
+		actor   func(at *userAdminTest) *domain.User
+		until   *time.Time
+		wantErr error
+	}{
+		{"admin suspends user", func(at *userAdminTest) *domain.User { return at.target }, func(at *userAdminTest) *domain.User { return at.admin }, nil, nil},
+		{"moderator suspends user", func(at *userAdminTest) *domain.User { return at.target }, func(at *userAdminTest) *domain.User { return at.moderator }, nil, nil},
+		{"moderator suspends admin", func(at *userAdminTest) *domain.User { return at.admin }, func(at *userAdminTest) *domain.User { return at.moderator }, nil, ErrUserOutranksActor},
+		{"admin suspends self", func(at *userAdminTest) *domain.User { return at.admin }, func(at *userAdminTest) *domain.User { return at.admin }, nil, ErrCannotSuspendSelf},
+		{"end in the past", func(at *userAdminTest) *domain.User { return at.target }, func(at *userAdminTest) *domain.User { return at.admin }, &past, ErrInvalidSuspensionEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			at := newUserAdminTest(t)
+			user, actor := tt.user(at), tt.actor(at)
+
+			session := domain.NewRefreshToken(user.ID(), uuid.NewString(), time.Now().Add(time.Hour))
+			if err := at.tokens.Create(ctx, session); err != nil {
+				t.Fatalf("create refresh token: %v", err)
+			}
+			access, err := at.service.tokenRevocation.jwtService.GenerateAccessToken(user, testUserAccess(), session.SessionID())
+			if err != nil {
+				t.Fatalf("generate access token: %v", err)
+			}
+			if tt.wantErr == nil {
+				waitForSecondStart()
+			}
+
+			_, err = at.service.SuspendUser(ctx, UserSuspension{UserID: user.ID(), ActorID: actor.ID(), Reason: "spam", Until: tt.until})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SuspendUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !user.IsActive() || session.IsRevoked() || len(at.audit.types()) != 0 {
+					t.Fatalf("denied suspension must not change the user")
+				}
+				return
+			}
+
+			if user.IsActive() || user.Suspension() == nil || *user.Suspension().SuspendedBy != actor.ID() {
+				t.Fatalf("user must be suspended by %v", actor.ID())
+			}
+			if !session.IsRevoked() {
+				t.Fatalf("sessions of the suspended user must be revoked")
+			}
+			if _, err := at.service.tokenRevocation.ValidateAccessToken(ctx, access); !errors.Is(err, ErrTokenRevoked) {
+				t.Fatalf("access tokens of the suspended user must be revoked, got %v", err)
+			}
+			if got := at.audit.types(); len(got) != 1 || got[0] != domain.AuditUserSuspended {
+				t.Fatalf("audit events = %v", got)
+			}
+
+			if _, err := at.service.SuspendUser(ctx, UserSuspension{UserID: user.ID(), ActorID: actor.ID()}); !errors.Is(err, ErrUserAlreadySuspended) {
+				t.Fatalf("expected ErrUserAlreadySuspended, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ReactivateUser(t *testing.T) {
+	ctx := context.Background()
+	at := newUserAdminTest(t)
+
+	if _, err := at.service.ReactivateUser(ctx, at.target.ID(), at.admin.ID()); !errors.Is(err, ErrUserNotSuspended) {
+		t.Fatalf("expected ErrUserNotSuspended, got %v", err)
+	}
+
+	at.target.Suspend(at.admin.ID(), "spam", nil)
+	user, err := at.service.ReactivateUser(ctx, at.target.ID(), at.admin.ID())
+	if err != nil {
+		t.Fatalf("ReactivateUser() error = %v", err)
+	}
+	if !user.IsActive() || user.Suspension() != nil {
+		t.Fatalf("user must be active without suspension")
+	}
+}
+
+func TestAuthService_ExpireSuspensions(t *testing.T) {
+	at := newUserAdminTest(t)
+
+	ended := time.Now().Add(-time.Minute)
+	at.target.Suspend(at.admin.ID(), "spam", &ended)
+	inDay := time.Now().Add(24 * time.Hour)
+	at.moderator.Suspend(at.admin.ID(), "abuse", &inDay)
+
+	count, err := at.service.ExpireSuspensions(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireSuspensions() error = %v", err)
+	}
+	if count != 1 || !at.target.IsActive() || at.moderator.IsActive() {
+		t.Fatalf("only the ended suspension must be lifted, count = %d", count)
+	}
+	if event := at.audit.events[0]; event.Type() != domain.AuditUserReactivated || event.ActorID() != nil {
+		t.Fatalf("lifted suspension must be recorded as a system change")
+	}
+}
+
+func TestAuthService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	users := &userStore{users: map[uuid.UUID]*domain.User{}}
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		user := domain.NewUser(fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("user%d", i), "User")
+		user.SetCreatedAt(start.Add(time.Duration(i) * time.Minute))
+		users.users[user.ID()] = user
+	}
+	s := &AuthService{userRepo: users, logger: newTestLogger()}
+
+	var names []string
+	query := UserQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("pagination does not terminate")
+		}
+		page, err := s.ListUsers(ctx, query)
+		if err != nil {
+			t.Fatalf("ListUsers() error = %v", err)
+		}
+		for _, user := range page.Users {
+			names = append(names, user.Username())
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if got := fmt.Sprint(names); got != "[user4 user3 user2 user1 user0]" {
+		t.Fatalf("users = %s, want newest first without repeats", got)
+	}
+
+	if _, err := s.ListUsers(ctx, UserQuery{Cursor: "garbage!"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
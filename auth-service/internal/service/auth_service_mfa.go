@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recoveryCodesCount - количество кодов восстановления, выдаваемых при включении 2FA
+const recoveryCodesCount = 10
+
+// IsMFAEnabled проверяет, включена ли у пользователя двухфакторная аутентификация
+func (s *AuthService) IsMFAEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.userMFARepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserMFANotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return mfa.IsEnabled(), nil
+}
+
+// RecordFirstFactorLogin записывает вход после проверки первого фактора: ссылки из письма,
+// passkey без проверки пользователя или внешнего провайдера. С 2FA вход еще не состоялся:
+// записывается только запрос второго фактора, а успешный вход и время последнего входа
+// фиксирует CompleteMFAChallenge.
+func (s *AuthService) RecordFirstFactorLogin(ctx context.Context, userID uuid.UUID, method string) error {
+	mfaEnabled, err := s.IsMFAEnabled(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if mfaEnabled {
+		s.audit.RecordLoginMFARequired(ctx, userID, method)
+		return nil
+	}
+
+	s.RecordLastLogin(ctx, userID)
+	s.audit.RecordLoginSucceeded(ctx, userID, method)
+	return nil
+}
+
+// EnrollTOTP начинает подключение TOTP: создает секрет и возвращает его вместе с otpauth URI.
+// 2FA не включается, пока пользователь не подтвердит секрет первым кодом.
+func (s *AuthService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret := helpers.GenerateTOTPSecret()
+
+	mfa, err := s.userMFARepo.GetByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, repository.ErrUserMFANotFound):
+		if err := s.userMFARepo.Create(ctx, domain.NewUserMFA(userID, secret)); err != nil {
+			return "", "", err
+		}
+	case err != nil:
+		return "", "", err
+	case mfa.IsEnabled():
+		return "", "", ErrMFAAlreadyEnabled
+	default:
+		// Повторное подключение до подтверждения заменяет секрет
+		mfa.SetSecret(secret)
+		if err := s.userMFARepo.Update(ctx, mfa); err != nil {
+			return "", "", err
+		}
+	}
+
+	s.logger.WithContext(ctx).Info("TOTP enrollment started", logger.String("user_id", userID.String()))
+
+	return secret, helpers.BuildTOTPURI(s.totpIssuer, user.Email(), secret), nil
+}
+
+// ConfirmTOTP включает 2FA после проверки первого кода и возвращает коды восстановления.
+// Коды показываются пользователю один раз, в БД хранятся только их хеши.
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	mfa, err := s.userMFARepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserMFANotFound) {
+			return nil, ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+
+	if mfa.IsEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := helpers.ValidateTOTPCode(mfa.Secret(), code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	// Включенная 2FA без кодов восстановления оставила бы пользователя без запасного входа
+	var recoveryCodes []string
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		mfa.Enable(step)
+		if err := repos.UserMFA.Update(ctx, mfa); err != nil {
+			return err
+		}
+
+		recoveryCodes, err = s.generateRecoveryCodes(ctx, repos.MFARecoveryCodes, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Two-factor authentication enabled", logger.String("user_id", userID.String()))
+
+	s.audit.Record(ctx, domain.AuditMFAEnabled, &userID, &userID, nil)
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP отключает 2FA. Требует пароль, если он задан, и действующий код (TOTP или код восстановления).
+func (s *AuthService) DisableTOTP(ctx context.Context, userID uuid.UUID, password, code string) error {
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Аккаунт без пароля подтверждает отключение только кодом
+	if userAuth.HasPassword() {
+		if err := s.passwordHasher.Verify(userAuth.PasswordHash(), password); err != nil {
+			return ErrInvalidCurrentPassword
+		}
+	}
+
+	if err := s.confirmSecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := repos.MFARecoveryCodes.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		return repos.UserMFA.DeleteByUserID(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Two-factor authentication disabled", logger.String("user_id", userID.String()))
+
+	s.audit.Record(ctx, domain.AuditMFADisabled, &userID, &userID, nil)
+
+	return nil
+}
+
+// CompleteMFAChallenge проверяет код второго фактора для challenge токена, выданного после
+// пароля, и погашает challenge: повторно обменять его на токены с новым кодом нельзя.
+// Только здесь вход пользователя с 2FA считается успешным.
+func (s *AuthService) CompleteMFAChallenge(ctx context.Context, challenge *MFAChallengeClaims, code string) error {
+	if err := s.tokenRevocation.CheckMFAChallenge(ctx, challenge); err != nil {
+		return err
+	}
+
+	if err := s.VerifySecondFactor(ctx, challenge.UserID, code); err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.ConsumeMFAChallenge(ctx, challenge); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.loginThrottle.RegisterLoginSuccess(ctx, user.Email())
+	s.RecordLastLogin(ctx, user.ID())
+
+	return nil
+}
+
+// VerifySecondFactor проверяет TOTP код или одноразовый код восстановления при входе.
+// Неверные коды учитываются так же, как неудачные попытки входа, и попадают в аудит входов.
+func (s *AuthService) VerifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.loginThrottle.CheckMFA(ctx, userID); err != nil {
+		s.audit.RecordLoginFailed(ctx, &userID, "mfa", "locked", "")
+		return err
+	}
+
+	err := s.verifySecondFactor(ctx, userID, code)
+	switch {
+	case errors.Is(err, ErrInvalidMFACode):
+		s.audit.RecordLoginFailed(ctx, &userID, "mfa", "invalid_code", "")
+	case err == nil:
+		s.audit.RecordLoginSucceeded(ctx, userID, "mfa")
+	}
+
+	return s.registerSecondFactorResult(ctx, userID, err)
+}
+
+// confirmSecondFactor проверяет код второго фактора для подтверждения действия с аккаунтом.
+// Попытки ограничиваются так же, как при входе, но в аудит входов не записываются.
+func (s *AuthService) confirmSecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.loginThrottle.CheckMFA(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.registerSecondFactorResult(ctx, userID, s.verifySecondFactor(ctx, userID, code))
+}
+
+// registerSecondFactorResult учитывает результат проверки кода в ограничении попыток
+func (s *AuthService) registerSecondFactorResult(ctx context.Context, userID uuid.UUID, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidMFACode):
+		if lockErr := s.loginThrottle.RegisterMFAFailure(ctx, userID); lockErr != nil {
+			return lockErr
+		}
+	case err == nil:
+		s.loginThrottle.RegisterMFASuccess(ctx, userID)
+	}
+
+	return err
+}
+
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.userMFARepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserMFANotFound) {
+			return ErrMFANotEnabled
+		}
+		return err
+	}
+
+	if !mfa.IsEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	if helpers.IsTOTPCode(code) {
+		return s.verifyTOTPCode(ctx, mfa, code)
+	}
+
+	return s.useRecoveryCode(ctx, userID, code)
+}
+
+func (s *AuthService) verifyTOTPCode(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	step, ok := helpers.ValidateTOTPCode(mfa.Secret(), code, time.Now())
+	if !ok || !mfa.CanUseStep(step) {
+		s.logger.WithContext(ctx).Warn("Invalid TOTP code", logger.String("user_id", mfa.UserID().String()))
+		return ErrInvalidMFACode
+	}
+
+	if err := s.userMFARepo.UseStep(ctx, mfa.UserID(), step); err != nil {
+		if errors.Is(err, repository.ErrTOTPStepAlreadyUsed) {
+			s.logger.WithContext(ctx).Warn("TOTP code replay detected", logger.String("user_id", mfa.UserID().String()))
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuthService) useRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error {
+	codeHash := helpers.HashToken(helpers.NormalizeRecoveryCode(code))
+	if err := s.mfaRecoveryCodeRepo.Use(ctx, userID, codeHash); err != nil {
+		if errors.Is(err, repository.ErrMFARecoveryCodeNotFound) {
+			s.logger.WithContext(ctx).Warn("Invalid MFA recovery code", logger.String("user_id", userID.String()))
+			return ErrInvalidMFACode
+		}
+		return err
+	}
+
+	remaining, err := s.mfaRecoveryCodeRepo.CountUnused(ctx, userID)
+	if err != nil {
+		remaining = -1
+	}
+
+	s.logger.WithContext(ctx).Info("MFA recovery code used",
+		logger.String("user_id", userID.String()),
+		logger.Int("remaining", remaining),
+	)
+
+	return nil
+}
+
+func (s *AuthService) generateRecoveryCodes(ctx context.Context, repo repository.MFARecoveryCodeRepository, userID uuid.UUID) ([]string, error) {
+	plain := make([]string, recoveryCodesCount)
+	codes := make([]*domain.MFARecoveryCode, recoveryCodesCount)
+
+	for i := range plain {
+		plain[i] = helpers.GenerateRecoveryCode()
+		codes[i] = domain.NewMFARecoveryCode(userID, helpers.HashToken(helpers.NormalizeRecoveryCode(plain[i])))
+	}
+
+	if err := repo.ReplaceForUser(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,1100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/mailer"
+	"social-network/auth-service/internal/infrastructure/memory"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/password"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// refreshTokenStore - хранилище refresh токенов в памяти. Повторяет контракт Rotate:
+// уже отозванный или ротированный токен второй раз не ротируется.
+type refreshTokenStore struct {
+	repository.RefreshTokenRepository
+
+	mu       sync.Mutex
+	tokens   map[string]*domain.RefreshToken
+	replaced map[uuid.UUID]bool
+}
+
+func newRefreshTokenStore() *refreshTokenStore {
+	return &refreshTokenStore{
+		tokens:   map[string]*domain.RefreshToken{},
+		replaced: map[uuid.UUID]bool{},
+	}
+}
+
+func (s *refreshTokenStore) Create(ctx context.Context, token *domain.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.Token()] = token
+	return nil
+}
+
+func (s *refreshTokenStore) GetByToken(ctx context.Context, token string) (*domain.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rt, ok := s.tokens[token]; ok {
+		return rt, nil
+	}
+	return nil, repository.ErrRefreshTokenNotFound
+}
+
+func (s *refreshTokenStore) Rotate(ctx context.Context, current, next *domain.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.replaced[current.ID()] {
+		return repository.ErrRefreshTokenRevoked
+	}
+	s.replaced[current.ID()] = true
+	s.tokens[next.Token()] = next
+	return nil
+}
+
+func (s *refreshTokenStore) RevokeFamily(ctx context.Context, token *domain.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rt := range s.tokens {
+		if rt.FamilyID() == token.FamilyID() {
+			rt.SetRevoked(true)
+		}
+	}
+	return nil
+}
+
+func (s *refreshTokenStore) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var active []*domain.RefreshToken
+	for _, rt := range s.tokens {
+		if rt.UserID() == userID && rt.IsValid() {
+			active = append(active, rt)
+		}
+	}
+	return active, nil
+}
+
+func (s *refreshTokenStore) RevokeAllExceptFamily(ctx context.Context, userID, familyID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var revoked int64
+	for _, rt := range s.tokens {
+		if rt.UserID() == userID && rt.FamilyID() != familyID && !rt.IsRevoked() {
+			rt.SetRevoked(true)
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (s *refreshTokenStore) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.RevokeAllExceptFamily(ctx, userID, uuid.Nil)
+}
+
+func newTestAuthService(refreshTokens repository.RefreshTokenRepository) (*AuthService, *auditRecorder) {
+	audit, recorder := newTestAuditService()
+	return &AuthService{
+		refreshTokenRepo: refreshTokens,
+		tokenRevocation:  NewTokenRevocationService(memory.NewTokenRevocationRepository(), nil, newTestLogger()),
+		audit:            audit,
+		logger:           newTestLogger(),
+	}, recorder
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare выпускает токен и возвращает строку, которую предъявит клиент
+		prepare   func(t *testing.T, s *AuthService, store *refreshTokenStore) string
+		clientID  string
+		wantErr   error
+		wantAudit []domain.AuditEventType
+	}{
+		{
+			name: "valid token rotates",
+			prepare: func(t *testing.T, s *AuthService, store *refreshTokenStore) string {
+				return issueTestRefreshToken(t, store).Token()
+			},
+		},
+		{
+			name: "token of another client",
+			prepare: func(t *testing.T, s *AuthService, store *refreshTokenStore) string {
+				return issueTestRefreshToken(t, store).Token()
+			},
+			clientID: "third-party",
+			wantErr:  repository.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "expired token",
+			prepare: func(t *testing.T, s *AuthService, store *refreshTokenStore) string {
+				token := issueTestRefreshToken(t, store)
+				token.SetExpiresAt(time.Now().Add(-time.Minute))
+				return token.Token()
+			},
+			wantErr: repository.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "already rotated token is a reuse",
+			prepare: func(t *testing.T, s *AuthService, store *refreshTokenStore) string {
+				token := issueTestRefreshToken(t, store)
+				if _, err := s.RotateRefreshToken(context.Background(), token.Token(), "", domain.ClientInfo{}); err != nil {
+					t.Fatalf("first rotation: %v", err)
+				}
+				return token.Token()
+			},
+			wantErr:   ErrRefreshTokenReused,
+			wantAudit: []domain.AuditEventType{domain.AuditRefreshTokenReused},
+		},
+		{
+			name: "token rotated by a concurrent request is a reuse",
+			prepare: func(t *testing.T, s *AuthService, store *refreshTokenStore) string {
+				token := issueTestRefreshToken(t, store)
+				// Параллельный запрос уже обменял токен, но текущий запрос прочитал его до этого
+				store.replaced[token.ID()] = true
+				return token.Token()
+			},
+			wantErr:   ErrRefreshTokenReused,
+			wantAudit: []domain.AuditEventType{domain.AuditRefreshTokenReused},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRefreshTokenStore()
+			s, recorder := newTestAuthService(store)
+			presented := tt.prepare(t, s, store)
+
+			next, err := s.RotateRefreshToken(context.Background(), presented, tt.clientID, domain.ClientInfo{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !slices.Equal(recorder.types(), tt.wantAudit) {
+				t.Fatalf("expected audit events %v, got %v", tt.wantAudit, recorder.types())
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			current, _ := store.GetByToken(context.Background(), presented)
+			if next.FamilyID() != current.FamilyID() || next.Token() == presented {
+				t.Fatalf("rotated token must continue the family with a new value")
+			}
+			if !current.IsRotated() || current.IsValid() {
+				t.Fatalf("presented token must be marked as replaced")
+			}
+		})
+	}
+}
+
+func TestRotateRefreshToken_ReuseRevokesWholeFamily(t *testing.T) {
+	ctx := context.Background()
+	store := newRefreshTokenStore()
+	s, _ := newTestAuthService(store)
+
+	stolen := issueTestRefreshToken(t, store)
+	next, err := s.RotateRefreshToken(ctx, stolen.Token(), "", domain.ClientInfo{})
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+
+	if _, err := s.RotateRefreshToken(ctx, stolen.Token(), "", domain.ClientInfo{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	// Законный владелец тоже теряет сессию: неизвестно, у кого из двоих настоящий токен
+	if _, err := s.RotateRefreshToken(ctx, next.Token(), "", domain.ClientInfo{}); !errors.Is(err, repository.ErrRefreshTokenInvalid) {
+		t.Fatalf("expected successor to be revoked, got %v", err)
+	}
+}
+
+func TestRotateRefreshToken_KeepsSession(t *testing.T) {
+	store := newRefreshTokenStore()
+	s, _ := newTestAuthService(store)
+
+	token := issueTestRefreshToken(t, store)
+	token.SetClientInfo(domain.ClientInfo{DeviceName: "Work laptop", UserAgent: "old-agent", IPAddress: "192.0.2.1"})
+
+	next, err := s.RotateRefreshToken(context.Background(), token.Token(), "", domain.ClientInfo{UserAgent: "new-agent", IPAddress: "192.0.2.2"})
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+
+	if next.SessionID() != token.SessionID() {
+		t.Fatalf("rotation must stay in session %v, got %v", token.SessionID(), next.SessionID())
+	}
+	// Имя устройства задается при входе, а адрес и User-Agent обновляются при каждом обмене
+	if next.DeviceName() != "Work laptop" || next.UserAgent() != "new-agent" || next.IPAddress() != "192.0.2.2" {
+		t.Fatalf("unexpected client info: %q, %q, %q", next.DeviceName(), next.UserAgent(), next.IPAddress())
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	store := newRefreshTokenStore()
+	s, _ := newTestAuthService(store)
+
+	session := issueTestRefreshToken(t, store)
+	foreign := issueTestRefreshToken(t, store)
+
+	tests := []struct {
+		name      string
+		sessionID uuid.UUID
+		wantErr   error
+	}{
+		{"unknown session", uuid.New(), ErrSessionNotFound},
+		{"session of another user", foreign.SessionID(), ErrSessionNotFound},
+		{"own session", session.SessionID(), nil},
+		{"already revoked session", session.SessionID(), ErrSessionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.RevokeSession(context.Background(), session.UserID(), tt.sessionID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if !session.IsRevoked() {
+		t.Fatalf("session token must be revoked")
+	}
+	if foreign.IsRevoked() {
+		t.Fatalf("session of another user must stay active")
+	}
+}
+
+func TestRevokeOtherSessions_KeepsCurrentSession(t *testing.T) {
+	ctx := context.Background()
+	store := newRefreshTokenStore()
+	s, _ := newTestAuthService(store)
+
+	current := issueTestRefreshToken(t, store)
+	other := domain.NewRefreshToken(current.UserID(), uuid.NewString(), time.Now().Add(time.Hour))
+	if err := store.Create(ctx, other); err != nil {
+		t.Fatalf("create refresh token: %v", err)
+	}
+	foreign := issueTestRefreshToken(t, store)
+
+	revoked, err := s.RevokeOtherSessions(ctx, current.UserID(), current.SessionID())
+	if err != nil {
+		t.Fatalf("revoke other sessions: %v", err)
+	}
+
+	if revoked != 1 {
+		t.Fatalf("revoked = %d, want 1", revoked)
+	}
+	if current.IsRevoked() || !other.IsRevoked() || foreign.IsRevoked() {
+		t.Fatalf("only the other session of the same user must be revoked")
+	}
+
+	sessions, err := s.ListSessions(ctx, current.UserID())
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].SessionID() != current.SessionID() {
+		t.Fatalf("expected only the current session to remain, got %d sessions", len(sessions))
+	}
+}
+
+// issueTestRefreshToken сохраняет новый refresh token, открывающий семейство
+func issueTestRefreshToken(t *testing.T, store *refreshTokenStore) *domain.RefreshToken {
+	t.Helper()
+
+	token := domain.NewRefreshToken(uuid.New(), uuid.NewString(), time.Now().Add(time.Hour))
+	if err := store.Create(context.Background(), token); err != nil {
+		t.Fatalf("create refresh token: %v", err)
+	}
+	return token
+}
+
+var errTestStorage = errors.New("storage failure")
+
+// txLog фиксирует операции, выполненные внутри транзакции. Операция failOn
+// завершается ошибкой, что приводит к откату всей транзакции.
+type txLog struct {
+	failOn string
+	ops    []string
+}
+
+func (l *txLog) do(op string) error {
+	if op == l.failOn {
+		return errTestStorage
+	}
+	l.ops = append(l.ops, op)
+	return nil
+}
+
+// stagingTxManager применяет операции транзакции только при успешном завершении fn
+type stagingTxManager struct {
+	failOn    string
+	committed []string
+}
+
+func (m *stagingTxManager) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	tx := &txLog{failOn: m.failOn}
+	if err := fn(repository.Repositories{
+		Users:              txUsers{tx: tx},
+		EmailVerifications: txEmailVerifications{tx: tx},
+	}); err != nil {
+		return err
+	}
+	m.committed = append(m.committed, tx.ops...)
+	return nil
+}
+
+type txUsers struct {
+	repository.UserRepository
+	tx *txLog
+}
+
+func (r txUsers) Update(ctx context.Context, user *domain.User) error {
+	return r.tx.do("users.update")
+}
+
+type txEmailVerifications struct {
+	repository.EmailVerificationRepository
+	tx *txLog
+}
+
+func (r txEmailVerifications) Update(ctx context.Context, verification *domain.EmailVerification) error {
+	return r.tx.do("email_verifications.update")
+}
+
+type userStore struct {
+	repository.UserRepository
+	users map[uuid.UUID]*domain.User
+}
+
+func (s *userStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *userStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	for _, user := range s.users {
+		if user.Email() == email {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (s *userStore) Update(ctx context.Context, user *domain.User) error {
+	return nil
+}
+
+// List выбирает пользователей так же, как postgres: от новых к старым, после курсора
+func (s *userStore) List(ctx context.Context, filter repository.UserFilter) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range s.users {
+		if filter.IsActive != nil && user.IsActive() != *filter.IsActive {
+			continue
+		}
+		if filter.UsernamePrefix != "" && !strings.HasPrefix(strings.ToLower(user.Username()), strings.ToLower(filter.UsernamePrefix)) {
+			continue
+		}
+		if after := filter.After; after != nil {
+			if c := user.CreatedAt().Compare(after.CreatedAt); c > 0 || c == 0 && user.ID().String() >= after.ID.String() {
+				continue
+			}
+		}
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b *domain.User) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID().String(), a.ID().String())
+	})
+
+	if filter.Limit > 0 && len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+	return users, nil
+}
+
+func (s *userStore) ListExpiredSuspensions(ctx context.Context, limit int) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range s.users {
+		suspension := user.Suspension()
+		if !user.IsActive() && suspension != nil && suspension.Until != nil && suspension.Until.Before(time.Now()) && len(users) < limit {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// userAuthStore - данные аутентификации в памяти
+type userAuthStore struct {
+	repository.UserAuthRepository
+
+	mu        sync.Mutex
+	userAuths map[uuid.UUID]*domain.UserAuth
+}
+
+func (s *userAuthStore) Create(ctx context.Context, userAuth *domain.UserAuth) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userAuths[userAuth.UserID()] = userAuth
+	return nil
+}
+
+func (s *userAuthStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserAuth, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if userAuth, ok := s.userAuths[userID]; ok {
+		return userAuth, nil
+	}
+	return nil, repository.ErrUserAuthNotFound
+}
+
+func (s *userAuthStore) Update(ctx context.Context, userAuth *domain.UserAuth) error {
+	return s.Create(ctx, userAuth)
+}
+
+func (s *userAuthStore) UpdateLastLogin(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userAuth, ok := s.userAuths[userID]
+	if !ok {
+		return repository.ErrUserAuthNotFound
+	}
+	userAuth.SetLastLoginAt(&at)
+	return nil
+}
+
+func (s *userAuthStore) ReplacePasswordHash(ctx context.Context, userID uuid.UUID, currentHash, newHash string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userAuth, ok := s.userAuths[userID]
+	if !ok || userAuth.PasswordHash() != currentHash {
+		return false, nil
+	}
+	userAuth.SetPasswordHash(newHash)
+	return true, nil
+}
+
+// userMFAStore - настройки 2FA в памяти
+type userMFAStore struct {
+	repository.UserMFARepository
+	mfa map[uuid.UUID]*domain.UserMFA
+}
+
+func (s *userMFAStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserMFA, error) {
+	if mfa, ok := s.mfa[userID]; ok {
+		return mfa, nil
+	}
+	return nil, repository.ErrUserMFANotFound
+}
+
+func (s *userMFAStore) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	mfa, ok := s.mfa[userID]
+	if !ok || !mfa.CanUseStep(step) {
+		return repository.ErrTOTPStepAlreadyUsed
+	}
+	mfa.Enable(step)
+	return nil
+}
+
+// racingResetStore отдает снимок данных аутентификации и сразу после чтения меняет хеш,
+// как будто параллельно завершился сброс пароля
+type racingResetStore struct {
+	*userAuthStore
+	resetHash string
+}
+
+func (s *racingResetStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserAuth, error) {
+	stored, err := s.userAuthStore.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := domain.NewUserAuth(userID, stored.PasswordHash())
+	stored.SetPasswordHash(s.resetHash)
+	return snapshot, nil
+}
+
+// userRoleStore - роли пользователей в памяти
+type userRoleStore struct {
+	repository.UserRoleRepository
+	roles map[uuid.UUID][]*domain.UserRole
+}
+
+func (s *userRoleStore) Create(ctx context.Context, userRole *domain.UserRole) error {
+	if s.roles == nil {
+		s.roles = map[uuid.UUID][]*domain.UserRole{}
+	}
+	s.roles[userRole.UserID()] = append(s.roles[userRole.UserID()], userRole)
+	return nil
+}
+
+func (s *userRoleStore) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserRole, error) {
+	return s.roles[userID], nil
+}
+
+func (s *userRoleStore) Update(ctx context.Context, userRole *domain.UserRole) error {
+	return nil
+}
+
+func (s *userRoleStore) ListExpired(ctx context.Context, limit int) ([]*domain.UserRole, error) {
+	var expired []*domain.UserRole
+	for _, userRoles := range s.roles {
+		for _, userRole := range userRoles {
+			if userRole.IsActive() && userRole.IsExpired() && len(expired) < limit {
+				expired = append(expired, userRole)
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (s *userRoleStore) CountActiveByRole(ctx context.Context, role domain.UserRoleType) (int, error) {
+	count := 0
+	for _, userRoles := range s.roles {
+		for _, userRole := range userRoles {
+			if userRole.Role() == role && userRole.IsActive() {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+type emailVerificationStore struct {
+	repository.EmailVerificationRepository
+	verifications map[string]*domain.EmailVerification
+}
+
+func (s *emailVerificationStore) GetByToken(ctx context.Context, token string) (*domain.EmailVerification, error) {
+	verification, ok := s.verifications[token]
+	if !ok {
+		return nil, repository.ErrEmailVerificationNotFound
+	}
+	return verification, nil
+}
+
+func TestVerifyEmail_Transaction(t *testing.T) {
+	tests := []struct {
+		name          string
+		failOn        string
+		wantErr       error
+		wantCommitted []string
+	}{
+		{
+			name:          "user and token are updated together",
+			wantCommitted: []string{"users.update", "email_verifications.update"},
+		},
+		{
+			name:    "failed token update rolls back the user",
+			failOn:  "email_verifications.update",
+			wantErr: errTestStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newTestUser()
+			verification := domain.NewEmailVerification(user.ID(), "verify-token", time.Now().Add(time.Hour))
+			tx := &stagingTxManager{failOn: tt.failOn}
+			audit, recorder := newTestAuditService()
+
+			s := &AuthService{
+				userRepo:              &userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}},
+				emailVerificationRepo: &emailVerificationStore{verifications: map[string]*domain.EmailVerification{"verify-token": verification}},
+				txManager:             tx,
+				audit:                 audit,
+				logger:                newTestLogger(),
+			}
+
+			err := s.VerifyEmail(context.Background(), "verify-token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyEmail() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(tx.committed) != len(tt.wantCommitted) {
+				t.Fatalf("committed = %v, want %v", tx.committed, tt.wantCommitted)
+			}
+			for i := range tt.wantCommitted {
+				if tx.committed[i] != tt.wantCommitted[i] {
+					t.Fatalf("committed = %v, want %v", tx.committed, tt.wantCommitted)
+				}
+			}
+			if verified := slices.Contains(recorder.types(), domain.AuditEmailVerified); verified != (err == nil) {
+				t.Fatalf("email.verified audit recorded = %v, want %v", verified, err == nil)
+			}
+		})
+	}
+}
+
+// magicLinkStore - ссылки для входа в памяти. MarkUsed, как и в postgres, гасит
+// ссылку только один раз.
+type magicLinkStore struct {
+	repository.MagicLinkRepository
+
+	mu    sync.Mutex
+	links map[string]*domain.MagicLink
+}
+
+func (s *magicLinkStore) Create(ctx context.Context, link *domain.MagicLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links[link.Token()] = link
+	return nil
+}
+
+func (s *magicLinkStore) GetByToken(ctx context.Context, token string) (*domain.MagicLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if link, ok := s.links[token]; ok {
+		return link, nil
+	}
+	return nil, repository.ErrMagicLinkNotFound
+}
+
+func (s *magicLinkStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.MagicLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var last *domain.MagicLink
+	for _, link := range s.links {
+		if link.UserID() == userID && (last == nil || link.CreatedAt().After(last.CreatedAt())) {
+			last = link
+		}
+	}
+	if last == nil {
+		return nil, repository.ErrMagicLinkNotFound
+	}
+	return last, nil
+}
+
+func (s *magicLinkStore) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, link := range s.links {
+		if link.ID() == id {
+			if !link.IsValid() {
+				return repository.ErrMagicLinkInvalid
+			}
+			link.SetUsed(true)
+			return nil
+		}
+	}
+	return repository.ErrMagicLinkInvalid
+}
+
+type magicLinkTest struct {
+	service   *AuthService
+	links     *magicLinkStore
+	userAuths *userAuthStore
+	mfa       *userMFAStore
+	mailer    *recordingMailer
+	audit     *auditRecorder
+	user      *domain.User
+}
+
+func newMagicLinkTest(t *testing.T) *magicLinkTest {
+	t.Helper()
+
+	templates, err := mailer.NewTemplates("en")
+	if err != nil {
+		t.Fatalf("load templates: %v", err)
+	}
+
+	user := newTestUser()
+	userAuths := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{user.ID(): domain.NewUserAuth(user.ID(), "")}}
+	mfa := &userMFAStore{mfa: map[uuid.UUID]*domain.UserMFA{}}
+	links := &magicLinkStore{links: map[string]*domain.MagicLink{}}
+	m := &recordingMailer{}
+	audit, recorder := newTestAuditService()
+
+	return &magicLinkTest{
+		service: &AuthService{
+			userRepo:      &userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}},
+			userAuthRepo:  userAuths,
+			userMFARepo:   mfa,
+			magicLinkRepo: links,
+			emailService:  NewEmailService(m, templates, "https://app.test", time.Minute, newTestLogger()),
+			audit:         audit,
+			logger:        newTestLogger(),
+		},
+		links:     links,
+		userAuths: userAuths,
+		mfa:       mfa,
+		mailer:    m,
+		audit:     recorder,
+		user:      user,
+	}
+}
+
+func (mt *magicLinkTest) issueLink(t *testing.T, expiresAt time.Time) string {
+	t.Helper()
+
+	link := domain.NewMagicLink(mt.user.ID(), uuid.NewString(), expiresAt)
+	if err := mt.links.Create(context.Background(), link); err != nil {
+		t.Fatalf("create magic link: %v", err)
+	}
+	return link.Token()
+}
+
+func TestRequestMagicLink(t *testing.T) {
+	ctx := context.Background()
+	mt := newMagicLinkTest(t)
+
+	if err := mt.service.RequestMagicLink(ctx, "unknown@example.com"); err != nil {
+		t.Fatalf("unknown email must not be an error: %v", err)
+	}
+	if len(mt.mailer.sent) != 0 {
+		t.Fatalf("no email must be sent for unknown address")
+	}
+
+	if err := mt.service.RequestMagicLink(ctx, mt.user.Email()); err != nil {
+		t.Fatalf("request magic link: %v", err)
+	}
+	// Повторный запрос в пределах cooldown молча пропускается
+	if err := mt.service.RequestMagicLink(ctx, mt.user.Email()); err != nil {
+		t.Fatalf("repeated request must not be an error: %v", err)
+	}
+
+	if len(mt.mailer.sent) != 1 || len(mt.links.links) != 1 {
+		t.Fatalf("expected one link and one email, got %d links and %d emails", len(mt.links.links), len(mt.mailer.sent))
+	}
+	for token := range mt.links.links {
+		if !strings.Contains(mt.mailer.sent[0].TextBody, "https://app.test/magic-link?token="+token) {
+			t.Fatalf("email must contain the magic link:\n%s", mt.mailer.sent[0].TextBody)
+		}
+	}
+}
+
+func TestLoginWithMagicLink(t *testing.T) {
+	ctx := context.Background()
+	mt := newMagicLinkTest(t)
+
+	token := mt.issueLink(t, time.Now().Add(time.Minute))
+	expired := mt.issueLink(t, time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"valid link", token, nil},
+		{"same link twice", token, repository.ErrMagicLinkInvalid},
+		{"expired link", expired, repository.ErrMagicLinkInvalid},
+		{"unknown link", "unknown", repository.ErrMagicLinkInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := mt.service.LoginWithMagicLink(ctx, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoginWithMagicLink() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && user.ID() != mt.user.ID() {
+				t.Fatalf("logged in as %v, want %v", user.ID(), mt.user.ID())
+			}
+		})
+	}
+
+	want := []domain.AuditEventType{domain.AuditLoginSucceeded, domain.AuditLoginFailed, domain.AuditLoginFailed, domain.AuditLoginFailed}
+	if got := mt.audit.types(); !slices.Equal(got, want) {
+		t.Fatalf("audit events = %v, want %v", got, want)
+	}
+}
+
+func TestLoginWithMagicLink_InactiveUserConsumesLink(t *testing.T) {
+	ctx := context.Background()
+	mt := newMagicLinkTest(t)
+	mt.user.SetActive(false)
+
+	token := mt.issueLink(t, time.Now().Add(time.Minute))
+
+	if _, err := mt.service.LoginWithMagicLink(ctx, token); !errors.Is(err, ErrUserInactive) {
+		t.Fatalf("expected ErrUserInactive, got %v", err)
+	}
+	if link, _ := mt.links.GetByToken(ctx, token); !link.IsUsed() {
+		t.Fatalf("link must be used even if login is refused")
+	}
+}
+
+func TestLoginWithMagicLink_MFA(t *testing.T) {
+	ctx := context.Background()
+	mt := newMagicLinkTest(t)
+
+	mfa := domain.NewUserMFA(mt.user.ID(), helpers.GenerateTOTPSecret())
+	mfa.Enable(0)
+	mt.mfa.mfa[mt.user.ID()] = mfa
+
+	token := mt.issueLink(t, time.Now().Add(time.Minute))
+	if _, err := mt.service.LoginWithMagicLink(ctx, token); err != nil {
+		t.Fatalf("LoginWithMagicLink() error = %v", err)
+	}
+
+	// Ссылка заменяет только пароль: вход завершится после проверки второго фактора
+	want := []domain.AuditEventType{domain.AuditLoginMFARequired}
+	if got := mt.audit.types(); !slices.Equal(got, want) {
+		t.Fatalf("audit events = %v, want %v", got, want)
+	}
+	if mt.userAuths.userAuths[mt.user.ID()].LastLoginAt() != nil {
+		t.Fatalf("last login must not be recorded before the second factor")
+	}
+}
+
+// newTestLoginService собирает AuthService для входа по паролю пользователя без 2FA
+func newTestLoginService(user *domain.User, userAuthRepo repository.UserAuthRepository, hasher *password.Hasher) (*AuthService, *auditRecorder) {
+	s, audit := newTestAuthService(newRefreshTokenStore())
+	s.userRepo = &userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}}
+	s.userAuthRepo = userAuthRepo
+	s.userMFARepo = &userMFAStore{mfa: map[uuid.UUID]*domain.UserMFA{}}
+	s.passwordHasher = hasher
+	s.loginThrottle = newTestLoginThrottle()
+	return s, audit
+}
+
+func TestAuthenticateUser_PasswordRehash(t *testing.T) {
+	ctx := context.Background()
+	legacy := password.NewBcrypt(bcrypt.MinCost)
+	hasher := password.NewHasher(password.NewArgon2id(password.Argon2idParams{
+		Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32,
+	}), legacy)
+
+	legacyHash, err := legacy.Hash("old-password")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		resetHash string
+		wantHash  func(t *testing.T, hash string)
+	}{
+		{
+			name: "legacy hash is upgraded",
+			wantHash: func(t *testing.T, hash string) {
+				if hash == legacyHash || hasher.NeedsRehash(hash) || hasher.Verify(hash, "old-password") != nil {
+					t.Fatalf("hash must be upgraded to the current algorithm, got %q", hash)
+				}
+			},
+		},
+		{
+			name:      "concurrent reset wins",
+			resetHash: "reset-hash",
+			wantHash: func(t *testing.T, hash string) {
+				if hash != "reset-hash" {
+					t.Fatalf("rehash must not overwrite the reset password, got %q", hash)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newTestUser()
+			store := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{user.ID(): domain.NewUserAuth(user.ID(), legacyHash)}}
+			var userAuthRepo repository.UserAuthRepository = store
+			if tt.resetHash != "" {
+				userAuthRepo = &racingResetStore{userAuthStore: store, resetHash: tt.resetHash}
+			}
+
+			s, _ := newTestLoginService(user, userAuthRepo, hasher)
+
+			if _, err := s.AuthenticateUser(ctx, user.Email(), "old-password", domain.ClientInfo{IPAddress: "192.0.2.1"}); err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+
+			stored := store.userAuths[user.ID()]
+			tt.wantHash(t, stored.PasswordHash())
+			if stored.LastLoginAt() == nil {
+				t.Fatalf("last login time must be recorded")
+			}
+		})
+	}
+}
+
+func TestAuthenticateUser_MFA(t *testing.T) {
+	ctx := context.Background()
+	hasher := password.NewHasher(password.NewBcrypt(bcrypt.MinCost))
+	passwordHash, err := hasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	user := newTestUser()
+	userAuths := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{user.ID(): domain.NewUserAuth(user.ID(), passwordHash)}}
+	s, audit := newTestLoginService(user, userAuths, hasher)
+
+	jwtService := newTestJWTService(t)
+	s.tokenRevocation = NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, newTestLogger())
+
+	mfa := domain.NewUserMFA(user.ID(), helpers.GenerateTOTPSecret())
+	mfa.Enable(0)
+	s.userMFARepo = &userMFAStore{mfa: map[uuid.UUID]*domain.UserMFA{user.ID(): mfa}}
+
+	client := domain.ClientInfo{IPAddress: "192.0.2.1"}
+	login := func(password string) error {
+		_, err := s.AuthenticateUser(ctx, user.Email(), password, client)
+		return err
+	}
+
+	// Две неудачи из трех допустимых, затем верный пароль
+	for i := 0; i < 2; i++ {
+		if err := login("wrong-password"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected ErrInvalidCredentials, got %v", i+1, err)
+		}
+	}
+	if err := login("correct-password"); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	if got := audit.types(); slices.Contains(got, domain.AuditLoginSucceeded) || !slices.Contains(got, domain.AuditLoginMFARequired) {
+		t.Fatalf("password without second factor must be audited as %s only, got %v", domain.AuditLoginMFARequired, got)
+	}
+	if userAuths.userAuths[user.ID()].LastLoginAt() != nil {
+		t.Fatalf("last login must not be recorded before the second factor")
+	}
+	// Верный пароль без кода не сбрасывает счетчик: третья неудача блокирует аккаунт
+	if err := s.loginThrottle.CheckLogin(ctx, user.Email(), ""); err != nil {
+		t.Fatalf("account must not be locked yet, got %v", err)
+	}
+	var locked *AccountLockedError
+	if err := s.loginThrottle.RegisterLoginFailure(ctx, user.Email(), ""); !errors.As(err, &locked) {
+		t.Fatalf("failure counter must survive a password-only login, got %v", err)
+	}
+
+	// Вход завершается проверкой кода
+	token, err := jwtService.GenerateMFAChallengeToken(user.ID())
+	if err != nil {
+		t.Fatalf("generate challenge: %v", err)
+	}
+	challenge, err := jwtService.ValidateMFAChallengeToken(token)
+	if err != nil {
+		t.Fatalf("validate challenge: %v", err)
+	}
+	code, err := helpers.GenerateTOTPCode(mfa.Secret(), helpers.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	if err := s.CompleteMFAChallenge(ctx, challenge, code); err != nil {
+		t.Fatalf("complete challenge: %v", err)
+	}
+
+	if !slices.Contains(audit.types(), domain.AuditLoginSucceeded) {
+		t.Fatalf("login must be audited as succeeded after the second factor, got %v", audit.types())
+	}
+	if userAuths.userAuths[user.ID()].LastLoginAt() == nil {
+		t.Fatalf("last login must be recorded after the second factor")
+	}
+	// Успешный вход снял блокировку и сбросил счетчик: две неудачи подряд аккаунт не блокируют
+	for i := 0; i < 2; i++ {
+		if err := s.loginThrottle.RegisterLoginFailure(ctx, user.Email(), ""); err != nil {
+			t.Fatalf("failure %d after completed login: %v", i+1, err)
+		}
+	}
+}
+
+func TestAuthenticateUser_EmailVariantsShareLockout(t *testing.T) {
+	ctx := context.Background()
+	hasher := password.NewHasher(password.NewBcrypt(bcrypt.MinCost))
+	passwordHash, err := hasher.Hash("correct-password")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	user := newTestUser()
+	userAuths := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{user.ID(): domain.NewUserAuth(user.ID(), passwordHash)}}
+	s, audit := newTestLoginService(user, userAuths, hasher)
+
+	// Три неудачи под разными вариантами написания одного email блокируют аккаунт
+	for _, email := range []string{"Alice@Example.com", " alice@example.com", "ALICE@EXAMPLE.COM "} {
+		if _, err := s.AuthenticateUser(ctx, email, "wrong-password", domain.ClientInfo{}); err == nil {
+			t.Fatalf("login as %q with wrong password must fail", email)
+		}
+	}
+
+	var locked *AccountLockedError
+	if _, err := s.AuthenticateUser(ctx, user.Email(), "correct-password", domain.ClientInfo{}); !errors.As(err, &locked) {
+		t.Fatalf("expected AccountLockedError, got %v", err)
+	}
+
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+	for _, event := range audit.events {
+		if email := event.Details()["email"]; email != user.Email() {
+			t.Fatalf("audit must store the normalized email, got %q", email)
+		}
+	}
+}
+
+// failingRevocationStore не может сохранить отзыв access токена по jti
+type failingRevocationStore struct {
+	repository.TokenRevocationRepository
+}
+
+func (s *failingRevocationStore) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	return errTestStorage
+}
+
+func TestLogout(t *testing.T) {
+	ctx := context.Background()
+	jwtService := newTestJWTService(t)
+	user := newTestUser()
+
+	tests := []struct {
+		name               string
+		failAccess         bool
+		unknownToken       bool
+		wantErr            error
+		wantRefreshRevoked bool
+		wantAccessRevoked  bool
+	}{
+		{name: "both revoked", wantRefreshRevoked: true, wantAccessRevoked: true},
+		{name: "access revocation fails", failAccess: true, wantErr: errTestStorage, wantRefreshRevoked: true},
+		{name: "unknown refresh token", unknownToken: true, wantErr: repository.ErrRefreshTokenNotFound, wantAccessRevoked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRefreshTokenStore()
+			s, _ := newTestAuthService(store)
+			repo := memory.NewTokenRevocationRepository()
+			if tt.failAccess {
+				repo = &failingRevocationStore{TokenRevocationRepository: repo}
+			}
+			s.tokenRevocation = NewTokenRevocationService(repo, jwtService, newTestLogger())
+
+			refresh := issueTestRefreshToken(t, store)
+			presented := refresh.Token()
+			if tt.unknownToken {
+				presented = "unknown-token"
+			}
+
+			claims := jwtService.NewAccessTokenClaims(user, testUserAccess(), refresh.SessionID(), DefaultAccessTokenScope, "")
+			access, err := jwtService.SignAccessToken(claims)
+			if err != nil {
+				t.Fatalf("sign access token: %v", err)
+			}
+
+			if err := s.Logout(ctx, claims, presented); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Logout() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if refresh.IsRevoked() != tt.wantRefreshRevoked {
+				t.Fatalf("refresh token revoked = %v, want %v", refresh.IsRevoked(), tt.wantRefreshRevoked)
+			}
+			_, err = s.tokenRevocation.ValidateAccessToken(ctx, access)
+			if revoked := errors.Is(err, ErrTokenRevoked); revoked != tt.wantAccessRevoked {
+				t.Fatalf("access token revoked = %v, want %v (%v)", revoked, tt.wantAccessRevoked, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/mailer"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
+)
+
+// EmailService формирует и отправляет письма со ссылками подтверждения email, сброса пароля
+// и входа без пароля.
+// Язык письма берется из контекста запроса (Accept-Language).
+type EmailService struct {
+	mailer         mailer.Mailer
+	templates      *mailer.Templates
+	appBaseURL     string
+	resendCooldown time.Duration
+	logger         logger.Logger
+}
+
+// emailLinkData - данные, доступные в шаблонах писем
+type emailLinkData struct {
+	DisplayName string
+	Link        string
+	Hours       int
+	Minutes     int
+}
+
+func NewEmailService(
+	m mailer.Mailer,
+	templates *mailer.Templates,
+	appBaseURL string,
+	resendCooldown time.Duration,
+	logger logger.Logger,
+) *EmailService {
+	return &EmailService{
+		mailer:         m,
+		templates:      templates,
+		appBaseURL:     strings.TrimRight(appBaseURL, "/"),
+		resendCooldown: resendCooldown,
+		logger:         logger,
+	}
+}
+
+// ResendCooldown возвращает минимальный интервал между письмами подтверждения
+func (s *EmailService) ResendCooldown() time.Duration {
+	return s.resendCooldown
+}
+
+// SendVerificationEmail отправляет ссылку для подтверждения email
+func (s *EmailService) SendVerificationEmail(ctx context.Context, user *domain.User, token string) error {
+	return s.send(ctx, user, mailer.TemplateVerifyEmail, "/verify-email", token, helpers.TokenExpirationTimes.EmailVerification)
+}
+
+// SendPasswordResetEmail отправляет ссылку для сброса пароля
+func (s *EmailService) SendPasswordResetEmail(ctx context.Context, user *domain.User, token string) error {
+	return s.send(ctx, user, mailer.TemplatePasswordReset, "/reset-password", token, helpers.TokenExpirationTimes.PasswordReset)
+}
+
+// SendMagicLinkEmail отправляет одноразовую ссылку для входа без пароля
+func (s *EmailService) SendMagicLinkEmail(ctx context.Context, user *domain.User, token string) error {
+	return s.send(ctx, user, mailer.TemplateMagicLink, "/magic-link", token, helpers.TokenExpirationTimes.MagicLink)
+}
+
+func (s *EmailService) send(ctx context.Context, user *domain.User, template, path, token string, ttl time.Duration) error {
+	msg, err := s.templates.Render(requestctx.Locale(ctx), template, emailLinkData{
+		DisplayName: user.DisplayName(),
+		Link:        s.appBaseURL + path + "?token=" + url.QueryEscape(token),
+		Hours:       int(ttl.Hours()),
+		Minutes:     int(ttl.Minutes()),
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = user.Email()
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Email queued",
+		logger.String("template", template),
+		logger.String("user_id", user.ID().String()),
+	)
+	return nil
+}
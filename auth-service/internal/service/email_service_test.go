@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/infrastructure/mailer"
+	"social-network/auth-service/pkg/requestctx"
+)
+
+// recordingMailer запоминает отправленные письма
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestEmailService_SendVerificationEmail(t *testing.T) {
+	templates, err := mailer.NewTemplates("en")
+	if err != nil {
+		t.Fatalf("load templates: %v", err)
+	}
+	m := &recordingMailer{}
+	s := NewEmailService(m, templates, "https://app.test/", time.Minute, newTestLogger())
+	user := newTestUser()
+
+	ctx := requestctx.WithLocale(context.Background(), "ru")
+	if err := s.SendVerificationEmail(ctx, user, "a+b/c"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if len(m.sent) != 1 {
+		t.Fatalf("expected one email, got %d", len(m.sent))
+	}
+	msg := m.sent[0]
+	if msg.To != user.Email() {
+		t.Fatalf("recipient = %q, want %q", msg.To, user.Email())
+	}
+	if !strings.Contains(msg.TextBody, "https://app.test/verify-email?token=a%2Bb%2Fc") {
+		t.Fatalf("link must be built from the base URL with an escaped token:\n%s", msg.TextBody)
+	}
+	if !strings.HasPrefix(msg.Subject, "Подтвердите") {
+		t.Fatalf("expected russian template for ru locale, got subject %q", msg.Subject)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation - access токен, выданный администратору от имени пользователя
+type Impersonation struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	User        *domain.User
+}
+
+// ImpersonationService выдает администраторам токены для входа от имени пользователя, чтобы
+// поддержка могла воспроизвести проблему. Токен несет claim act с ID администратора, живет
+// несколько минут, не обновляется и не подходит для смены учетных данных, 2FA и ролей.
+type ImpersonationService struct {
+	authService *AuthService
+	jwtService  *JWTService
+	audit       *AuditService
+	logger      logger.Logger
+}
+
+func NewImpersonationService(authService *AuthService, jwtService *JWTService, audit *AuditService, logger logger.Logger) *ImpersonationService {
+	return &ImpersonationService{
+		authService: authService,
+		jwtService:  jwtService,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// Impersonate выдает администратору actorID токен пользователя userID. Нельзя войти от своего
+// имени, от имени заблокированного пользователя и пользователя с правами, которых нет у администратора.
+func (s *ImpersonationService) Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (*Impersonation, error) {
+	if actorID == userID {
+		return nil, ErrCannotImpersonateSelf
+	}
+
+	user, err := s.authService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, ErrUserInactive
+	}
+
+	if err := s.authService.checkActorOutranks(ctx, actorID, userID); err != nil {
+		return nil, err
+	}
+
+	access, err := s.authService.GetUserAccess(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, claims, err := s.jwtService.GenerateImpersonationToken(user, access, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Impersonation token issued",
+		logger.String("actor_id", actorID.String()),
+		logger.String("user_id", userID.String()),
+		logger.String("token_id", claims.ID),
+	)
+
+	s.audit.Record(ctx, domain.AuditImpersonationStarted, &actorID, &userID, map[string]string{
+		"reason":     reason,
+		"token_id":   claims.ID,
+		"expires_at": claims.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &Impersonation{
+		AccessToken: token,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		User:        user,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+func TestImpersonationService_Impersonate(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    func(at *userAdminTest) *domain.User
+		actor   func(at *userAdminTest) *domain.User
+		suspend bool
+		wantErr error
+	}{
+		{"admin impersonates user", func(at *userAdminTest) *domain.User { return at.target }, func(at *userAdminTest) *domain.User { return at.admin }, false, nil},
+		{"moderator impersonates admin", func(at *userAdminTest) *domain.User { return at.admin }, func(at *userAdminTest) *domain.User { return at.moderator }, false, ErrUserOutranksActor},
+		{"admin impersonates self", func(at *userAdminTest) *domain.User { return at.admin }, func(at *userAdminTest) *domain.User { return at.admin }, false, ErrCannotImpersonateSelf},
+		{"suspended user", func(at *userAdminTest) *domain.User { return at.target }, func(at *userAdminTest) *domain.User { return at.admin }, true, ErrUserInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			at := newUserAdminTest(t)
+			user, actor := tt.user(at), tt.actor(at)
+			if tt.suspend {
+				user.Suspend(actor.ID(), "spam", nil)
+			}
+
+			s := NewImpersonationService(at.service, at.service.tokenRevocation.jwtService, at.service.audit, newTestLogger())
+			impersonation, err := s.Impersonate(ctx, actor.ID(), user.ID(), "ticket 42")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Impersonate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(at.audit.types()) != 0 {
+					t.Fatalf("denied impersonation must not be audited as started")
+				}
+				return
+			}
+
+			claims, err := at.service.tokenRevocation.ValidateAccessToken(ctx, impersonation.AccessToken)
+			if err != nil {
+				t.Fatalf("validate impersonation token: %v", err)
+			}
+			if impersonatorID, ok := claims.ImpersonatorID(); !ok || impersonatorID != actor.ID() || claims.UserID != user.ID() {
+				t.Fatalf("token must belong to %v and carry actor %v, got %v and %v", user.ID(), actor.ID(), claims.UserID, impersonatorID)
+			}
+			// Права токена - права пользователя, а не администратора
+			if claims.HasPermission(domain.PermissionRolesManage) {
+				t.Fatalf("impersonation token must not carry the actor's permissions: %q", claims.Permissions)
+			}
+
+			if got := at.audit.types(); !slices.Equal(got, []domain.AuditEventType{domain.AuditImpersonationStarted}) {
+				t.Fatalf("audit events = %v", got)
+			}
+			if event := at.audit.events[0]; *event.ActorID() != actor.ID() || event.Details()["reason"] != "ticket 42" || event.Details()["token_id"] != claims.ID {
+				t.Fatalf("unexpected audit event: actor %v, details %v", event.ActorID(), event.Details())
+			}
+		})
+	}
+}
+
+func TestImpersonationService_UnknownUser(t *testing.T) {
+	at := newUserAdminTest(t)
+	s := NewImpersonationService(at.service, at.service.tokenRevocation.jwtService, at.service.audit, newTestLogger())
+
+	if _, err := s.Impersonate(context.Background(), at.admin.ID(), uuid.New(), ""); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -1,15 +1,52 @@
 package service
 
 import (
+	"slices"
 	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/keys"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// Значения заголовка typ различают назначение токенов, подписанных одними ключами,
+// чтобы challenge токен 2FA нельзя было предъявить вместо access токена
+const (
+	accessTokenType       = "JWT"
+	mfaChallengeTokenType = "mfa-challenge+jwt"
+	idTokenType           = "id-token+jwt"
+	serviceTokenType      = "service+jwt"
+)
+
+// DefaultAccessTokenScope - scope access токенов, выданных пользователю при входе
+const DefaultAccessTokenScope = "openid profile email"
+
+// accessTokenTTL - время жизни access токена
+const accessTokenTTL = 15 * time.Minute
+
+// impersonationTokenTTL - время жизни access токена, выданного администратору от имени пользователя
+const impersonationTokenTTL = 10 * time.Minute
+
+// idTokenTTL - время жизни ID токена OpenID Connect
+const idTokenTTL = time.Hour
+
+// serviceTokenTTL - время жизни токена сервиса, полученного по client credentials
+const serviceTokenTTL = 5 * time.Minute
+
+// mfaChallengeTTL - время, за которое пользователь должен ввести код второго фактора
+const mfaChallengeTTL = 5 * time.Minute
+
+// KeyProvider предоставляет асимметричные ключи для подписи и проверки access токенов
+type KeyProvider interface {
+	SigningKey() (*keys.Key, error)
+	VerificationKey(id string) (*keys.Key, error)
+	JWKS() keys.JWKSet
+}
+
 type JWTService struct {
-	accessSecret  []byte
+	keys          KeyProvider
 	refreshSecret []byte
 	issuer        string
 }
@@ -20,7 +57,90 @@ type AccessTokenClaims struct {
 	Username    string                `json:"username"`
 	DisplayName string                `json:"display_name"`
 	Roles       []domain.UserRoleType `json:"roles"`
-	IsVerified  bool                  `json:"is_verified"`
+	// Permissions - права ролей через пробел, как scope, чтобы токен оставался компактным
+	Permissions string    `json:"perm,omitempty"`
+	IsVerified  bool      `json:"is_verified"`
+	SessionID   uuid.UUID `json:"sid"`
+	Scope       string    `json:"scope,omitempty"`
+	ClientID    string    `json:"client_id,omitempty"`
+	// Actor заполнен в токене имперсонации: администратор действует от имени пользователя из sub
+	Actor *ActorClaim `json:"act,omitempty"`
+	// PersonalAccessTokenID заполняется при проверке персонального токена и не попадает в JWT
+	PersonalAccessTokenID uuid.UUID `json:"-"`
+	jwt.RegisteredClaims
+}
+
+// ActorClaim - claim act (RFC 8693): кто на самом деле действует от имени субъекта токена
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
+
+// IsImpersonated сообщает, что токен выдан администратору от имени пользователя
+func (c *AccessTokenClaims) IsImpersonated() bool {
+	return c.Actor != nil
+}
+
+// ImpersonatorID возвращает ID администратора из claim act
+func (c *AccessTokenClaims) ImpersonatorID() (uuid.UUID, bool) {
+	if c.Actor == nil {
+		return uuid.Nil, false
+	}
+	actorID, err := uuid.Parse(c.Actor.Subject)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return actorID, true
+}
+
+// IsClientToken сообщает, что токен выдан OAuth клиенту через /oauth/token. Такой токен
+// подходит только для userinfo, но не для API сервиса.
+func (c *AccessTokenClaims) IsClientToken() bool {
+	return c.ClientID != ""
+}
+
+// IsPersonalAccessToken сообщает, что claims получены из персонального токена, а не из JWT
+func (c *AccessTokenClaims) IsPersonalAccessToken() bool {
+	return c.PersonalAccessTokenID != uuid.Nil
+}
+
+func (c *AccessTokenClaims) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
+
+func (c *AccessTokenClaims) HasPermission(permission domain.Permission) bool {
+	return slices.Contains(strings.Fields(c.Permissions), string(permission))
+}
+
+// IDTokenClaims - claims ID токена OpenID Connect. Claims о пользователе зависят от scope.
+type IDTokenClaims struct {
+	Nonce     string    `json:"nonce,omitempty"`
+	SessionID uuid.UUID `json:"sid"`
+	UserInfoClaims
+	jwt.RegisteredClaims
+}
+
+// UserInfoClaims - стандартные claims OpenID Connect о пользователе, общие для ID токена и /userinfo
+type UserInfoClaims struct {
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// ServiceTokenClaims - claims токена сервиса, полученного по client credentials. sub и
+// client_id - вызывающий сервис, aud - сервис, который он может вызывать.
+type ServiceTokenClaims struct {
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *ServiceTokenClaims) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
+
+type MFAChallengeClaims struct {
+	UserID uuid.UUID `json:"user_id"`
 	jwt.RegisteredClaims
 }
 
@@ -29,35 +149,171 @@ type RefreshTokenClaims struct {
 	jwt.RegisteredClaims
 }
 
-func NewJWTService(accessSecret, refreshSecret []byte, issuer string) *JWTService {
+func NewJWTService(keyProvider KeyProvider, refreshSecret []byte, issuer string) *JWTService {
 	return &JWTService{
-		accessSecret:  accessSecret,
+		keys:          keyProvider,
 		refreshSecret: refreshSecret,
 		issuer:        issuer,
 	}
 }
 
-// GenerateAccessToken создает access token
-func (s *JWTService) GenerateAccessToken(user *domain.User, roles []domain.UserRoleType) (string, error) {
+// GenerateAccessToken создает access token, привязанный к сессии (семейству refresh токенов)
+func (s *JWTService) GenerateAccessToken(user *domain.User, access *UserAccess, sessionID uuid.UUID) (string, error) {
+	return s.SignAccessToken(s.NewAccessTokenClaims(user, access, sessionID, DefaultAccessTokenScope, ""))
+}
+
+// NewAccessTokenClaims формирует claims access токена. clientID заполняется, когда токен
+// выдан OAuth клиенту через OpenID Connect. Токен клиента содержит email и профиль только
+// при согласии на scope email и profile, как и ответ userinfo.
+func (s *JWTService) NewAccessTokenClaims(user *domain.User, access *UserAccess, sessionID uuid.UUID, scope, clientID string) *AccessTokenClaims {
 	now := time.Now()
-	claims := AccessTokenClaims{
+	claims := &AccessTokenClaims{
 		UserID:      user.ID(),
 		Email:       user.Email(),
 		Username:    user.Username(),
 		DisplayName: user.DisplayName(),
-		Roles:       roles,
+		Roles:       access.Roles,
+		Permissions: domain.JoinPermissions(access.Permissions),
 		IsVerified:  user.IsVerified(),
+		SessionID:   sessionID,
+		Scope:       scope,
+		ClientID:    clientID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    s.issuer,
 			Subject:   user.ID().String(),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)), // 15 минут
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.accessSecret)
+	if clientID != "" {
+		if !claims.HasScope(ScopeEmail) {
+			claims.Email = ""
+			claims.IsVerified = false
+		}
+		if !claims.HasScope(ScopeProfile) {
+			claims.Username = ""
+			claims.DisplayName = ""
+		}
+	}
+
+	return claims
+}
+
+// GenerateImpersonationToken создает короткоживущий access токен пользователя для администратора
+// actorID. Токен не привязан к сессии, поэтому его нельзя обновить.
+func (s *JWTService) GenerateImpersonationToken(user *domain.User, access *UserAccess, actorID uuid.UUID) (string, *AccessTokenClaims, error) {
+	claims := s.NewAccessTokenClaims(user, access, uuid.Nil, DefaultAccessTokenScope, "")
+	claims.Actor = &ActorClaim{Subject: actorID.String()}
+	claims.ExpiresAt = jwt.NewNumericDate(claims.IssuedAt.Add(impersonationTokenTTL))
+
+	token, err := s.SignAccessToken(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+// SignAccessToken подписывает claims access токена
+func (s *JWTService) SignAccessToken(claims *AccessTokenClaims) (string, error) {
+	return s.sign(claims, accessTokenType)
+}
+
+// GenerateIDToken создает ID токен для клиента, которому выдан access токен с этими claims.
+// Claims о пользователе берутся из access токена с учетом его scope.
+func (s *JWTService) GenerateIDToken(accessClaims *AccessTokenClaims, nonce string) (string, error) {
+	now := time.Now()
+	claims := IDTokenClaims{
+		Nonce:          nonce,
+		SessionID:      accessClaims.SessionID,
+		UserInfoClaims: NewUserInfoClaims(accessClaims.DisplayName, accessClaims.Username, accessClaims.Email, accessClaims.IsVerified, accessClaims.Scope),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   accessClaims.Subject,
+			Audience:  jwt.ClaimStrings{accessClaims.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(idTokenTTL)),
+		},
+	}
+
+	return s.sign(claims, idTokenType)
+}
+
+// NewUserInfoClaims оставляет claims, разрешенные scope: profile - имя и username, email - адрес
+func NewUserInfoClaims(displayName, username, email string, isVerified bool, scope string) UserInfoClaims {
+	scopes := strings.Fields(scope)
+
+	var claims UserInfoClaims
+	if slices.Contains(scopes, ScopeProfile) {
+		claims.Name = displayName
+		claims.PreferredUsername = username
+	}
+	if slices.Contains(scopes, ScopeEmail) {
+		claims.Email = email
+		claims.EmailVerified = &isVerified
+	}
+	return claims
+}
+
+// GenerateMFAChallengeToken создает короткоживущий токен, подтверждающий, что пароль
+// проверен и осталось предъявить второй фактор. По jti challenge погашается после обмена.
+func (s *JWTService) GenerateMFAChallengeToken(userID uuid.UUID) (string, error) {
+	now := time.Now()
+	claims := MFAChallengeClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    s.issuer,
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return s.sign(claims, mfaChallengeTokenType)
+}
+
+// GenerateServiceToken создает токен сервиса для вызова audience
+func (s *JWTService) GenerateServiceToken(clientID, audience, scope string) (string, error) {
+	now := time.Now()
+	claims := ServiceTokenClaims{
+		ClientID: clientID,
+		Scope:    scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    s.issuer,
+			Subject:   clientID,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(serviceTokenTTL)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return s.sign(claims, serviceTokenType)
+}
+
+// Issuer возвращает значение iss выдаваемых токенов
+func (s *JWTService) Issuer() string {
+	return s.issuer
+}
+
+// AccessTokenTTL возвращает время жизни access токена
+func (s *JWTService) AccessTokenTTL() time.Duration {
+	return accessTokenTTL
+}
+
+// ServiceTokenTTL возвращает время жизни токена сервиса
+func (s *JWTService) ServiceTokenTTL() time.Duration {
+	return serviceTokenTTL
+}
+
+// MFAChallengeTTL возвращает время жизни challenge токена 2FA
+func (s *JWTService) MFAChallengeTTL() time.Duration {
+	return mfaChallengeTTL
 }
 
 // GenerateRefreshToken создает refresh token
@@ -78,24 +334,34 @@ func (s *JWTService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	return token.SignedString(s.refreshSecret)
 }
 
-// ValidateAccessToken проверяет access token
+// ValidateAccessToken проверяет access token, выданный этим сервером
 func (s *JWTService) ValidateAccessToken(tokenString string) (*AccessTokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &AccessTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrTokenInvalid
-		}
-		return s.accessSecret, nil
-	})
+	claims := &AccessTokenClaims{}
+	if err := s.parse(tokenString, claims, accessTokenType, jwt.WithIssuer(s.issuer)); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
-		return nil, ErrTokenInvalid
+	return claims, nil
+}
+
+// ValidateMFAChallengeToken проверяет challenge токен 2FA
+func (s *JWTService) ValidateMFAChallengeToken(tokenString string) (*MFAChallengeClaims, error) {
+	claims := &MFAChallengeClaims{}
+	if err := s.parse(tokenString, claims, mfaChallengeTokenType, jwt.WithIssuer(s.issuer)); err != nil {
+		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*AccessTokenClaims); ok && token.Valid {
-		return claims, nil
+	return claims, nil
+}
+
+// ValidateServiceToken проверяет токен сервиса, выданный этим сервером для audience
+func (s *JWTService) ValidateServiceToken(tokenString, audience string) (*ServiceTokenClaims, error) {
+	claims := &ServiceTokenClaims{}
+	if err := s.parse(tokenString, claims, serviceTokenType, jwt.WithIssuer(s.issuer), jwt.WithAudience(audience)); err != nil {
+		return nil, err
 	}
 
-	return nil, ErrTokenInvalid
+	return claims, nil
 }
 
 // ValidateRefreshToken проверяет refresh token
@@ -118,6 +384,22 @@ func (s *JWTService) ValidateRefreshToken(tokenString string) (*RefreshTokenClai
 	return nil, ErrTokenInvalid
 }
 
+// JWKS возвращает открытые ключи для проверки access токенов другими сервисами
+func (s *JWTService) JWKS() keys.JWKSet {
+	return s.keys.JWKS()
+}
+
+// SigningAlgorithms возвращает алгоритмы ключей, которыми подписываются токены
+func (s *JWTService) SigningAlgorithms() []string {
+	var algorithms []string
+	for _, key := range s.keys.JWKS().Keys {
+		if !slices.Contains(algorithms, key.Algorithm) {
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
 // ExtractUserIDFromToken извлекает ID пользователя из токена без полной валидации
 func (s *JWTService) ExtractUserIDFromToken(tokenString string) (uuid.UUID, error) {
 	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, &AccessTokenClaims{})
@@ -131,3 +413,42 @@ func (s *JWTService) ExtractUserIDFromToken(tokenString string) (uuid.UUID, erro
 
 	return uuid.Nil, ErrTokenInvalid
 }
+
+// sign подписывает claims текущим асимметричным ключом
+func (s *JWTService) sign(claims jwt.Claims, tokenType string) (string, error) {
+	key, err := s.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm()), claims)
+	token.Header["kid"] = key.ID()
+	token.Header["typ"] = tokenType
+	return token.SignedString(key.PrivateKey())
+}
+
+// parse проверяет подпись по kid и назначение токена по заголовку typ
+func (s *JWTService) parse(tokenString string, claims jwt.Claims, tokenType string, opts ...jwt.ParserOption) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != tokenType {
+			return nil, ErrTokenInvalid
+		}
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, ErrTokenInvalid
+		}
+
+		key, err := s.keys.VerificationKey(kid)
+		if err != nil || key.Algorithm() != token.Method.Alg() {
+			return nil, ErrTokenInvalid
+		}
+		return key.PublicKey(), nil
+	}, append(opts, jwt.WithValidMethods([]string{keys.AlgorithmRS256, keys.AlgorithmEdDSA}))...)
+
+	if err != nil || !token.Valid {
+		return ErrTokenInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestJWTService_ValidateAccessToken(t *testing.T) {
+	keyProvider := newTestKeyProvider(t)
+	jwtService := NewJWTService(keyProvider, []byte("test-refresh-secret"), testIssuer)
+	foreign := NewJWTService(keyProvider, []byte("test-refresh-secret"), "https://other.test")
+	user := newTestUser()
+
+	accessClaims := jwtService.NewAccessTokenClaims(user, testUserAccess(), uuid.New(), DefaultAccessTokenScope, "client")
+
+	mustToken := func(token string, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("generate token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"access token", mustToken(jwtService.SignAccessToken(accessClaims)), false},
+		{"impersonation token", mustToken(firstOf(jwtService.GenerateImpersonationToken(user, testUserAccess(), uuid.New()))), false},
+		{"other issuer", mustToken(foreign.GenerateAccessToken(user, testUserAccess(), uuid.New())), true},
+		{"mfa challenge", mustToken(jwtService.GenerateMFAChallengeToken(user.ID())), true},
+		{"refresh token", mustToken(jwtService.GenerateRefreshToken(user.ID())), true},
+		{"id token", mustToken(jwtService.GenerateIDToken(accessClaims, "nonce")), true},
+		{"service token", mustToken(jwtService.GenerateServiceToken("client", "auth-service", "auth:validate")), true},
+		{"malformed", "not-a-jwt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwtService.ValidateAccessToken(tt.token)
+			if tt.wantErr && !errors.Is(err, ErrTokenInvalid) {
+				t.Fatalf("expected ErrTokenInvalid, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTService_ValidateMFAChallengeToken(t *testing.T) {
+	keyProvider := newTestKeyProvider(t)
+	jwtService := NewJWTService(keyProvider, []byte("test-refresh-secret"), testIssuer)
+	foreign := NewJWTService(keyProvider, []byte("test-refresh-secret"), "https://other.test")
+	userID := uuid.New()
+
+	valid, err := jwtService.GenerateMFAChallengeToken(userID)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	otherIssuer, err := foreign.GenerateMFAChallengeToken(userID)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	access, err := jwtService.GenerateAccessToken(newTestUser(), testUserAccess(), uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", valid, false},
+		{"other issuer", otherIssuer, true},
+		{"access token", access, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := jwtService.ValidateMFAChallengeToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrTokenInvalid) {
+					t.Fatalf("expected ErrTokenInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.UserID != userID {
+				t.Fatalf("user_id = %v, want %v", claims.UserID, userID)
+			}
+		})
+	}
+}
+
+func TestJWTService_ValidateServiceToken(t *testing.T) {
+	keyProvider := newTestKeyProvider(t)
+	jwtService := NewJWTService(keyProvider, []byte("test-refresh-secret"), testIssuer)
+	foreign := NewJWTService(keyProvider, []byte("test-refresh-secret"), "https://other.test")
+
+	valid, err := jwtService.GenerateServiceToken("gateway", "auth-service", "auth:login")
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	otherIssuer, err := foreign.GenerateServiceToken("gateway", "auth-service", "auth:login")
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	access, err := jwtService.GenerateAccessToken(newTestUser(), testUserAccess(), uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		audience string
+		wantErr  bool
+	}{
+		{"valid", valid, "auth-service", false},
+		{"other audience", valid, "posts-service", true},
+		{"other issuer", otherIssuer, "auth-service", true},
+		{"access token", access, "auth-service", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := jwtService.ValidateServiceToken(tt.token, tt.audience)
+			if tt.wantErr {
+				if !errors.Is(err, ErrTokenInvalid) {
+					t.Fatalf("expected ErrTokenInvalid, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.ClientID != "gateway" || !claims.HasScope("auth:login") {
+				t.Fatalf("unexpected claims: client %q, scope %q", claims.ClientID, claims.Scope)
+			}
+		})
+	}
+}
+
+func TestJWTService_ImpersonationClaims(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	actorID := uuid.New()
+
+	token, _, err := jwtService.GenerateImpersonationToken(newTestUser(), testUserAccess(), actorID)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	claims, err := jwtService.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if got, ok := claims.ImpersonatorID(); !ok || got != actorID {
+		t.Fatalf("impersonator = %v, %v; want %v", got, ok, actorID)
+	}
+	if claims.SessionID != uuid.Nil {
+		t.Fatalf("impersonation token must not belong to a session, got sid %v", claims.SessionID)
+	}
+	if ttl := claims.ExpiresAt.Sub(claims.IssuedAt.Time); ttl != impersonationTokenTTL {
+		t.Fatalf("ttl = %v, want %v", ttl, impersonationTokenTTL)
+	}
+}
+
+func firstOf(token string, _ *AccessTokenClaims, err error) (string, error) {
+	return token, err
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LockoutPolicy задает пороги защиты от перебора паролей
+type LockoutPolicy struct {
+	// MaxAccountFailures - неудачных попыток на аккаунт до первой блокировки
+	MaxAccountFailures int
+	// MaxIPFailures - неудачных попыток с одного IP до первой блокировки
+	MaxIPFailures int
+	// BaseLockout - длительность первой блокировки, каждая следующая неудача ее удваивает
+	BaseLockout time.Duration
+	// MaxLockout - верхняя граница длительности блокировки
+	MaxLockout time.Duration
+	// Window - время без неудач, после которого счетчик начинается заново
+	Window time.Duration
+}
+
+// LoginThrottle считает неудачные попытки входа по аккаунту и по IP клиента
+// и временно блокирует вход с экспоненциально растущей задержкой
+type LoginThrottle struct {
+	repo   repository.LoginAttemptRepository
+	policy LockoutPolicy
+	logger logger.Logger
+}
+
+type throttleKey struct {
+	key   string
+	limit int
+}
+
+func NewLoginThrottle(repo repository.LoginAttemptRepository, policy LockoutPolicy, logger logger.Logger) *LoginThrottle {
+	return &LoginThrottle{
+		repo:   repo,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+// CheckLogin возвращает *AccountLockedError, если вход для аккаунта или IP временно заблокирован
+func (t *LoginThrottle) CheckLogin(ctx context.Context, email, ipAddress string) error {
+	return t.check(ctx, t.loginKeys(email, ipAddress)...)
+}
+
+// RegisterLoginFailure учитывает неудачную попытку входа и возвращает
+// *AccountLockedError, если эта попытка привела к блокировке
+func (t *LoginThrottle) RegisterLoginFailure(ctx context.Context, email, ipAddress string) error {
+	return t.registerFailure(ctx, t.loginKeys(email, ipAddress)...)
+}
+
+// RegisterLoginSuccess сбрасывает счетчик аккаунта. Счетчик IP не сбрасывается,
+// иначе успешный вход в собственный аккаунт позволял бы продолжать перебор чужих.
+func (t *LoginThrottle) RegisterLoginSuccess(ctx context.Context, email string) {
+	t.reset(ctx, accountKey(email))
+}
+
+// CheckMFA проверяет блокировку ввода второго фактора
+func (t *LoginThrottle) CheckMFA(ctx context.Context, userID uuid.UUID) error {
+	return t.check(ctx, t.mfaKey(userID))
+}
+
+// RegisterMFAFailure учитывает неверный код второго фактора
+func (t *LoginThrottle) RegisterMFAFailure(ctx context.Context, userID uuid.UUID) error {
+	return t.registerFailure(ctx, t.mfaKey(userID))
+}
+
+// RegisterMFASuccess сбрасывает счетчик второго фактора
+func (t *LoginThrottle) RegisterMFASuccess(ctx context.Context, userID uuid.UUID) {
+	t.reset(ctx, t.mfaKey(userID).key)
+}
+
+func (t *LoginThrottle) loginKeys(email, ipAddress string) []throttleKey {
+	keys := []throttleKey{{key: accountKey(email), limit: t.policy.MaxAccountFailures}}
+	if ipAddress != "" {
+		keys = append(keys, throttleKey{key: "ip:" + ipAddress, limit: t.policy.MaxIPFailures})
+	}
+	return keys
+}
+
+func (t *LoginThrottle) mfaKey(userID uuid.UUID) throttleKey {
+	return throttleKey{key: "mfa:" + userID.String(), limit: t.policy.MaxAccountFailures}
+}
+
+func (t *LoginThrottle) check(ctx context.Context, keys ...throttleKey) error {
+	now := time.Now()
+	var retryAfter time.Duration
+
+	for _, k := range keys {
+		attempt, err := t.repo.GetByKey(ctx, k.key)
+		if err != nil {
+			if !errors.Is(err, repository.ErrLoginAttemptNotFound) {
+				// При недоступности хранилища не блокируем вход всем пользователям
+				t.logger.WithContext(ctx).Error("Failed to check login attempts", logger.String("key", k.key), logger.Error(err))
+			}
+			continue
+		}
+
+		if wait := attempt.RetryAfter(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &AccountLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (t *LoginThrottle) registerFailure(ctx context.Context, keys ...throttleKey) error {
+	// Неудача учитывается, даже если клиент уже разорвал соединение
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+	var retryAfter time.Duration
+
+	for _, k := range keys {
+		attempt, err := t.repo.RegisterFailure(ctx, k.key, t.policy.Window)
+		if err != nil {
+			t.logger.WithContext(ctx).Error("Failed to register login failure", logger.String("key", k.key), logger.Error(err))
+			continue
+		}
+
+		lockout := t.lockoutDuration(attempt, k.limit)
+		if lockout <= 0 {
+			continue
+		}
+
+		if err := t.repo.Lock(ctx, k.key, now.Add(lockout)); err != nil {
+			t.logger.WithContext(ctx).Error("Failed to lock login", logger.String("key", k.key), logger.Error(err))
+			continue
+		}
+
+		t.logger.WithContext(ctx).Warn("Security event: login temporarily locked",
+			logger.String("key", k.key),
+			logger.Int("failed_count", attempt.FailedCount()),
+			logger.Duration("lockout", lockout),
+		)
+
+		if lockout > retryAfter {
+			retryAfter = lockout
+		}
+	}
+
+	if retryAfter > 0 {
+		return &AccountLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// lockoutDuration вычисляет блокировку: BaseLockout после limit неудач,
+// затем удвоение на каждую следующую неудачу, но не больше MaxLockout
+func (t *LoginThrottle) lockoutDuration(attempt *domain.LoginAttempt, limit int) time.Duration {
+	if limit <= 0 || attempt.FailedCount() < limit {
+		return 0
+	}
+
+	lockout := t.policy.BaseLockout
+	for i := limit; i < attempt.FailedCount() && lockout < t.policy.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	if lockout > t.policy.MaxLockout {
+		lockout = t.policy.MaxLockout
+	}
+	return lockout
+}
+
+func (t *LoginThrottle) reset(ctx context.Context, key string) {
+	if err := t.repo.Reset(ctx, key); err != nil {
+		t.logger.WithContext(ctx).Error("Failed to reset login attempts", logger.String("key", key), logger.Error(err))
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + normalizeEmail(email)
+}
+
+// normalizeEmail приводит email к виду, по которому варианты с другим регистром и пробелами
+// считаются одним аккаунтом
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/infrastructure/memory"
+
+	"github.com/google/uuid"
+)
+
+func newTestLoginThrottle() *LoginThrottle {
+	return NewLoginThrottle(memory.NewLoginAttemptRepository(), LockoutPolicy{
+		MaxAccountFailures: 3,
+		MaxIPFailures:      5,
+		BaseLockout:        time.Minute,
+		MaxLockout:         4 * time.Minute,
+		Window:             time.Hour,
+	}, newTestLogger())
+}
+
+func lockedFor(t *testing.T, err error) time.Duration {
+	t.Helper()
+
+	if err == nil {
+		return 0
+	}
+	var lockErr *AccountLockedError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected *AccountLockedError, got %v", err)
+	}
+	if !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("AccountLockedError must unwrap to ErrAccountLocked")
+	}
+	return lockErr.RetryAfter
+}
+
+func TestLoginThrottle_ExponentialLockout(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestLoginThrottle()
+
+	// Порог 3, база 1 минута, потолок 4 минуты
+	want := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute}
+	for i, expected := range want {
+		got := lockedFor(t, throttle.RegisterLoginFailure(ctx, "alice@example.com", ""))
+		if got != expected {
+			t.Fatalf("failure %d: lockout = %v, want %v", i+1, got, expected)
+		}
+	}
+
+	retryAfter := lockedFor(t, throttle.CheckLogin(ctx, "alice@example.com", ""))
+	if retryAfter <= 0 || retryAfter > 4*time.Minute {
+		t.Fatalf("CheckLogin RetryAfter = %v, want (0, 4m]", retryAfter)
+	}
+
+	if err := throttle.CheckLogin(ctx, "bob@example.com", ""); err != nil {
+		t.Fatalf("other account must not be locked: %v", err)
+	}
+}
+
+func TestLoginThrottle_AccountKeyIsNormalized(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestLoginThrottle()
+
+	for _, email := range []string{"Alice@Example.com", " alice@example.com", "ALICE@EXAMPLE.COM "} {
+		_ = throttle.RegisterLoginFailure(ctx, email, "")
+	}
+
+	if lockedFor(t, throttle.CheckLogin(ctx, "alice@example.com", "")) == 0 {
+		t.Fatalf("case and whitespace variants of one email must share the lockout counter")
+	}
+
+	throttle.RegisterLoginSuccess(ctx, "ALICE@example.com")
+	if err := throttle.CheckLogin(ctx, "alice@example.com", ""); err != nil {
+		t.Fatalf("success must reset the normalized account counter: %v", err)
+	}
+}
+
+func TestLoginThrottle_SuccessKeepsIPCounter(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestLoginThrottle()
+	const ip = "203.0.113.7"
+
+	// Перебор разных аккаунтов с одного IP
+	for i := 0; i < 4; i++ {
+		_ = throttle.RegisterLoginFailure(ctx, uuid.NewString()+"@example.com", ip)
+	}
+	throttle.RegisterLoginSuccess(ctx, "mallory@example.com")
+
+	if lockedFor(t, throttle.RegisterLoginFailure(ctx, uuid.NewString()+"@example.com", ip)) == 0 {
+		t.Fatalf("IP must be locked after MaxIPFailures even after a successful login")
+	}
+	if lockedFor(t, throttle.CheckLogin(ctx, "mallory@example.com", ip)) == 0 {
+		t.Fatalf("locked IP must block login to any account")
+	}
+	if err := throttle.CheckLogin(ctx, "mallory@example.com", "198.51.100.1"); err != nil {
+		t.Fatalf("other IP must not be locked: %v", err)
+	}
+}
+
+func TestLoginThrottle_MFA(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestLoginThrottle()
+	userID := uuid.New()
+
+	for i := 0; i < 3; i++ {
+		_ = throttle.RegisterMFAFailure(ctx, userID)
+	}
+	if lockedFor(t, throttle.CheckMFA(ctx, userID)) == 0 {
+		t.Fatalf("MFA must be locked after MaxAccountFailures")
+	}
+	if err := throttle.CheckMFA(ctx, uuid.New()); err != nil {
+		t.Fatalf("other user must not be locked: %v", err)
+	}
+
+	throttle.RegisterMFASuccess(ctx, userID)
+	if err := throttle.CheckMFA(ctx, userID); err != nil {
+		t.Fatalf("MFA success must reset the counter: %v", err)
+	}
+}
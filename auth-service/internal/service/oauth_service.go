@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Scope, которые нужны клиенту для вызова служебных endpoint'ов
+const (
+	ScopeTokenIntrospect = "token:introspect"
+	ScopeTokenRevoke     = "token:revoke"
+)
+
+// Значения token_type_hint (RFC 7662, RFC 7009)
+const (
+	TokenTypeAccessToken  = "access_token"
+	TokenTypeRefreshToken = "refresh_token"
+)
+
+// TokenIntrospection - результат проверки токена (RFC 7662). Для недействительного
+// токена заполнено только поле Active.
+type TokenIntrospection struct {
+	Active      bool
+	Scope       string
+	Username    string
+	TokenType   string
+	ExpiresAt   int64
+	IssuedAt    int64
+	Subject     string
+	Issuer      string
+	SessionID   string
+	ClientID    string
+	Roles       []string
+	Permissions []string
+	// Actor - ID администратора, если токен выдан ему от имени пользователя
+	Actor string
+}
+
+// OAuthService реализует introspection и revocation для доверенных клиентов
+type OAuthService struct {
+	clientRepo      repository.OAuthClientRepository
+	authService     *AuthService
+	jwtService      *JWTService
+	tokenRevocation *TokenRevocationService
+	issuer          string
+	logger          logger.Logger
+}
+
+func NewOAuthService(
+	clientRepo repository.OAuthClientRepository,
+	authService *AuthService,
+	jwtService *JWTService,
+	tokenRevocation *TokenRevocationService,
+	issuer string,
+	logger logger.Logger,
+) *OAuthService {
+	return &OAuthService{
+		clientRepo:      clientRepo,
+		authService:     authService,
+		jwtService:      jwtService,
+		tokenRevocation: tokenRevocation,
+		issuer:          issuer,
+		logger:          logger,
+	}
+}
+
+// AuthenticateClient проверяет client_id и client_secret
+func (s *OAuthService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OAuthClient, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, ErrInvalidClient
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOAuthClientNotFound) {
+			return nil, ErrInvalidClient
+		}
+		return nil, err
+	}
+
+	if !clientSecretMatches(client, clientSecret) || !client.IsActive() {
+		s.logger.WithContext(ctx).Warn("OAuth client authentication failed", logger.String("client_id", clientID))
+		return nil, ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// IssueServiceToken выдает токен сервиса по client credentials (RFC 6749, раздел 4.4).
+// Без audience используется единственный разрешенный клиенту, без scope выдаются все scope клиента.
+func (s *OAuthService) IssueServiceToken(ctx context.Context, client *domain.OAuthClient, audience, scope string) (*TokenSet, error) {
+	if !client.CanUseClientCredentials() {
+		return nil, newOAuthError(OAuthErrorUnauthorizedClient, "the client is not allowed to use client_credentials")
+	}
+
+	if audience == "" {
+		if len(client.Audiences()) != 1 {
+			return nil, newOAuthError(OAuthErrorInvalidRequest, "audience is required")
+		}
+		audience = client.Audiences()[0]
+	}
+	if !client.HasAudience(audience) {
+		return nil, newOAuthError(OAuthErrorInvalidTarget, "audience is not allowed for the client")
+	}
+
+	scopes := NormalizeScopes(scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes()
+	}
+	for _, requested := range scopes {
+		if !client.HasScope(requested) {
+			return nil, newOAuthError(OAuthErrorInvalidScope, "scope is not allowed for the client")
+		}
+	}
+	granted := strings.Join(scopes, " ")
+
+	token, err := s.jwtService.GenerateServiceToken(client.ClientID(), audience, granted)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Service token issued",
+		logger.String("client_id", client.ClientID()),
+		logger.String("audience", audience),
+		logger.String("scope", granted),
+	)
+
+	return &TokenSet{
+		AccessToken: token,
+		Scope:       granted,
+		ExpiresIn:   int64(s.jwtService.ServiceTokenTTL().Seconds()),
+	}, nil
+}
+
+// IntrospectToken проверяет access или refresh токен. Подсказка tokenTypeHint задает,
+// какой тип проверяется первым; при неудаче проверяется второй. Клиент со scope
+// token:introspect - доверенный сервер ресурсов и видит токены входа в само приложение;
+// токен, выданный другому OAuth клиенту, для него неактивен.
+func (s *OAuthService) IntrospectToken(ctx context.Context, client *domain.OAuthClient, token, tokenTypeHint string) (*TokenIntrospection, error) {
+	if !client.HasScope(ScopeTokenIntrospect) {
+		return nil, ErrClientScopeNotAllowed
+	}
+
+	inspectors := []func(context.Context, string) (*TokenIntrospection, error){s.introspectAccessToken, s.introspectRefreshToken}
+	if tokenTypeHint == TokenTypeRefreshToken {
+		inspectors[0], inspectors[1] = inspectors[1], inspectors[0]
+	}
+
+	for _, inspect := range inspectors {
+		result, err := inspect(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Active {
+			continue
+		}
+		// О токене другого OAuth клиента вызывающий узнает лишь, что токен неактивен
+		if !issuedToClientOrFirstParty(result.ClientID, client) {
+			s.logger.WithContext(ctx).Warn("Client introspected a token of another client",
+				logger.String("client_id", client.ClientID()),
+			)
+			break
+		}
+		return result, nil
+	}
+
+	return &TokenIntrospection{Active: false}, nil
+}
+
+// RevokeToken отзывает refresh токен вместе с его сессией или access токен по jti
+// (RFC 7009). Клиент со scope token:revoke отзывает свои токены и токены входа в само
+// приложение. Неизвестные токены и токены другого OAuth клиента не отзываются,
+// но и не считаются ошибкой (RFC 7009, раздел 2.1).
+func (s *OAuthService) RevokeToken(ctx context.Context, client *domain.OAuthClient, token, tokenTypeHint string) error {
+	if !client.HasScope(ScopeTokenRevoke) {
+		return ErrClientScopeNotAllowed
+	}
+
+	refreshToken, err := s.authService.ValidateRefreshToken(ctx, token)
+	switch {
+	case err == nil:
+		if !issuedToClientOrFirstParty(refreshToken.ClientID(), client) {
+			s.logger.WithContext(ctx).Warn("Client tried to revoke a refresh token of another client",
+				logger.String("client_id", client.ClientID()),
+			)
+			return nil
+		}
+		if err := s.authService.RevokeRefreshToken(ctx, token); err != nil {
+			return err
+		}
+		s.logger.WithContext(ctx).Info("Refresh token revoked by client", logger.String("client_id", client.ClientID()))
+		return nil
+	case errors.Is(err, repository.ErrRefreshTokenInvalid):
+		// Токен уже отозван или истек
+		return nil
+	case !errors.Is(err, repository.ErrRefreshTokenNotFound):
+		return err
+	}
+
+	claims, err := s.jwtService.ValidateAccessToken(token)
+	if err != nil {
+		return nil
+	}
+	if !issuedToClientOrFirstParty(claims.ClientID, client) {
+		s.logger.WithContext(ctx).Warn("Client tried to revoke an access token of another client",
+			logger.String("client_id", client.ClientID()),
+		)
+		return nil
+	}
+	if err := s.tokenRevocation.RevokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("Access token revoked by client", logger.String("client_id", client.ClientID()))
+
+	return nil
+}
+
+func (s *OAuthService) introspectAccessToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	inactive := &TokenIntrospection{Active: false}
+
+	claims, err := s.tokenRevocation.ValidateAccessToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrTokenRevoked) || errors.Is(err, ErrTokenInvalid) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+
+	// Токен заблокированного пользователя или завершенной сессии недействителен
+	user, err := s.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+	if !user.IsActive() {
+		return inactive, nil
+	}
+
+	if claims.SessionID != uuid.Nil {
+		active, err := s.isSessionActive(ctx, claims.UserID, claims.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return inactive, nil
+		}
+	}
+
+	roles := make([]string, 0, len(claims.Roles))
+	for _, role := range claims.Roles {
+		roles = append(roles, string(role))
+	}
+
+	result := &TokenIntrospection{
+		Active:      true,
+		Scope:       claims.Scope,
+		Username:    claims.Username,
+		TokenType:   TokenTypeAccessToken,
+		Subject:     claims.Subject,
+		Issuer:      claims.Issuer,
+		SessionID:   claims.SessionID.String(),
+		ClientID:    claims.ClientID,
+		Roles:       roles,
+		Permissions: strings.Fields(claims.Permissions),
+	}
+	if claims.Actor != nil {
+		result.Actor = claims.Actor.Subject
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Unix()
+	}
+
+	return result, nil
+}
+
+func (s *OAuthService) introspectRefreshToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	inactive := &TokenIntrospection{Active: false}
+
+	refreshToken, err := s.authService.ValidateRefreshToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) || errors.Is(err, repository.ErrRefreshTokenInvalid) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+
+	user, err := s.authService.GetUserByID(ctx, refreshToken.UserID())
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+	if !user.IsActive() {
+		return inactive, nil
+	}
+
+	access, err := s.authService.GetUserAccess(ctx, user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, len(access.Roles))
+	for i, role := range access.Roles {
+		roles[i] = string(role)
+	}
+
+	permissions := make([]string, len(access.Permissions))
+	for i, permission := range access.Permissions {
+		permissions[i] = string(permission)
+	}
+
+	// Токены, выданные OAuth клиентам, хранят согласованный scope
+	scope := DefaultAccessTokenScope
+	if refreshToken.Scope() != "" {
+		scope = refreshToken.Scope()
+	}
+
+	return &TokenIntrospection{
+		Active:      true,
+		Scope:       scope,
+		Username:    user.Username(),
+		TokenType:   TokenTypeRefreshToken,
+		ExpiresAt:   refreshToken.ExpiresAt().Unix(),
+		IssuedAt:    refreshToken.CreatedAt().Unix(),
+		Subject:     user.ID().String(),
+		Issuer:      s.issuer,
+		SessionID:   refreshToken.SessionID().String(),
+		ClientID:    refreshToken.ClientID(),
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *OAuthService) isSessionActive(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	sessions, err := s.authService.ListSessions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, session := range sessions {
+		if session.SessionID() == sessionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// issuedToClientOrFirstParty сообщает, может ли client работать с токеном, выданным tokenClientID:
+// токен выдан ему самому или входом в само приложение (пустой client_id)
+func issuedToClientOrFirstParty(tokenClientID string, client *domain.OAuthClient) bool {
+	return tokenClientID == "" || tokenClientID == client.ClientID()
+}
+
+// clientSecretMatches сравнивает хеш предъявленного секрета с сохраненным за постоянное время
+func clientSecretMatches(client *domain.OAuthClient, secret string) bool {
+	secretHash := helpers.HashToken(secret)
+	return subtle.ConstantTimeCompare([]byte(secretHash), []byte(client.SecretHash())) == 1
+}
+
+// GenerateClientSecret создает случайный секрет клиента и его хеш для хранения
+func GenerateClientSecret() (secret, secretHash string) {
+	secret = helpers.GenerateSecureToken()
+	return secret, helpers.HashToken(secret)
+}
+
+// NormalizeScopes разбирает список scope, разделенных пробелами или запятыми
+func NormalizeScopes(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	scopes := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, scope := range fields {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+// oauthClientStore - OAuth клиенты в памяти
+type oauthClientStore struct {
+	repository.OAuthClientRepository
+	clients map[string]*domain.OAuthClient
+}
+
+func (s *oauthClientStore) GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	if client, ok := s.clients[clientID]; ok {
+		return client, nil
+	}
+	return nil, repository.ErrOAuthClientNotFound
+}
+
+type oauthTest struct {
+	service *OAuthService
+	jwt     *JWTService
+	tokens  *refreshTokenStore
+	user    *domain.User
+	client  *domain.OAuthClient
+}
+
+func newOAuthTest(t *testing.T) *oauthTest {
+	t.Helper()
+
+	user := newTestUser()
+	tokens := newRefreshTokenStore()
+	revocation, jwtService := newTestTokenRevocationService(t)
+
+	secretHash := helpers.HashToken("resource-secret")
+	client := domain.NewOAuthClient("resource-server", secretHash, "Resource server",
+		[]string{ScopeTokenIntrospect, ScopeTokenRevoke})
+
+	authService := &AuthService{
+		userRepo:         &userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}},
+		userRoleRepo:     &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{user.ID(): {domain.NewUserRole(user.ID(), domain.RoleUser)}}},
+		roleRepo:         newRoleStore(),
+		refreshTokenRepo: tokens,
+		tokenRevocation:  revocation,
+		logger:           newTestLogger(),
+	}
+
+	return &oauthTest{
+		service: NewOAuthService(
+			&oauthClientStore{clients: map[string]*domain.OAuthClient{client.ClientID(): client}},
+			authService, jwtService, revocation, testIssuer, newTestLogger(),
+		),
+		jwt:    jwtService,
+		tokens: tokens,
+		user:   user,
+		client: client,
+	}
+}
+
+// issueSession выпускает refresh токен и access токен одной сессии, выданные клиенту clientID
+// (пустой clientID - вход в само приложение)
+func (ot *oauthTest) issueSession(t *testing.T, clientID string) (*domain.RefreshToken, string) {
+	t.Helper()
+
+	scope := DefaultAccessTokenScope
+	if clientID != "" {
+		scope = ScopeOpenID
+	}
+
+	refresh := domain.NewRefreshToken(ot.user.ID(), uuid.NewString(), time.Now().Add(time.Hour))
+	refresh.SetOAuthClient(clientID, scope)
+	if err := ot.tokens.Create(context.Background(), refresh); err != nil {
+		t.Fatalf("create refresh token: %v", err)
+	}
+
+	access, err := ot.jwt.SignAccessToken(ot.jwt.NewAccessTokenClaims(ot.user, testUserAccess(), refresh.SessionID(), scope, clientID))
+	if err != nil {
+		t.Fatalf("sign access token: %v", err)
+	}
+	return refresh, access
+}
+
+func TestOAuthService_AuthenticateClient(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+
+	inactive := domain.NewOAuthClient("disabled", helpers.HashToken("secret"), "Disabled", nil)
+	inactive.SetActive(false)
+	ot.service.clientRepo.(*oauthClientStore).clients[inactive.ClientID()] = inactive
+
+	tests := []struct {
+		name     string
+		clientID string
+		secret   string
+		wantErr  error
+	}{
+		{"valid credentials", "resource-server", "resource-secret", nil},
+		{"wrong secret", "resource-server", "other-secret", ErrInvalidClient},
+		{"unknown client", "unknown", "resource-secret", ErrInvalidClient},
+		{"inactive client", "disabled", "secret", ErrInvalidClient},
+		{"missing secret", "resource-server", "", ErrInvalidClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := ot.service.AuthenticateClient(ctx, tt.clientID, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AuthenticateClient() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && client.ClientID() != tt.clientID {
+				t.Fatalf("client = %q, want %q", client.ClientID(), tt.clientID)
+			}
+		})
+	}
+}
+
+func TestOAuthService_IntrospectToken(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+
+	refresh, access := ot.issueSession(t, ot.client.ClientID())
+	revokedRefresh, revokedAccess := ot.issueSession(t, ot.client.ClientID())
+	if err := ot.tokens.RevokeFamily(ctx, revokedRefresh); err != nil {
+		t.Fatalf("revoke session: %v", err)
+	}
+	otherRefresh, otherAccess := ot.issueSession(t, "other-client")
+	firstPartyRefresh, firstPartyAccess := ot.issueSession(t, "")
+
+	tests := []struct {
+		name       string
+		token      string
+		hint       string
+		wantActive bool
+		wantType   string
+		// session - сессия, которую должен описать активный результат
+		session *domain.RefreshToken
+	}{
+		{"access token", access, "", true, TokenTypeAccessToken, refresh},
+		{"access token with wrong hint", access, TokenTypeRefreshToken, true, TokenTypeAccessToken, refresh},
+		{"refresh token", refresh.Token(), TokenTypeRefreshToken, true, TokenTypeRefreshToken, refresh},
+		{"refresh token without hint", refresh.Token(), "", true, TokenTypeRefreshToken, refresh},
+		{"access token of ended session", revokedAccess, "", false, "", nil},
+		{"revoked refresh token", revokedRefresh.Token(), TokenTypeRefreshToken, false, "", nil},
+		{"garbage", "not-a-token", "", false, "", nil},
+		{"access token of another client", otherAccess, "", false, "", nil},
+		{"refresh token of another client", otherRefresh.Token(), TokenTypeRefreshToken, false, "", nil},
+		// Сервер ресурсов проверяет токены входа в само приложение
+		{"first-party access token", firstPartyAccess, "", true, TokenTypeAccessToken, firstPartyRefresh},
+		{"first-party refresh token", firstPartyRefresh.Token(), "", true, TokenTypeRefreshToken, firstPartyRefresh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ot.service.IntrospectToken(ctx, ot.client, tt.token, tt.hint)
+			if err != nil {
+				t.Fatalf("introspect: %v", err)
+			}
+			if result.Active != tt.wantActive || result.TokenType != tt.wantType {
+				t.Fatalf("active = %v, type = %q; want %v, %q", result.Active, result.TokenType, tt.wantActive, tt.wantType)
+			}
+			if result.Active && (result.Subject != ot.user.ID().String() || result.SessionID != tt.session.SessionID().String() || result.ClientID != tt.session.ClientID()) {
+				t.Fatalf("unexpected subject %q, session %q or client %q", result.Subject, result.SessionID, result.ClientID)
+			}
+		})
+	}
+}
+
+func TestOAuthService_RevokeToken(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+
+	refresh, access := ot.issueSession(t, ot.client.ClientID())
+
+	if err := ot.service.RevokeToken(ctx, ot.client, refresh.Token(), TokenTypeRefreshToken); err != nil {
+		t.Fatalf("revoke refresh token: %v", err)
+	}
+	if !refresh.IsRevoked() {
+		t.Fatalf("refresh token must be revoked")
+	}
+
+	// Неизвестный токен не считается ошибкой (RFC 7009)
+	if err := ot.service.RevokeToken(ctx, ot.client, "unknown-token", ""); err != nil {
+		t.Fatalf("revoke unknown token: %v", err)
+	}
+
+	// Access токен отзывается по jti
+	if err := ot.service.RevokeToken(ctx, ot.client, access, ""); err != nil {
+		t.Fatalf("revoke access token: %v", err)
+	}
+	result, err := ot.service.IntrospectToken(ctx, ot.client, access, "")
+	if err != nil {
+		t.Fatalf("introspect: %v", err)
+	}
+	if result.Active {
+		t.Fatalf("revoked access token must be inactive")
+	}
+}
+
+func TestOAuthService_RevokeToken_Ownership(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		clientID    string
+		wantRevoked bool
+	}{
+		{"token of another client", "other-client", false},
+		{"first-party token", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresh, access := ot.issueSession(t, tt.clientID)
+
+			// Чужой токен не отзывается, но ответ такой же, как при успехе (RFC 7009)
+			if err := ot.service.RevokeToken(ctx, ot.client, refresh.Token(), TokenTypeRefreshToken); err != nil {
+				t.Fatalf("revoke refresh token: %v", err)
+			}
+			if err := ot.service.RevokeToken(ctx, ot.client, access, ""); err != nil {
+				t.Fatalf("revoke access token: %v", err)
+			}
+
+			if refresh.IsRevoked() != tt.wantRevoked {
+				t.Fatalf("refresh token revoked = %v, want %v", refresh.IsRevoked(), tt.wantRevoked)
+			}
+			_, err := ot.service.tokenRevocation.ValidateAccessToken(ctx, access)
+			if revoked := errors.Is(err, ErrTokenRevoked); revoked != tt.wantRevoked {
+				t.Fatalf("access token revoked = %v, want %v (%v)", revoked, tt.wantRevoked, err)
+			}
+		})
+	}
+}
+
+func TestOAuthService_ClientScopes(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+	_, access := ot.issueSession(t, ot.client.ClientID())
+
+	client := domain.NewOAuthClient("introspect-only", "", "Introspect only", []string{ScopeTokenIntrospect})
+
+	if _, err := ot.service.IntrospectToken(ctx, client, access, ""); err != nil {
+		t.Fatalf("introspect with scope: %v", err)
+	}
+	if err := ot.service.RevokeToken(ctx, client, access, ""); !errors.Is(err, ErrClientScopeNotAllowed) {
+		t.Fatalf("expected ErrClientScopeNotAllowed, got %v", err)
+	}
+}
+
+func TestOAuthService_IssueServiceToken(t *testing.T) {
+	ot := newOAuthTest(t)
+	ctx := context.Background()
+
+	gateway := domain.NewOAuthClient("gateway", "", "Gateway", []string{"auth:login", "auth:validate"})
+	gateway.SetAudiences([]string{"auth-service"})
+
+	multi := domain.NewOAuthClient("multi", "", "Multi", []string{"auth:login"})
+	multi.SetAudiences([]string{"auth-service", "posts-service"})
+
+	public := domain.NewOAuthClient("spa", "", "SPA", []string{"auth:login"})
+	public.SetAudiences([]string{"auth-service"})
+	public.SetPublic(true)
+
+	tests := []struct {
+		name      string
+		client    *domain.OAuthClient
+		audience  string
+		scope     string
+		wantCode  string
+		wantScope string
+	}{
+		{"single audience by default", gateway, "", "", "", "auth:login auth:validate"},
+		{"requested scope subset", gateway, "auth-service", "auth:validate", "", "auth:validate"},
+		{"scope not allowed", gateway, "auth-service", "auth:roles.write", OAuthErrorInvalidScope, ""},
+		{"audience not allowed", gateway, "posts-service", "", OAuthErrorInvalidTarget, ""},
+		{"audience required for several", multi, "", "", OAuthErrorInvalidRequest, ""},
+		{"public client", public, "auth-service", "", OAuthErrorUnauthorizedClient, ""},
+		{"client without audiences", ot.client, "auth-service", "", OAuthErrorUnauthorizedClient, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := ot.service.IssueServiceToken(ctx, tt.client, tt.audience, tt.scope)
+			if tt.wantCode != "" {
+				var oauthErr *OAuthError
+				if !errors.As(err, &oauthErr) || oauthErr.Code != tt.wantCode {
+					t.Fatalf("expected OAuth error %q, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("issue service token: %v", err)
+			}
+
+			claims, err := ot.jwt.ValidateServiceToken(tokens.AccessToken, "auth-service")
+			if err != nil {
+				t.Fatalf("validate service token: %v", err)
+			}
+			if claims.ClientID != tt.client.ClientID() || claims.Scope != tt.wantScope || tokens.Scope != tt.wantScope {
+				t.Fatalf("unexpected claims: client %q, scope %q", claims.ClientID, claims.Scope)
+			}
+			// Токен сервиса не принимается там, где ожидается токен пользователя
+			if _, err := ot.jwt.ValidateAccessToken(tokens.AccessToken); err == nil {
+				t.Fatalf("service token must not be accepted as an access token")
+			}
+		})
+	}
+}
+
+func TestNormalizeScopes(t *testing.T) {
+	got := NormalizeScopes("token:introspect, token:revoke,token:introspect  ")
+	want := []string{ScopeTokenIntrospect, ScopeTokenRevoke}
+
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("NormalizeScopes() = %v, want %v", got, want)
+	}
+}
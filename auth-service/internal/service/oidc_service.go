@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+)
+
+// Scope OpenID Connect, которые может запросить клиент
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// SupportedScopes - scope, объявленные в discovery документе
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+// Параметры протокола, поддерживаемые сервером авторизации
+const (
+	ResponseTypeCode           = "code"
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// Решение пользователя на экране согласия
+const (
+	ConsentApprove = "approve"
+	ConsentDeny    = "deny"
+)
+
+// pkceChallengeLength - длина BASE64URL(SHA256(verifier)) без выравнивания
+const pkceChallengeLength = 43
+
+// AuthorizationRequest - параметры запроса авторизации (RFC 6749, раздел 4.1.1)
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// AuthorizationResult - итог запроса авторизации: либо адрес возврата к клиенту,
+// либо требование показать пользователю экран согласия
+type AuthorizationResult struct {
+	RedirectURI     string
+	ConsentRequired bool
+	Client          *domain.OAuthClient
+	Scopes          []string
+}
+
+// TokenSet - токены, выданные клиенту на token endpoint
+type TokenSet struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	Scope        string
+	ExpiresIn    int64
+}
+
+// UserInfo - ответ /userinfo: subject и claims, разрешенные scope токена
+type UserInfo struct {
+	Subject string
+	UserInfoClaims
+}
+
+// OIDCService реализует сервер авторизации OpenID Connect: authorization code с PKCE,
+// согласие пользователя, выдачу ID токенов и /userinfo. Вход пользователя остается
+// за основным приложением, куда /oauth/authorize перенаправляет браузер.
+type OIDCService struct {
+	clientRepo      repository.OAuthClientRepository
+	codeRepo        repository.AuthorizationCodeRepository
+	consentRepo     repository.OAuthConsentRepository
+	authService     *AuthService
+	jwtService      *JWTService
+	tokenRevocation *TokenRevocationService
+	loginURL        string
+	logger          logger.Logger
+}
+
+func NewOIDCService(
+	clientRepo repository.OAuthClientRepository,
+	codeRepo repository.AuthorizationCodeRepository,
+	consentRepo repository.OAuthConsentRepository,
+	authService *AuthService,
+	jwtService *JWTService,
+	tokenRevocation *TokenRevocationService,
+	loginURL string,
+	logger logger.Logger,
+) *OIDCService {
+	return &OIDCService{
+		clientRepo:      clientRepo,
+		codeRepo:        codeRepo,
+		consentRepo:     consentRepo,
+		authService:     authService,
+		jwtService:      jwtService,
+		tokenRevocation: tokenRevocation,
+		loginURL:        loginURL,
+		logger:          logger,
+	}
+}
+
+// ValidateAuthorizationRequest проверяет запрос авторизации. Неизвестный клиент или
+// незарегистрированный redirect_uri возвращают ErrInvalidClient и ErrInvalidRedirectURI:
+// о них сообщается пользователю, а не клиенту. Остальные ошибки - *OAuthError,
+// которые передаются клиенту через redirect_uri.
+func (s *OIDCService) ValidateAuthorizationRequest(ctx context.Context, req *AuthorizationRequest) (*domain.OAuthClient, []string, error) {
+	client, err := s.clientRepo.GetByClientID(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOAuthClientNotFound) {
+			return nil, nil, ErrInvalidClient
+		}
+		return nil, nil, err
+	}
+	if !client.IsActive() {
+		return nil, nil, ErrInvalidClient
+	}
+
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return nil, nil, ErrInvalidRedirectURI
+	}
+
+	if req.ResponseType != ResponseTypeCode {
+		return nil, nil, newOAuthError(OAuthErrorUnsupportedResponseType, "only the code response type is supported")
+	}
+
+	scopes := NormalizeScopes(req.Scope)
+	if !slices.Contains(scopes, ScopeOpenID) {
+		return nil, nil, newOAuthError(OAuthErrorInvalidScope, "the openid scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(SupportedScopes, scope) {
+			return nil, nil, newOAuthError(OAuthErrorInvalidScope, "unsupported scope: "+scope)
+		}
+	}
+
+	// PKCE обязателен для всех клиентов, включая конфиденциальные
+	if req.CodeChallengeMethod != domain.CodeChallengeMethodS256 {
+		return nil, nil, newOAuthError(OAuthErrorInvalidRequest, "code_challenge_method must be S256")
+	}
+	if len(req.CodeChallenge) != pkceChallengeLength {
+		return nil, nil, newOAuthError(OAuthErrorInvalidRequest, "code_challenge is invalid")
+	}
+
+	return client, scopes, nil
+}
+
+// LoginRedirectURL возвращает адрес страницы входа, которой передаются исходные параметры
+// запроса авторизации. После входа страница завершает авторизацию через API.
+func (s *OIDCService) LoginRedirectURL(rawQuery string) string {
+	return appendQuery(s.loginURL, rawQuery)
+}
+
+// ErrorRedirectURL возвращает адрес возврата к клиенту с ошибкой авторизации
+func (s *OIDCService) ErrorRedirectURL(req *AuthorizationRequest, oauthErr *OAuthError) string {
+	params := url.Values{}
+	params.Set("error", oauthErr.Code)
+	params.Set("error_description", oauthErr.Description)
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return appendQuery(req.RedirectURI, params.Encode())
+}
+
+// Authorize завершает авторизацию от имени вошедшего пользователя. Если пользователь еще не
+// соглашался на запрошенные scope и решение не передано, возвращается ConsentRequired.
+// При отказе или ошибке возвращается *OAuthError для передачи клиенту.
+func (s *OIDCService) Authorize(ctx context.Context, claims *AccessTokenClaims, req *AuthorizationRequest, consent string) (*AuthorizationResult, error) {
+	// Токеном стороннего клиента нельзя выдать доступ другому клиенту
+	if claims.ClientID != "" {
+		return nil, ErrClientTokenNotAllowed
+	}
+
+	client, scopes, err := s.ValidateAuthorizationRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if consent == ConsentDeny {
+		s.logger.WithContext(ctx).Info("OAuth authorization denied by user",
+			logger.String("user_id", claims.UserID.String()),
+			logger.String("client_id", client.ClientID()),
+		)
+		return nil, newOAuthError(OAuthErrorAccessDenied, "the user denied the request")
+	}
+
+	user, err := s.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, ErrUserInactive
+	}
+
+	if !client.SkipConsent() {
+		granted, err := s.consentRepo.Get(ctx, user.ID(), client.ClientID())
+		if err != nil && !errors.Is(err, repository.ErrOAuthConsentNotFound) {
+			return nil, err
+		}
+
+		if granted == nil || !granted.Covers(scopes) {
+			if consent != ConsentApprove {
+				return &AuthorizationResult{ConsentRequired: true, Client: client, Scopes: scopes}, nil
+			}
+
+			if granted == nil {
+				granted = domain.NewOAuthConsent(user.ID(), client.ClientID(), scopes)
+			} else {
+				granted.Grant(scopes)
+			}
+			if err := s.consentRepo.Save(ctx, granted); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	code := helpers.GenerateSecureToken()
+	authCode := domain.NewAuthorizationCode(
+		helpers.HashToken(code),
+		client.ClientID(),
+		user.ID(),
+		req.RedirectURI,
+		scopes,
+		helpers.GetExpirationTime("authorization_code"),
+	)
+	authCode.SetNonce(req.Nonce)
+	authCode.SetCodeChallenge(req.CodeChallenge, req.CodeChallengeMethod)
+
+	if err := s.codeRepo.Create(ctx, authCode); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("OAuth authorization code issued",
+		logger.String("user_id", user.ID().String()),
+		logger.String("client_id", client.ClientID()),
+	)
+
+	params := url.Values{}
+	params.Set("code", code)
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+
+	return &AuthorizationResult{
+		RedirectURI: appendQuery(req.RedirectURI, params.Encode()),
+		Client:      client,
+		Scopes:      scopes,
+	}, nil
+}
+
+// AuthenticateClient проверяет клиента на token endpoint. Публичные клиенты (SPA,
+// мобильные приложения) не имеют секрета и защищены только PKCE.
+func (s *OIDCService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OAuthClient, error) {
+	if clientID == "" {
+		return nil, ErrInvalidClient
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOAuthClientNotFound) {
+			return nil, ErrInvalidClient
+		}
+		return nil, err
+	}
+
+	if !client.IsActive() || (!client.IsPublic() && (clientSecret == "" || !clientSecretMatches(client, clientSecret))) {
+		s.logger.WithContext(ctx).Warn("OAuth client authentication failed", logger.String("client_id", clientID))
+		return nil, ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// ExchangeCode обменивает код авторизации на access, refresh и ID токены (RFC 6749, раздел 4.1.3)
+func (s *OIDCService) ExchangeCode(ctx context.Context, client *domain.OAuthClient, code, redirectURI, codeVerifier string, info domain.ClientInfo) (*TokenSet, error) {
+	invalidGrant := newOAuthError(OAuthErrorInvalidGrant, "authorization code is invalid or expired")
+
+	if code == "" {
+		return nil, newOAuthError(OAuthErrorInvalidRequest, "code is required")
+	}
+
+	authCode, err := s.codeRepo.GetByCodeHash(ctx, helpers.HashToken(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrAuthorizationCodeNotFound) {
+			return nil, invalidGrant
+		}
+		return nil, err
+	}
+
+	if !authCode.IsValid() || authCode.ClientID() != client.ClientID() || authCode.RedirectURI() != redirectURI {
+		return nil, invalidGrant
+	}
+	if !authCode.VerifyCodeVerifier(codeVerifier) {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, "code_verifier does not match the code challenge")
+	}
+
+	// Код одноразовый: параллельный обмен того же кода получит ошибку
+	if err := s.codeRepo.MarkUsed(ctx, authCode.ID()); err != nil {
+		if errors.Is(err, repository.ErrAuthorizationCodeInvalid) {
+			return nil, invalidGrant
+		}
+		return nil, err
+	}
+
+	user, err := s.authService.GetUserByID(ctx, authCode.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, invalidGrant
+	}
+
+	scope := strings.Join(authCode.Scopes(), " ")
+	if info.DeviceName == "" {
+		info.DeviceName = client.Name()
+	}
+
+	refreshToken, err := s.authService.CreateClientRefreshToken(ctx, user.ID(), client.ClientID(), scope, info)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(user, refreshToken, client.ClientID(), authCode.Nonce())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("OAuth authorization code exchanged",
+		logger.String("user_id", user.ID().String()),
+		logger.String("client_id", client.ClientID()),
+		logger.String("session_id", refreshToken.SessionID().String()),
+	)
+
+	return tokens, nil
+}
+
+// Refresh обменивает refresh токен клиента на новую пару токенов. Токен, выданный
+// другому клиенту или самому приложению, не принимается.
+func (s *OIDCService) Refresh(ctx context.Context, client *domain.OAuthClient, token string, info domain.ClientInfo) (*TokenSet, error) {
+	if token == "" {
+		return nil, newOAuthError(OAuthErrorInvalidRequest, "refresh_token is required")
+	}
+
+	if info.DeviceName == "" {
+		info.DeviceName = client.Name()
+	}
+
+	refreshToken, err := s.authService.RotateRefreshToken(ctx, token, client.ClientID(), info)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) ||
+			errors.Is(err, repository.ErrRefreshTokenInvalid) ||
+			errors.Is(err, ErrRefreshTokenReused) {
+			return nil, newOAuthError(OAuthErrorInvalidGrant, "refresh token is invalid or expired")
+		}
+		return nil, err
+	}
+
+	user, err := s.authService.GetUserByID(ctx, refreshToken.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, newOAuthError(OAuthErrorInvalidGrant, "refresh token is invalid or expired")
+	}
+
+	return s.issueTokens(user, refreshToken, client.ClientID(), "")
+}
+
+// UserInfo возвращает claims пользователя по access токену со scope openid.
+// Данные читаются из базы, поэтому отражают изменения профиля после выдачи токена.
+func (s *OIDCService) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	claims, err := s.tokenRevocation.ValidateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(strings.Fields(claims.Scope), ScopeOpenID) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	user, err := s.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, ErrTokenInvalid
+	}
+
+	return &UserInfo{
+		Subject:        user.ID().String(),
+		UserInfoClaims: NewUserInfoClaims(user.DisplayName(), user.Username(), user.Email(), user.IsVerified(), claims.Scope),
+	}, nil
+}
+
+// RunCleanup периодически удаляет истекшие коды авторизации
+func (s *OIDCService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.codeRepo.DeleteExpired(ctx)
+			if err != nil {
+				s.logger.Error("Failed to delete expired authorization codes", logger.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Debug("Expired authorization codes deleted", logger.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+// issueTokens выпускает access и ID токены для сессии refresh токена клиента
+func (s *OIDCService) issueTokens(user *domain.User, refreshToken *domain.RefreshToken, clientID, nonce string) (*TokenSet, error) {
+	// Роли и права в токен клиента не попадают: он подходит только для userinfo
+	claims := s.jwtService.NewAccessTokenClaims(user, &UserAccess{}, refreshToken.SessionID(), refreshToken.Scope(), clientID)
+	accessToken, err := s.jwtService.SignAccessToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := s.jwtService.GenerateIDToken(claims, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenSet{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken.Token(),
+		IDToken:      idToken,
+		Scope:        refreshToken.Scope(),
+		ExpiresIn:    int64(s.jwtService.AccessTokenTTL().Seconds()),
+	}, nil
+}
+
+// appendQuery добавляет параметры к адресу, сохраняя уже имеющуюся query-часть
+func appendQuery(rawURL, query string) string {
+	if query == "" {
+		return rawURL
+	}
+	if strings.Contains(rawURL, "?") {
+		return rawURL + "&" + query
+	}
+	return rawURL + "?" + query
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+const (
+	testClientID    = "web-client"
+	testRedirectURI = "https://client.example/callback"
+
+	// Пример из приложения B RFC 7636
+	testCodeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testCodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+// authorizationCodeStore - коды авторизации в памяти
+type authorizationCodeStore struct {
+	repository.AuthorizationCodeRepository
+	codes map[string]*domain.AuthorizationCode
+}
+
+func (s *authorizationCodeStore) GetByCodeHash(ctx context.Context, codeHash string) (*domain.AuthorizationCode, error) {
+	if code, ok := s.codes[codeHash]; ok {
+		return code, nil
+	}
+	return nil, repository.ErrAuthorizationCodeNotFound
+}
+
+func newTestOAuthClient() *domain.OAuthClient {
+	client := domain.NewOAuthClient(testClientID, "", "Web", []string{ScopeOpenID})
+	client.SetPublic(true)
+	client.SetRedirectURIs([]string{testRedirectURI})
+	return client
+}
+
+func newTestOIDCService(codes map[string]*domain.AuthorizationCode) *OIDCService {
+	return &OIDCService{
+		clientRepo: &oauthClientStore{clients: map[string]*domain.OAuthClient{testClientID: newTestOAuthClient()}},
+		codeRepo:   &authorizationCodeStore{codes: codes},
+		logger:     newTestLogger(),
+	}
+}
+
+func assertOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var oauthErr *OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != code {
+		t.Fatalf("expected OAuth error %s, got %v", code, err)
+	}
+}
+
+func TestValidateAuthorizationRequest_PKCE(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		method    string
+		wantErr   bool
+	}{
+		{name: "s256 challenge", challenge: testCodeChallenge, method: domain.CodeChallengeMethodS256},
+		{name: "missing challenge", method: domain.CodeChallengeMethodS256, wantErr: true},
+		{name: "missing method", challenge: testCodeChallenge, wantErr: true},
+		{name: "plain method", challenge: testCodeVerifier, method: "plain", wantErr: true},
+		{name: "challenge of wrong length", challenge: testCodeChallenge[:42], method: domain.CodeChallengeMethodS256, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := newTestOIDCService(nil).ValidateAuthorizationRequest(context.Background(), &AuthorizationRequest{
+				ResponseType:        ResponseTypeCode,
+				ClientID:            testClientID,
+				RedirectURI:         testRedirectURI,
+				Scope:               ScopeOpenID,
+				CodeChallenge:       tt.challenge,
+				CodeChallengeMethod: tt.method,
+			})
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			assertOAuthError(t, err, OAuthErrorInvalidRequest)
+		})
+	}
+}
+
+func TestExchangeCode_PKCE(t *testing.T) {
+	// Код, перехваченный злоумышленником, бесполезен без code_verifier
+	tests := []struct {
+		name     string
+		verifier string
+	}{
+		{name: "missing verifier"},
+		{name: "verifier of another request", verifier: "M25iVXpKU3puUjFaYWg3T1NDTDQtcW1ROUY5YXlwalNoc0hhakxifmZHag"},
+		{name: "challenge sent as verifier", verifier: testCodeChallenge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := helpers.GenerateSecureToken()
+			authCode := domain.NewAuthorizationCode(helpers.HashToken(code), testClientID, uuid.New(), testRedirectURI, []string{ScopeOpenID}, time.Now().Add(time.Minute))
+			authCode.SetCodeChallenge(testCodeChallenge, domain.CodeChallengeMethodS256)
+
+			s := newTestOIDCService(map[string]*domain.AuthorizationCode{authCode.CodeHash(): authCode})
+			_, err := s.ExchangeCode(context.Background(), newTestOAuthClient(), code, testRedirectURI, tt.verifier, domain.ClientInfo{})
+
+			assertOAuthError(t, err, OAuthErrorInvalidGrant)
+		})
+	}
+}
+
+func TestExchangeCode_RejectsCodeBeforePKCE(t *testing.T) {
+	code := helpers.GenerateSecureToken()
+	authCode := domain.NewAuthorizationCode(helpers.HashToken(code), testClientID, uuid.New(), testRedirectURI, []string{ScopeOpenID}, time.Now().Add(time.Minute))
+	authCode.SetCodeChallenge(testCodeChallenge, domain.CodeChallengeMethodS256)
+
+	tests := []struct {
+		name        string
+		code        string
+		redirectURI string
+		used        bool
+	}{
+		{name: "unknown code", code: "unknown", redirectURI: testRedirectURI},
+		{name: "other redirect uri", code: code, redirectURI: "https://other.example/callback"},
+		{name: "already exchanged", code: code, redirectURI: testRedirectURI, used: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCode.SetUsed(tt.used)
+			s := newTestOIDCService(map[string]*domain.AuthorizationCode{authCode.CodeHash(): authCode})
+
+			// Даже верный code_verifier не спасает неподходящий код
+			_, err := s.ExchangeCode(context.Background(), newTestOAuthClient(), tt.code, tt.redirectURI, testCodeVerifier, domain.ClientInfo{})
+			assertOAuthError(t, err, OAuthErrorInvalidGrant)
+		})
+	}
+}
+
+func TestIssueTokens_ClientTokenHasNoRoles(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	s := &OIDCService{jwtService: jwtService, logger: newTestLogger()}
+	user := newTestUser()
+	refreshToken := domain.NewRefreshToken(user.ID(), uuid.NewString(), time.Now().Add(time.Hour))
+	refreshToken.SetOAuthClient(testClientID, ScopeOpenID)
+
+	tokens, err := s.issueTokens(user, refreshToken, testClientID, "nonce")
+	if err != nil {
+		t.Fatalf("issue tokens: %v", err)
+	}
+
+	claims, err := jwtService.ValidateAccessToken(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !claims.IsClientToken() || claims.ClientID != testClientID {
+		t.Fatalf("client_id = %q, want %q", claims.ClientID, testClientID)
+	}
+	if len(claims.Roles) != 0 || claims.Permissions != "" {
+		t.Fatalf("client token must not carry roles or permissions, got %v %q", claims.Roles, claims.Permissions)
+	}
+}
+
+func TestIssueTokens_ClientTokenProfileClaimsFollowScope(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	s := &OIDCService{jwtService: jwtService, logger: newTestLogger()}
+	user := newTestUser()
+
+	tests := []struct {
+		name        string
+		scope       string
+		wantEmail   bool
+		wantProfile bool
+	}{
+		{"openid only", ScopeOpenID, false, false},
+		{"email scope", ScopeOpenID + " " + ScopeEmail, true, false},
+		{"profile scope", ScopeOpenID + " " + ScopeProfile, false, true},
+		{"all scopes", ScopeOpenID + " " + ScopeProfile + " " + ScopeEmail, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshToken := domain.NewRefreshToken(user.ID(), uuid.NewString(), time.Now().Add(time.Hour))
+			refreshToken.SetOAuthClient(testClientID, tt.scope)
+
+			tokens, err := s.issueTokens(user, refreshToken, testClientID, "nonce")
+			if err != nil {
+				t.Fatalf("issue tokens: %v", err)
+			}
+			claims, err := jwtService.ValidateAccessToken(tokens.AccessToken)
+			if err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+
+			if (claims.Email != "") != tt.wantEmail {
+				t.Fatalf("email = %q, want present = %v", claims.Email, tt.wantEmail)
+			}
+			if (claims.Username != "" || claims.DisplayName != "") != tt.wantProfile {
+				t.Fatalf("username = %q, display name = %q, want present = %v", claims.Username, claims.DisplayName, tt.wantProfile)
+			}
+		})
+	}
+}
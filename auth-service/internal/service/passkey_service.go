@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/password"
+	"social-network/auth-service/pkg/webauthn"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasskeyService регистрирует passkeys (WebAuthn) и выполняет вход по ним. Каждая церемония
+// состоит из двух шагов: begin выдает параметры для браузера и сохраняет challenge
+// в одноразовой сессии, finish проверяет ответ аутентификатора по этой сессии.
+type PasskeyService struct {
+	userRepo       repository.UserRepository
+	userAuthRepo   repository.UserAuthRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	sessionRepo    repository.WebAuthnSessionRepository
+	identityRepo   repository.UserIdentityRepository
+	relyingParty   *webauthn.RelyingParty
+	passwordHasher *password.Hasher
+	authService    *AuthService
+	audit          *AuditService
+	logger         logger.Logger
+}
+
+func NewPasskeyService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	sessionRepo repository.WebAuthnSessionRepository,
+	identityRepo repository.UserIdentityRepository,
+	relyingParty *webauthn.RelyingParty,
+	passwordHasher *password.Hasher,
+	authService *AuthService,
+	audit *AuditService,
+	logger logger.Logger,
+) *PasskeyService {
+	return &PasskeyService{
+		userRepo:       userRepo,
+		userAuthRepo:   userAuthRepo,
+		credentialRepo: credentialRepo,
+		sessionRepo:    sessionRepo,
+		identityRepo:   identityRepo,
+		relyingParty:   relyingParty,
+		passwordHasher: passwordHasher,
+		authService:    authService,
+		audit:          audit,
+		logger:         logger,
+	}
+}
+
+// BeginRegistration начинает регистрацию нового passkey для аутентифицированного пользователя
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (uuid.UUID, *webauthn.CredentialCreationOptions, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	credentials, err := s.credentialRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	exclude := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, webauthn.NewCredentialDescriptor(credential.CredentialID(), credential.Transports()))
+	}
+
+	session, err := s.createSession(ctx, &userID, domain.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	// user handle - ID пользователя; по нему discoverable ключ находит аккаунт при входе
+	userHandle := user.ID()
+	options := s.relyingParty.CreationOptions(session.Challenge(), webauthn.UserEntity{
+		ID:          userHandle[:],
+		Name:        user.Email(),
+		DisplayName: user.DisplayName(),
+	}, exclude)
+
+	return session.ID(), options, nil
+}
+
+// FinishRegistration проверяет ответ аутентификатора и сохраняет новый passkey
+func (s *PasskeyService) FinishRegistration(ctx context.Context, userID, sessionID uuid.UUID, name string, credentialJSON []byte) (*domain.WebAuthnCredential, error) {
+	session, err := s.sessionRepo.Consume(ctx, sessionID, domain.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if !session.BelongsTo(userID) {
+		return nil, repository.ErrWebAuthnSessionNotFound
+	}
+
+	response, err := webauthn.ParseRegistrationCredential(credentialJSON)
+	if err != nil {
+		return nil, s.verificationFailed(ctx, userID, err)
+	}
+
+	verified, err := s.relyingParty.VerifyRegistration(session.Challenge(), response)
+	if err != nil {
+		return nil, s.verificationFailed(ctx, userID, err)
+	}
+
+	credential := domain.NewWebAuthnCredential(userID, verified.ID, verified.PublicKey, verified.Algorithm, verified.SignCount)
+	credential.SetTransports(verified.Transports)
+	credential.SetAAGUID(verified.AAGUID)
+	credential.SetName(name)
+
+	if err := s.credentialRepo.Create(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Passkey registered",
+		logger.String("user_id", userID.String()),
+		logger.String("credential_id", credential.ID().String()),
+	)
+
+	s.audit.Record(ctx, domain.AuditPasskeyRegistered, &userID, &userID, map[string]string{
+		"credential_id": credential.ID().String(),
+		"name":          credential.Name(),
+	})
+
+	return credential, nil
+}
+
+// BeginLogin начинает вход по passkey. Список ключей не передается: браузер предлагает
+// discoverable ключи этого сайта, поэтому email не нужен и не раскрывается.
+func (s *PasskeyService) BeginLogin(ctx context.Context) (uuid.UUID, *webauthn.CredentialRequestOptions, error) {
+	session, err := s.createSession(ctx, nil, domain.WebAuthnCeremonyLogin)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return session.ID(), s.relyingParty.RequestOptions(session.Challenge(), nil), nil
+}
+
+// FinishLogin проверяет подпись аутентификатора и возвращает пользователя. Второй результат
+// сообщает, подтвердил ли аутентификатор личность пользователя (PIN, биометрия): без этого
+// passkey считается одним фактором и при включенной 2FA нужен второй.
+func (s *PasskeyService) FinishLogin(ctx context.Context, sessionID uuid.UUID, credentialJSON []byte) (*domain.User, bool, error) {
+	session, err := s.sessionRepo.Consume(ctx, sessionID, domain.WebAuthnCeremonyLogin)
+	if err != nil {
+		return nil, false, err
+	}
+
+	response, err := webauthn.ParseAssertionCredential(credentialJSON)
+	if err != nil {
+		return nil, false, s.loginFailed(ctx, uuid.Nil, err)
+	}
+
+	credential, err := s.credentialRepo.GetByCredentialID(ctx, response.RawID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebAuthnCredentialNotFound) {
+			return nil, false, ErrPasskeyVerificationFailed
+		}
+		return nil, false, err
+	}
+
+	// Ключ, на котором уже замечено клонирование, не принимается до повторной регистрации
+	if credential.CloneWarning() {
+		return nil, false, ErrPasskeyCloneDetected
+	}
+
+	assertion, err := s.relyingParty.VerifyAssertion(session.Challenge(), response, credential.PublicKey())
+	if err != nil {
+		return nil, false, s.loginFailed(ctx, credential.UserID(), err)
+	}
+
+	if len(assertion.UserHandle) > 0 {
+		if handle, err := uuid.FromBytes(assertion.UserHandle); err != nil || handle != credential.UserID() {
+			return nil, false, s.loginFailed(ctx, credential.UserID(), errors.New("user handle mismatch"))
+		}
+	}
+
+	if !credential.IsSignCountValid(assertion.SignCount) {
+		credential.SetCloneWarning(true)
+		if err := s.credentialRepo.Update(ctx, credential); err != nil {
+			return nil, false, err
+		}
+
+		s.logger.WithContext(ctx).Warn("Passkey sign counter did not increase, credential may be cloned",
+			logger.String("user_id", credential.UserID().String()),
+			logger.String("credential_id", credential.ID().String()),
+			logger.Int64("stored_sign_count", int64(credential.SignCount())),
+			logger.Int64("sign_count", int64(assertion.SignCount)),
+		)
+		return nil, false, ErrPasskeyCloneDetected
+	}
+
+	credential.RecordUse(assertion.SignCount)
+	if err := s.credentialRepo.Update(ctx, credential); err != nil {
+		return nil, false, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, credential.UserID())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !user.IsActive() {
+		return nil, false, ErrUserInactive
+	}
+
+	// Passkey с проверкой пользователя уже дает два фактора, без нее с 2FA вход
+	// завершается в CompleteMFAChallenge
+	if !assertion.UserVerified {
+		if err := s.authService.RecordFirstFactorLogin(ctx, user.ID(), "passkey"); err != nil {
+			return nil, false, err
+		}
+		return user, false, nil
+	}
+
+	s.authService.RecordLastLogin(ctx, user.ID())
+	s.audit.RecordLoginSucceeded(ctx, user.ID(), "passkey")
+	return user, true, nil
+}
+
+// ListCredentials возвращает passkeys пользователя
+func (s *PasskeyService) ListCredentials(ctx context.Context, userID uuid.UUID) ([]*domain.WebAuthnCredential, error) {
+	return s.credentialRepo.GetByUserID(ctx, userID)
+}
+
+// DeleteCredential удаляет passkey пользователя. Последний passkey аккаунта без пароля
+// и привязанных провайдеров удалить нельзя, иначе войти будет нечем.
+func (s *PasskeyService) DeleteCredential(ctx context.Context, userID, credentialID uuid.UUID) error {
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !userAuth.HasPassword() {
+		count, err := s.credentialRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		identities, err := s.identityRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if count <= 1 && identities == 0 {
+			return ErrLastSignInMethod
+		}
+	}
+
+	if err := s.credentialRepo.Delete(ctx, userID, credentialID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditPasskeyDeleted, &userID, &userID, map[string]string{
+		"credential_id": credentialID.String(),
+	})
+
+	return nil
+}
+
+// RemovePassword удаляет пароль, оставляя вход через passkeys или внешних провайдеров.
+// Требует текущий пароль и хотя бы один passkey или привязанного провайдера.
+func (s *PasskeyService) RemovePassword(ctx context.Context, userID uuid.UUID, currentPassword string) error {
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !userAuth.HasPassword() {
+		return ErrPasswordNotSet
+	}
+
+	if err := s.passwordHasher.Verify(userAuth.PasswordHash(), currentPassword); err != nil {
+		return ErrInvalidCurrentPassword
+	}
+
+	count, err := s.credentialRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := s.identityRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count == 0 && identities == 0 {
+		return ErrLastSignInMethod
+	}
+
+	userAuth.ChangePassword("", domain.PasswordChangeReasonRemoved)
+	if err := s.userAuthRepo.Update(ctx, userAuth); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Password removed, account uses passwordless sign-in only",
+		logger.String("user_id", userID.String()),
+	)
+
+	s.audit.Record(ctx, domain.AuditPasswordRemoved, &userID, &userID, nil)
+
+	return nil
+}
+
+// RunCleanup периодически удаляет незавершенные церемонии с истекшим challenge
+func (s *PasskeyService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.sessionRepo.DeleteExpired(ctx)
+			if err != nil {
+				s.logger.Error("Failed to delete expired webauthn sessions", logger.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Debug("Expired webauthn sessions deleted", logger.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+func (s *PasskeyService) createSession(ctx context.Context, userID *uuid.UUID, ceremony string) (*domain.WebAuthnSession, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	session := domain.NewWebAuthnSession(userID, challenge, ceremony, time.Now().Add(s.relyingParty.Timeout()))
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// verificationFailed логирует причину отказа и возвращает клиенту общую ошибку
+func (s *PasskeyService) verificationFailed(ctx context.Context, userID uuid.UUID, cause error) error {
+	s.logger.WithContext(ctx).Warn("Passkey verification failed",
+		logger.String("user_id", userID.String()),
+		logger.Error(cause),
+	)
+	return ErrPasskeyVerificationFailed
+}
+
+// loginFailed дополнительно записывает неудачный вход в журнал аудита
+func (s *PasskeyService) loginFailed(ctx context.Context, userID uuid.UUID, cause error) error {
+	var target *uuid.UUID
+	if userID != uuid.Nil {
+		target = &userID
+	}
+	s.audit.RecordLoginFailed(ctx, target, "passkey", "verification_failed", "")
+
+	return s.verificationFailed(ctx, userID, cause)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/webauthn"
+
+	"github.com/google/uuid"
+)
+
+const (
+	testPasskeyRPID   = "example.com"
+	testPasskeyOrigin = "https://example.com"
+)
+
+// passkeyCredentialStore - passkeys в памяти
+type passkeyCredentialStore struct {
+	repository.WebAuthnCredentialRepository
+
+	mu          sync.Mutex
+	credentials []*domain.WebAuthnCredential
+}
+
+func (s *passkeyCredentialStore) GetByCredentialID(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, credential := range s.credentials {
+		if bytes.Equal(credential.CredentialID(), credentialID) {
+			return credential, nil
+		}
+	}
+	return nil, repository.ErrWebAuthnCredentialNotFound
+}
+
+func (s *passkeyCredentialStore) Update(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	return nil
+}
+
+// passkeySessionStore - сессии церемоний в памяти. Consume удаляет сессию, как и запрос к базе.
+type passkeySessionStore struct {
+	repository.WebAuthnSessionRepository
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*domain.WebAuthnSession
+}
+
+func (s *passkeySessionStore) Create(ctx context.Context, session *domain.WebAuthnSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.ID()] = session
+	return nil
+}
+
+func (s *passkeySessionStore) Consume(ctx context.Context, id uuid.UUID, ceremony string) (*domain.WebAuthnSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok || session.Ceremony() != ceremony {
+		return nil, repository.ErrWebAuthnSessionNotFound
+	}
+	delete(s.sessions, id)
+	return session, nil
+}
+
+// passkeyAuthenticator - аутентификатор с ключом Ed25519, подписывающий ответы на вход
+type passkeyAuthenticator struct {
+	credentialID []byte
+	privateKey   ed25519.PrivateKey
+	coseKey      []byte
+}
+
+func newPasskeyAuthenticator(t *testing.T) *passkeyAuthenticator {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	credentialID := make([]byte, 16)
+	rand.Read(credentialID)
+
+	// COSE_Key {1: 1 (OKP), 3: -8 (EdDSA), -1: 6 (Ed25519), -2: x}
+	coseKey := append([]byte{0xa4, 0x01, 0x01, 0x03, 0x27, 0x20, 0x06, 0x21, 0x58, 0x20}, public...)
+
+	return &passkeyAuthenticator{credentialID: credentialID, privateKey: private, coseKey: coseKey}
+}
+
+// assert собирает подписанный ответ navigator.credentials.get() на challenge
+func (a *passkeyAuthenticator) assert(t *testing.T, challenge []byte, userID uuid.UUID, userVerified bool) []byte {
+	t.Helper()
+
+	clientDataJSON, err := json.Marshal(map[string]any{
+		"type":      "webauthn.get",
+		"challenge": webauthn.Base64URL(challenge),
+		"origin":    testPasskeyOrigin,
+	})
+	if err != nil {
+		t.Fatalf("marshal client data: %v", err)
+	}
+
+	// rpIdHash || flags (UP, UV) || signCount
+	rpIDHash := sha256.Sum256([]byte(testPasskeyRPID))
+	flags := byte(0x01)
+	if userVerified {
+		flags |= 0x04
+	}
+	authData := binary.BigEndian.AppendUint32(append(rpIDHash[:], flags), 1)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signature := ed25519.Sign(a.privateKey, append(bytes.Clone(authData), clientDataHash[:]...))
+
+	credential, err := json.Marshal(webauthn.AssertionCredential{
+		RawID: a.credentialID,
+		Type:  "public-key",
+		Response: webauthn.AssertionResponse{
+			ClientDataJSON:    clientDataJSON,
+			AuthenticatorData: authData,
+			Signature:         signature,
+			UserHandle:        userID[:],
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal assertion: %v", err)
+	}
+	return credential
+}
+
+func TestPasskeyService_FinishLogin_MFA(t *testing.T) {
+	tests := []struct {
+		name         string
+		userVerified bool
+		wantAudit    domain.AuditEventType
+		wantLogin    bool
+	}{
+		// Без проверки пользователя passkey - только первый фактор
+		{"user not verified", false, domain.AuditLoginMFARequired, false},
+		// PIN или биометрия аутентификатора уже дают второй фактор
+		{"user verified", true, domain.AuditLoginSucceeded, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			user := newTestUser()
+			authenticator := newPasskeyAuthenticator(t)
+
+			userAuths := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{user.ID(): domain.NewUserAuth(user.ID(), "")}}
+			mfa := domain.NewUserMFA(user.ID(), helpers.GenerateTOTPSecret())
+			mfa.Enable(0)
+
+			audit, recorder := newTestAuditService()
+			authService := &AuthService{
+				userAuthRepo: userAuths,
+				userMFARepo:  &userMFAStore{mfa: map[uuid.UUID]*domain.UserMFA{user.ID(): mfa}},
+				audit:        audit,
+				logger:       newTestLogger(),
+			}
+
+			relyingParty, err := webauthn.NewRelyingParty(webauthn.Config{
+				RPID:    testPasskeyRPID,
+				Origins: []string{testPasskeyOrigin},
+				Timeout: time.Minute,
+			})
+			if err != nil {
+				t.Fatalf("new relying party: %v", err)
+			}
+
+			credentials := &passkeyCredentialStore{credentials: []*domain.WebAuthnCredential{
+				domain.NewWebAuthnCredential(user.ID(), authenticator.credentialID, authenticator.coseKey, webauthn.AlgorithmEdDSA, 0),
+			}}
+			sessions := &passkeySessionStore{sessions: map[uuid.UUID]*domain.WebAuthnSession{}}
+			service := NewPasskeyService(
+				&userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}},
+				userAuths,
+				credentials,
+				sessions,
+				nil,
+				relyingParty,
+				nil,
+				authService,
+				audit,
+				newTestLogger(),
+			)
+
+			sessionID, options, err := service.BeginLogin(ctx)
+			if err != nil {
+				t.Fatalf("begin login: %v", err)
+			}
+
+			_, userVerified, err := service.FinishLogin(ctx, sessionID, authenticator.assert(t, options.Challenge, user.ID(), tt.userVerified))
+			if err != nil {
+				t.Fatalf("finish login: %v", err)
+			}
+			if userVerified != tt.userVerified {
+				t.Fatalf("userVerified = %v, want %v", userVerified, tt.userVerified)
+			}
+
+			if got := recorder.types(); !slices.Equal(got, []domain.AuditEventType{tt.wantAudit}) {
+				t.Fatalf("audit events = %v, want [%s]", got, tt.wantAudit)
+			}
+			if got := userAuths.userAuths[user.ID()].LastLoginAt() != nil; got != tt.wantLogin {
+				t.Fatalf("last login recorded = %v, want %v", got, tt.wantLogin)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/password"
+
+	"github.com/google/uuid"
+)
+
+// passwordHistoryLimit - сколько прежних паролей хранится для каждого пользователя.
+// Правило истории может проверять не больше этого числа.
+const passwordHistoryLimit = 24
+
+// Имена правил пароля, которые возвращаются клиенту в списке нарушений
+const (
+	PasswordRuleComplexity = "complexity"
+	PasswordRuleStrength   = "strength"
+	PasswordRuleBreached   = "breached"
+	PasswordRuleHistory    = "history"
+)
+
+// PasswordCandidate - новый пароль и то, что известно о его владельце
+type PasswordCandidate struct {
+	Password string
+
+	// UserID равен uuid.Nil при регистрации, когда истории паролей еще нет
+	UserID uuid.UUID
+
+	// CurrentPasswordHash - действующий хеш пароля, если пароль меняется
+	CurrentPasswordHash string
+
+	// UserInputs - email, username и имя, которые не должны помогать угадать пароль
+	UserInputs []string
+}
+
+// PasswordViolation - одно нарушенное правило
+type PasswordViolation struct {
+	Rule    string
+	Message string
+}
+
+// PasswordRule - шаг проверки нового пароля. Возвращает nil, если правило выполнено;
+// ошибка означает, что правило не удалось проверить.
+type PasswordRule interface {
+	Check(ctx context.Context, candidate PasswordCandidate) (*PasswordViolation, error)
+}
+
+// BreachedPasswordChecker проверяет пароль по базе утечек
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// complexityRule - длина и классы символов
+type complexityRule struct{}
+
+// NewComplexityRule требует 8-128 символов, заглавные и строчные буквы, цифру и спецсимвол
+func NewComplexityRule() PasswordRule {
+	return complexityRule{}
+}
+
+func (complexityRule) Check(ctx context.Context, candidate PasswordCandidate) (*PasswordViolation, error) {
+	if !helpers.ValidatePassword(candidate.Password) {
+		return &PasswordViolation{
+			Rule:    PasswordRuleComplexity,
+			Message: "password must be 8-128 characters long and contain upper and lower case letters, a digit and a special character",
+		}, nil
+	}
+	return nil, nil
+}
+
+// strengthRule - оценка числа попыток угадывания
+type strengthRule struct {
+	minScore int
+}
+
+// NewStrengthRule требует оценку стойкости не ниже minScore (0-4)
+func NewStrengthRule(minScore int) PasswordRule {
+	return strengthRule{minScore: minScore}
+}
+
+func (r strengthRule) Check(ctx context.Context, candidate PasswordCandidate) (*PasswordViolation, error) {
+	strength := password.EstimateStrength(candidate.Password, candidate.UserInputs...)
+	if strength.Score >= r.minScore {
+		return nil, nil
+	}
+
+	message := "password is too easy to guess"
+	if strength.Warning != "" {
+		message = fmt.Sprintf("%s: %s", message, strength.Warning)
+	}
+	return &PasswordViolation{Rule: PasswordRuleStrength, Message: message}, nil
+}
+
+// breachedRule - проверка по базе утекших паролей
+type breachedRule struct {
+	checker BreachedPasswordChecker
+}
+
+// NewBreachedRule отклоняет пароли, встречавшиеся в утечках
+func NewBreachedRule(checker BreachedPasswordChecker) PasswordRule {
+	return breachedRule{checker: checker}
+}
+
+func (r breachedRule) Check(ctx context.Context, candidate PasswordCandidate) (*PasswordViolation, error) {
+	breached, err := r.checker.IsBreached(ctx, candidate.Password)
+	if err != nil {
+		return nil, err
+	}
+	if breached {
+		return &PasswordViolation{
+			Rule:    PasswordRuleBreached,
+			Message: "password has appeared in a data breach",
+		}, nil
+	}
+	return nil, nil
+}
+
+// historyRule - запрет повторного использования последних паролей
+type historyRule struct {
+	repo   repository.PasswordHistoryRepository
+	hasher *password.Hasher
+	size   int
+}
+
+// NewHistoryRule отклоняет действующий пароль и size последних паролей пользователя
+// (не больше passwordHistoryLimit)
+func NewHistoryRule(repo repository.PasswordHistoryRepository, hasher *password.Hasher, size int) PasswordRule {
+	return historyRule{repo: repo, hasher: hasher, size: min(size, passwordHistoryLimit)}
+}
+
+func (r historyRule) Check(ctx context.Context, candidate PasswordCandidate) (*PasswordViolation, error) {
+	if candidate.UserID == uuid.Nil {
+		return nil, nil
+	}
+
+	hashes := []string{}
+	if candidate.CurrentPasswordHash != "" {
+		hashes = append(hashes, candidate.CurrentPasswordHash)
+	}
+
+	entries, err := r.repo.GetRecentByUserID(ctx, candidate.UserID, r.size)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		hashes = append(hashes, entry.PasswordHash())
+	}
+
+	// Хеш неизвестного формата просто не совпадает и не блокирует смену пароля
+	for _, hash := range hashes {
+		if r.hasher.Verify(hash, candidate.Password) == nil {
+			return &PasswordViolation{
+				Rule:    PasswordRuleHistory,
+				Message: fmt.Sprintf("password must differ from your last %d passwords", r.size),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenPrefix отличает персональные токены от JWT и помогает сканерам
+// секретов находить их в коде
+const PersonalAccessTokenPrefix = "snpat_"
+
+// TokenTypePersonalAccessToken - тип токена в ответе ValidateToken для персональных токенов
+const TokenTypePersonalAccessToken = "personal_access_token"
+
+// Scope персональных токенов: api:read разрешает только чтение (GET, HEAD, OPTIONS),
+// api:write - любые запросы
+const (
+	ScopeAPIRead  = "api:read"
+	ScopeAPIWrite = "api:write"
+)
+
+// PersonalAccessTokenScopes - scope, которые можно выдать персональному токену
+var PersonalAccessTokenScopes = []string{ScopeAPIRead, ScopeAPIWrite}
+
+const (
+	// personalAccessTokenVisibleChars - сколько символов секрета после префикса видно в списке токенов
+	personalAccessTokenVisibleChars = 8
+
+	// lastUsedUpdateInterval ограничивает запись времени использования, чтобы каждый запрос бота не обновлял строку
+	lastUsedUpdateInterval = time.Minute
+)
+
+// PersonalAccessTokenService выпускает и проверяет персональные токены для ботов и интеграций
+type PersonalAccessTokenService struct {
+	tokenRepo   repository.PersonalAccessTokenRepository
+	authService *AuthService
+	maxPerUser  int
+	maxLifetime time.Duration
+	logger      logger.Logger
+}
+
+func NewPersonalAccessTokenService(
+	tokenRepo repository.PersonalAccessTokenRepository,
+	authService *AuthService,
+	maxPerUser int,
+	maxLifetime time.Duration,
+	logger logger.Logger,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		tokenRepo:   tokenRepo,
+		authService: authService,
+		maxPerUser:  maxPerUser,
+		maxLifetime: maxLifetime,
+		logger:      logger,
+	}
+}
+
+// IsPersonalAccessToken проверяет формат токена без обращения к базе
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// CreateToken выпускает токен. Возвращает токен, который показывается пользователю один раз,
+// и сохраненную запись. expiresAt nil означает бессрочный токен, если конфигурация это разрешает.
+func (s *PersonalAccessTokenService) CreateToken(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (string, *domain.PersonalAccessToken, error) {
+	scopes = NormalizeScopes(strings.Join(scopes, " "))
+	if len(scopes) == 0 {
+		return "", nil, ErrInvalidTokenScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(PersonalAccessTokenScopes, scope) {
+			return "", nil, ErrInvalidTokenScope
+		}
+	}
+
+	if err := s.validateExpiry(expiresAt); err != nil {
+		return "", nil, err
+	}
+
+	count, err := s.tokenRepo.CountActiveByUserID(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if count >= s.maxPerUser {
+		return "", nil, ErrTokenLimitReached
+	}
+
+	token := PersonalAccessTokenPrefix + helpers.GenerateSecureToken()
+	prefix := token[:len(PersonalAccessTokenPrefix)+personalAccessTokenVisibleChars]
+
+	accessToken := domain.NewPersonalAccessToken(userID, name, prefix, helpers.HashToken(token), scopes, expiresAt)
+	if err := s.tokenRepo.Create(ctx, accessToken); err != nil {
+		return "", nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Personal access token created",
+		logger.String("user_id", userID.String()),
+		logger.String("token_id", accessToken.ID().String()),
+		logger.String("scope", strings.Join(scopes, " ")),
+	)
+
+	return token, accessToken, nil
+}
+
+// ListTokens возвращает неотозванные токены пользователя
+func (s *PersonalAccessTokenService) ListTokens(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	return s.tokenRepo.GetByUserID(ctx, userID)
+}
+
+// RevokeToken отзывает токен пользователя; следующий запрос с ним будет отклонен
+func (s *PersonalAccessTokenService) RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	if err := s.tokenRepo.Revoke(ctx, userID, tokenID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Personal access token revoked",
+		logger.String("user_id", userID.String()),
+		logger.String("token_id", tokenID.String()),
+	)
+
+	return nil
+}
+
+// Authenticate проверяет персональный токен и возвращает claims в том же виде, что и для
+// access токена. Роли и данные пользователя читаются из базы при каждой проверке, поэтому
+// смена ролей и блокировка действуют сразу. ipAddress пустой, если адрес клиента неизвестен.
+func (s *PersonalAccessTokenService) Authenticate(ctx context.Context, token, ipAddress string) (*AccessTokenClaims, error) {
+	if !IsPersonalAccessToken(token) {
+		return nil, ErrTokenInvalid
+	}
+
+	accessToken, err := s.tokenRepo.GetByTokenHash(ctx, helpers.HashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrPersonalAccessTokenNotFound) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, err
+	}
+
+	if accessToken.IsRevoked() {
+		return nil, ErrTokenRevoked
+	}
+	if accessToken.IsExpired() {
+		return nil, ErrTokenExpired
+	}
+
+	user, err := s.authService.GetUserByID(ctx, accessToken.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, ErrUserInactive
+	}
+
+	access, err := s.authService.GetUserAccess(ctx, user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordUse(ctx, accessToken, ipAddress)
+
+	claims := &AccessTokenClaims{
+		UserID:                user.ID(),
+		Email:                 user.Email(),
+		Username:              user.Username(),
+		DisplayName:           user.DisplayName(),
+		Roles:                 access.Roles,
+		Permissions:           domain.JoinPermissions(access.Permissions),
+		IsVerified:            user.IsVerified(),
+		Scope:                 strings.Join(accessToken.Scopes(), " "),
+		PersonalAccessTokenID: accessToken.ID(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       accessToken.ID().String(),
+			Subject:  user.ID().String(),
+			IssuedAt: jwt.NewNumericDate(accessToken.CreatedAt()),
+		},
+	}
+	if accessToken.ExpiresAt() != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*accessToken.ExpiresAt())
+	}
+
+	return claims, nil
+}
+
+func (s *PersonalAccessTokenService) validateExpiry(expiresAt *time.Time) error {
+	if expiresAt == nil {
+		if s.maxLifetime > 0 {
+			return ErrInvalidTokenExpiry
+		}
+		return nil
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return ErrInvalidTokenExpiry
+	}
+	if s.maxLifetime > 0 && expiresAt.After(now.Add(s.maxLifetime)) {
+		return ErrInvalidTokenExpiry
+	}
+
+	return nil
+}
+
+// recordUse сохраняет время и адрес использования не чаще lastUsedUpdateInterval.
+// Ошибка записи не мешает запросу.
+func (s *PersonalAccessTokenService) recordUse(ctx context.Context, token *domain.PersonalAccessToken, ipAddress string) {
+	if token.LastUsedAt() != nil && time.Since(*token.LastUsedAt()) < lastUsedUpdateInterval && (ipAddress == "" || ipAddress == token.LastUsedIP()) {
+		return
+	}
+
+	token.RecordUse(ipAddress)
+	if err := s.tokenRepo.UpdateLastUsed(ctx, token); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update personal access token usage",
+			logger.String("token_id", token.ID().String()),
+			logger.Error(err),
+		)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// personalAccessTokenStore - персональные токены в памяти
+type personalAccessTokenStore struct {
+	repository.PersonalAccessTokenRepository
+
+	mu     sync.Mutex
+	tokens map[uuid.UUID]*domain.PersonalAccessToken
+}
+
+func (s *personalAccessTokenStore) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.ID()] = token
+	return nil
+}
+
+func (s *personalAccessTokenStore) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, token := range s.tokens {
+		if token.TokenHash() == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrPersonalAccessTokenNotFound
+}
+
+func (s *personalAccessTokenStore) CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, token := range s.tokens {
+		if token.UserID() == userID && token.IsValid() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *personalAccessTokenStore) UpdateLastUsed(ctx context.Context, token *domain.PersonalAccessToken) error {
+	return nil
+}
+
+func (s *personalAccessTokenStore) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[id]
+	if !ok || token.UserID() != userID || token.IsRevoked() {
+		return repository.ErrPersonalAccessTokenNotFound
+	}
+	now := time.Now()
+	token.SetRevokedAt(&now)
+	return nil
+}
+
+func newTestPersonalAccessTokenService(user *domain.User, maxLifetime time.Duration) *PersonalAccessTokenService {
+	authService := &AuthService{
+		userRepo:     &userStore{users: map[uuid.UUID]*domain.User{user.ID(): user}},
+		userRoleRepo: &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{user.ID(): {domain.NewUserRole(user.ID(), domain.RoleUser)}}},
+		roleRepo:     newRoleStore(),
+		logger:       newTestLogger(),
+	}
+
+	return NewPersonalAccessTokenService(
+		&personalAccessTokenStore{tokens: map[uuid.UUID]*domain.PersonalAccessToken{}},
+		authService, 2, maxLifetime, newTestLogger(),
+	)
+}
+
+func TestPersonalAccessTokenService_CreateToken(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser()
+	inWeek := time.Now().Add(7 * 24 * time.Hour)
+	inYear := time.Now().Add(365 * 24 * time.Hour)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		scopes    []string
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"read token", []string{ScopeAPIRead}, &inWeek, nil},
+		{"no scopes", nil, &inWeek, ErrInvalidTokenScope},
+		{"unknown scope", []string{ScopeAPIRead, "admin"}, &inWeek, ErrInvalidTokenScope},
+		{"expiry in the past", []string{ScopeAPIRead}, &past, ErrInvalidTokenExpiry},
+		{"expiry beyond max lifetime", []string{ScopeAPIRead}, &inYear, ErrInvalidTokenExpiry},
+		{"no expiry when lifetime is limited", []string{ScopeAPIRead}, nil, ErrInvalidTokenExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestPersonalAccessTokenService(user, 90*24*time.Hour)
+
+			token, stored, err := s.CreateToken(ctx, user.ID(), "bot", tt.scopes, tt.expiresAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !IsPersonalAccessToken(token) || !strings.HasPrefix(token, stored.Prefix()) {
+				t.Fatalf("token %q must start with prefix %q", token, stored.Prefix())
+			}
+			if stored.TokenHash() == token || strings.Contains(stored.TokenHash(), token) {
+				t.Fatalf("token must be stored only as a hash")
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_CreateToken_Limit(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser()
+	s := newTestPersonalAccessTokenService(user, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := s.CreateToken(ctx, user.ID(), "bot", []string{ScopeAPIRead}, nil); err != nil {
+			t.Fatalf("create token %d: %v", i+1, err)
+		}
+	}
+	if _, _, err := s.CreateToken(ctx, user.ID(), "bot", []string{ScopeAPIRead}, nil); !errors.Is(err, ErrTokenLimitReached) {
+		t.Fatalf("expected ErrTokenLimitReached, got %v", err)
+	}
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser()
+	s := newTestPersonalAccessTokenService(user, 0)
+
+	token, stored, err := s.CreateToken(ctx, user.ID(), "bot", []string{ScopeAPIRead}, nil)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	claims, err := s.Authenticate(ctx, token, "192.0.2.1")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if claims.UserID != user.ID() || !claims.IsPersonalAccessToken() || claims.PersonalAccessTokenID != stored.ID() {
+		t.Fatalf("unexpected claims: user %v, token %v", claims.UserID, claims.PersonalAccessTokenID)
+	}
+	if !claims.HasScope(ScopeAPIRead) || claims.HasScope(ScopeAPIWrite) {
+		t.Fatalf("claims scope = %q, want %q", claims.Scope, ScopeAPIRead)
+	}
+	if stored.LastUsedIP() != "192.0.2.1" {
+		t.Fatalf("last used ip = %q", stored.LastUsedIP())
+	}
+
+	if _, err := s.Authenticate(ctx, "snpat_unknown", ""); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid for unknown token, got %v", err)
+	}
+	if _, err := s.Authenticate(ctx, "not-a-pat", ""); !errors.Is(err, ErrTokenInvalid) {
+		t.Fatalf("expected ErrTokenInvalid for token without prefix, got %v", err)
+	}
+
+	// Чужой токен отозвать нельзя
+	if err := s.RevokeToken(ctx, uuid.New(), stored.ID()); err == nil {
+		t.Fatalf("token of another user must not be revoked")
+	}
+	if err := s.RevokeToken(ctx, user.ID(), stored.ID()); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, token, ""); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+}
+
+func TestPersonalAccessTokenService_Authenticate_InactiveUser(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser()
+	s := newTestPersonalAccessTokenService(user, 0)
+
+	token, _, err := s.CreateToken(ctx, user.ID(), "bot", []string{ScopeAPIWrite}, nil)
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	user.SetActive(false)
+	if _, err := s.Authenticate(ctx, token, ""); !errors.Is(err, ErrUserInactive) {
+		t.Fatalf("expected ErrUserInactive, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+)
+
+// RoleService управляет определениями ролей. Изменение прав роли попадает в токены
+// пользователей при следующем обновлении access токена.
+type RoleService struct {
+	roleRepo     repository.RoleRepository
+	userRoleRepo repository.UserRoleRepository
+	logger       logger.Logger
+}
+
+func NewRoleService(roleRepo repository.RoleRepository, userRoleRepo repository.UserRoleRepository, logger logger.Logger) *RoleService {
+	return &RoleService{
+		roleRepo:     roleRepo,
+		userRoleRepo: userRoleRepo,
+		logger:       logger,
+	}
+}
+
+// ListRoles возвращает все роли: сначала системные, затем остальные по имени
+func (s *RoleService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+func (s *RoleService) GetRole(ctx context.Context, name domain.UserRoleType) (*domain.Role, error) {
+	return s.roleRepo.GetByName(ctx, name)
+}
+
+// CreateRole создает роль с набором прав
+func (s *RoleService) CreateRole(ctx context.Context, name domain.UserRoleType, description string, permissions []domain.Permission) (*domain.Role, error) {
+	if !domain.IsValidRoleName(name) {
+		return nil, ErrInvalidRoleName
+	}
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	role := domain.NewRole(name, description, permissions)
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Role created",
+		logger.String("role", string(name)),
+		logger.String("permissions", domain.JoinPermissions(role.Permissions())),
+	)
+
+	return role, nil
+}
+
+// UpdateRole заменяет описание и права роли, в том числе системной
+func (s *RoleService) UpdateRole(ctx context.Context, name domain.UserRoleType, description string, permissions []domain.Permission) (*domain.Role, error) {
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Update(description, permissions)
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Role updated",
+		logger.String("role", string(name)),
+		logger.String("permissions", domain.JoinPermissions(role.Permissions())),
+	)
+
+	return role, nil
+}
+
+// DeleteRole удаляет роль, которая никому не назначена. Системные роли удалить нельзя.
+func (s *RoleService) DeleteRole(ctx context.Context, name domain.UserRoleType) error {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem() {
+		return ErrSystemRole
+	}
+
+	assigned, err := s.userRoleRepo.CountActiveByRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if assigned > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := s.roleRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Role deleted", logger.String("role", string(name)))
+
+	return nil
+}
+
+func validatePermissions(permissions []domain.Permission) error {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return ErrInvalidPermission
+		}
+	}
+	return nil
+}
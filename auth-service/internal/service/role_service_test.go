@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// roleStore - определения ролей в памяти
+type roleStore struct {
+	repository.RoleRepository
+	roles map[domain.UserRoleType]*domain.Role
+}
+
+// newRoleStore возвращает хранилище с системными ролями, как после миграции
+func newRoleStore() *roleStore {
+	s := &roleStore{roles: map[domain.UserRoleType]*domain.Role{}}
+	for _, role := range []*domain.Role{
+		domain.NewRole(domain.RoleUser, "Regular user", nil),
+		domain.NewRole(domain.RoleModerator, "Removes posts and comments",
+			[]domain.Permission{domain.PermissionPostsDelete, domain.PermissionCommentsDelete}),
+		domain.NewRole(domain.RoleAdmin, "Full access", domain.KnownPermissions),
+	} {
+		role.SetSystem(true)
+		s.roles[role.Name()] = role
+	}
+	return s
+}
+
+func (s *roleStore) Create(ctx context.Context, role *domain.Role) error {
+	if _, ok := s.roles[role.Name()]; ok {
+		return repository.ErrRoleAlreadyExists
+	}
+	s.roles[role.Name()] = role
+	return nil
+}
+
+func (s *roleStore) GetByName(ctx context.Context, name domain.UserRoleType) (*domain.Role, error) {
+	if role, ok := s.roles[name]; ok {
+		return role, nil
+	}
+	return nil, repository.ErrRoleNotFound
+}
+
+func (s *roleStore) GetByNames(ctx context.Context, names []domain.UserRoleType) ([]*domain.Role, error) {
+	var roles []*domain.Role
+	for _, name := range names {
+		if role, ok := s.roles[name]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func (s *roleStore) Update(ctx context.Context, role *domain.Role) error {
+	s.roles[role.Name()] = role
+	return nil
+}
+
+func (s *roleStore) Delete(ctx context.Context, name domain.UserRoleType) error {
+	delete(s.roles, name)
+	return nil
+}
+
+func TestAuthService_GetUserAccess(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	revoked := domain.NewUserRole(userID, domain.RoleAdmin)
+	revoked.SetActive(false)
+
+	tests := []struct {
+		name            string
+		userRoles       []*domain.UserRole
+		wantRoles       []domain.UserRoleType
+		wantPermissions []domain.Permission
+	}{
+		{
+			name:      "role without permissions",
+			userRoles: []*domain.UserRole{domain.NewUserRole(userID, domain.RoleUser)},
+			wantRoles: []domain.UserRoleType{domain.RoleUser},
+		},
+		{
+			name:            "permissions of several roles are merged",
+			userRoles:       []*domain.UserRole{domain.NewUserRole(userID, domain.RoleUser), domain.NewUserRole(userID, domain.RoleModerator)},
+			wantRoles:       []domain.UserRoleType{domain.RoleUser, domain.RoleModerator},
+			wantPermissions: []domain.Permission{domain.PermissionCommentsDelete, domain.PermissionPostsDelete},
+		},
+		{
+			name:      "inactive role grants nothing",
+			userRoles: []*domain.UserRole{domain.NewUserRole(userID, domain.RoleUser), revoked},
+			wantRoles: []domain.UserRoleType{domain.RoleUser},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AuthService{
+				userRoleRepo: &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{userID: tt.userRoles}},
+				roleRepo:     newRoleStore(),
+				logger:       newTestLogger(),
+			}
+
+			access, err := s.GetUserAccess(ctx, userID)
+			if err != nil {
+				t.Fatalf("GetUserAccess() error = %v", err)
+			}
+			if !slices.Equal(access.Roles, tt.wantRoles) {
+				t.Fatalf("roles = %v, want %v", access.Roles, tt.wantRoles)
+			}
+			if !slices.Equal(access.Permissions, tt.wantPermissions) {
+				t.Fatalf("permissions = %v, want %v", access.Permissions, tt.wantPermissions)
+			}
+		})
+	}
+}
+
+// roleChangeStore - история изменений ролей в памяти
+type roleChangeStore struct {
+	repository.RoleChangeRepository
+	changes []*domain.RoleChange
+}
+
+func (s *roleChangeStore) Create(ctx context.Context, change *domain.RoleChange) error {
+	s.changes = append(s.changes, change)
+	return nil
+}
+
+type roleGrantTest struct {
+	service   *AuthService
+	userRoles *userRoleStore
+	changes   *roleChangeStore
+	audit     *auditRecorder
+	admin     uuid.UUID
+	moderator uuid.UUID
+	target    uuid.UUID
+}
+
+func newRoleGrantTest(t *testing.T) *roleGrantTest {
+	t.Helper()
+
+	rt := &roleGrantTest{admin: uuid.New(), moderator: uuid.New(), target: uuid.New()}
+	rt.userRoles = &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{
+		rt.admin:     {domain.NewUserRole(rt.admin, domain.RoleAdmin)},
+		rt.moderator: {domain.NewUserRole(rt.moderator, domain.RoleModerator)},
+		rt.target:    {domain.NewUserRole(rt.target, domain.RoleUser)},
+	}}
+	rt.changes = &roleChangeStore{}
+
+	revocation, _ := newTestTokenRevocationService(t)
+	audit, recorder := newTestAuditService()
+	rt.audit = recorder
+	rt.service = &AuthService{
+		userRoleRepo:    rt.userRoles,
+		roleRepo:        newRoleStore(),
+		roleChangeRepo:  rt.changes,
+		tokenRevocation: revocation,
+		audit:           audit,
+		txManager:       &inlineTxManager{repos: repository.Repositories{UserRoles: rt.userRoles, RoleChanges: rt.changes}},
+		logger:          newTestLogger(),
+	}
+	return rt
+}
+
+func TestAuthService_AssignRole(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	inDay := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		role      domain.UserRoleType
+		actor     func(rt *roleGrantTest) uuid.UUID
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"admin grants moderator", domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, nil, nil},
+		{"temporary role", domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, &inDay, nil},
+		{"moderator grants moderator", domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.moderator }, nil, nil},
+		{"moderator grants admin", domain.RoleAdmin, func(rt *roleGrantTest) uuid.UUID { return rt.moderator }, nil, ErrRoleNotGrantable},
+		{"user grants moderator", domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.target }, nil, ErrRoleNotGrantable},
+		{"role already granted", domain.RoleUser, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, nil, repository.ErrUserRoleAlreadyExists},
+		{"unknown role", "support", func(rt *roleGrantTest) uuid.UUID { return rt.admin }, nil, repository.ErrInvalidRole},
+		{"expiry in the past", domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, &past, ErrInvalidRoleExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newRoleGrantTest(t)
+			actor := tt.actor(rt)
+
+			err := rt.service.AssignRole(context.Background(), RoleGrant{
+				UserID: rt.target, Role: tt.role, ActorID: actor, Reason: "on call", ExpiresAt: tt.expiresAt,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssignRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(rt.changes.changes) != 0 {
+					t.Fatalf("denied grant must not be recorded in history")
+				}
+				return
+			}
+
+			if hasRole, _ := rt.service.HasRole(context.Background(), rt.target, tt.role); !hasRole {
+				t.Fatalf("role %q must be granted", tt.role)
+			}
+			if len(rt.changes.changes) != 1 {
+				t.Fatalf("history entries = %d, want 1", len(rt.changes.changes))
+			}
+			change := rt.changes.changes[0]
+			if change.Action() != domain.RoleChangeGranted || change.ActorID() == nil || *change.ActorID() != actor || change.Reason() != "on call" {
+				t.Fatalf("unexpected history entry: %+v", change)
+			}
+			if got := rt.audit.types(); !slices.Equal(got, []domain.AuditEventType{domain.AuditRoleGranted}) {
+				t.Fatalf("audit events = %v", got)
+			}
+		})
+	}
+}
+
+func TestAuthService_AssignRole_RegrantsExpiredRole(t *testing.T) {
+	rt := newRoleGrantTest(t)
+	ctx := context.Background()
+
+	expired := domain.NewRoleGrant(rt.target, domain.RoleModerator, rt.admin, "", nil)
+	expiresAt := time.Now().Add(-time.Minute)
+	expired.SetExpiresAt(&expiresAt)
+	rt.userRoles.roles[rt.target] = append(rt.userRoles.roles[rt.target], expired)
+
+	if hasRole, _ := rt.service.HasRole(ctx, rt.target, domain.RoleModerator); hasRole {
+		t.Fatalf("expired role must not grant access before the background job runs")
+	}
+
+	if err := rt.service.AssignRole(ctx, RoleGrant{UserID: rt.target, Role: domain.RoleModerator, ActorID: rt.admin}); err != nil {
+		t.Fatalf("regrant expired role: %v", err)
+	}
+	if expired.IsActive() {
+		t.Fatalf("expired role must be deactivated")
+	}
+	if len(rt.changes.changes) != 2 || rt.changes.changes[0].Action() != domain.RoleChangeExpired || rt.changes.changes[1].Action() != domain.RoleChangeGranted {
+		t.Fatalf("history must record the expiry and the new grant, got %d entries", len(rt.changes.changes))
+	}
+}
+
+func TestAuthService_RevokeRole(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		granted domain.UserRoleType
+		role    domain.UserRoleType
+		actor   func(rt *roleGrantTest) uuid.UUID
+		wantErr error
+	}{
+		{"admin revokes moderator", domain.RoleModerator, domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, nil},
+		{"moderator revokes moderator", domain.RoleModerator, domain.RoleModerator, func(rt *roleGrantTest) uuid.UUID { return rt.moderator }, nil},
+		{"moderator revokes admin", domain.RoleAdmin, domain.RoleAdmin, func(rt *roleGrantTest) uuid.UUID { return rt.moderator }, ErrRoleNotGrantable},
+		{"role not granted", domain.RoleModerator, domain.RoleAdmin, func(rt *roleGrantTest) uuid.UUID { return rt.admin }, repository.ErrUserRoleNotFound},
+		{"unknown role", domain.RoleModerator, "support", func(rt *roleGrantTest) uuid.UUID { return rt.admin }, repository.ErrUserRoleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := newRoleGrantTest(t)
+			granted := domain.NewUserRole(rt.target, tt.granted)
+			rt.userRoles.roles[rt.target] = append(rt.userRoles.roles[rt.target], granted)
+
+			err := rt.service.RevokeRole(ctx, rt.target, tt.role, tt.actor(rt), "rotation")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevokeRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !granted.IsActive() || len(rt.changes.changes) != 0 || len(rt.audit.types()) != 0 {
+					t.Fatalf("failed revoke must not change the role")
+				}
+				return
+			}
+			if granted.IsActive() || len(rt.changes.changes) != 1 || rt.changes.changes[0].Action() != domain.RoleChangeRevoked {
+				t.Fatalf("role must be revoked and recorded in history")
+			}
+		})
+	}
+}
+
+func TestAuthService_ExpireRoles(t *testing.T) {
+	rt := newRoleGrantTest(t)
+
+	expiresAt := time.Now().Add(-time.Minute)
+	expired := domain.NewRoleGrant(rt.target, domain.RoleModerator, rt.admin, "incident", &expiresAt)
+	inDay := time.Now().Add(24 * time.Hour)
+	temporary := domain.NewRoleGrant(rt.target, domain.RoleAdmin, rt.admin, "", &inDay)
+	rt.userRoles.roles[rt.target] = append(rt.userRoles.roles[rt.target], expired, temporary)
+
+	count, err := rt.service.ExpireRoles(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireRoles() error = %v", err)
+	}
+	if count != 1 || expired.IsActive() || !temporary.IsActive() {
+		t.Fatalf("only the expired role must be deactivated, count = %d", count)
+	}
+	if len(rt.changes.changes) != 1 || rt.changes.changes[0].Action() != domain.RoleChangeExpired || rt.changes.changes[0].ActorID() != nil {
+		t.Fatalf("expiry must be recorded as a system change")
+	}
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        domain.UserRoleType
+		permissions []domain.Permission
+		wantErr     error
+	}{
+		{"custom role", "support", []domain.Permission{domain.PermissionUsersRead, "tickets.close"}, nil},
+		{"invalid name", "Support Team", nil, ErrInvalidRoleName},
+		{"invalid permission", "support", []domain.Permission{"users"}, ErrInvalidPermission},
+		{"existing role", domain.RoleAdmin, nil, repository.ErrRoleAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := newRoleStore()
+			s := NewRoleService(roles, &userRoleStore{}, newTestLogger())
+
+			role, err := s.CreateRole(context.Background(), tt.role, "", tt.permissions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (role.IsSystem() || roles.roles[tt.role] != role) {
+				t.Fatalf("custom role must be stored as a non-system role")
+			}
+		})
+	}
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	ctx := context.Background()
+	s := NewRoleService(newRoleStore(), &userRoleStore{}, newTestLogger())
+
+	// Права системной роли можно менять
+	role, err := s.UpdateRole(ctx, domain.RoleUser, "Regular user", []domain.Permission{"posts.create"})
+	if err != nil {
+		t.Fatalf("update system role: %v", err)
+	}
+	if !role.HasPermission("posts.create") {
+		t.Fatalf("permissions = %v", role.Permissions())
+	}
+
+	if _, err := s.UpdateRole(ctx, domain.RoleUser, "", []domain.Permission{"Posts.Create"}); !errors.Is(err, ErrInvalidPermission) {
+		t.Fatalf("expected ErrInvalidPermission, got %v", err)
+	}
+	if _, err := s.UpdateRole(ctx, "support", "", nil); !errors.Is(err, repository.ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound, got %v", err)
+	}
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	userID := uuid.New()
+
+	revoked := domain.NewUserRole(userID, "support")
+	revoked.SetActive(false)
+
+	tests := []struct {
+		name      string
+		role      domain.UserRoleType
+		userRoles []*domain.UserRole
+		wantErr   error
+	}{
+		{"unused custom role", "support", nil, nil},
+		{"role with revoked assignments", "support", []*domain.UserRole{revoked}, nil},
+		{"role assigned to a user", "support", []*domain.UserRole{domain.NewUserRole(userID, "support")}, ErrRoleInUse},
+		{"system role", domain.RoleModerator, nil, ErrSystemRole},
+		{"unknown role", "unknown", nil, repository.ErrRoleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := newRoleStore()
+			roles.roles["support"] = domain.NewRole("support", "Support", []domain.Permission{domain.PermissionUsersRead})
+			s := NewRoleService(roles, &userRoleStore{roles: map[uuid.UUID][]*domain.UserRole{userID: tt.userRoles}}, newTestLogger())
+
+			err := s.DeleteRole(context.Background(), tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, exists := roles.roles[tt.role]; err == nil && exists {
+				t.Fatalf("role %q must be deleted", tt.role)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"strings"
+	"time"
+)
 
 // Authentication Errors
 var (
@@ -27,6 +31,158 @@ var (
 
 	// ErrTokenRevoked is returned when token has been revoked
 	ErrTokenRevoked = errors.New("token has been revoked")
+
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+// OAuth Client Errors
+var (
+	// ErrInvalidClient is returned when client authentication fails
+	ErrInvalidClient = errors.New("invalid client credentials")
+
+	// ErrClientScopeNotAllowed is returned when the client lacks the scope required by the endpoint
+	ErrClientScopeNotAllowed = errors.New("client is not allowed to perform this operation")
+)
+
+// OpenID Connect Errors
+var (
+	// ErrInvalidRedirectURI is returned when the redirect_uri is not registered for the client; such requests are never redirected back
+	ErrInvalidRedirectURI = errors.New("redirect uri is not registered for the client")
+
+	// ErrClientTokenNotAllowed is returned when an access token issued to an OAuth client is used where only first-party tokens are accepted
+	ErrClientTokenNotAllowed = errors.New("access token issued to a client cannot be used here")
+)
+
+// Коды ошибок OAuth 2.0 (RFC 6749, разделы 4.1.2.1 и 5.2)
+const (
+	OAuthErrorInvalidRequest          = "invalid_request"
+	OAuthErrorInvalidClient           = "invalid_client"
+	OAuthErrorInvalidGrant            = "invalid_grant"
+	OAuthErrorInvalidScope            = "invalid_scope"
+	OAuthErrorUnauthorizedClient      = "unauthorized_client"
+	OAuthErrorAccessDenied            = "access_denied"
+	OAuthErrorUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrorUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrorServerError             = "server_error"
+
+	// OAuthErrorInvalidTarget - запрошенный audience не разрешен клиенту (RFC 8707)
+	OAuthErrorInvalidTarget = "invalid_target"
+)
+
+// Session Errors
+var (
+	// ErrSessionNotFound is returned when the session does not exist, is already revoked or belongs to another user
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// MFA Errors
+var (
+	// ErrMFAAlreadyEnabled is returned when trying to enroll a user who already has two-factor authentication enabled
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	// ErrMFANotEnrolled is returned when confirming two-factor authentication that was never started
+	ErrMFANotEnrolled = errors.New("two-factor authentication enrollment not found")
+
+	// ErrMFANotEnabled is returned when a second factor is checked for a user without two-factor authentication
+	ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+
+	// ErrInvalidMFACode is returned when the TOTP or recovery code is wrong, expired or already used
+	ErrInvalidMFACode = errors.New("invalid two-factor authentication code")
+
+	// ErrMFAChallengeInvalid is returned when an MFA challenge token has no jti or was already exchanged for tokens
+	ErrMFAChallengeInvalid = errors.New("mfa challenge is invalid or already used")
+)
+
+// Passkey Errors
+var (
+	// ErrPasskeyVerificationFailed is returned when the authenticator response does not match the ceremony or its signature is invalid
+	ErrPasskeyVerificationFailed = errors.New("passkey verification failed")
+
+	// ErrPasskeyCloneDetected is returned when the signature counter did not increase, which indicates a cloned authenticator
+	ErrPasskeyCloneDetected = errors.New("passkey signature counter did not increase")
+
+	// ErrPasswordNotSet is returned when removing a password from an account that has none
+	ErrPasswordNotSet = errors.New("password is not set")
+
+	// ErrLastSignInMethod is returned when removing the only remaining password or passkey
+	ErrLastSignInMethod = errors.New("cannot remove the last sign-in method")
+)
+
+// Social Login Errors
+var (
+	// ErrSocialLoginFailed is returned when the provider response cannot be exchanged or its ID token does not verify
+	ErrSocialLoginFailed = errors.New("external sign-in failed")
+
+	// ErrExternalEmailRequired is returned when a new account would be created but the provider did not share an email address
+	ErrExternalEmailRequired = errors.New("external account has no email address")
+
+	// ErrExternalAccountConflict is returned when an account with the same email exists but cannot be linked automatically
+	ErrExternalAccountConflict = errors.New("an account with this email already exists, sign in and link the provider")
+
+	// ErrIdentityLinkedToAnotherUser is returned when linking an external account that already belongs to another user
+	ErrIdentityLinkedToAnotherUser = errors.New("external account is linked to another user")
+)
+
+// Personal Access Token Errors
+var (
+	// ErrInvalidTokenScope is returned when a personal access token is requested without scopes or with an unknown scope
+	ErrInvalidTokenScope = errors.New("invalid personal access token scope")
+
+	// ErrInvalidTokenExpiry is returned when a personal access token expiry is in the past or beyond the allowed lifetime
+	ErrInvalidTokenExpiry = errors.New("invalid personal access token expiry")
+
+	// ErrTokenLimitReached is returned when the user already has the maximum number of active personal access tokens
+	ErrTokenLimitReached = errors.New("personal access token limit reached")
+)
+
+// Role Errors
+var (
+	// ErrInvalidRoleName is returned when a role name is not lowercase letters, digits, - or _
+	ErrInvalidRoleName = errors.New("invalid role name")
+
+	// ErrInvalidPermission is returned when a permission is not in the <resource>.<action> format
+	ErrInvalidPermission = errors.New("invalid permission")
+
+	// ErrSystemRole is returned when trying to delete one of the built-in roles
+	ErrSystemRole = errors.New("system roles cannot be deleted")
+
+	// ErrRoleInUse is returned when deleting a role that is still assigned to users
+	ErrRoleInUse = errors.New("role is assigned to users")
+
+	// ErrRoleNotGrantable is returned when the actor lacks some of the permissions of the role
+	// being granted or revoked
+	ErrRoleNotGrantable = errors.New("role grants permissions the actor does not have")
+
+	// ErrInvalidRoleExpiry is returned when a temporary role expires in the past
+	ErrInvalidRoleExpiry = errors.New("role expiry must be in the future")
+)
+
+// Pagination Errors
+var (
+	// ErrInvalidCursor is returned when a page cursor is malformed
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// User Administration Errors
+var (
+	// ErrUserAlreadySuspended is returned when suspending a user who is already suspended
+	ErrUserAlreadySuspended = errors.New("user is already suspended")
+
+	// ErrUserNotSuspended is returned when reactivating a user who is active
+	ErrUserNotSuspended = errors.New("user is not suspended")
+
+	// ErrCannotSuspendSelf is returned when an administrator tries to suspend their own account
+	ErrCannotSuspendSelf = errors.New("cannot suspend your own account")
+
+	// ErrUserOutranksActor is returned when suspending or impersonating a user who has permissions the actor does not have
+	ErrUserOutranksActor = errors.New("user has permissions the actor does not have")
+
+	// ErrCannotImpersonateSelf is returned when an administrator tries to impersonate their own account
+	ErrCannotImpersonateSelf = errors.New("cannot impersonate your own account")
+
+	// ErrInvalidSuspensionEnd is returned when a temporary suspension ends in the past
+	ErrInvalidSuspensionEnd = errors.New("suspension end must be in the future")
 )
 
 // Permission Errors
@@ -63,4 +219,54 @@ var (
 
 	// ErrTooManyAttempts is returned when too many failed attempts are made
 	ErrTooManyAttempts = errors.New("too many failed attempts")
+
+	// ErrAccountLocked is returned when login is temporarily locked after repeated failures
+	ErrAccountLocked = errors.New("account is temporarily locked")
 )
+
+// AccountLockedError is returned instead of the bare ErrAccountLocked and carries
+// how long the client has to wait. errors.Is(err, ErrAccountLocked) matches it.
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// PasswordPolicyError is returned instead of the bare ErrPasswordTooWeak and lists every
+// rule the new password failed. errors.Is(err, ErrPasswordTooWeak) matches it.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return ErrPasswordTooWeak.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordTooWeak
+}
+
+// OAuthError is returned by the OpenID Connect flows and carries the RFC 6749 error code
+// that is sent to the client as is.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
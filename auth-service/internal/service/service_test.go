@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/keys"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const testIssuer = "https://auth.test"
+
+// staticKeyProvider подписывает токены одним ключом, сгенерированным для теста
+type staticKeyProvider struct {
+	key *keys.Key
+}
+
+func (p *staticKeyProvider) SigningKey() (*keys.Key, error) {
+	return p.key, nil
+}
+
+func (p *staticKeyProvider) VerificationKey(id string) (*keys.Key, error) {
+	if id != p.key.ID() {
+		return nil, ErrTokenInvalid
+	}
+	return p.key, nil
+}
+
+func (p *staticKeyProvider) JWKS() keys.JWKSet {
+	return keys.JWKSet{Keys: []keys.JWK{p.key.JWK()}}
+}
+
+func newTestKeyProvider(t *testing.T) *staticKeyProvider {
+	t.Helper()
+
+	key, err := keys.GenerateEd25519Key("test-key")
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return &staticKeyProvider{key: key}
+}
+
+func newTestJWTService(t *testing.T) *JWTService {
+	t.Helper()
+
+	return NewJWTService(newTestKeyProvider(t), []byte("test-refresh-secret"), testIssuer)
+}
+
+func newTestLogger() logger.Logger {
+	return logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+}
+
+func newTestUser() *domain.User {
+	return domain.NewUser("alice@example.com", "alice", "Alice")
+}
+
+func testUserAccess() *UserAccess {
+	return &UserAccess{
+		Roles:       []domain.UserRoleType{domain.RoleUser},
+		Permissions: []domain.Permission{domain.PermissionPostsDelete},
+	}
+}
+
+// waitForSecondStart ждет начала следующей секунды, чтобы последующие шаги теста
+// гарантированно уложились в одну секунду
+func waitForSecondStart() {
+	now := time.Now()
+	time.Sleep(now.Truncate(time.Second).Add(time.Second).Sub(now) + 5*time.Millisecond)
+}
+
+// auditRecorder сохраняет события аудита в памяти, чтобы тест мог проверить, что записано
+type auditRecorder struct {
+	mu     sync.Mutex
+	events []*domain.AuditEvent
+}
+
+func (r *auditRecorder) Create(ctx context.Context, event *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+// List выбирает события так же, как postgres: от новых к старым, после курсора
+func (r *auditRecorder) List(ctx context.Context, filter repository.AuditEventFilter) ([]*domain.AuditEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := make([]*domain.AuditEvent, 0, len(r.events))
+	for _, event := range r.events {
+		if auditFilterMatches(filter, event) {
+			events = append(events, event)
+		}
+	}
+	slices.SortFunc(events, func(a, b *domain.AuditEvent) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID().String(), a.ID().String())
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+func auditFilterMatches(filter repository.AuditEventFilter, event *domain.AuditEvent) bool {
+	if filter.UserID != nil && !sameUser(event.ActorID(), *filter.UserID) && !sameUser(event.TargetID(), *filter.UserID) {
+		return false
+	}
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, event.Type()) {
+		return false
+	}
+	if filter.From != nil && event.CreatedAt().Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !event.CreatedAt().Before(*filter.To) {
+		return false
+	}
+	if after := filter.After; after != nil {
+		if c := event.CreatedAt().Compare(after.CreatedAt); c > 0 || c == 0 && event.ID().String() >= after.ID.String() {
+			return false
+		}
+	}
+	return true
+}
+
+func sameUser(id *uuid.UUID, userID uuid.UUID) bool {
+	return id != nil && *id == userID
+}
+
+// types возвращает типы записанных событий по порядку
+func (r *auditRecorder) types() []domain.AuditEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types := make([]domain.AuditEventType, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type())
+	}
+	return types
+}
+
+func newTestAuditService() (*AuditService, *auditRecorder) {
+	recorder := &auditRecorder{}
+	return NewAuditService(recorder, newTestLogger()), recorder
+}
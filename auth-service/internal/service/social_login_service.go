@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/oidc"
+
+	"github.com/google/uuid"
+)
+
+// usernameAttempts - сколько вариантов username перебирается для нового пользователя
+const usernameAttempts = 5
+
+// SocialLoginService выполняет вход через внешних провайдеров OpenID Connect и привязку
+// их учетных записей к аккаунтам. Как и у passkeys, каждая операция состоит из двух шагов:
+// begin возвращает адрес провайдера и сохраняет state, finish принимает code и state,
+// с которыми провайдер вернул браузер.
+//
+// Правила входа:
+//   - учетная запись провайдера уже привязана - вход в привязанный аккаунт;
+//   - аккаунт с тем же email существует - привязка, только если email подтвержден
+//     и провайдером, и у нас; иначе пользователь должен войти и привязать провайдера сам;
+//   - иначе создается новый аккаунт без пароля.
+type SocialLoginService struct {
+	userRepo       repository.UserRepository
+	userAuthRepo   repository.UserAuthRepository
+	identityRepo   repository.UserIdentityRepository
+	stateRepo      repository.SocialLoginStateRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	authService    *AuthService
+	audit          *AuditService
+	providers      *oidc.Registry
+	stateTTL       time.Duration
+	logger         logger.Logger
+}
+
+func NewSocialLoginService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	identityRepo repository.UserIdentityRepository,
+	stateRepo repository.SocialLoginStateRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	authService *AuthService,
+	audit *AuditService,
+	providers *oidc.Registry,
+	stateTTL time.Duration,
+	logger logger.Logger,
+) *SocialLoginService {
+	return &SocialLoginService{
+		userRepo:       userRepo,
+		userAuthRepo:   userAuthRepo,
+		identityRepo:   identityRepo,
+		stateRepo:      stateRepo,
+		credentialRepo: credentialRepo,
+		authService:    authService,
+		audit:          audit,
+		providers:      providers,
+		stateTTL:       stateTTL,
+		logger:         logger,
+	}
+}
+
+// Providers возвращает настроенных провайдеров
+func (s *SocialLoginService) Providers() []*oidc.Provider {
+	return s.providers.Providers()
+}
+
+// BeginLogin начинает вход через провайдера. Возвращает state и адрес страницы провайдера;
+// клиент сохраняет state и сверяет его с вернувшимся от провайдера, защищаясь от подмены входа.
+func (s *SocialLoginService) BeginLogin(ctx context.Context, providerName string) (string, string, error) {
+	return s.begin(ctx, providerName, domain.SocialLoginPurposeLogin, nil)
+}
+
+// FinishLogin проверяет ответ провайдера и возвращает пользователя, найденного или созданного
+// по правилам привязки
+func (s *SocialLoginService) FinishLogin(ctx context.Context, providerName, code, state string) (*domain.User, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, claims, err := s.verifyCallback(ctx, provider, domain.SocialLoginPurposeLogin, code, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, provider.Name(), claims.Subject)
+	switch {
+	case err == nil:
+		user, err = s.userRepo.GetByID(ctx, identity.UserID())
+		if err != nil {
+			return nil, err
+		}
+
+		identity.RecordLogin(claims.Email)
+		if err := s.identityRepo.Update(ctx, identity); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to update external identity",
+				logger.String("identity_id", identity.ID().String()),
+				logger.Error(err),
+			)
+		}
+	case errors.Is(err, repository.ErrUserIdentityNotFound):
+		user, err = s.resolveNewIdentity(ctx, provider, claims)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	userID := user.ID()
+	if !user.IsActive() {
+		s.audit.RecordLoginFailed(ctx, &userID, "social:"+provider.Name(), "inactive", "")
+		return nil, ErrUserInactive
+	}
+
+	// Вход через провайдера заменяет только пароль: с 2FA вход завершается в CompleteMFAChallenge
+	if err := s.authService.RecordFirstFactorLogin(ctx, userID, "social:"+provider.Name()); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// BeginLink начинает привязку провайдера к аккаунту вошедшего пользователя
+func (s *SocialLoginService) BeginLink(ctx context.Context, userID uuid.UUID, providerName string) (string, string, error) {
+	return s.begin(ctx, providerName, domain.SocialLoginPurposeLink, &userID)
+}
+
+// FinishLink привязывает учетную запись провайдера к аккаунту. Email провайдера при явной
+// привязке не сверяется: пользователь подтвердил владение обоими аккаунтами.
+func (s *SocialLoginService) FinishLink(ctx context.Context, userID uuid.UUID, providerName, code, state string) (*domain.UserIdentity, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	loginState, claims, err := s.verifyCallback(ctx, provider, domain.SocialLoginPurposeLink, code, state)
+	if err != nil {
+		return nil, err
+	}
+	if !loginState.BelongsTo(userID) {
+		return nil, repository.ErrSocialLoginStateNotFound
+	}
+
+	existing, err := s.identityRepo.GetByProviderSubject(ctx, provider.Name(), claims.Subject)
+	if err == nil {
+		if existing.UserID() != userID {
+			return nil, ErrIdentityLinkedToAnotherUser
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, repository.ErrUserIdentityNotFound) {
+		return nil, err
+	}
+
+	identity := domain.NewUserIdentity(userID, provider.Name(), claims.Subject, claims.Email)
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("External identity linked",
+		logger.String("user_id", userID.String()),
+		logger.String("provider", provider.Name()),
+	)
+
+	return identity, nil
+}
+
+// ListIdentities возвращает привязанные учетные записи провайдеров
+func (s *SocialLoginService) ListIdentities(ctx context.Context, userID uuid.UUID) ([]*domain.UserIdentity, error) {
+	return s.identityRepo.GetByUserID(ctx, userID)
+}
+
+// UnlinkIdentity удаляет привязку. Последний способ входа аккаунта без пароля и passkeys
+// удалить нельзя.
+func (s *SocialLoginService) UnlinkIdentity(ctx context.Context, userID, identityID uuid.UUID) error {
+	userAuth, err := s.userAuthRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !userAuth.HasPassword() {
+		passkeys, err := s.credentialRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		identities, err := s.identityRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if passkeys == 0 && identities <= 1 {
+			return ErrLastSignInMethod
+		}
+	}
+
+	if err := s.identityRepo.Delete(ctx, userID, identityID); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("External identity unlinked",
+		logger.String("user_id", userID.String()),
+		logger.String("identity_id", identityID.String()),
+	)
+
+	return nil
+}
+
+// RunCleanup периодически удаляет state незавершенных входов
+func (s *SocialLoginService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.stateRepo.DeleteExpired(ctx)
+			if err != nil {
+				s.logger.Error("Failed to delete expired social login states", logger.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Debug("Expired social login states deleted", logger.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+func (s *SocialLoginService) begin(ctx context.Context, providerName, purpose string, userID *uuid.UUID) (string, string, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return "", "", err
+	}
+
+	state := helpers.GenerateSecureToken()
+	nonce := helpers.GenerateSecureToken()
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	loginState := domain.NewSocialLoginState(helpers.HashToken(state), provider.Name(), purpose, userID, nonce, codeVerifier, time.Now().Add(s.stateTTL))
+	if err := s.stateRepo.Create(ctx, loginState); err != nil {
+		return "", "", err
+	}
+
+	return state, authURL, nil
+}
+
+// verifyCallback принимает state, обменивает code на токены провайдера и проверяет ID токен.
+// Если ID токен не содержит email, он запрашивается у userinfo endpoint.
+func (s *SocialLoginService) verifyCallback(ctx context.Context, provider *oidc.Provider, purpose, code, state string) (*domain.SocialLoginState, *oidc.Claims, error) {
+	loginState, err := s.stateRepo.Consume(ctx, helpers.HashToken(state), provider.Name(), purpose)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := provider.Exchange(ctx, code, loginState.CodeVerifier())
+	if err != nil {
+		return nil, nil, s.loginFailed(ctx, provider, err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, tokens.IDToken, loginState.Nonce())
+	if err != nil {
+		return nil, nil, s.loginFailed(ctx, provider, err)
+	}
+
+	if claims.Email == "" && tokens.AccessToken != "" {
+		info, err := provider.UserInfo(ctx, tokens.AccessToken)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to load external userinfo",
+				logger.String("provider", provider.Name()),
+				logger.Error(err),
+			)
+		} else if info.Subject == claims.Subject {
+			claims.Email = info.Email
+			claims.EmailVerified = info.EmailVerified
+			if claims.Name == "" {
+				claims.Name = info.Name
+			}
+			if claims.PreferredUsername == "" {
+				claims.PreferredUsername = info.PreferredUsername
+			}
+		}
+	}
+
+	return loginState, claims, nil
+}
+
+// resolveNewIdentity привязывает еще не известную учетную запись провайдера к аккаунту
+// с тем же подтвержденным email или создает новый аккаунт
+func (s *SocialLoginService) resolveNewIdentity(ctx context.Context, provider *oidc.Provider, claims *oidc.Claims) (*domain.User, error) {
+	if claims.Email == "" {
+		return nil, ErrExternalEmailRequired
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, claims.Email)
+	if err == nil {
+		// Неподтвержденный email с любой стороны позволил бы захватить чужой аккаунт
+		if !bool(claims.EmailVerified) || !existing.IsVerified() {
+			return nil, ErrExternalAccountConflict
+		}
+
+		identity := domain.NewUserIdentity(existing.ID(), provider.Name(), claims.Subject, claims.Email)
+		identity.RecordLogin(claims.Email)
+		if err := s.identityRepo.Create(ctx, identity); err != nil {
+			if errors.Is(err, repository.ErrUserIdentityExists) {
+				return nil, ErrExternalAccountConflict
+			}
+			return nil, err
+		}
+
+		s.logger.WithContext(ctx).Info("External identity linked by verified email",
+			logger.String("user_id", existing.ID().String()),
+			logger.String("provider", provider.Name()),
+		)
+		return existing, nil
+	}
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+
+	username, err := s.availableUsername(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(claims.Name)
+	if !helpers.ValidateDisplayName(displayName) {
+		displayName = username
+	}
+
+	user, _, err := s.authService.RegisterExternalUser(ctx, claims.Email, username, displayName, bool(claims.EmailVerified), provider.Name(), claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// availableUsername подбирает свободный username из preferred_username или email провайдера
+func (s *SocialLoginService) availableUsername(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if base == "" {
+		localPart, _, _ := strings.Cut(claims.Email, "@")
+		base = sanitizeUsername(localPart)
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range usernameAttempts {
+		if helpers.ValidateUsername(candidate) {
+			exists, err := s.userRepo.ExistsByUsername(ctx, candidate)
+			if err != nil {
+				return "", err
+			}
+			if !exists {
+				return candidate, nil
+			}
+		}
+		candidate = base + "_" + helpers.GenerateSecureTokenWithLength(3)
+	}
+
+	return "", repository.ErrUserUsernameExists
+}
+
+// sanitizeUsername оставляет в имени только допустимые для username символы
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		case r == '.' || r == '-':
+			b.WriteRune('_')
+		}
+	}
+
+	username := b.String()
+	// Оставляем место для суффикса при совпадении
+	if len(username) > 23 {
+		username = username[:23]
+	}
+	if len(username) < 3 {
+		return ""
+	}
+	return username
+}
+
+// loginFailed логирует причину отказа и возвращает клиенту общую ошибку
+func (s *SocialLoginService) loginFailed(ctx context.Context, provider *oidc.Provider, cause error) error {
+	s.logger.WithContext(ctx).Warn("External sign-in failed",
+		logger.String("provider", provider.Name()),
+		logger.Error(cause),
+	)
+	return ErrSocialLoginFailed
+}
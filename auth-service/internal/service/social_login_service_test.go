@@ -0,0 +1,606 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/helpers"
+	"social-network/auth-service/pkg/oidc"
+	"social-network/auth-service/pkg/oidc/oidctest"
+
+	"github.com/google/uuid"
+)
+
+const testProviderName = "mock"
+
+// socialUserStore - пользователи в памяти
+type socialUserStore struct {
+	repository.UserRepository
+
+	mu    sync.Mutex
+	users map[uuid.UUID]*domain.User
+}
+
+func (s *socialUserStore) Create(ctx context.Context, user *domain.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.users {
+		if existing.Email() == user.Email() {
+			return repository.ErrUserEmailExists
+		}
+	}
+	s.users[user.ID()] = user
+	return nil
+}
+
+func (s *socialUserStore) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user, ok := s.users[id]; ok {
+		return user, nil
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (s *socialUserStore) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range s.users {
+		if user.Email() == email {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func (s *socialUserStore) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	_, err := s.GetByEmail(ctx, email)
+	return err == nil, nil
+}
+
+func (s *socialUserStore) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range s.users {
+		if user.Username() == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// identityStore - привязки учетных записей провайдеров в памяти
+type identityStore struct {
+	repository.UserIdentityRepository
+
+	mu         sync.Mutex
+	identities []*domain.UserIdentity
+}
+
+func (s *identityStore) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.identities {
+		if existing.Provider() == identity.Provider() &&
+			(existing.Subject() == identity.Subject() || existing.UserID() == identity.UserID()) {
+			return repository.ErrUserIdentityExists
+		}
+	}
+	s.identities = append(s.identities, identity)
+	return nil
+}
+
+func (s *identityStore) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, identity := range s.identities {
+		if identity.Provider() == provider && identity.Subject() == subject {
+			return identity, nil
+		}
+	}
+	return nil, repository.ErrUserIdentityNotFound
+}
+
+func (s *identityStore) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	return nil
+}
+
+// userIdentities возвращает привязки пользователя
+func (s *identityStore) userIdentities(userID uuid.UUID) []*domain.UserIdentity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var identities []*domain.UserIdentity
+	for _, identity := range s.identities {
+		if identity.UserID() == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities
+}
+
+// socialStateStore - state незавершенных входов в памяти. Consume удаляет state,
+// как и запрос к базе.
+type socialStateStore struct {
+	repository.SocialLoginStateRepository
+
+	mu     sync.Mutex
+	states map[string]*domain.SocialLoginState
+}
+
+func (s *socialStateStore) Create(ctx context.Context, state *domain.SocialLoginState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.StateHash()] = state
+	return nil
+}
+
+func (s *socialStateStore) Consume(ctx context.Context, stateHash, provider, purpose string) (*domain.SocialLoginState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[stateHash]
+	if !ok || state.Provider() != provider || state.Purpose() != purpose || state.IsExpired() {
+		return nil, repository.ErrSocialLoginStateNotFound
+	}
+	delete(s.states, stateHash)
+	return state, nil
+}
+
+// inlineTxManager выполняет fn без транзакции над репозиториями в памяти
+type inlineTxManager struct {
+	repos repository.Repositories
+}
+
+func (m *inlineTxManager) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return fn(m.repos)
+}
+
+// socialLoginTest - сервис входа через провайдера, запущенного локально
+type socialLoginTest struct {
+	service    *SocialLoginService
+	provider   *oidctest.Provider
+	users      *socialUserStore
+	userAuths  *userAuthStore
+	mfa        *userMFAStore
+	identities *identityStore
+	audit      *auditRecorder
+}
+
+func newSocialLoginTest(t *testing.T) *socialLoginTest {
+	t.Helper()
+
+	mock := oidctest.NewProvider(t)
+	provider := oidc.NewProvider(oidc.Config{
+		Name:         testProviderName,
+		Issuer:       mock.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  oidctest.RedirectURL,
+	}, mock.Client())
+
+	users := &socialUserStore{users: map[uuid.UUID]*domain.User{}}
+	userAuths := &userAuthStore{userAuths: map[uuid.UUID]*domain.UserAuth{}}
+	identities := &identityStore{}
+	mfa := &userMFAStore{mfa: map[uuid.UUID]*domain.UserMFA{}}
+	audit, recorder := newTestAuditService()
+
+	authService := &AuthService{
+		userRepo:     users,
+		userAuthRepo: userAuths,
+		userMFARepo:  mfa,
+		txManager: &inlineTxManager{repos: repository.Repositories{
+			Users:          users,
+			UserAuth:       userAuths,
+			UserRoles:      &userRoleStore{},
+			UserIdentities: identities,
+		}},
+		audit:  audit,
+		logger: newTestLogger(),
+	}
+
+	service := NewSocialLoginService(
+		users,
+		userAuths,
+		identities,
+		&socialStateStore{states: map[string]*domain.SocialLoginState{}},
+		nil,
+		authService,
+		audit,
+		oidc.NewRegistry(provider),
+		10*time.Minute,
+		newTestLogger(),
+	)
+
+	return &socialLoginTest{
+		service:    service,
+		provider:   mock,
+		users:      users,
+		userAuths:  userAuths,
+		mfa:        mfa,
+		identities: identities,
+		audit:      recorder,
+	}
+}
+
+// addUser сохраняет локальный аккаунт
+func (st *socialLoginTest) addUser(t *testing.T, email string, verified bool) *domain.User {
+	t.Helper()
+
+	user := domain.NewUser(email, "local_user", "Local User")
+	if verified {
+		user.VerifyEmail()
+	}
+	if err := st.users.Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+// link сохраняет привязку учетной записи провайдера к аккаунту
+func (st *socialLoginTest) link(t *testing.T, user *domain.User, subject string) {
+	t.Helper()
+
+	identity := domain.NewUserIdentity(user.ID(), testProviderName, subject, user.Email())
+	if err := st.identities.Create(context.Background(), identity); err != nil {
+		t.Fatalf("create identity: %v", err)
+	}
+}
+
+// login проходит вход целиком: страница провайдера выдает код пользователю providerUser
+func (st *socialLoginTest) login(t *testing.T, providerUser oidctest.User) (*domain.User, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	state, authURL, err := st.service.BeginLogin(ctx, testProviderName)
+	if err != nil {
+		t.Fatalf("begin login: %v", err)
+	}
+	code := st.provider.Authorize(t, authURL, providerUser)
+	return st.service.FinishLogin(ctx, testProviderName, code, state)
+}
+
+func TestSocialLogin_FinishLogin(t *testing.T) {
+	providerUser := oidctest.User{
+		Subject:           "provider-user-1",
+		Email:             "alice@example.com",
+		EmailVerified:     true,
+		Name:              "Alice",
+		PreferredUsername: "alice.provider",
+	}
+
+	tests := []struct {
+		name string
+		// setup готовит локальные аккаунты и возвращает тот, в который ожидается вход
+		setup        func(t *testing.T, st *socialLoginTest) *domain.User
+		providerUser func() oidctest.User
+		omitEmail    bool
+		wantErr      error
+		// wantIdentities - сколько привязок ожидается у аккаунта после входа
+		wantIdentities int
+	}{
+		{
+			name: "linked identity",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				// Email привязанного аккаунта не важен
+				user := st.addUser(t, "old@example.com", false)
+				st.link(t, user, providerUser.Subject)
+				return user
+			},
+			wantIdentities: 1,
+		},
+		{
+			name: "auto link by verified email",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				return st.addUser(t, providerUser.Email, true)
+			},
+			wantIdentities: 1,
+		},
+		{
+			name: "auto link by verified email from userinfo",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				return st.addUser(t, providerUser.Email, true)
+			},
+			omitEmail:      true,
+			wantIdentities: 1,
+		},
+		{
+			name: "email not verified by provider",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				return st.addUser(t, providerUser.Email, true)
+			},
+			providerUser: func() oidctest.User {
+				user := providerUser
+				user.EmailVerified = false
+				return user
+			},
+			wantErr: ErrExternalAccountConflict,
+		},
+		{
+			name: "local email not verified",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				return st.addUser(t, providerUser.Email, false)
+			},
+			wantErr: ErrExternalAccountConflict,
+		},
+		{
+			name: "provider shares no email",
+			providerUser: func() oidctest.User {
+				user := providerUser
+				user.Email = ""
+				return user
+			},
+			wantErr: ErrExternalEmailRequired,
+		},
+		{
+			name: "linked account is inactive",
+			setup: func(t *testing.T, st *socialLoginTest) *domain.User {
+				user := st.addUser(t, providerUser.Email, true)
+				user.SetActive(false)
+				st.link(t, user, providerUser.Subject)
+				return user
+			},
+			wantErr:        ErrUserInactive,
+			wantIdentities: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSocialLoginTest(t)
+			st.provider.OmitEmail = tt.omitEmail
+
+			var want *domain.User
+			if tt.setup != nil {
+				want = tt.setup(t, st)
+			}
+			signedIn := providerUser
+			if tt.providerUser != nil {
+				signedIn = tt.providerUser()
+			}
+
+			user, err := st.login(t, signedIn)
+
+			if want != nil {
+				if got := len(st.identities.userIdentities(want.ID())); got != tt.wantIdentities {
+					t.Fatalf("expected %d identities, got %d", tt.wantIdentities, got)
+				}
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if user.ID() != want.ID() {
+				t.Fatalf("signed in to another account")
+			}
+			if types := st.audit.types(); len(types) != 1 || types[0] != domain.AuditLoginSucceeded {
+				t.Fatalf("expected login.succeeded audit event, got %v", types)
+			}
+		})
+	}
+}
+
+func TestSocialLogin_FinishLoginCreatesAccount(t *testing.T) {
+	st := newSocialLoginTest(t)
+	// Username занят, поэтому подбирается вариант с суффиксом
+	existing := st.addUser(t, "someone@example.com", true)
+
+	user, err := st.login(t, oidctest.User{
+		Subject:           "provider-user-2",
+		Email:             "bob@example.com",
+		EmailVerified:     true,
+		Name:              "Bob",
+		PreferredUsername: existing.Username(),
+	})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	if user.Email() != "bob@example.com" || !user.IsVerified() || user.DisplayName() != "Bob" {
+		t.Fatalf("unexpected account email=%s verified=%v name=%s", user.Email(), user.IsVerified(), user.DisplayName())
+	}
+	if user.Username() == existing.Username() {
+		t.Fatalf("username %q is already taken", user.Username())
+	}
+	if _, err := st.users.GetByID(context.Background(), user.ID()); err != nil {
+		t.Fatalf("account was not saved: %v", err)
+	}
+
+	identities := st.identities.userIdentities(user.ID())
+	if len(identities) != 1 || identities[0].Subject() != "provider-user-2" {
+		t.Fatalf("expected identity of the provider account, got %d", len(identities))
+	}
+
+	// Повторный вход попадает в тот же аккаунт
+	again, err := st.login(t, oidctest.User{Subject: "provider-user-2", Email: "bob@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if again.ID() != user.ID() {
+		t.Fatalf("second login created another account")
+	}
+}
+
+func TestSocialLogin_RejectsCallback(t *testing.T) {
+	providerUser := oidctest.User{Subject: "provider-user-1", Email: "alice@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name string
+		// callback возвращает code и state, с которыми провайдер якобы вернул браузер
+		callback func(t *testing.T, st *socialLoginTest) (string, string)
+		wantErr  error
+	}{
+		{
+			name: "unknown state",
+			callback: func(t *testing.T, st *socialLoginTest) (string, string) {
+				_, authURL, err := st.service.BeginLogin(context.Background(), testProviderName)
+				if err != nil {
+					t.Fatalf("begin login: %v", err)
+				}
+				return st.provider.Authorize(t, authURL, providerUser), "forged-state"
+			},
+			wantErr: repository.ErrSocialLoginStateNotFound,
+		},
+		{
+			name: "replayed state",
+			callback: func(t *testing.T, st *socialLoginTest) (string, string) {
+				ctx := context.Background()
+				state, authURL, err := st.service.BeginLogin(ctx, testProviderName)
+				if err != nil {
+					t.Fatalf("begin login: %v", err)
+				}
+				code := st.provider.Authorize(t, authURL, providerUser)
+				if _, err := st.service.FinishLogin(ctx, testProviderName, code, state); err != nil {
+					t.Fatalf("first finish: %v", err)
+				}
+				return st.provider.Authorize(t, authURL, providerUser), state
+			},
+			wantErr: repository.ErrSocialLoginStateNotFound,
+		},
+		{
+			name: "state of link flow",
+			callback: func(t *testing.T, st *socialLoginTest) (string, string) {
+				state, authURL, err := st.service.BeginLink(context.Background(), uuid.New(), testProviderName)
+				if err != nil {
+					t.Fatalf("begin link: %v", err)
+				}
+				return st.provider.Authorize(t, authURL, providerUser), state
+			},
+			wantErr: repository.ErrSocialLoginStateNotFound,
+		},
+		{
+			name: "code not issued by provider",
+			callback: func(t *testing.T, st *socialLoginTest) (string, string) {
+				state, _, err := st.service.BeginLogin(context.Background(), testProviderName)
+				if err != nil {
+					t.Fatalf("begin login: %v", err)
+				}
+				return "forged-code", state
+			},
+			wantErr: ErrSocialLoginFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSocialLoginTest(t)
+			code, state := tt.callback(t, st)
+
+			if _, err := st.service.FinishLogin(context.Background(), testProviderName, code, state); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSocialLogin_FinishLink(t *testing.T) {
+	providerUser := oidctest.User{Subject: "provider-user-1", Email: "other@provider.example"}
+
+	tests := []struct {
+		name string
+		// setup готовит привязки; another - привязку начал другой пользователь
+		setup   func(t *testing.T, st *socialLoginTest, user *domain.User)
+		another bool
+		wantErr error
+	}{
+		{
+			// Email провайдера не подтвержден и не совпадает: привязку подтвердил сам пользователь
+			name: "links provider account",
+		},
+		{
+			name: "already linked to this user",
+			setup: func(t *testing.T, st *socialLoginTest, user *domain.User) {
+				st.link(t, user, providerUser.Subject)
+			},
+		},
+		{
+			name: "linked to another user",
+			setup: func(t *testing.T, st *socialLoginTest, user *domain.User) {
+				other := domain.NewUser("mallory@example.com", "mallory", "Mallory")
+				st.link(t, other, providerUser.Subject)
+			},
+			wantErr: ErrIdentityLinkedToAnotherUser,
+		},
+		{
+			name:    "state started by another user",
+			another: true,
+			wantErr: repository.ErrSocialLoginStateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			st := newSocialLoginTest(t)
+			user := st.addUser(t, "alice@example.com", true)
+			if tt.setup != nil {
+				tt.setup(t, st, user)
+			}
+
+			stateOwner := user.ID()
+			if tt.another {
+				stateOwner = uuid.New()
+			}
+			state, authURL, err := st.service.BeginLink(ctx, stateOwner, testProviderName)
+			if err != nil {
+				t.Fatalf("begin link: %v", err)
+			}
+			code := st.provider.Authorize(t, authURL, providerUser)
+
+			identity, err := st.service.FinishLink(ctx, user.ID(), testProviderName, code, state)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if len(st.identities.userIdentities(user.ID())) != 0 {
+					t.Fatalf("identity must not be linked")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.UserID() != user.ID() || identity.Subject() != providerUser.Subject {
+				t.Fatalf("unexpected identity user=%s subject=%s", identity.UserID(), identity.Subject())
+			}
+			if got := len(st.identities.userIdentities(user.ID())); got != 1 {
+				t.Fatalf("expected one identity, got %d", got)
+			}
+		})
+	}
+}
+
+func TestSocialLogin_FinishLogin_MFA(t *testing.T) {
+	st := newSocialLoginTest(t)
+	providerUser := oidctest.User{Subject: "provider-user-1", Email: "alice@example.com", EmailVerified: true}
+
+	user := st.addUser(t, providerUser.Email, true)
+	st.link(t, user, providerUser.Subject)
+	st.userAuths.userAuths[user.ID()] = domain.NewUserAuth(user.ID(), "")
+
+	mfa := domain.NewUserMFA(user.ID(), helpers.GenerateTOTPSecret())
+	mfa.Enable(0)
+	st.mfa.mfa[user.ID()] = mfa
+
+	if _, err := st.login(t, providerUser); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Вход через провайдера заменяет только пароль: вход завершится после проверки второго фактора
+	if types := st.audit.types(); len(types) != 1 || types[0] != domain.AuditLoginMFARequired {
+		t.Fatalf("expected only login.mfa_required audit event, got %v", types)
+	}
+	if st.userAuths.userAuths[user.ID()].LastLoginAt() != nil {
+		t.Fatalf("last login must not be recorded before the second factor")
+	}
+}
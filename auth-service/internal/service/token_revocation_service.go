@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"social-network/auth-service/internal/repository"
+	"social-network/auth-service/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocationService отзывает access токены до истечения их срока: по jti при выходе,
+// по sid при завершении сессии и маркером "выданные раньше T недействительны" при смене
+// ролей или блокировке
+type TokenRevocationService struct {
+	repo       repository.TokenRevocationRepository
+	jwtService *JWTService
+	logger     logger.Logger
+}
+
+func NewTokenRevocationService(repo repository.TokenRevocationRepository, jwtService *JWTService, logger logger.Logger) *TokenRevocationService {
+	return &TokenRevocationService{
+		repo:       repo,
+		jwtService: jwtService,
+		logger:     logger,
+	}
+}
+
+// ValidateAccessToken проверяет подпись и срок access токена, а затем список отзыва
+func (s *TokenRevocationService) ValidateAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error) {
+	claims, err := s.jwtService.ValidateAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Токены без jti и iat выпущены до появления списка отзыва и проверяются только маркером пользователя
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := s.repo.IsRevoked(ctx, claims.ID, claims.UserID, claims.SessionID, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	// Токен имперсонации перестает действовать и вместе с токенами администратора
+	if actorID, ok := claims.ImpersonatorID(); ok {
+		revoked, err = s.repo.IsRevoked(ctx, claims.ID, actorID, uuid.Nil, issuedAt)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+// CheckMFAChallenge проверяет, что challenge токен 2FA еще не обменян и не отозван вместе
+// с остальными токенами пользователя
+func (s *TokenRevocationService) CheckMFAChallenge(ctx context.Context, claims *MFAChallengeClaims) error {
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return ErrMFAChallengeInvalid
+	}
+
+	revoked, err := s.repo.IsRevoked(ctx, claims.ID, claims.UserID, uuid.Nil, claims.IssuedAt.Time)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrMFAChallengeInvalid
+	}
+
+	return nil
+}
+
+// ConsumeMFAChallenge погашает challenge токен 2FA после успешной проверки кода
+func (s *TokenRevocationService) ConsumeMFAChallenge(ctx context.Context, claims *MFAChallengeClaims) error {
+	if claims.ID == "" {
+		return ErrMFAChallengeInvalid
+	}
+
+	expiresAt := time.Now().Add(s.jwtService.MFAChallengeTTL())
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	consumed, err := s.repo.ConsumeToken(ctx, claims.ID, claims.UserID, expiresAt)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrMFAChallengeInvalid
+	}
+
+	return nil
+}
+
+// RevokeAccessToken отзывает конкретный access токен до конца его срока
+func (s *TokenRevocationService) RevokeAccessToken(ctx context.Context, claims *AccessTokenClaims) error {
+	if claims.ID == "" {
+		return s.RevokeUserAccessTokens(ctx, claims.UserID)
+	}
+
+	expiresAt := time.Now().Add(s.jwtService.AccessTokenTTL())
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	return s.repo.RevokeToken(ctx, claims.ID, claims.UserID, expiresAt)
+}
+
+// RevokeUserAccessTokens делает недействительными все уже выданные access токены пользователя.
+// Маркер хранится, пока не истечет самый поздний из этих токенов.
+func (s *TokenRevocationService) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID) error {
+	// iat в JWT хранится с точностью до секунды, поэтому маркер округляется вверх и покрывает
+	// всю текущую секунду: токен, выданный в ней до отзыва, тоже недействителен. Вход в ту же
+	// секунду после отзыва не страдает: новая сессия выводится из-под маркера в RegisterSession.
+	issuedBefore := time.Now().Truncate(time.Second).Add(time.Second)
+	if err := s.repo.RevokeUserTokens(ctx, userID, issuedBefore, issuedBefore.Add(s.jwtService.AccessTokenTTL())); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("User access tokens revoked", logger.String("user_id", userID.String()))
+	return nil
+}
+
+// RegisterSession вызывается при открытии новой сессии. Если в текущей секунде токены пользователя
+// уже отозваны, токены этой сессии не должны попасть под маркер отзыва.
+func (s *TokenRevocationService) RegisterSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.repo.ExemptSession(ctx, sessionID, userID, time.Now())
+}
+
+// RevokeSessionAccessTokens делает недействительными access токены завершенной сессии.
+// Новых токенов с этим sid не будет: refresh токены сессии отзываются вместе с ней.
+func (s *TokenRevocationService) RevokeSessionAccessTokens(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if sessionID == uuid.Nil {
+		return nil
+	}
+
+	if err := s.repo.RevokeSession(ctx, sessionID, userID, time.Now().Add(s.jwtService.AccessTokenTTL())); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("Session access tokens revoked",
+		logger.String("user_id", userID.String()),
+		logger.String("session_id", sessionID.String()),
+	)
+	return nil
+}
+
+// RunCleanup периодически удаляет отзывы истекших токенов, пока ctx не отменен
+func (s *TokenRevocationService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.repo.DeleteExpired(ctx)
+			if err != nil {
+				s.logger.Error("Failed to delete expired token revocations", logger.Error(err))
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Debug("Expired token revocations deleted", logger.Int64("count", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"social-network/auth-service/internal/infrastructure/memory"
+
+	"github.com/google/uuid"
+)
+
+func newTestTokenRevocationService(t *testing.T) (*TokenRevocationService, *JWTService) {
+	t.Helper()
+
+	jwtService := newTestJWTService(t)
+	return NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, newTestLogger()), jwtService
+}
+
+func TestRevokeUserAccessTokens_SameSecond(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	user := newTestUser()
+	oldSession := uuid.New()
+	newSession := uuid.New()
+
+	mustToken := func(sessionID uuid.UUID) string {
+		t.Helper()
+		token, err := jwtService.GenerateAccessToken(user, testUserAccess(), sessionID)
+		if err != nil {
+			t.Fatalf("generate token: %v", err)
+		}
+		return token
+	}
+
+	// Все шаги укладываются в одну секунду, поэтому iat у токенов совпадает с секундой отзыва
+	waitForSecondStart()
+	issuedBefore := mustToken(oldSession)
+	if err := revocation.RevokeUserAccessTokens(ctx, user.ID()); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := revocation.RegisterSession(ctx, user.ID(), newSession); err != nil {
+		t.Fatalf("register session: %v", err)
+	}
+	refreshedOld := mustToken(oldSession)
+	freshLogin := mustToken(newSession)
+
+	if _, err := revocation.ValidateAccessToken(ctx, issuedBefore); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("token issued earlier in the same second must be revoked, got %v", err)
+	}
+	if _, err := revocation.ValidateAccessToken(ctx, refreshedOld); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("token of a session opened before revocation must be revoked, got %v", err)
+	}
+	if _, err := revocation.ValidateAccessToken(ctx, freshLogin); err != nil {
+		t.Fatalf("token of a session opened right after revocation must be valid, got %v", err)
+	}
+
+	// Следующий отзыв действует и на сессию, выведенную из-под предыдущего
+	waitForSecondStart()
+	if err := revocation.RevokeUserAccessTokens(ctx, user.ID()); err != nil {
+		t.Fatalf("revoke again: %v", err)
+	}
+	if _, err := revocation.ValidateAccessToken(ctx, freshLogin); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("later revocation must cover the exempted session, got %v", err)
+	}
+}
+
+func TestRevokeUserAccessTokens_EarlierTokenIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	user := newTestUser()
+
+	token, err := jwtService.GenerateAccessToken(user, testUserAccess(), uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	waitForSecondStart()
+	if err := revocation.RevokeUserAccessTokens(ctx, user.ID()); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+
+	if _, err := revocation.ValidateAccessToken(ctx, token); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+}
+
+func TestRevokeAccessToken_ByJTI(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	user := newTestUser()
+
+	revoked := jwtService.NewAccessTokenClaims(user, testUserAccess(), uuid.New(), DefaultAccessTokenScope, "")
+	revokedToken, err := jwtService.SignAccessToken(revoked)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	otherToken, err := jwtService.GenerateAccessToken(user, testUserAccess(), uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	if err := revocation.RevokeAccessToken(ctx, revoked); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+
+	if _, err := revocation.ValidateAccessToken(ctx, revokedToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := revocation.ValidateAccessToken(ctx, otherToken); err != nil {
+		t.Fatalf("other token must stay valid, got %v", err)
+	}
+}
+
+func TestValidateAccessToken_ImpersonationRevokedWithActor(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	user := newTestUser()
+	actorID := uuid.New()
+
+	token, _, err := jwtService.GenerateImpersonationToken(user, testUserAccess(), actorID)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	waitForSecondStart()
+	if err := revocation.RevokeUserAccessTokens(ctx, actorID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+
+	if _, err := revocation.ValidateAccessToken(ctx, token); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+}
+
+func TestRevokeSessionAccessTokens(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	user := newTestUser()
+	sessionID := uuid.New()
+
+	sessionToken, err := jwtService.GenerateAccessToken(user, testUserAccess(), sessionID)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	otherToken, err := jwtService.GenerateAccessToken(user, testUserAccess(), uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	if err := revocation.RevokeSessionAccessTokens(ctx, user.ID(), sessionID); err != nil {
+		t.Fatalf("revoke session: %v", err)
+	}
+
+	if _, err := revocation.ValidateAccessToken(ctx, sessionToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := revocation.ValidateAccessToken(ctx, otherToken); err != nil {
+		t.Fatalf("token of another session must stay valid, got %v", err)
+	}
+}
+
+func TestMFAChallenge_SingleUse(t *testing.T) {
+	ctx := context.Background()
+	revocation, jwtService := newTestTokenRevocationService(t)
+	userID := uuid.New()
+
+	token, err := jwtService.GenerateMFAChallengeToken(userID)
+	if err != nil {
+		t.Fatalf("generate challenge: %v", err)
+	}
+	challenge, err := jwtService.ValidateMFAChallengeToken(token)
+	if err != nil {
+		t.Fatalf("validate challenge: %v", err)
+	}
+	if challenge.ID == "" {
+		t.Fatal("challenge must carry a jti")
+	}
+
+	if err := revocation.CheckMFAChallenge(ctx, challenge); err != nil {
+		t.Fatalf("fresh challenge must be usable, got %v", err)
+	}
+	if err := revocation.ConsumeMFAChallenge(ctx, challenge); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+
+	if err := revocation.CheckMFAChallenge(ctx, challenge); !errors.Is(err, ErrMFAChallengeInvalid) {
+		t.Fatalf("expected ErrMFAChallengeInvalid on check, got %v", err)
+	}
+	if err := revocation.ConsumeMFAChallenge(ctx, challenge); !errors.Is(err, ErrMFAChallengeInvalid) {
+		t.Fatalf("expected ErrMFAChallengeInvalid on second consume, got %v", err)
+	}
+}
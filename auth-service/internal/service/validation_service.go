@@ -1,11 +1,20 @@
 package service
 
-import "social-network/auth-service/pkg/helpers"
+import (
+	"context"
+	"social-network/auth-service/pkg/helpers"
+)
 
-type ValidationService struct{}
+type ValidationService struct {
+	passwordRules []PasswordRule
+}
 
-func NewValidationService() *ValidationService {
-	return &ValidationService{}
+// NewValidationService создает сервис валидации. Сложность пароля проверяется всегда,
+// остальные правила пароля выполняются после нее в переданном порядке.
+func NewValidationService(passwordRules ...PasswordRule) *ValidationService {
+	return &ValidationService{
+		passwordRules: append([]PasswordRule{NewComplexityRule()}, passwordRules...),
+	}
 }
 
 // ValidateEmail проверяет формат email
@@ -24,10 +33,22 @@ func (s *ValidationService) ValidateUsername(username string) error {
 	return nil
 }
 
-// ValidatePassword проверяет надежность пароля
-func (s *ValidationService) ValidatePassword(password string) error {
-	if !helpers.ValidatePassword(password) {
-		return ErrPasswordTooWeak
+// ValidatePassword прогоняет новый пароль через все правила и возвращает
+// *PasswordPolicyError со списком всех нарушенных
+func (s *ValidationService) ValidatePassword(ctx context.Context, candidate PasswordCandidate) error {
+	var violations []PasswordViolation
+	for _, rule := range s.passwordRules {
+		violation, err := rule.Check(ctx, candidate)
+		if err != nil {
+			return err
+		}
+		if violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
 	}
 	return nil
 }
@@ -40,8 +61,9 @@ func (s *ValidationService) ValidateDisplayName(displayName string) error {
 	return nil
 }
 
-// ValidateRegistrationData проверяет все данные регистрации
-func (s *ValidationService) ValidateRegistrationData(email, username, displayName, password string) error {
+// ValidateRegistrationData проверяет формат данных регистрации. Пароль проверяется
+// при регистрации полным набором правил.
+func (s *ValidationService) ValidateRegistrationData(email, username, displayName string) error {
 	if err := s.ValidateEmail(email); err != nil {
 		return err
 	}
@@ -54,9 +76,5 @@ func (s *ValidationService) ValidateRegistrationData(email, username, displayNam
 		return err
 	}
 
-	if err := s.ValidatePassword(password); err != nil {
-		return err
-	}
-
 	return nil
 }
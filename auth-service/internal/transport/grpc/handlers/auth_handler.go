@@ -2,60 +2,85 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/grpc/interceptors"
 	pb "social-network/auth-service/pkg/api/proto/auth/v1"
 	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
 )
 
 type AuthHandler struct {
 	pb.UnimplementedAuthServiceServer
-	authService       *service.AuthService
-	jwtService        *service.JWTService
-	validationService *service.ValidationService
-	logger            logger.Logger
+	authService          *service.AuthService
+	jwtService           *service.JWTService
+	tokenRevocation      *service.TokenRevocationService
+	passkeyService       *service.PasskeyService
+	personalAccessTokens *service.PersonalAccessTokenService
+	impersonation        *service.ImpersonationService
+	auditService         *service.AuditService
+	validationService    *service.ValidationService
+	logger               logger.Logger
 }
 
 func NewAuthHandler(
 	authService *service.AuthService,
 	jwtService *service.JWTService,
+	tokenRevocation *service.TokenRevocationService,
+	passkeyService *service.PasskeyService,
+	personalAccessTokens *service.PersonalAccessTokenService,
+	impersonation *service.ImpersonationService,
+	auditService *service.AuditService,
 	validationService *service.ValidationService,
 	logger logger.Logger,
 ) *AuthHandler {
 	return &AuthHandler{
-		authService:       authService,
-		jwtService:        jwtService,
-		validationService: validationService,
-		logger:            logger,
+		authService:          authService,
+		jwtService:           jwtService,
+		tokenRevocation:      tokenRevocation,
+		passkeyService:       passkeyService,
+		personalAccessTokens: personalAccessTokens,
+		impersonation:        impersonation,
+		auditService:         auditService,
+		validationService:    validationService,
+		logger:               logger,
 	}
 }
 
 func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	// Валидация данных
 	if err := h.validationService.ValidateRegistrationData(
-		req.Email, req.Username, req.DisplayName, req.Password,
+		req.Email, req.Username, req.DisplayName,
 	); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
 	}
 
 	// Регистрация пользователя
-	user, err := h.authService.RegisterUser(req.Email, req.Username, req.DisplayName, req.Password)
+	user, err := h.authService.RegisterUser(ctx, req.Email, req.Username, req.DisplayName, req.Password)
 	if err != nil {
-		h.logger.Error("Registration failed",
+		h.logger.WithContext(ctx).Error("Registration failed",
 			logger.String("email", req.Email),
 			logger.String("username", req.Username),
 			logger.Error(err),
 		)
-		return nil, h.handleServiceError(err)
+		return nil, h.handleServiceError(ctx, err)
 	}
 
-	h.logger.Info("User registered successfully",
+	h.logger.WithContext(ctx).Info("User registered successfully",
 		logger.String("user_id", user.ID().String()),
 		logger.String("username", user.Username()),
 	)
@@ -68,101 +93,56 @@ func (h *AuthHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 
 func (h *AuthHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// Аутентификация
-	user, err := h.authService.AuthenticateUser(req.Email, req.Password)
+	user, err := h.authService.AuthenticateUser(ctx, req.Email, req.Password, h.clientInfo(ctx, req.DeviceName))
 	if err != nil {
-		h.logger.Warn("Login attempt failed",
+		h.logger.WithContext(ctx).Warn("Login attempt failed",
 			logger.String("email", req.Email),
 			logger.Error(err),
 		)
-		return nil, h.handleServiceError(err)
+		return nil, h.handleServiceError(ctx, err)
 	}
 
-	// Получение ролей
-	roles, err := h.authService.GetUserRoles(user.ID())
+	// Проверка второго фактора
+	mfaEnabled, err := h.authService.IsMFAEnabled(ctx, user.ID())
 	if err != nil {
-		h.logger.Error("Failed to get user roles",
-			logger.String("user_id", user.ID().String()),
-			logger.Error(err),
-		)
-		roles = []*domain.UserRole{}
+		return nil, h.handleServiceError(ctx, err)
 	}
 
-	// Генерация токенов
-	roleStrings := make([]domain.UserRoleType, len(roles))
-	for i, role := range roles {
-		roleStrings[i] = role.Role()
+	if mfaEnabled {
+		return h.mfaChallenge(ctx, user)
 	}
 
-	accessToken, err := h.jwtService.GenerateAccessToken(user, roleStrings)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate access token")
-	}
-
-	refreshTokenEntity, err := h.authService.CreateRefreshToken(user.ID())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate refresh token")
-	}
-
-	h.logger.Info("User logged in successfully",
-		logger.String("user_id", user.ID().String()),
-		logger.String("username", user.Username()),
-	)
-
-	return &pb.LoginResponse{
-		Tokens: &pb.TokenPair{
-			AccessToken:  accessToken,
-			RefreshToken: refreshTokenEntity.Token(),
-			TokenType:    "Bearer",
-			ExpiresIn:    900, // 15 minutes
-		},
-		User: h.mapUserToPB(user),
-	}, nil
+	return h.completeLogin(ctx, user, req.DeviceName)
 }
 
 func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	// Валидация refresh token
-	refreshToken, err := h.authService.ValidateRefreshToken(req.RefreshToken)
+	// Ротация refresh token
+	newRefreshToken, err := h.authService.RotateRefreshToken(ctx, req.RefreshToken, "", h.clientInfo(ctx, ""))
 	if err != nil {
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "refresh token has already been used; the session has been revoked")
+		}
 		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired refresh token")
 	}
 
 	// Получение пользователя
-	user, err := h.authService.GetUserByID(refreshToken.UserID())
+	user, err := h.authService.GetUserByID(ctx, newRefreshToken.UserID())
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "user not found")
 	}
 
-	// Получение ролей
-	roles, err := h.authService.GetUserRoles(user.ID())
+	// Получение ролей и прав
+	access, err := h.authService.GetUserAccess(ctx, user.ID())
 	if err != nil {
-		roles = []*domain.UserRole{}
-	}
-
-	roleStrings := make([]domain.UserRoleType, len(roles))
-	for i, role := range roles {
-		roleStrings[i] = role.Role()
+		access = &service.UserAccess{}
 	}
 
 	// Генерация нового access token
-	accessToken, err := h.jwtService.GenerateAccessToken(user, roleStrings)
+	accessToken, err := h.jwtService.GenerateAccessToken(user, access, newRefreshToken.SessionID())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate access token")
 	}
 
-	// Создание нового refresh token
-	newRefreshToken, err := h.authService.CreateRefreshToken(user.ID())
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate refresh token")
-	}
-
-	// Отзыв старого refresh token
-	if err := h.authService.RevokeRefreshToken(req.RefreshToken); err != nil {
-		h.logger.Error("Failed to revoke old refresh token",
-			logger.String("user_id", user.ID().String()),
-			logger.Error(err),
-		)
-	}
-
 	return &pb.RefreshTokenResponse{
 		Tokens: &pb.TokenPair{
 			AccessToken:  accessToken,
@@ -174,8 +154,8 @@ func (h *AuthHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequ
 }
 
 func (h *AuthHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
-	if err := h.authService.VerifyEmail(req.Token); err != nil {
-		return nil, h.handleServiceError(err)
+	if err := h.authService.VerifyEmail(ctx, req.Token); err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
 	return &pb.VerifyEmailResponse{
@@ -183,9 +163,19 @@ func (h *AuthHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReques
 	}, nil
 }
 
+func (h *AuthHandler) ResendVerificationEmail(ctx context.Context, req *pb.ResendVerificationEmailRequest) (*pb.ResendVerificationEmailResponse, error) {
+	if err := h.authService.ResendVerificationEmail(ctx, req.Email); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.ResendVerificationEmailResponse{
+		Message: "If the email is registered and not yet verified, a verification link has been sent",
+	}, nil
+}
+
 func (h *AuthHandler) InitiatePasswordReset(ctx context.Context, req *pb.InitiatePasswordResetRequest) (*pb.InitiatePasswordResetResponse, error) {
-	if err := h.authService.InitiatePasswordReset(req.Email); err != nil {
-		h.logger.Error("Password reset initiation failed",
+	if err := h.authService.InitiatePasswordReset(ctx, req.Email); err != nil {
+		h.logger.WithContext(ctx).Error("Password reset initiation failed",
 			logger.String("email", req.Email),
 			logger.Error(err),
 		)
@@ -198,12 +188,8 @@ func (h *AuthHandler) InitiatePasswordReset(ctx context.Context, req *pb.Initiat
 }
 
 func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
-	if err := h.validationService.ValidatePassword(req.NewPassword); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
-	}
-
-	if err := h.authService.ResetPassword(req.Token, req.NewPassword); err != nil {
-		return nil, h.handleServiceError(err)
+	if err := h.authService.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
 	return &pb.ResetPasswordResponse{
@@ -211,16 +197,49 @@ func (h *AuthHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRe
 	}, nil
 }
 
+func (h *AuthHandler) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) (*pb.RequestMagicLinkResponse, error) {
+	if err := h.authService.RequestMagicLink(ctx, req.Email); err != nil {
+		h.logger.WithContext(ctx).Error("Magic link request failed",
+			logger.String("email", req.Email),
+			logger.Error(err),
+		)
+	}
+
+	// Всегда возвращаем успешный ответ для безопасности
+	return &pb.RequestMagicLinkResponse{
+		Message: "If the email exists, a sign-in link has been sent",
+	}, nil
+}
+
+func (h *AuthHandler) LoginWithMagicLink(ctx context.Context, req *pb.LoginWithMagicLinkRequest) (*pb.LoginResponse, error) {
+	user, err := h.authService.LoginWithMagicLink(ctx, req.Token)
+	if err != nil {
+		h.logger.WithContext(ctx).Warn("Magic link login failed", logger.Error(err))
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	// Ссылка заменяет только пароль: второй фактор по-прежнему нужен
+	mfaEnabled, err := h.authService.IsMFAEnabled(ctx, user.ID())
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	if mfaEnabled {
+		return h.mfaChallenge(ctx, user)
+	}
+
+	return h.completeLogin(ctx, user, req.DeviceName)
+}
+
 func (h *AuthHandler) GetCurrentUser(ctx context.Context, req *pb.GetCurrentUserRequest) (*pb.GetCurrentUserResponse, error) {
-	// Валидация токена
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
+		return nil, err
 	}
 
-	user, err := h.authService.GetUserByID(claims.UserID)
+	user, err := h.authService.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, h.handleServiceError(err)
+		return nil, h.handleServiceError(ctx, err)
 	}
 
 	return &pb.GetCurrentUserResponse{
@@ -229,18 +248,13 @@ func (h *AuthHandler) GetCurrentUser(ctx context.Context, req *pb.GetCurrentUser
 }
 
 func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
-	// Валидация токена
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
-	}
-
-	if err := h.validationService.ValidatePassword(req.NewPassword); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+		return nil, err
 	}
 
-	if err := h.authService.ChangePassword(claims.UserID, req.CurrentPassword, req.NewPassword); err != nil {
-		return nil, h.handleServiceError(err)
+	if err := h.authService.ChangePassword(ctx, claims.UserID, claims.SessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
 	return &pb.ChangePasswordResponse{
@@ -249,15 +263,15 @@ func (h *AuthHandler) ChangePassword(ctx context.Context, req *pb.ChangePassword
 }
 
 func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
-	// Валидация токена
-	_, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
+		return nil, err
 	}
 
-	if err := h.authService.RevokeRefreshToken(req.RefreshToken); err != nil {
-		h.logger.Error("Failed to revoke refresh token during logout",
-			logger.String("token", req.RefreshToken),
+	if err := h.authService.Logout(ctx, claims, req.RefreshToken); err != nil {
+		h.logger.WithContext(ctx).Error("Failed to revoke tokens during logout",
+			logger.String("user_id", claims.UserID.String()),
+			logger.String("session_id", claims.SessionID.String()),
 			logger.Error(err),
 		)
 	}
@@ -267,15 +281,34 @@ func (h *AuthHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Lo
 	}, nil
 }
 
+// ValidateToken проверяет access токен или персональный токен. Адрес бота, предъявившего
+// персональный токен, здесь неизвестен: вызывает другой сервис. Токены OAuth клиентов
+// недействительны для других сервисов.
 func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+	var claims *service.AccessTokenClaims
+	var err error
+	if service.IsPersonalAccessToken(req.AccessToken) {
+		claims, err = h.personalAccessTokens.Authenticate(ctx, req.AccessToken, "")
+	} else {
+		claims, err = h.tokenRevocation.ValidateAccessToken(ctx, req.AccessToken)
+	}
 	if err != nil {
 		return &pb.ValidateTokenResponse{
 			Valid: false,
 		}, nil
 	}
+	// Токен OAuth клиента годится только для userinfo, как и в API самого сервиса
+	if claims.IsClientToken() {
+		h.logger.WithContext(ctx).Warn("OAuth client token rejected by token validation",
+			logger.String("client_id", claims.ClientID),
+		)
+		return &pb.ValidateTokenResponse{
+			Valid: false,
+		}, nil
+	}
+	ctx = requestctx.WithUserID(ctx, claims.UserID.String())
 
-	user, err := h.authService.GetUserByID(claims.UserID)
+	user, err := h.authService.GetUserByID(ctx, claims.UserID)
 	if err != nil {
 		return &pb.ValidateTokenResponse{
 			Valid: false,
@@ -287,64 +320,317 @@ func (h *AuthHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 		roleStrings[i] = string(role)
 	}
 
-	return &pb.ValidateTokenResponse{
-		Valid: true,
-		User:  h.mapUserToPB(user),
-		Roles: roleStrings,
-	}, nil
+	tokenType := service.TokenTypeAccessToken
+	if claims.IsPersonalAccessToken() {
+		tokenType = service.TokenTypePersonalAccessToken
+	}
+
+	response := &pb.ValidateTokenResponse{
+		Valid:       true,
+		User:        h.mapUserToPB(user),
+		Roles:       roleStrings,
+		TokenType:   tokenType,
+		Scopes:      strings.Fields(claims.Scope),
+		Permissions: strings.Fields(claims.Permissions),
+	}
+	if claims.Actor != nil {
+		response.ImpersonatorId = claims.Actor.Subject
+	}
+
+	return response, nil
 }
 
-func (h *AuthHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.AssignRoleResponse, error) {
-	// Валидация токена и проверка прав администратора
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+func (h *AuthHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
+		return nil, err
 	}
 
-	hasPermission := false
-	for _, role := range claims.Roles {
-		if role == domain.RoleAdmin {
-			hasPermission = true
-			break
+	sessions, err := h.authService.ListSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	pbSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		pbSession := &pb.Session{
+			Id:         session.SessionID().String(),
+			DeviceName: session.DeviceName(),
+			UserAgent:  session.UserAgent(),
+			IpAddress:  session.IPAddress(),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt()),
+			IsCurrent:  session.SessionID() == claims.SessionID,
+		}
+		if session.LastUsedAt() != nil {
+			pbSession.LastUsedAt = timestamppb.New(*session.LastUsedAt())
 		}
+		pbSessions[i] = pbSession
 	}
 
-	if !hasPermission {
-		return nil, status.Errorf(codes.PermissionDenied, "insufficient permissions")
+	return &pb.ListSessionsResponse{
+		Sessions: pbSessions,
+	}, nil
+}
+
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	userID, err := uuid.Parse(req.UserId)
+	sessionID, err := uuid.Parse(req.SessionId)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session ID")
 	}
 
-	roleType := domain.UserRoleType(req.Role)
-	if err := h.authService.AssignRole(userID, roleType); err != nil {
-		return nil, h.handleServiceError(err)
+	if err := h.authService.RevokeSession(ctx, claims.UserID, sessionID); err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
-	return &pb.AssignRoleResponse{
-		Message: "Role assigned successfully",
+	return &pb.RevokeSessionResponse{
+		Message: "Session revoked successfully",
 	}, nil
 }
 
-func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
-	// Валидация токена и проверка прав администратора
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+func (h *AuthHandler) RevokeOtherSessions(ctx context.Context, req *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := h.authService.RevokeOtherSessions(ctx, claims.UserID, claims.SessionID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.RevokeOtherSessionsResponse{
+		Message:      "Other sessions revoked successfully",
+		RevokedCount: revoked,
+	}, nil
+}
+
+func (h *AuthHandler) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.LoginResponse, error) {
+	claims, err := h.jwtService.ValidateMFAChallengeToken(req.MfaToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+
+	if err := h.authService.CompleteMFAChallenge(ctx, claims, req.Code); err != nil {
+		h.logger.WithContext(ctx).Warn("Second factor verification failed",
+			logger.String("user_id", claims.UserID.String()),
+			logger.Error(err),
+		)
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	user, err := h.authService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	if !user.IsActive() {
+		return nil, status.Errorf(codes.PermissionDenied, "user account is inactive")
+	}
+
+	return h.completeLogin(ctx, user, req.DeviceName)
+}
+
+func (h *AuthHandler) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, err := h.authService.EnrollTOTP(ctx, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+func (h *AuthHandler) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
+		return nil, err
 	}
 
-	hasPermission := false
-	for _, role := range claims.Roles {
-		if role == domain.RoleAdmin {
-			hasPermission = true
-			break
+	recoveryCodes, err := h.authService.ConfirmTOTP(ctx, claims.UserID, req.Code)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		Message:       "Two-factor authentication enabled",
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *AuthHandler) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.authService.DisableTOTP(ctx, claims.UserID, req.Password, req.Code); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.DisableTOTPResponse{
+		Message: "Two-factor authentication disabled",
+	}, nil
+}
+
+func (h *AuthHandler) BeginPasskeyRegistration(ctx context.Context, req *pb.BeginPasskeyRegistrationRequest) (*pb.BeginPasskeyRegistrationResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, options, err := h.passkeyService.BeginRegistration(ctx, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode passkey options")
+	}
+
+	return &pb.BeginPasskeyRegistrationResponse{
+		SessionId:   sessionID.String(),
+		OptionsJson: string(optionsJSON),
+	}, nil
+}
+
+func (h *AuthHandler) FinishPasskeyRegistration(ctx context.Context, req *pb.FinishPasskeyRegistrationRequest) (*pb.FinishPasskeyRegistrationResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session ID")
+	}
+
+	credential, err := h.passkeyService.FinishRegistration(ctx, claims.UserID, sessionID, req.Name, []byte(req.CredentialJson))
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.FinishPasskeyRegistrationResponse{
+		Passkey: h.mapPasskeyToPB(credential),
+	}, nil
+}
+
+func (h *AuthHandler) BeginPasskeyLogin(ctx context.Context, req *pb.BeginPasskeyLoginRequest) (*pb.BeginPasskeyLoginResponse, error) {
+	sessionID, options, err := h.passkeyService.BeginLogin(ctx)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode passkey options")
+	}
+
+	return &pb.BeginPasskeyLoginResponse{
+		SessionId:   sessionID.String(),
+		OptionsJson: string(optionsJSON),
+	}, nil
+}
+
+func (h *AuthHandler) FinishPasskeyLogin(ctx context.Context, req *pb.FinishPasskeyLoginRequest) (*pb.LoginResponse, error) {
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid session ID")
+	}
+
+	user, userVerified, err := h.passkeyService.FinishLogin(ctx, sessionID, []byte(req.CredentialJson))
+	if err != nil {
+		h.logger.WithContext(ctx).Warn("Passkey login failed", logger.Error(err))
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	// Passkey с проверкой пользователя уже дает два фактора: владение ключом и PIN/биометрию
+	if !userVerified {
+		mfaEnabled, err := h.authService.IsMFAEnabled(ctx, user.ID())
+		if err != nil {
+			return nil, h.handleServiceError(ctx, err)
 		}
+
+		if mfaEnabled {
+			return h.mfaChallenge(ctx, user)
+		}
+	}
+
+	return h.completeLogin(ctx, user, req.DeviceName)
+}
+
+func (h *AuthHandler) ListPasskeys(ctx context.Context, req *pb.ListPasskeysRequest) (*pb.ListPasskeysResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := h.passkeyService.ListCredentials(ctx, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
-	if !hasPermission {
-		return nil, status.Errorf(codes.PermissionDenied, "insufficient permissions")
+	pbPasskeys := make([]*pb.Passkey, len(credentials))
+	for i, credential := range credentials {
+		pbPasskeys[i] = h.mapPasskeyToPB(credential)
+	}
+
+	return &pb.ListPasskeysResponse{
+		Passkeys: pbPasskeys,
+	}, nil
+}
+
+func (h *AuthHandler) DeletePasskey(ctx context.Context, req *pb.DeletePasskeyRequest) (*pb.DeletePasskeyResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeyID, err := uuid.Parse(req.PasskeyId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid passkey ID")
+	}
+
+	if err := h.passkeyService.DeleteCredential(ctx, claims.UserID, passkeyID); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.DeletePasskeyResponse{
+		Message: "Passkey deleted successfully",
+	}, nil
+}
+
+func (h *AuthHandler) RemovePassword(ctx context.Context, req *pb.RemovePasswordRequest) (*pb.RemovePasswordResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.passkeyService.RemovePassword(ctx, claims.UserID, req.CurrentPassword); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.RemovePasswordResponse{
+		Message: "Password removed successfully",
+	}, nil
+}
+
+func (h *AuthHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.AssignRoleResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	userID, err := uuid.Parse(req.UserId)
@@ -352,43 +638,56 @@ func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest)
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
 	}
 
-	roleType := domain.UserRoleType(req.Role)
-	if err := h.authService.RevokeRole(userID, roleType); err != nil {
-		return nil, h.handleServiceError(err)
+	grant := service.RoleGrant{
+		UserID:  userID,
+		Role:    domain.UserRoleType(req.Role),
+		ActorID: claims.UserID,
+		Reason:  req.Reason,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.AsTime()
+		grant.ExpiresAt = &expiresAt
 	}
 
-	return &pb.RevokeRoleResponse{
-		Message: "Role revoked successfully",
+	if err := h.authService.AssignRole(ctx, grant); err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.AssignRoleResponse{
+		Message: "Role assigned successfully",
 	}, nil
 }
 
-func (h *AuthHandler) GetUserRoles(ctx context.Context, req *pb.GetUserRolesRequest) (*pb.GetUserRolesResponse, error) {
-	// Валидация токена и проверка прав администратора
-	claims, err := h.jwtService.ValidateAccessToken(req.AccessToken)
+func (h *AuthHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	claims, err := h.currentClaims(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid or expired token")
+		return nil, err
 	}
 
-	hasPermission := false
-	for _, role := range claims.Roles {
-		if role == domain.RoleAdmin {
-			hasPermission = true
-			break
-		}
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
 	}
 
-	if !hasPermission {
-		return nil, status.Errorf(codes.PermissionDenied, "insufficient permissions")
+	roleType := domain.UserRoleType(req.Role)
+	if err := h.authService.RevokeRole(ctx, userID, roleType, claims.UserID, req.Reason); err != nil {
+		return nil, h.handleServiceError(ctx, err)
 	}
 
+	return &pb.RevokeRoleResponse{
+		Message: "Role revoked successfully",
+	}, nil
+}
+
+func (h *AuthHandler) GetUserRoles(ctx context.Context, req *pb.GetUserRolesRequest) (*pb.GetUserRolesResponse, error) {
 	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
 	}
 
-	roles, err := h.authService.GetUserRoles(userID)
+	roles, err := h.authService.GetUserRoles(ctx, userID)
 	if err != nil {
-		return nil, h.handleServiceError(err)
+		return nil, h.handleServiceError(ctx, err)
 	}
 
 	pbRoles := make([]*pb.UserRole, len(roles))
@@ -399,6 +698,13 @@ func (h *AuthHandler) GetUserRoles(ctx context.Context, req *pb.GetUserRolesRequ
 			Role:      string(role.Role()),
 			GrantedAt: timestamppb.New(role.GrantedAt()),
 			IsActive:  role.IsActive(),
+			Reason:    role.Reason(),
+		}
+		if role.GrantedBy() != nil {
+			pbRoles[i].GrantedBy = role.GrantedBy().String()
+		}
+		if role.ExpiresAt() != nil {
+			pbRoles[i].ExpiresAt = timestamppb.New(*role.ExpiresAt())
 		}
 	}
 
@@ -407,6 +713,210 @@ func (h *AuthHandler) GetUserRoles(ctx context.Context, req *pb.GetUserRolesRequ
 	}, nil
 }
 
+func (h *AuthHandler) GetRoleHistory(ctx context.Context, req *pb.GetRoleHistoryRequest) (*pb.GetRoleHistoryResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+	}
+
+	changes, err := h.authService.GetRoleHistory(ctx, userID, int(req.Limit))
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	pbChanges := make([]*pb.RoleChange, len(changes))
+	for i, change := range changes {
+		pbChanges[i] = &pb.RoleChange{
+			Id:        change.ID().String(),
+			UserId:    change.UserID().String(),
+			Role:      string(change.Role()),
+			Action:    string(change.Action()),
+			Reason:    change.Reason(),
+			CreatedAt: timestamppb.New(change.CreatedAt()),
+		}
+		if change.ActorID() != nil {
+			pbChanges[i].ActorId = change.ActorID().String()
+		}
+		if change.ExpiresAt() != nil {
+			pbChanges[i].ExpiresAt = timestamppb.New(*change.ExpiresAt())
+		}
+	}
+
+	return &pb.GetRoleHistoryResponse{
+		Changes: pbChanges,
+	}, nil
+}
+
+func (h *AuthHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	query := service.AuditQuery{
+		Cursor: req.Cursor,
+		Limit:  int(req.Limit),
+	}
+
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+		}
+		query.UserID = &userID
+	}
+	for _, eventType := range req.Types {
+		query.Types = append(query.Types, domain.AuditEventType(eventType))
+	}
+	if req.From != nil {
+		from := req.From.AsTime()
+		query.From = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		query.To = &to
+	}
+
+	page, err := h.auditService.ListEvents(ctx, query)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	events := make([]*pb.AuditEvent, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = &pb.AuditEvent{
+			Id:        event.ID().String(),
+			Type:      string(event.Type()),
+			IpAddress: event.IPAddress(),
+			UserAgent: event.UserAgent(),
+			RequestId: event.RequestID(),
+			Details:   event.Details(),
+			CreatedAt: timestamppb.New(event.CreatedAt()),
+		}
+		if event.ActorID() != nil {
+			events[i].ActorId = event.ActorID().String()
+		}
+		if event.TargetID() != nil {
+			events[i].TargetId = event.TargetID().String()
+		}
+	}
+
+	return &pb.ListAuditEventsResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (h *AuthHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	query := service.UserQuery{
+		Email:          req.Email,
+		UsernamePrefix: req.UsernamePrefix,
+		IsVerified:     req.IsVerified,
+		IsActive:       req.IsActive,
+		Cursor:         req.Cursor,
+		Limit:          int(req.Limit),
+	}
+	if req.CreatedFrom != nil {
+		createdFrom := req.CreatedFrom.AsTime()
+		query.CreatedFrom = &createdFrom
+	}
+	if req.CreatedTo != nil {
+		createdTo := req.CreatedTo.AsTime()
+		query.CreatedTo = &createdTo
+	}
+
+	page, err := h.authService.ListUsers(ctx, query)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	users := make([]*pb.AdminUser, len(page.Users))
+	for i, user := range page.Users {
+		users[i] = h.mapAdminUserToPB(user)
+	}
+
+	return &pb.ListUsersResponse{
+		Users:      users,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (h *AuthHandler) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.SuspendUserResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+	}
+	if req.Reason == "" || len(req.Reason) > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required and must be at most 255 characters")
+	}
+
+	suspension := service.UserSuspension{
+		UserID:  userID,
+		ActorID: claims.UserID,
+		Reason:  req.Reason,
+	}
+	if req.Until != nil {
+		until := req.Until.AsTime()
+		suspension.Until = &until
+	}
+
+	user, err := h.authService.SuspendUser(ctx, suspension)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.SuspendUserResponse{
+		User: h.mapAdminUserToPB(user),
+	}, nil
+}
+
+func (h *AuthHandler) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.ReactivateUserResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+	}
+
+	user, err := h.authService.ReactivateUser(ctx, userID, claims.UserID)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.ReactivateUserResponse{
+		User: h.mapAdminUserToPB(user),
+	}, nil
+}
+
+func (h *AuthHandler) ImpersonateUser(ctx context.Context, req *pb.ImpersonateUserRequest) (*pb.ImpersonateUserResponse, error) {
+	claims, err := h.currentClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID")
+	}
+	if req.Reason == "" || len(req.Reason) > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required and must be at most 255 characters")
+	}
+
+	impersonation, err := h.impersonation.Impersonate(ctx, claims.UserID, userID, req.Reason)
+	if err != nil {
+		return nil, h.handleServiceError(ctx, err)
+	}
+
+	return &pb.ImpersonateUserResponse{
+		AccessToken: impersonation.AccessToken,
+		ExpiresAt:   timestamppb.New(impersonation.ExpiresAt),
+		User:        h.mapAdminUserToPB(impersonation.User),
+	}, nil
+}
+
 // Helper methods
 func (h *AuthHandler) mapUserToPB(user *domain.User) *pb.User {
 	return &pb.User{
@@ -421,7 +931,158 @@ func (h *AuthHandler) mapUserToPB(user *domain.User) *pb.User {
 	}
 }
 
-func (h *AuthHandler) handleServiceError(err error) error {
+func (h *AuthHandler) mapAdminUserToPB(user *domain.User) *pb.AdminUser {
+	adminUser := &pb.AdminUser{User: h.mapUserToPB(user)}
+	if suspension := user.Suspension(); suspension != nil {
+		adminUser.Suspension = &pb.Suspension{
+			Reason:      suspension.Reason,
+			SuspendedAt: timestamppb.New(suspension.SuspendedAt),
+		}
+		if suspension.SuspendedBy != nil {
+			adminUser.Suspension.SuspendedBy = suspension.SuspendedBy.String()
+		}
+		if suspension.Until != nil {
+			adminUser.Suspension.Until = timestamppb.New(*suspension.Until)
+		}
+	}
+	return adminUser
+}
+
+func (h *AuthHandler) mapPasskeyToPB(credential *domain.WebAuthnCredential) *pb.Passkey {
+	passkey := &pb.Passkey{
+		Id:           credential.ID().String(),
+		Name:         credential.Name(),
+		Transports:   credential.Transports(),
+		CloneWarning: credential.CloneWarning(),
+		CreatedAt:    timestamppb.New(credential.CreatedAt()),
+	}
+	if credential.LastUsedAt() != nil {
+		passkey.LastUsedAt = timestamppb.New(*credential.LastUsedAt())
+	}
+	return passkey
+}
+
+// completeLogin выдает пару токенов пользователю, прошедшему аутентификацию
+func (h *AuthHandler) completeLogin(ctx context.Context, user *domain.User, deviceName string) (*pb.LoginResponse, error) {
+	// Получение ролей и прав
+	access, err := h.authService.GetUserAccess(ctx, user.ID())
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to get user roles",
+			logger.String("user_id", user.ID().String()),
+			logger.Error(err),
+		)
+		access = &service.UserAccess{}
+	}
+
+	refreshTokenEntity, err := h.authService.CreateRefreshToken(ctx, user.ID(), h.clientInfo(ctx, deviceName))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate refresh token")
+	}
+
+	accessToken, err := h.jwtService.GenerateAccessToken(user, access, refreshTokenEntity.SessionID())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate access token")
+	}
+
+	h.logger.WithContext(ctx).Info("User logged in successfully",
+		logger.String("user_id", user.ID().String()),
+		logger.String("username", user.Username()),
+	)
+
+	return &pb.LoginResponse{
+		Tokens: &pb.TokenPair{
+			AccessToken:  accessToken,
+			RefreshToken: refreshTokenEntity.Token(),
+			TokenType:    "Bearer",
+			ExpiresIn:    900, // 15 minutes
+		},
+		User: h.mapUserToPB(user),
+	}, nil
+}
+
+// mfaChallenge возвращает challenge токен вместо пары токенов
+func (h *AuthHandler) mfaChallenge(ctx context.Context, user *domain.User) (*pb.LoginResponse, error) {
+	mfaToken, err := h.jwtService.GenerateMFAChallengeToken(user.ID())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate mfa token")
+	}
+
+	h.logger.WithContext(ctx).Info("Password accepted, second factor required",
+		logger.String("user_id", user.ID().String()),
+	)
+
+	return &pb.LoginResponse{
+		MfaRequired:  true,
+		MfaToken:     mfaToken,
+		MfaExpiresIn: int64(h.jwtService.MFAChallengeTTL().Seconds()),
+	}, nil
+}
+
+// clientInfo извлекает сведения об устройстве клиента из метаданных запроса
+func (h *AuthHandler) clientInfo(ctx context.Context, deviceName string) domain.ClientInfo {
+	client := domain.ClientInfo{DeviceName: deviceName}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			client.UserAgent = values[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		client.IPAddress = host
+	}
+
+	return client
+}
+
+// currentClaims возвращает claims пользователя, проверенные интерцептором авторизации
+func (h *AuthHandler) currentClaims(ctx context.Context) (*service.AccessTokenClaims, error) {
+	claims := interceptors.AccessClaims(ctx)
+	if claims == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "access token is required")
+	}
+	return claims, nil
+}
+
+func (h *AuthHandler) handleServiceError(ctx context.Context, err error) error {
+	// Блокировка несет время ожидания, поэтому обрабатывается до сравнения по тексту
+	var lockedErr *service.AccountLockedError
+	if errors.As(err, &lockedErr) {
+		st := status.New(codes.ResourceExhausted, "too many failed attempts, try again later")
+		if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(lockedErr.RetryAfter),
+		}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	// Нарушенные правила пароля передаются клиенту списком
+	var policyErr *service.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		st := status.New(codes.InvalidArgument, "password does not meet the password policy")
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(policyErr.Violations))
+		for _, violation := range policyErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "password",
+				Description: violation.Rule + ": " + violation.Message,
+			})
+		}
+		if detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+
+	// Отмена клиентом или истекший дедлайн дошли до запроса в базу
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
 	switch err.Error() {
 	case "user not found":
 		return status.Errorf(codes.NotFound, "user not found")
@@ -447,8 +1108,60 @@ func (h *AuthHandler) handleServiceError(err error) error {
 		return status.Errorf(codes.InvalidArgument, "email verification token has already been used")
 	case "email verification token is invalid":
 		return status.Errorf(codes.InvalidArgument, "email verification token is invalid")
+	case "magic link token is invalid":
+		return status.Errorf(codes.InvalidArgument, "magic link token is invalid")
+	case "session not found":
+		return status.Errorf(codes.NotFound, "session not found")
+	case "two-factor authentication is already enabled":
+		return status.Errorf(codes.AlreadyExists, "two-factor authentication is already enabled")
+	case "two-factor authentication enrollment not found":
+		return status.Errorf(codes.FailedPrecondition, "two-factor authentication enrollment not found")
+	case "two-factor authentication is not enabled":
+		return status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	case "invalid two-factor authentication code":
+		return status.Errorf(codes.Unauthenticated, "invalid two-factor authentication code")
+	case "mfa challenge is invalid or already used":
+		return status.Errorf(codes.Unauthenticated, "invalid or expired mfa token")
+	case "webauthn session not found":
+		return status.Errorf(codes.FailedPrecondition, "passkey ceremony has expired or was already completed")
+	case "passkey verification failed":
+		return status.Errorf(codes.Unauthenticated, "passkey verification failed")
+	case "passkey signature counter did not increase":
+		return status.Errorf(codes.PermissionDenied, "passkey may have been cloned and was blocked")
+	case "webauthn credential not found":
+		return status.Errorf(codes.NotFound, "passkey not found")
+	case "webauthn credential already exists":
+		return status.Errorf(codes.AlreadyExists, "passkey is already registered")
+	case "password is not set":
+		return status.Errorf(codes.FailedPrecondition, "password is not set")
+	case "cannot remove the last sign-in method":
+		return status.Errorf(codes.FailedPrecondition, "cannot remove the last sign-in method")
+	case "invalid role type":
+		return status.Errorf(codes.InvalidArgument, "role is not defined")
+	case "user role already exists":
+		return status.Errorf(codes.AlreadyExists, "user already has this role")
+	case "user role not found":
+		return status.Errorf(codes.NotFound, "user does not have this role")
+	case "role grants permissions the actor does not have":
+		return status.Errorf(codes.PermissionDenied, "role grants permissions you do not have")
+	case "role expiry must be in the future":
+		return status.Errorf(codes.InvalidArgument, "role expiry must be in the future")
+	case "invalid cursor":
+		return status.Errorf(codes.InvalidArgument, "invalid cursor")
+	case "user is already suspended":
+		return status.Errorf(codes.AlreadyExists, "user is already suspended")
+	case "user is not suspended":
+		return status.Errorf(codes.FailedPrecondition, "user is not suspended")
+	case "cannot suspend your own account":
+		return status.Errorf(codes.InvalidArgument, "cannot suspend your own account")
+	case "user has permissions the actor does not have":
+		return status.Errorf(codes.PermissionDenied, "user has permissions the actor does not have")
+	case "cannot impersonate your own account":
+		return status.Errorf(codes.InvalidArgument, "cannot impersonate your own account")
+	case "suspension end must be in the future":
+		return status.Errorf(codes.InvalidArgument, "suspension end must be in the future")
 	default:
-		h.logger.Error("Unhandled service error", logger.Error(err))
+		h.logger.WithContext(ctx).Error("Unhandled service error", logger.Error(err))
 		return status.Errorf(codes.Internal, "internal server error")
 	}
 }
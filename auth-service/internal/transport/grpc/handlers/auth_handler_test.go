@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/keys"
+	"social-network/auth-service/internal/infrastructure/memory"
+	"social-network/auth-service/internal/service"
+	pb "social-network/auth-service/pkg/api/proto/auth/v1"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+func TestAuthHandler_ValidateToken_RejectsClientToken(t *testing.T) {
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+	keySet, err := keys.NewKeySet("", "", 0, log)
+	if err != nil {
+		t.Fatalf("create key set: %v", err)
+	}
+	jwtService := service.NewJWTService(keySet, []byte("test-refresh-secret"), "https://auth.test")
+	tokenRevocation := service.NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, log)
+	h := NewAuthHandler(nil, jwtService, tokenRevocation, nil, nil, nil, nil, nil, log)
+
+	user := domain.NewUser("alice@example.com", "alice", "Alice")
+	clientToken, err := jwtService.SignAccessToken(jwtService.NewAccessTokenClaims(user,
+		&service.UserAccess{}, uuid.New(), service.ScopeOpenID+" "+service.ScopeProfile, "web-client"))
+	if err != nil {
+		t.Fatalf("sign client token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"oauth client token", clientToken},
+		{"invalid token", "not-a-jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := h.ValidateToken(context.Background(), &pb.ValidateTokenRequest{AccessToken: tt.token})
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if resp.Valid || resp.User != nil {
+				t.Fatalf("token must be reported invalid, got %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
+)
+
+// MetadataAuthorization - ключ metadata с access токеном пользователя
+const MetadataAuthorization = "authorization"
+
+type accessClaimsKey struct{}
+
+// AccessClaims возвращает claims пользователя, проверенные UnaryAuth, или nil для публичных методов
+func AccessClaims(ctx context.Context) *service.AccessTokenClaims {
+	claims, _ := ctx.Value(accessClaimsKey{}).(*service.AccessTokenClaims)
+	return claims
+}
+
+// accessTokenRequest - запросы с устаревшим полем access_token
+type accessTokenRequest interface {
+	GetAccessToken() string
+}
+
+// UnaryAuth проверяет access токен пользователя из metadata authorization по уровню доступа
+// метода в таблице policies и кладет claims в контекст. Для совместимости со старыми клиентами
+// токен берется из поля access_token запроса, если metadata пустая.
+func UnaryAuth(tokenRevocation *service.TokenRevocationService, policies map[string]MethodPolicy, log logger.Logger) grpc.UnaryServerInterceptor {
+	authorize := userAuthorizer(tokenRevocation, policies, log)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token := bearerFromMetadata(ctx, MetadataAuthorization)
+		if token == "" {
+			if r, ok := req.(accessTokenRequest); ok {
+				token = r.GetAccessToken()
+			}
+		}
+
+		ctx, err := authorize(ctx, info.FullMethod, token)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth - вариант UnaryAuth для потоковых RPC; токен читается только из metadata
+func StreamAuth(tokenRevocation *service.TokenRevocationService, policies map[string]MethodPolicy, log logger.Logger) grpc.StreamServerInterceptor {
+	authorize := userAuthorizer(tokenRevocation, policies, log)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, bearerFromMetadata(ss.Context(), MetadataAuthorization))
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func userAuthorizer(tokenRevocation *service.TokenRevocationService, policies map[string]MethodPolicy, log logger.Logger) func(context.Context, string, string) (context.Context, error) {
+	return func(ctx context.Context, method, token string) (context.Context, error) {
+		policy, ok := policies[method]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method is not allowed")
+		}
+
+		if policy.Access == AccessPublic {
+			return ctx, nil
+		}
+
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "access token is required")
+		}
+
+		claims, err := tokenRevocation.ValidateAccessToken(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		if claims.IsClientToken() {
+			log.WithContext(ctx).Warn("RPC called with token issued to OAuth client",
+				logger.String("method", method),
+				logger.String("client_id", claims.ClientID),
+			)
+			return nil, status.Error(codes.PermissionDenied, "tokens issued to OAuth clients cannot be used for this method")
+		}
+		ctx = requestctx.WithUserID(ctx, claims.UserID.String())
+		if claims.IsImpersonated() {
+			ctx = requestctx.WithImpersonatorID(ctx, claims.Actor.Subject)
+
+			if policy.DenyImpersonation {
+				log.WithContext(ctx).Warn("RPC called with impersonation token",
+					logger.String("method", method),
+				)
+				return nil, status.Error(codes.PermissionDenied, "impersonation tokens cannot be used for this method")
+			}
+		}
+
+		if policy.Permission != "" && !claims.HasPermission(policy.Permission) {
+			log.WithContext(ctx).Warn("RPC called without required permission",
+				logger.String("method", method),
+				logger.String("permission", string(policy.Permission)),
+			)
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+
+		return context.WithValue(ctx, accessClaimsKey{}, claims), nil
+	}
+}
@@ -0,0 +1,148 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/memory"
+	"social-network/auth-service/internal/service"
+	pb "social-network/auth-service/pkg/api/proto/auth/v1"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	testPublicMethod  = "/test.Service/Public"
+	testAccountMethod = "/test.Service/Account"
+	testAdminMethod   = "/test.Service/Admin"
+	testAccountChange = "/test.Service/ChangePassword"
+)
+
+var testAccessPolicies = map[string]MethodPolicy{
+	testPublicMethod:  {Access: AccessPublic},
+	testAccountMethod: {Access: AccessAuthenticated},
+	testAdminMethod:   {Access: AccessAuthenticated, Permission: domain.PermissionRolesManage},
+	testAccountChange: {Access: AccessAuthenticated, DenyImpersonation: true},
+}
+
+func withAccessToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer "+token))
+}
+
+// fakeServerStream - поток с заданным контекстом
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryAuth(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+	tokenRevocation := service.NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, log)
+	user := domain.NewUser("alice@example.com", "alice", "Alice")
+
+	mustToken := func(permissions ...domain.Permission) string {
+		t.Helper()
+		access := &service.UserAccess{Roles: []domain.UserRoleType{domain.RoleUser}, Permissions: permissions}
+		token, err := jwtService.GenerateAccessToken(user, access, uuid.New())
+		if err != nil {
+			t.Fatalf("generate token: %v", err)
+		}
+		return token
+	}
+	userToken := mustToken(domain.PermissionPostsDelete)
+	adminToken := mustToken(domain.PermissionRolesRead, domain.PermissionRolesManage)
+	clientToken, err := jwtService.SignAccessToken(jwtService.NewAccessTokenClaims(user,
+		&service.UserAccess{Roles: []domain.UserRoleType{domain.RoleAdmin}, Permissions: []domain.Permission{domain.PermissionRolesManage}},
+		uuid.New(), service.ScopeOpenID, "web-client"))
+	if err != nil {
+		t.Fatalf("sign client token: %v", err)
+	}
+	impersonationToken, _, err := jwtService.GenerateImpersonationToken(user, &service.UserAccess{Roles: []domain.UserRoleType{domain.RoleUser}}, uuid.New())
+	if err != nil {
+		t.Fatalf("generate impersonation token: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        any
+		method     string
+		wantCode   codes.Code
+		wantClaims bool
+	}{
+		{"public method without token", context.Background(), nil, testPublicMethod, codes.OK, false},
+		{"unknown method", withAccessToken(adminToken), nil, "/test.Service/Unknown", codes.PermissionDenied, false},
+		{"account method without token", context.Background(), nil, testAccountMethod, codes.Unauthenticated, false},
+		{"account method with invalid token", withAccessToken("not-a-jwt"), nil, testAccountMethod, codes.Unauthenticated, false},
+		{"account method with token", withAccessToken(userToken), nil, testAccountMethod, codes.OK, true},
+		{"token from legacy request field", context.Background(), &pb.GetCurrentUserRequest{AccessToken: userToken}, testAccountMethod, codes.OK, true},
+		{"admin method without permission", withAccessToken(userToken), nil, testAdminMethod, codes.PermissionDenied, false},
+		{"admin method with permission", withAccessToken(adminToken), nil, testAdminMethod, codes.OK, true},
+		{"oauth client token on admin method", withAccessToken(clientToken), nil, testAdminMethod, codes.PermissionDenied, false},
+		{"oauth client token on account method", withAccessToken(clientToken), nil, testAccountMethod, codes.PermissionDenied, false},
+		{"impersonation token on account method", withAccessToken(impersonationToken), nil, testAccountMethod, codes.OK, true},
+		{"impersonation token on credential change", withAccessToken(impersonationToken), nil, testAccountChange, codes.PermissionDenied, false},
+		{"credential change with own token", withAccessToken(userToken), nil, testAccountChange, codes.OK, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := UnaryAuth(tokenRevocation, testAccessPolicies, log)
+
+			var claims *service.AccessTokenClaims
+			_, err := interceptor(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req any) (any, error) {
+					claims = AccessClaims(ctx)
+					return nil, nil
+				})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantClaims && (claims == nil || claims.UserID != user.ID()) {
+				t.Fatalf("access claims must be stored in the context, got %+v", claims)
+			}
+		})
+	}
+}
+
+func TestStreamAuth(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+	tokenRevocation := service.NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, log)
+	user := domain.NewUser("alice@example.com", "alice", "Alice")
+
+	token, err := jwtService.GenerateAccessToken(user, &service.UserAccess{Roles: []domain.UserRoleType{domain.RoleUser}}, uuid.New())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	interceptor := StreamAuth(tokenRevocation, testAccessPolicies, log)
+	handler := func(srv any, ss grpc.ServerStream) error {
+		if claims := AccessClaims(ss.Context()); claims == nil || claims.UserID != user.ID() {
+			t.Fatalf("access claims must be visible to the stream handler")
+		}
+		return nil
+	}
+
+	if err := interceptor(nil, &fakeServerStream{ctx: withAccessToken(token)}, &grpc.StreamServerInfo{FullMethod: testAccountMethod}, handler); err != nil {
+		t.Fatalf("stream with token: %v", err)
+	}
+	err = interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: testAccountMethod}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("stream without token: code = %v, want Unauthenticated", status.Code(err))
+	}
+}
@@ -0,0 +1,25 @@
+package interceptors
+
+import "social-network/auth-service/internal/domain"
+
+// AccessLevel - требования RPC метода к токену пользователя
+type AccessLevel int
+
+const (
+	// AccessPublic - токен пользователя не нужен
+	AccessPublic AccessLevel = iota
+	// AccessAuthenticated - нужен действующий access токен
+	AccessAuthenticated
+)
+
+// MethodPolicy описывает требования к вызову RPC метода
+type MethodPolicy struct {
+	// Access - требования к токену пользователя
+	Access AccessLevel
+	// Permission - право, которое должно быть в access токене пользователя
+	Permission domain.Permission
+	// ServiceScope - scope, который должен быть в токене вызывающего сервиса
+	ServiceScope string
+	// DenyImpersonation отклоняет токены, выданные администратору от имени пользователя
+	DenyImpersonation bool
+}
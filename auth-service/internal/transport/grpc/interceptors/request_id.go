@@ -0,0 +1,63 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"social-network/auth-service/pkg/requestctx"
+)
+
+// UnaryRequestID берет идентификатор запроса из metadata x-request-id или генерирует
+// новый, возвращает его в заголовке ответа и сохраняет в контексте запроса
+// вместе с языком клиента из accept-language, его адресом и user-agent
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	key := strings.ToLower(requestctx.HeaderRequestID)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var requestID, locale, userAgent string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(key); len(values) > 0 {
+				requestID = values[0]
+			}
+			if values := md.Get("accept-language"); len(values) > 0 {
+				locale = requestctx.ParseAcceptLanguage(values[0])
+			}
+			if values := md.Get("user-agent"); len(values) > 0 {
+				userAgent = values[0]
+			}
+		}
+
+		if !requestctx.IsValidRequestID(requestID) {
+			requestID = requestctx.NewRequestID()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(key, requestID))
+
+		ctx = requestctx.WithRequestID(ctx, requestID)
+		ctx = requestctx.WithClient(ctx, peerIP(ctx), userAgent)
+		if locale != "" {
+			ctx = requestctx.WithLocale(ctx, locale)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerIP возвращает IP адрес, с которого пришел вызов
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,49 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"social-network/auth-service/pkg/requestctx"
+)
+
+func TestUnaryRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantSame string
+	}{
+		{"id from metadata is kept", metadata.Pairs("x-request-id", "req-123"), "req-123"},
+		{"missing metadata gets generated id", nil, ""},
+		{"invalid id is replaced", metadata.Pairs("x-request-id", "bad id"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var got string
+			_, err := UnaryRequestID()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"},
+				func(ctx context.Context, req any) (any, error) {
+					got = requestctx.RequestID(ctx)
+					return nil, nil
+				})
+			if err != nil {
+				t.Fatalf("interceptor: %v", err)
+			}
+
+			if tt.wantSame != "" && got != tt.wantSame {
+				t.Fatalf("request id = %q, want %q", got, tt.wantSame)
+			}
+			if tt.wantSame == "" && (got == "bad id" || !requestctx.IsValidRequestID(got)) {
+				t.Fatalf("expected a freshly generated id, got %q", got)
+			}
+		})
+	}
+}
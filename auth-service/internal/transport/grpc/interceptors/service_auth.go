@@ -0,0 +1,130 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
+)
+
+// MetadataServiceAuthorization - ключ metadata с токеном вызывающего сервиса. Токен
+// пользователя передается отдельно, поэтому ключ authorization здесь не используется.
+const MetadataServiceAuthorization = "x-service-authorization"
+
+type serviceClaimsKey struct{}
+
+// ServiceClaims возвращает claims токена вызывающего сервиса или nil, если токена не было
+func ServiceClaims(ctx context.Context) *service.ServiceTokenClaims {
+	claims, _ := ctx.Value(serviceClaimsKey{}).(*service.ServiceTokenClaims)
+	return claims
+}
+
+// UnaryServiceAuth проверяет токен сервиса из metadata x-service-authorization: подпись,
+// audience и scope из таблицы policies. Методы без записи в таблице отклоняются.
+// При required=false вызовы без токена пропускаются, но предъявленный токен все равно проверяется.
+func UnaryServiceAuth(jwtService *service.JWTService, audience string, policies map[string]MethodPolicy, required bool, log logger.Logger) grpc.UnaryServerInterceptor {
+	authorize := serviceAuthorizer(jwtService, audience, policies, required, log)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServiceAuth - вариант UnaryServiceAuth для потоковых RPC
+func StreamServiceAuth(jwtService *service.JWTService, audience string, policies map[string]MethodPolicy, required bool, log logger.Logger) grpc.StreamServerInterceptor {
+	authorize := serviceAuthorizer(jwtService, audience, policies, required, log)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func serviceAuthorizer(jwtService *service.JWTService, audience string, policies map[string]MethodPolicy, required bool, log logger.Logger) func(context.Context, string) (context.Context, error) {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		policy, ok := policies[method]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method is not allowed")
+		}
+
+		token := bearerFromMetadata(ctx, MetadataServiceAuthorization)
+		if token == "" {
+			if required {
+				return nil, status.Error(codes.Unauthenticated, "service token is required")
+			}
+			log.WithContext(ctx).Debug("gRPC call without service token",
+				logger.String("method", method),
+			)
+			return ctx, nil
+		}
+
+		claims, err := jwtService.ValidateServiceToken(token, audience)
+		if err != nil {
+			log.WithContext(ctx).Warn("Invalid service token",
+				logger.String("method", method),
+				logger.Error(err),
+			)
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired service token")
+		}
+
+		if policy.ServiceScope != "" && !claims.HasScope(policy.ServiceScope) {
+			log.WithContext(ctx).Warn("Service token lacks required scope",
+				logger.String("method", method),
+				logger.String("client_id", claims.ClientID),
+				logger.String("scope", policy.ServiceScope),
+			)
+			return nil, status.Errorf(codes.PermissionDenied, "service token lacks scope %s", policy.ServiceScope)
+		}
+
+		ctx = context.WithValue(ctx, serviceClaimsKey{}, claims)
+		ctx = requestctx.WithClientID(ctx, claims.ClientID)
+
+		return ctx, nil
+	}
+}
+
+// bearerFromMetadata возвращает токен из значения "Bearer <token>" ключа metadata
+func bearerFromMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// contextStream подменяет контекст потока, чтобы обработчик видел значения интерцепторов
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
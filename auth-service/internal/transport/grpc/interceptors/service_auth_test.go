@@ -0,0 +1,87 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"social-network/auth-service/internal/infrastructure/keys"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/logger"
+)
+
+const (
+	testAudience = "auth-service"
+	testMethod   = "/auth.v1.AuthService/Login"
+)
+
+func newTestJWTService(t *testing.T) *service.JWTService {
+	t.Helper()
+
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+	keySet, err := keys.NewKeySet("", "", 0, log)
+	if err != nil {
+		t.Fatalf("create key set: %v", err)
+	}
+	return service.NewJWTService(keySet, []byte("test-refresh-secret"), "https://auth.test")
+}
+
+func withServiceToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataServiceAuthorization, "Bearer "+token))
+}
+
+func TestUnaryServiceAuth(t *testing.T) {
+	jwtService := newTestJWTService(t)
+	policies := map[string]MethodPolicy{testMethod: {ServiceScope: "auth:login"}}
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+
+	mustToken := func(audience, scope string) string {
+		t.Helper()
+		token, err := jwtService.GenerateServiceToken("gateway", audience, scope)
+		if err != nil {
+			t.Fatalf("generate token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		required bool
+		wantCode codes.Code
+	}{
+		{"valid token", withServiceToken(mustToken(testAudience, "auth:login")), testMethod, true, codes.OK},
+		{"method without policy", withServiceToken(mustToken(testAudience, "auth:login")), "/auth.v1.AuthService/Unknown", true, codes.PermissionDenied},
+		{"missing token when required", context.Background(), testMethod, true, codes.Unauthenticated},
+		{"missing token when optional", context.Background(), testMethod, false, codes.OK},
+		{"invalid token when optional", withServiceToken("not-a-jwt"), testMethod, false, codes.Unauthenticated},
+		{"token for other audience", withServiceToken(mustToken("posts-service", "auth:login")), testMethod, true, codes.Unauthenticated},
+		{"token without scope", withServiceToken(mustToken(testAudience, "auth:validate")), testMethod, true, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := UnaryServiceAuth(jwtService, testAudience, policies, tt.required, log)
+
+			var claims *service.ServiceTokenClaims
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req any) (any, error) {
+					claims = ServiceClaims(ctx)
+					return nil, nil
+				})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && tt.ctx != context.Background() && (claims == nil || claims.ClientID != "gateway") {
+				t.Fatalf("service claims must be stored in the context, got %+v", claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/grpc/interceptors"
+	pb "social-network/auth-service/pkg/api/proto/auth/v1"
+)
+
+// Scope токенов сервисов для вызова RPC auth-service. Выдаются OAuth клиентам
+// с audience auth-service (см. cmd/oauth-client).
+const (
+	// ScopeAuthLogin - регистрация, вход и восстановление доступа от имени пользователя (API gateway)
+	ScopeAuthLogin = "auth:login"
+	// ScopeAuthAccount - управление аккаунтом по access токену пользователя
+	ScopeAuthAccount = "auth:account"
+	// ScopeAuthValidate - проверка токенов пользователей
+	ScopeAuthValidate = "auth:validate"
+	// ScopeAuthRolesRead - чтение ролей пользователей
+	ScopeAuthRolesRead = "auth:roles.read"
+	// ScopeAuthRolesWrite - назначение и отзыв ролей
+	ScopeAuthRolesWrite = "auth:roles.write"
+	// ScopeAuthAuditRead - чтение журнала аудита
+	ScopeAuthAuditRead = "auth:audit.read"
+	// ScopeAuthUsersRead - поиск пользователей
+	ScopeAuthUsersRead = "auth:users.read"
+	// ScopeAuthUsersWrite - блокировка и разблокировка пользователей
+	ScopeAuthUsersWrite = "auth:users.write"
+	// ScopeAuthUsersImpersonate - вход администратора от имени пользователя
+	ScopeAuthUsersImpersonate = "auth:users.impersonate"
+)
+
+// methodPolicies - требования к каждому RPC методу: уровень доступа пользователя и scope
+// вызывающего сервиса. Метод без записи в таблице отклоняется, поэтому новый RPC нужно добавить сюда.
+// ValidateToken публичный: проверяемый токен передается в теле запроса, а не как токен вызывающего.
+// Токеном имперсонации нельзя менять учетные данные, 2FA, сессии и роли (DenyImpersonation).
+var methodPolicies = map[string]interceptors.MethodPolicy{
+	pb.AuthService_Register_FullMethodName:                {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_Login_FullMethodName:                   {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_RefreshToken_FullMethodName:            {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_VerifyEmail_FullMethodName:             {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_ResendVerificationEmail_FullMethodName: {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_InitiatePasswordReset_FullMethodName:   {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_ResetPassword_FullMethodName:           {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_RequestMagicLink_FullMethodName:        {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_LoginWithMagicLink_FullMethodName:      {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_VerifyMFA_FullMethodName:               {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_BeginPasskeyLogin_FullMethodName:       {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+	pb.AuthService_FinishPasskeyLogin_FullMethodName:      {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthLogin},
+
+	pb.AuthService_GetCurrentUser_FullMethodName:            {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount},
+	pb.AuthService_ChangePassword_FullMethodName:            {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_Logout_FullMethodName:                    {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount},
+	pb.AuthService_ListSessions_FullMethodName:              {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount},
+	pb.AuthService_RevokeSession_FullMethodName:             {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_RevokeOtherSessions_FullMethodName:       {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_EnrollTOTP_FullMethodName:                {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_ConfirmTOTP_FullMethodName:               {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_DisableTOTP_FullMethodName:               {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_BeginPasskeyRegistration_FullMethodName:  {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_FinishPasskeyRegistration_FullMethodName: {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_ListPasskeys_FullMethodName:              {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount},
+	pb.AuthService_DeletePasskey_FullMethodName:             {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+	pb.AuthService_RemovePassword_FullMethodName:            {Access: interceptors.AccessAuthenticated, ServiceScope: ScopeAuthAccount, DenyImpersonation: true},
+
+	pb.AuthService_ValidateToken_FullMethodName: {Access: interceptors.AccessPublic, ServiceScope: ScopeAuthValidate},
+
+	pb.AuthService_GetUserRoles_FullMethodName:   {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionRolesRead, ServiceScope: ScopeAuthRolesRead},
+	pb.AuthService_GetRoleHistory_FullMethodName: {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionRolesRead, ServiceScope: ScopeAuthRolesRead},
+	pb.AuthService_AssignRole_FullMethodName:     {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionRolesAssign, ServiceScope: ScopeAuthRolesWrite, DenyImpersonation: true},
+	pb.AuthService_RevokeRole_FullMethodName:     {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionRolesAssign, ServiceScope: ScopeAuthRolesWrite, DenyImpersonation: true},
+
+	pb.AuthService_ListAuditEvents_FullMethodName: {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionAuditRead, ServiceScope: ScopeAuthAuditRead},
+
+	pb.AuthService_ListUsers_FullMethodName:       {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionUsersRead, ServiceScope: ScopeAuthUsersRead},
+	pb.AuthService_SuspendUser_FullMethodName:     {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionUsersBan, ServiceScope: ScopeAuthUsersWrite, DenyImpersonation: true},
+	pb.AuthService_ImpersonateUser_FullMethodName: {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionUsersImpersonate, ServiceScope: ScopeAuthUsersImpersonate, DenyImpersonation: true},
+	pb.AuthService_ReactivateUser_FullMethodName:  {Access: interceptors.AccessAuthenticated, Permission: domain.PermissionUsersBan, ServiceScope: ScopeAuthUsersWrite, DenyImpersonation: true},
+
+	// Reflection отдает только схему API. Токен пользователя не нужен, а токен сервиса
+	// проверяется как у остальных методов, если он обязателен.
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName:      {Access: interceptors.AccessPublic},
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: {Access: interceptors.AccessPublic},
+}
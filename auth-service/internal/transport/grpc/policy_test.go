@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "social-network/auth-service/pkg/api/proto/auth/v1"
+)
+
+// Метод без записи в methodPolicies всегда отклоняется, поэтому новый RPC без политики
+// был бы недоступен
+func TestMethodPolicies_CoverAuthService(t *testing.T) {
+	for _, method := range pb.AuthService_ServiceDesc.Methods {
+		fullMethod := "/" + pb.AuthService_ServiceDesc.ServiceName + "/" + method.MethodName
+		if _, ok := methodPolicies[fullMethod]; !ok {
+			t.Errorf("no policy for %s", fullMethod)
+		}
+	}
+}
+
+// Зарегистрированные на сервере сервисы, включая reflection, тоже должны иметь политику
+func TestMethodPolicies_CoverRegisteredServices(t *testing.T) {
+	server := grpc.NewServer()
+	registerServices(server, pb.UnimplementedAuthServiceServer{})
+
+	for serviceName, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			fullMethod := "/" + serviceName + "/" + method.Name
+			if _, ok := methodPolicies[fullMethod]; !ok {
+				t.Errorf("no policy for %s", fullMethod)
+			}
+		}
+	}
+}
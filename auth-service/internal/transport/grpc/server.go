@@ -13,6 +13,7 @@ import (
 	"social-network/auth-service/internal/config"
 	"social-network/auth-service/internal/service"
 	"social-network/auth-service/internal/transport/grpc/handlers"
+	"social-network/auth-service/internal/transport/grpc/interceptors"
 	pb "social-network/auth-service/pkg/api/proto/auth/v1"
 	"social-network/auth-service/pkg/logger"
 )
@@ -27,6 +28,11 @@ func NewServer(
 	cfg *config.Config,
 	authService *service.AuthService,
 	jwtService *service.JWTService,
+	tokenRevocation *service.TokenRevocationService,
+	passkeyService *service.PasskeyService,
+	personalAccessTokens *service.PersonalAccessTokenService,
+	impersonation *service.ImpersonationService,
+	auditService *service.AuditService,
 	validationService *service.ValidationService,
 	logger logger.Logger,
 ) *Server {
@@ -43,16 +49,22 @@ func NewServer(
 			MinTime:             5 * time.Second,
 			PermitWithoutStream: true,
 		}),
+		grpc.ChainUnaryInterceptor(
+			interceptors.UnaryRequestID(),
+			interceptors.UnaryServiceAuth(jwtService, cfg.Server.GRPC.ServiceAudience, methodPolicies, cfg.Server.GRPC.RequireServiceAuth, logger),
+			interceptors.UnaryAuth(tokenRevocation, methodPolicies, logger),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.StreamServiceAuth(jwtService, cfg.Server.GRPC.ServiceAudience, methodPolicies, cfg.Server.GRPC.RequireServiceAuth, logger),
+			interceptors.StreamAuth(tokenRevocation, methodPolicies, logger),
+		),
 	}
 
 	server := grpc.NewServer(opts...)
 
 	// Register services
-	authHandler := handlers.NewAuthHandler(authService, jwtService, validationService, logger)
-	pb.RegisterAuthServiceServer(server, authHandler)
-
-	// Enable reflection for gRPC testing (always enabled for development)
-	reflection.Register(server)
+	authHandler := handlers.NewAuthHandler(authService, jwtService, tokenRevocation, passkeyService, personalAccessTokens, impersonation, auditService, validationService, logger)
+	registerServices(server, authHandler)
 
 	logger.Info("gRPC server created with reflection enabled")
 
@@ -63,6 +75,14 @@ func NewServer(
 	}
 }
 
+// registerServices регистрирует сервисы сервера. У каждого их метода должна быть запись в methodPolicies.
+func registerServices(server *grpc.Server, authServer pb.AuthServiceServer) {
+	pb.RegisterAuthServiceServer(server, authServer)
+
+	// Enable reflection for gRPC testing (always enabled for development)
+	reflection.Register(server)
+}
+
 func (s *Server) Start() error {
 	lis, err := net.Listen("tcp", ":"+s.config.Server.GRPC.Port)
 	if err != nil {
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"encoding/json"
+	"social-network/auth-service/pkg/webauthn"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,12 +13,14 @@ type RegisterRequest struct {
 	Email       string `json:"email" binding:"required,email"`
 	Username    string `json:"username" binding:"required,min=3,max=30"`
 	DisplayName string `json:"display_name" binding:"required,min=1,max=100"`
-	Password    string `json:"password" binding:"required,min=8,max=128"`
+	// Пароль необязателен: аккаунт без пароля входит через passkeys или magic link
+	Password string `json:"password" binding:"omitempty,min=8,max=128"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
 }
 
 type RefreshTokenRequest struct {
@@ -27,6 +31,10 @@ type VerifyEmailRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type InitiatePasswordResetRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
@@ -36,8 +44,18 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" binding:"required,min=8,max=128"`
 }
 
+type RequestMagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type MagicLinkLoginRequest struct {
+	Token      string `json:"token" binding:"required"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
+}
+
 type ChangePasswordRequest struct {
-	CurrentPassword string `json:"current_password" binding:"required"`
+	// Для аккаунта без пароля current_password не передается
+	CurrentPassword string `json:"current_password"`
 	NewPassword     string `json:"new_password" binding:"required,min=8,max=128"`
 }
 
@@ -46,7 +64,99 @@ type LogoutRequest struct {
 }
 
 type AssignRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+	Role   string `json:"role" binding:"required,max=50"`
+	Reason string `json:"reason" binding:"max=255"`
+	// ExpiresAt делает роль временной
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type RoleHistoryQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// UsersQuery - поиск пользователей администратором. email сравнивается целиком, username - по
+// началу имени, обе проверки без учета регистра. created_from включительно, created_to не включительно.
+type UsersQuery struct {
+	Email          string    `form:"email" binding:"omitempty,max=255"`
+	UsernamePrefix string    `form:"username" binding:"omitempty,max=30"`
+	IsVerified     *bool     `form:"is_verified"`
+	IsActive       *bool     `form:"is_active"`
+	CreatedFrom    time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo      time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor         string    `form:"cursor"`
+	Limit          int       `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+	// Until делает блокировку временной
+	Until *time.Time `json:"until,omitempty"`
+}
+
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest заменяет описание и права роли целиком
+type UpdateRoleRequest struct {
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTOTPRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken   string `json:"mfa_token" binding:"required"`
+	Code       string `json:"code" binding:"required"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	SessionID  uuid.UUID       `json:"session_id" binding:"required"`
+	Name       string          `json:"name" binding:"omitempty,max=100"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+}
+
+type FinishPasskeyLoginRequest struct {
+	SessionID  uuid.UUID       `json:"session_id" binding:"required"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+	DeviceName string          `json:"device_name" binding:"omitempty,max=100"`
+}
+
+type RemovePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+// FinishSocialLoginRequest - code и state из адреса возврата провайдера
+type FinishSocialLoginRequest struct {
+	Code       string `json:"code" binding:"required"`
+	State      string `json:"state" binding:"required"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
+}
+
+// CreatePersonalAccessTokenRequest - без expires_at токен бессрочный, если это разрешено конфигурацией
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type FinishSocialLinkRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
 }
 
 // Response DTOs
@@ -61,6 +171,25 @@ type UserResponse struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+type SuspensionResponse struct {
+	SuspendedBy *uuid.UUID `json:"suspended_by,omitempty"`
+	Reason      string     `json:"reason"`
+	SuspendedAt time.Time  `json:"suspended_at"`
+	Until       *time.Time `json:"until,omitempty"`
+}
+
+// AdminUserResponse - пользователь с данными о блокировке, виден администраторам
+type AdminUserResponse struct {
+	UserResponse
+	Suspension *SuspensionResponse `json:"suspension,omitempty"`
+}
+
+type ListUsersResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	// NextCursor пуст на последней странице
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -68,11 +197,127 @@ type TokenResponse struct {
 	ExpiresIn    int64  `json:"expires_in"`
 }
 
+// ImpersonationResponse - access токен без refresh токена: по истечении имперсонацию начинают заново
+type ImpersonationResponse struct {
+	AccessToken string            `json:"access_token"`
+	TokenType   string            `json:"token_type"`
+	ExpiresIn   int64             `json:"expires_in"`
+	User        AdminUserResponse `json:"user"`
+}
+
 type LoginResponse struct {
 	Tokens TokenResponse `json:"tokens"`
 	User   UserResponse  `json:"user"`
 }
 
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type ConfirmTOTPResponse struct {
+	Message       string   `json:"message"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// BeginPasskeyRegistrationResponse - options передаются в navigator.credentials.create()
+type BeginPasskeyRegistrationResponse struct {
+	SessionID uuid.UUID                           `json:"session_id"`
+	Options   *webauthn.CredentialCreationOptions `json:"options" swaggertype:"object"`
+}
+
+// BeginPasskeyLoginResponse - options передаются в navigator.credentials.get()
+type BeginPasskeyLoginResponse struct {
+	SessionID uuid.UUID                          `json:"session_id"`
+	Options   *webauthn.CredentialRequestOptions `json:"options" swaggertype:"object"`
+}
+
+type PasskeyResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Transports   []string   `json:"transports"`
+	CloneWarning bool       `json:"clone_warning"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type ListPasskeysResponse struct {
+	Passkeys []PasskeyResponse `json:"passkeys"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	IsExpired  bool       `json:"is_expired"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatePersonalAccessTokenResponse - token показывается только в этом ответе
+type CreatePersonalAccessTokenResponse struct {
+	Token               string                      `json:"token"`
+	PersonalAccessToken PersonalAccessTokenResponse `json:"personal_access_token"`
+}
+
+type ListPersonalAccessTokensResponse struct {
+	Tokens []PersonalAccessTokenResponse `json:"tokens"`
+}
+
+type RoleResponse struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	IsSystem    bool      `json:"is_system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListRolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// ListPermissionsResponse - права, о которых знает auth-service; роли могут содержать и другие
+type ListPermissionsResponse struct {
+	Permissions []string `json:"permissions"`
+}
+
+type SocialProviderResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+type ListSocialProvidersResponse struct {
+	Providers []SocialProviderResponse `json:"providers"`
+}
+
+// BeginSocialLoginResponse - клиент сохраняет state и перенаправляет браузер на authorization_url
+type BeginSocialLoginResponse struct {
+	State            string `json:"state"`
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type UserIdentityResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ListUserIdentitiesResponse struct {
+	Identities []UserIdentityResponse `json:"identities"`
+}
+
 type RegisterResponse struct {
 	User    UserResponse `json:"user"`
 	Message string       `json:"message"`
@@ -83,26 +328,105 @@ type MessageResponse struct {
 }
 
 type ValidateTokenResponse struct {
-	Valid bool         `json:"valid"`
-	User  UserResponse `json:"user,omitempty"`
-	Roles []string     `json:"roles,omitempty"`
+	Valid       bool         `json:"valid"`
+	User        UserResponse `json:"user,omitempty"`
+	Roles       []string     `json:"roles,omitempty"`
+	Permissions []string     `json:"permissions,omitempty"`
+	// ImpersonatorID - администратор, вошедший от имени пользователя
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
 }
 
 type UserRoleResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Role      string    `json:"role"`
-	GrantedAt time.Time `json:"granted_at"`
-	IsActive  bool      `json:"is_active"`
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Role      string     `json:"role"`
+	GrantedAt time.Time  `json:"granted_at"`
+	GrantedBy *uuid.UUID `json:"granted_by,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	IsActive  bool       `json:"is_active"`
 }
 
 type GetUserRolesResponse struct {
 	Roles []UserRoleResponse `json:"roles"`
 }
 
+type RoleChangeResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Role      string     `json:"role"`
+	Action    string     `json:"action" example:"granted"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RoleHistoryResponse struct {
+	Changes []RoleChangeResponse `json:"changes"`
+}
+
+// AuditEventsQuery - фильтр журнала аудита. user_id совпадает и с актором, и с целью события,
+// type можно передать несколько раз, from включительно, to не включительно.
+type AuditEventsQuery struct {
+	UserID string    `form:"user_id" binding:"omitempty,uuid"`
+	Types  []string  `form:"type"`
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor string    `form:"cursor"`
+	Limit  int       `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type AuditEventResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      string            `json:"type" example:"login.failed"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	TargetID  *uuid.UUID        `json:"target_id,omitempty"`
+	IPAddress string            `json:"ip_address"`
+	UserAgent string            `json:"user_agent"`
+	RequestID string            `json:"request_id,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type ListAuditEventsResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	// NextCursor пуст на последней странице
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	DeviceName string     `json:"device_name"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	IsCurrent  bool       `json:"is_current"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type RevokeSessionsResponse struct {
+	Message      string `json:"message"`
+	RevokedCount int64  `json:"revoked_count"`
+}
+
 type ErrorResponse struct {
 	Error     string    `json:"error"`
 	Message   string    `json:"message"`
 	Timestamp time.Time `json:"timestamp"`
 	Path      string    `json:"path"`
 }
+
+type PasswordViolationResponse struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type PasswordPolicyErrorResponse struct {
+	ErrorResponse
+	Violations []PasswordViolationResponse `json:"violations"`
+}
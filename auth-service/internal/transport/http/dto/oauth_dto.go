@@ -0,0 +1,130 @@
+package dto
+
+// IntrospectRequest - запрос проверки токена (RFC 7662), передается как form-urlencoded
+type IntrospectRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// IntrospectResponse - ответ проверки токена. Для недействительного токена
+// возвращается только active=false.
+type IntrospectResponse struct {
+	Active      bool     `json:"active"`
+	Scope       string   `json:"scope,omitempty"`
+	Username    string   `json:"username,omitempty"`
+	TokenType   string   `json:"token_type,omitempty"`
+	Exp         int64    `json:"exp,omitempty"`
+	Iat         int64    `json:"iat,omitempty"`
+	Sub         string   `json:"sub,omitempty"`
+	Iss         string   `json:"iss,omitempty"`
+	SessionID   string   `json:"sid,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"perm,omitempty"`
+	// Act заполнен для токена, выданного администратору от имени пользователя (RFC 8693)
+	Act *IntrospectActor `json:"act,omitempty"`
+}
+
+type IntrospectActor struct {
+	Sub string `json:"sub"`
+}
+
+// RevokeRequest - запрос отзыва токена (RFC 7009), передается как form-urlencoded
+type RevokeRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// OAuthErrorResponse - ошибка в формате RFC 6749, раздел 5.2
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// AuthorizeRequest - параметры запроса авторизации OpenID Connect (RFC 6749, раздел 4.1.1).
+// В /oauth/authorize передаются в query, в /api/oauth/authorize - в теле JSON.
+type AuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required"`
+	ClientID            string `form:"client_id" json:"client_id" binding:"required"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri" binding:"required"`
+	Scope               string `form:"scope" json:"scope" binding:"required"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// AuthorizationDecisionRequest - завершение авторизации вошедшим пользователем. Consent
+// передается после показа экрана согласия.
+type AuthorizationDecisionRequest struct {
+	AuthorizeRequest
+	Consent string `json:"consent" binding:"omitempty,oneof=approve deny" example:"approve"`
+}
+
+// AuthorizationRedirectResponse - адрес, на который страница входа перенаправляет браузер
+type AuthorizationRedirectResponse struct {
+	RedirectTo string `json:"redirect_to" example:"https://client.example.com/callback?code=...&state=..."`
+}
+
+// ConsentRequiredResponse - нужно согласие пользователя на выдачу клиенту scope
+type ConsentRequiredResponse struct {
+	ConsentRequired bool     `json:"consent_required" example:"true"`
+	ClientID        string   `json:"client_id" example:"photo-app"`
+	ClientName      string   `json:"client_name" example:"Photo App"`
+	Scopes          []string `json:"scopes" example:"openid,profile,email"`
+}
+
+// TokenRequest - запрос token endpoint (RFC 6749, разделы 4.1.3, 4.4 и 6), передается как form-urlencoded
+type TokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Audience     string `form:"audience"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// TokenEndpointResponse - токены, выданные клиенту OpenID Connect или сервису по client credentials
+type TokenEndpointResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// UserInfoResponse - ответ /oauth/userinfo. Набор claims зависит от scope токена.
+type UserInfoResponse struct {
+	Sub               string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// OpenIDConfigurationResponse - discovery документ OpenID Connect
+type OpenIDConfigurationResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListAuditEvents godoc
+// @Summary List audit events
+// @Description Query the security audit log, newest first. Pass next_cursor as cursor to get the next page. Requires the audit.read permission
+// @Tags audit
+// @Security BearerAuth
+// @Produce json
+// @Param user_id query string false "Actor or target user ID"
+// @Param type query []string false "Event types, e.g. login.failed" collectionFormat(multi)
+// @Param from query string false "Inclusive lower bound, RFC 3339"
+// @Param to query string false "Exclusive upper bound, RFC 3339"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default 50, at most 500)"
+// @Success 200 {object} dto.ListAuditEventsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/audit/events [get]
+func (h *AuthHandler) ListAuditEvents(c *gin.Context) {
+	query, ok := h.bindAuditQuery(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.auditService.ListEvents(c.Request.Context(), query)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	events := make([]dto.AuditEventResponse, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = h.mapAuditEventToDTO(event)
+	}
+
+	c.JSON(http.StatusOK, dto.ListAuditEventsResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// ExportAuditEvents godoc
+// @Summary Export audit events
+// @Description Stream all matching audit events as newline-delimited JSON, newest first. Accepts the same filters as the list endpoint except cursor and limit. Requires the audit.read permission
+// @Tags audit
+// @Security BearerAuth
+// @Produce application/x-ndjson
+// @Param user_id query string false "Actor or target user ID"
+// @Param type query []string false "Event types, e.g. login.failed" collectionFormat(multi)
+// @Param from query string false "Inclusive lower bound, RFC 3339"
+// @Param to query string false "Exclusive upper bound, RFC 3339"
+// @Success 200 {object} dto.AuditEventResponse "One event per line"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/audit/events/export [get]
+func (h *AuthHandler) ExportAuditEvents(c *gin.Context) {
+	query, ok := h.bindAuditQuery(c)
+	if !ok {
+		return
+	}
+	query.Cursor = ""
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="audit-events.ndjson"`)
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	err := h.auditService.ExportEvents(c.Request.Context(), query, func(event *domain.AuditEvent) error {
+		return encoder.Encode(h.mapAuditEventToDTO(event))
+	})
+	if err != nil {
+		// Заголовки уже отправлены, поэтому оборванный экспорт видно только по логу и по неполному файлу
+		h.logger.WithContext(c.Request.Context()).Error("Audit export failed", logger.Error(err))
+	}
+}
+
+func (h *AuthHandler) bindAuditQuery(c *gin.Context) (service.AuditQuery, bool) {
+	var req dto.AuditEventsQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return service.AuditQuery{}, false
+	}
+
+	query := service.AuditQuery{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+	if req.UserID != "" {
+		userID := uuid.MustParse(req.UserID)
+		query.UserID = &userID
+	}
+	for _, eventType := range req.Types {
+		query.Types = append(query.Types, domain.AuditEventType(eventType))
+	}
+	if !req.From.IsZero() {
+		query.From = &req.From
+	}
+	if !req.To.IsZero() {
+		query.To = &req.To
+	}
+
+	return query, true
+}
+
+func (h *AuthHandler) mapAuditEventToDTO(event *domain.AuditEvent) dto.AuditEventResponse {
+	return dto.AuditEventResponse{
+		ID:        event.ID(),
+		Type:      string(event.Type()),
+		ActorID:   event.ActorID(),
+		TargetID:  event.TargetID(),
+		IPAddress: event.IPAddress(),
+		UserAgent: event.UserAgent(),
+		RequestID: event.RequestID(),
+		Details:   event.Details(),
+		CreatedAt: event.CreatedAt(),
+	}
+}
@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"math"
 	"net/http"
 	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/service"
 	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/internal/transport/http/middleware"
 	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/oidc"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,23 +20,41 @@ import (
 )
 
 type AuthHandler struct {
-	authService       *service.AuthService
-	jwtService        *service.JWTService
-	validationService *service.ValidationService
-	logger            logger.Logger
+	authService          *service.AuthService
+	jwtService           *service.JWTService
+	passkeyService       *service.PasskeyService
+	socialLoginService   *service.SocialLoginService
+	personalAccessTokens *service.PersonalAccessTokenService
+	roleService          *service.RoleService
+	impersonation        *service.ImpersonationService
+	auditService         *service.AuditService
+	validationService    *service.ValidationService
+	logger               logger.Logger
 }
 
 func NewAuthHandler(
 	authService *service.AuthService,
 	jwtService *service.JWTService,
+	passkeyService *service.PasskeyService,
+	socialLoginService *service.SocialLoginService,
+	personalAccessTokens *service.PersonalAccessTokenService,
+	roleService *service.RoleService,
+	impersonation *service.ImpersonationService,
+	auditService *service.AuditService,
 	validationService *service.ValidationService,
 	logger logger.Logger,
 ) *AuthHandler {
 	return &AuthHandler{
-		authService:       authService,
-		jwtService:        jwtService,
-		validationService: validationService,
-		logger:            logger,
+		authService:          authService,
+		jwtService:           jwtService,
+		passkeyService:       passkeyService,
+		socialLoginService:   socialLoginService,
+		personalAccessTokens: personalAccessTokens,
+		roleService:          roleService,
+		impersonation:        impersonation,
+		auditService:         auditService,
+		validationService:    validationService,
+		logger:               logger,
 	}
 }
 
@@ -41,7 +66,7 @@ func NewAuthHandler(
 // @Produce json
 // @Param request body dto.RegisterRequest true "Registration data"
 // @Success 201 {object} dto.RegisterResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.PasswordPolicyErrorResponse "Validation error or password policy violations"
 // @Failure 409 {object} dto.ErrorResponse
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -53,16 +78,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// Валидация данных
 	if err := h.validationService.ValidateRegistrationData(
-		req.Email, req.Username, req.DisplayName, req.Password,
+		req.Email, req.Username, req.DisplayName,
 	); err != nil {
 		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
 		return
 	}
 
 	// Регистрация пользователя
-	user, err := h.authService.RegisterUser(req.Email, req.Username, req.DisplayName, req.Password)
+	user, err := h.authService.RegisterUser(c.Request.Context(), req.Email, req.Username, req.DisplayName, req.Password)
 	if err != nil {
-		h.logger.Error("Registration failed",
+		h.logger.WithContext(c.Request.Context()).Error("Registration failed",
 			logger.String("email", req.Email),
 			logger.String("username", req.Username),
 			logger.Error(err),
@@ -76,7 +101,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Message: "User registered successfully. Please check your email for verification.",
 	}
 
-	h.logger.Info("User registered successfully",
+	h.logger.WithContext(c.Request.Context()).Info("User registered successfully",
 		logger.String("user_id", user.ID().String()),
 		logger.String("username", user.Username()),
 	)
@@ -86,14 +111,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login user
-// @Description Authenticate user and return tokens
+// @Description Authenticate user and return tokens. If two-factor authentication is enabled, an mfa_required challenge is returned instead; exchange it via /auth/2fa/verify
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Login credentials"
 // @Success 200 {object} dto.LoginResponse
+// @Success 202 {object} dto.MFAChallengeResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts; see the Retry-After header"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -103,9 +130,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Аутентификация
-	user, err := h.authService.AuthenticateUser(req.Email, req.Password)
+	user, err := h.authService.AuthenticateUser(c.Request.Context(), req.Email, req.Password, h.clientInfo(c, req.DeviceName))
 	if err != nil {
-		h.logger.Warn("Login attempt failed",
+		h.logger.WithContext(c.Request.Context()).Warn("Login attempt failed",
 			logger.String("email", req.Email),
 			logger.String("client_ip", c.ClientIP()),
 			logger.Error(err),
@@ -114,56 +141,24 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Получение ролей
-	roles, err := h.authService.GetUserRoles(user.ID())
-	if err != nil {
-		h.logger.Error("Failed to get user roles",
-			logger.String("user_id", user.ID().String()),
-			logger.Error(err),
-		)
-		roles = []*domain.UserRole{} // Пустой массив ролей
-	}
-
-	// Генерация токенов
-	roleStrings := make([]domain.UserRoleType, len(roles))
-	for i, role := range roles {
-		roleStrings[i] = role.Role()
-	}
-
-	accessToken, err := h.jwtService.GenerateAccessToken(user, roleStrings)
+	// Проверка второго фактора
+	mfaEnabled, err := h.authService.IsMFAEnabled(c.Request.Context(), user.ID())
 	if err != nil {
-		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate access token")
+		h.handleServiceError(c, err)
 		return
 	}
 
-	refreshTokenEntity, err := h.authService.CreateRefreshToken(user.ID())
-	if err != nil {
-		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate refresh token")
+	if mfaEnabled {
+		h.respondMFAChallenge(c, user)
 		return
 	}
 
-	response := dto.LoginResponse{
-		Tokens: dto.TokenResponse{
-			AccessToken:  accessToken,
-			RefreshToken: refreshTokenEntity.Token(),
-			TokenType:    "Bearer",
-			ExpiresIn:    900, // 15 minutes
-		},
-		User: h.mapUserToDTO(user),
-	}
-
-	h.logger.Info("User logged in successfully",
-		logger.String("user_id", user.ID().String()),
-		logger.String("username", user.Username()),
-		logger.String("client_ip", c.ClientIP()),
-	)
-
-	c.JSON(http.StatusOK, response)
+	h.completeLogin(c, user, req.DeviceName)
 }
 
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Generate new access token using refresh token
+// @Description Rotate refresh token and generate new access token. Reusing an already rotated refresh token revokes the whole session
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -179,53 +174,40 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	// Валидация refresh token
-	refreshToken, err := h.authService.ValidateRefreshToken(req.RefreshToken)
+	// Ротация refresh token
+	newRefreshToken, err := h.authService.RotateRefreshToken(c.Request.Context(), req.RefreshToken, "", h.clientInfo(c, ""))
 	if err != nil {
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			h.logger.WithContext(c.Request.Context()).Warn("Refresh token reuse detected",
+				logger.String("client_ip", c.ClientIP()),
+			)
+			h.respondError(c, http.StatusUnauthorized, "token_reused", "Refresh token has already been used; the session has been revoked")
+			return
+		}
 		h.respondError(c, http.StatusUnauthorized, "invalid_token", "Invalid or expired refresh token")
 		return
 	}
 
 	// Получение пользователя
-	user, err := h.authService.GetUserByID(refreshToken.UserID())
+	user, err := h.authService.GetUserByID(c.Request.Context(), newRefreshToken.UserID())
 	if err != nil {
 		h.respondError(c, http.StatusUnauthorized, "user_not_found", "User not found")
 		return
 	}
 
-	// Получение ролей
-	roles, err := h.authService.GetUserRoles(user.ID())
+	// Получение ролей и прав
+	access, err := h.authService.GetUserAccess(c.Request.Context(), user.ID())
 	if err != nil {
-		roles = []*domain.UserRole{}
-	}
-
-	roleStrings := make([]domain.UserRoleType, len(roles))
-	for i, role := range roles {
-		roleStrings[i] = role.Role()
+		access = &service.UserAccess{}
 	}
 
 	// Генерация нового access token
-	accessToken, err := h.jwtService.GenerateAccessToken(user, roleStrings)
+	accessToken, err := h.jwtService.GenerateAccessToken(user, access, newRefreshToken.SessionID())
 	if err != nil {
 		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate access token")
 		return
 	}
 
-	// Создание нового refresh token
-	newRefreshToken, err := h.authService.CreateRefreshToken(user.ID())
-	if err != nil {
-		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate refresh token")
-		return
-	}
-
-	// Отзыв старого refresh token
-	if err := h.authService.RevokeRefreshToken(req.RefreshToken); err != nil {
-		h.logger.Error("Failed to revoke old refresh token",
-			logger.String("user_id", user.ID().String()),
-			logger.Error(err),
-		)
-	}
-
 	response := dto.TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken.Token(),
@@ -253,7 +235,7 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.VerifyEmail(req.Token); err != nil {
+	if err := h.authService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
@@ -263,6 +245,33 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	})
 }
 
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description Send a new email verification link. Unknown and already verified addresses, as well as repeated requests within the resend cooldown, get the same response
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResendVerificationEmailRequest true "Email address"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	var req dto.ResendVerificationEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	if err := h.authService.ResendVerificationEmail(c.Request.Context(), req.Email); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "If the email is registered and not yet verified, a verification link has been sent",
+	})
+}
+
 // InitiatePasswordReset godoc
 // @Summary Initiate password reset
 // @Description Send password reset email to user
@@ -279,8 +288,8 @@ func (h *AuthHandler) InitiatePasswordReset(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.InitiatePasswordReset(req.Email); err != nil {
-		h.logger.Error("Password reset initiation failed",
+	if err := h.authService.InitiatePasswordReset(c.Request.Context(), req.Email); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Password reset initiation failed",
 			logger.String("email", req.Email),
 			logger.Error(err),
 		)
@@ -300,7 +309,7 @@ func (h *AuthHandler) InitiatePasswordReset(c *gin.Context) {
 // @Produce json
 // @Param request body dto.ResetPasswordRequest true "Reset token and new password"
 // @Success 200 {object} dto.MessageResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.PasswordPolicyErrorResponse "Validation error or password policy violations"
 // @Router /auth/reset-password/confirm [post]
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req dto.ResetPasswordRequest
@@ -309,21 +318,90 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.validationService.ValidatePassword(req.NewPassword); err != nil {
-		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		h.handleServiceError(c, err)
 		return
 	}
 
-	if err := h.authService.ResetPassword(req.Token, req.NewPassword); err != nil {
-		h.handleServiceError(c, err)
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Password reset successfully",
+	})
+}
+
+// RequestMagicLink godoc
+// @Summary Request magic link
+// @Description Send a single-use sign-in link to the email address. The response does not reveal whether the email is registered
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RequestMagicLinkRequest true "Email address"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /auth/magic-link [post]
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	var req dto.RequestMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
 		return
 	}
 
+	if err := h.authService.RequestMagicLink(c.Request.Context(), req.Email); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Magic link request failed",
+			logger.String("email", req.Email),
+			logger.Error(err),
+		)
+	}
+
+	// Всегда возвращаем успешный ответ для безопасности
 	c.JSON(http.StatusOK, dto.MessageResponse{
-		Message: "Password reset successfully",
+		Message: "If the email exists, a sign-in link has been sent",
 	})
 }
 
+// MagicLinkLogin godoc
+// @Summary Login with magic link
+// @Description Exchange a magic link token for tokens. If two-factor authentication is enabled, an mfa_required challenge is returned instead; exchange it via /auth/2fa/verify
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MagicLinkLoginRequest true "Magic link token"
+// @Success 200 {object} dto.LoginResponse
+// @Success 202 {object} dto.MFAChallengeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/magic-link/verify [post]
+func (h *AuthHandler) MagicLinkLogin(c *gin.Context) {
+	var req dto.MagicLinkLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	user, err := h.authService.LoginWithMagicLink(c.Request.Context(), req.Token)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn("Magic link login failed",
+			logger.String("client_ip", c.ClientIP()),
+			logger.Error(err),
+		)
+		h.handleServiceError(c, err)
+		return
+	}
+
+	// Ссылка заменяет только пароль: второй фактор по-прежнему нужен
+	mfaEnabled, err := h.authService.IsMFAEnabled(c.Request.Context(), user.ID())
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	if mfaEnabled {
+		h.respondMFAChallenge(c, user)
+		return
+	}
+
+	h.completeLogin(c, user, req.DeviceName)
+}
+
 // GetCurrentUser godoc
 // @Summary Get current user
 // @Description Get current authenticated user information
@@ -340,7 +418,7 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.GetUserByID(userID.(uuid.UUID))
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID.(uuid.UUID))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -351,14 +429,14 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 
 // ChangePassword godoc
 // @Summary Change password
-// @Description Change user password
+// @Description Change user password. All other sessions are revoked, the current one stays active. Accounts without a password omit current_password to set one
 // @Tags auth
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param request body dto.ChangePasswordRequest true "Current and new password"
 // @Success 200 {object} dto.MessageResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.PasswordPolicyErrorResponse "Validation error or password policy violations"
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /auth/change-password [put]
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
@@ -374,12 +452,10 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.validationService.ValidatePassword(req.NewPassword); err != nil {
-		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
-		return
-	}
+	sessionID, _ := c.Get("session_id")
+	currentSessionID, _ := sessionID.(uuid.UUID)
 
-	if err := h.authService.ChangePassword(userID.(uuid.UUID), req.CurrentPassword, req.NewPassword); err != nil {
+	if err := h.authService.ChangePassword(c.Request.Context(), userID.(uuid.UUID), currentSessionID, req.CurrentPassword, req.NewPassword); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
@@ -391,7 +467,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 
 // Logout godoc
 // @Summary Logout user
-// @Description Logout user and revoke refresh token
+// @Description Logout user, revoke the presented access token and the refresh token
 // @Tags auth
 // @Security BearerAuth
 // @Accept json
@@ -407,9 +483,17 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.RevokeRefreshToken(req.RefreshToken); err != nil {
-		h.logger.Error("Failed to revoke refresh token during logout",
-			logger.String("token", req.RefreshToken),
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), claims, req.RefreshToken); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to revoke tokens during logout",
+			logger.String("user_id", claims.UserID.String()),
+			logger.String("session_id", claims.SessionID.String()),
 			logger.Error(err),
 		)
 	}
@@ -435,7 +519,7 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 		return
 	}
 
-	user, err := h.authService.GetUserByID(userID.(uuid.UUID))
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID.(uuid.UUID))
 	if err != nil {
 		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not found")
 		return
@@ -451,18 +535,138 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 		}
 	}
 
+	var permissions []string
+	var impersonatorID *uuid.UUID
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	if claims, ok := value.(*service.AccessTokenClaims); ok {
+		permissions = strings.Fields(claims.Permissions)
+		if actorID, ok := claims.ImpersonatorID(); ok {
+			impersonatorID = &actorID
+		}
+	}
+
 	response := dto.ValidateTokenResponse{
-		Valid: true,
-		User:  h.mapUserToDTO(user),
-		Roles: roleStrings,
+		Valid:          true,
+		User:           h.mapUserToDTO(user),
+		Roles:          roleStrings,
+		Permissions:    permissions,
+		ImpersonatorID: impersonatorID,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// ListSessions godoc
+// @Summary List active sessions
+// @Description List devices where the current user is logged in
+// @Tags sessions
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	sessionID, _ := c.Get("session_id")
+	currentSessionID, _ := sessionID.(uuid.UUID)
+
+	sessionResponses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = dto.SessionResponse{
+			ID:         session.SessionID(),
+			DeviceName: session.DeviceName(),
+			UserAgent:  session.UserAgent(),
+			IPAddress:  session.IPAddress(),
+			LastUsedAt: session.LastUsedAt(),
+			ExpiresAt:  session.ExpiresAt(),
+			IsCurrent:  session.SessionID() == currentSessionID,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.ListSessionsResponse{
+		Sessions: sessionResponses,
+	})
+}
+
+// RevokeSession godoc
+// @Summary Revoke session
+// @Description Log out one of the current user's devices
+// @Tags sessions
+// @Security BearerAuth
+// @Produce json
+// @Param session_id path string true "Session ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/sessions/{session_id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("session_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid session ID")
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID.(uuid.UUID), sessionID); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// RevokeOtherSessions godoc
+// @Summary Revoke other sessions
+// @Description Log out all devices except the current one
+// @Tags sessions
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.RevokeSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/sessions/revoke-others [post]
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	sessionID, _ := c.Get("session_id")
+	currentSessionID, _ := sessionID.(uuid.UUID)
+
+	revoked, err := h.authService.RevokeOtherSessions(c.Request.Context(), userID.(uuid.UUID), currentSessionID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{
+		Message:      "Other sessions revoked successfully",
+		RevokedCount: revoked,
+	})
+}
+
 // AssignRole godoc
 // @Summary Assign role to user
-// @Description Assign a role to a user (admin only)
+// @Description Assign a role to a user. Requires the roles.assign permission and every permission of the assigned role. A role with expires_at is removed automatically when it expires
 // @Tags admin
 // @Security BearerAuth
 // @Accept json
@@ -472,8 +676,16 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Router /auth/users/{user_id}/roles [post]
 func (h *AuthHandler) AssignRole(c *gin.Context) {
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
 	userIDStr := c.Param("user_id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
@@ -487,8 +699,14 @@ func (h *AuthHandler) AssignRole(c *gin.Context) {
 		return
 	}
 
-	roleType := domain.UserRoleType(req.Role)
-	if err := h.authService.AssignRole(userID, roleType); err != nil {
+	grant := service.RoleGrant{
+		UserID:    userID,
+		Role:      domain.UserRoleType(req.Role),
+		ActorID:   claims.UserID,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := h.authService.AssignRole(c.Request.Context(), grant); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
@@ -500,17 +718,26 @@ func (h *AuthHandler) AssignRole(c *gin.Context) {
 
 // RevokeRole godoc
 // @Summary Revoke role from user
-// @Description Revoke a role from a user (admin only)
+// @Description Revoke a role from a user. Requires the roles.assign permission and every permission of the revoked role
 // @Tags admin
 // @Security BearerAuth
 // @Produce json
 // @Param user_id path string true "User ID"
 // @Param role path string true "Role to revoke"
+// @Param reason query string false "Why the role is revoked"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
 // @Router /auth/users/{user_id}/roles/{role} [delete]
 func (h *AuthHandler) RevokeRole(c *gin.Context) {
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
 	userIDStr := c.Param("user_id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
@@ -518,10 +745,16 @@ func (h *AuthHandler) RevokeRole(c *gin.Context) {
 		return
 	}
 
+	reason := c.Query("reason")
+	if len(reason) > 255 {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Reason must be at most 255 characters")
+		return
+	}
+
 	role := c.Param("role")
 	roleType := domain.UserRoleType(role)
 
-	if err := h.authService.RevokeRole(userID, roleType); err != nil {
+	if err := h.authService.RevokeRole(c.Request.Context(), userID, roleType, claims.UserID, reason); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
@@ -533,7 +766,7 @@ func (h *AuthHandler) RevokeRole(c *gin.Context) {
 
 // GetUserRoles godoc
 // @Summary Get user roles
-// @Description Get all roles assigned to a user (admin only)
+// @Description Get all roles assigned to a user, including revoked and expired ones. Requires the roles.read permission
 // @Tags admin
 // @Security BearerAuth
 // @Produce json
@@ -550,7 +783,7 @@ func (h *AuthHandler) GetUserRoles(c *gin.Context) {
 		return
 	}
 
-	roles, err := h.authService.GetUserRoles(userID)
+	roles, err := h.authService.GetUserRoles(c.Request.Context(), userID)
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -563,6 +796,9 @@ func (h *AuthHandler) GetUserRoles(c *gin.Context) {
 			UserID:    role.UserID(),
 			Role:      string(role.Role()),
 			GrantedAt: role.GrantedAt(),
+			GrantedBy: role.GrantedBy(),
+			Reason:    role.Reason(),
+			ExpiresAt: role.ExpiresAt(),
 			IsActive:  role.IsActive(),
 		}
 	}
@@ -574,6 +810,55 @@ func (h *AuthHandler) GetUserRoles(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetRoleHistory godoc
+// @Summary Get role change history
+// @Description Get grants, revocations and expiries of a user's roles, newest first. Requires the roles.read permission
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param limit query int false "Maximum number of changes (default 50, at most 200)"
+// @Success 200 {object} dto.RoleHistoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/users/{user_id}/roles/history [get]
+func (h *AuthHandler) GetRoleHistory(c *gin.Context) {
+	userIDStr := c.Param("user_id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid user ID")
+		return
+	}
+
+	var query dto.RoleHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	changes, err := h.authService.GetRoleHistory(c.Request.Context(), userID, query.Limit)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	response := make([]dto.RoleChangeResponse, len(changes))
+	for i, change := range changes {
+		response[i] = dto.RoleChangeResponse{
+			ID:        change.ID(),
+			UserID:    change.UserID(),
+			Role:      string(change.Role()),
+			Action:    string(change.Action()),
+			ActorID:   change.ActorID(),
+			Reason:    change.Reason(),
+			ExpiresAt: change.ExpiresAt(),
+			CreatedAt: change.CreatedAt(),
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.RoleHistoryResponse{Changes: response})
+}
+
 // Helper methods
 func (h *AuthHandler) mapUserToDTO(user *domain.User) dto.UserResponse {
 	return dto.UserResponse{
@@ -588,6 +873,58 @@ func (h *AuthHandler) mapUserToDTO(user *domain.User) dto.UserResponse {
 	}
 }
 
+// completeLogin выдает пару токенов пользователю, прошедшему аутентификацию
+func (h *AuthHandler) completeLogin(c *gin.Context, user *domain.User, deviceName string) {
+	// Получение ролей и прав
+	access, err := h.authService.GetUserAccess(c.Request.Context(), user.ID())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to get user roles",
+			logger.String("user_id", user.ID().String()),
+			logger.Error(err),
+		)
+		access = &service.UserAccess{} // Без ролей и прав
+	}
+
+	refreshTokenEntity, err := h.authService.CreateRefreshToken(c.Request.Context(), user.ID(), h.clientInfo(c, deviceName))
+	if err != nil {
+		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate refresh token")
+		return
+	}
+
+	accessToken, err := h.jwtService.GenerateAccessToken(user, access, refreshTokenEntity.SessionID())
+	if err != nil {
+		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate access token")
+		return
+	}
+
+	response := dto.LoginResponse{
+		Tokens: dto.TokenResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshTokenEntity.Token(),
+			TokenType:    "Bearer",
+			ExpiresIn:    900, // 15 minutes
+		},
+		User: h.mapUserToDTO(user),
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("User logged in successfully",
+		logger.String("user_id", user.ID().String()),
+		logger.String("username", user.Username()),
+		logger.String("client_ip", c.ClientIP()),
+	)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// clientInfo собирает сведения об устройстве клиента для привязки к сессии
+func (h *AuthHandler) clientInfo(c *gin.Context, deviceName string) domain.ClientInfo {
+	return domain.ClientInfo{
+		DeviceName: deviceName,
+		UserAgent:  c.Request.UserAgent(),
+		IPAddress:  c.ClientIP(),
+	}
+}
+
 func (h *AuthHandler) respondError(c *gin.Context, statusCode int, errorType, message string) {
 	c.JSON(statusCode, dto.ErrorResponse{
 		Error:     errorType,
@@ -598,6 +935,52 @@ func (h *AuthHandler) respondError(c *gin.Context, statusCode int, errorType, me
 }
 
 func (h *AuthHandler) handleServiceError(c *gin.Context, err error) {
+	// Блокировка несет время ожидания, поэтому обрабатывается до сравнения по тексту
+	var lockedErr *service.AccountLockedError
+	if errors.As(err, &lockedErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		h.respondError(c, http.StatusTooManyRequests, "account_locked", "Too many failed attempts, try again later")
+		return
+	}
+
+	// Нарушенные правила пароля передаются клиенту списком
+	var policyErr *service.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		violations := make([]dto.PasswordViolationResponse, 0, len(policyErr.Violations))
+		for _, violation := range policyErr.Violations {
+			violations = append(violations, dto.PasswordViolationResponse{
+				Rule:    violation.Rule,
+				Message: violation.Message,
+			})
+		}
+		c.JSON(http.StatusBadRequest, dto.PasswordPolicyErrorResponse{
+			ErrorResponse: dto.ErrorResponse{
+				Error:     "weak_password",
+				Message:   "Password does not meet the password policy",
+				Timestamp: time.Now(),
+				Path:      c.Request.URL.Path,
+			},
+			Violations: violations,
+		})
+		return
+	}
+
+	// Истекший таймаут запроса дошел до запроса в базу
+	if errors.Is(err, context.DeadlineExceeded) {
+		h.respondError(c, http.StatusGatewayTimeout, "request_timeout", "Request timed out")
+		return
+	}
+
+	// Ошибки провайдеров входа приходят обернутыми, поэтому проверяются через errors.Is
+	switch {
+	case errors.Is(err, oidc.ErrProviderNotFound):
+		h.respondError(c, http.StatusNotFound, "provider_not_found", "Sign-in provider not found")
+		return
+	case errors.Is(err, oidc.ErrDiscoveryFailed):
+		h.respondError(c, http.StatusServiceUnavailable, "provider_unavailable", "Sign-in provider is unavailable")
+		return
+	}
+
 	// Здесь можно добавить более детальную обработку различных типов ошибок
 	switch err.Error() {
 	case "user not found":
@@ -620,8 +1003,96 @@ func (h *AuthHandler) handleServiceError(c *gin.Context, err error) {
 		h.respondError(c, http.StatusBadRequest, "verification_used", "Email verification token has already been used")
 	case "email verification token is invalid":
 		h.respondError(c, http.StatusBadRequest, "verification_invalid", "Email verification token is invalid")
+	case "magic link token is invalid":
+		h.respondError(c, http.StatusBadRequest, "magic_link_invalid", "Magic link is invalid or has expired")
+	case "session not found":
+		h.respondError(c, http.StatusNotFound, "session_not_found", "Session not found")
+	case "current password is incorrect":
+		h.respondError(c, http.StatusBadRequest, "invalid_password", "Current password is incorrect")
+	case "two-factor authentication is already enabled":
+		h.respondError(c, http.StatusConflict, "mfa_already_enabled", "Two-factor authentication is already enabled")
+	case "two-factor authentication enrollment not found":
+		h.respondError(c, http.StatusBadRequest, "mfa_not_enrolled", "Two-factor authentication enrollment not found")
+	case "two-factor authentication is not enabled":
+		h.respondError(c, http.StatusBadRequest, "mfa_not_enabled", "Two-factor authentication is not enabled")
+	case "invalid two-factor authentication code":
+		h.respondError(c, http.StatusUnauthorized, "invalid_mfa_code", "Invalid two-factor authentication code")
+	case "mfa challenge is invalid or already used":
+		h.respondError(c, http.StatusUnauthorized, "invalid_mfa_token", "Invalid or expired MFA token")
+	case "webauthn session not found":
+		h.respondError(c, http.StatusBadRequest, "passkey_session_invalid", "Passkey ceremony has expired or was already completed")
+	case "passkey verification failed":
+		h.respondError(c, http.StatusUnauthorized, "passkey_invalid", "Passkey verification failed")
+	case "passkey signature counter did not increase":
+		h.respondError(c, http.StatusForbidden, "passkey_clone_detected", "Passkey may have been cloned and was blocked; register it again")
+	case "webauthn credential not found":
+		h.respondError(c, http.StatusNotFound, "passkey_not_found", "Passkey not found")
+	case "webauthn credential already exists":
+		h.respondError(c, http.StatusConflict, "passkey_exists", "Passkey is already registered")
+	case "password is not set":
+		h.respondError(c, http.StatusBadRequest, "password_not_set", "Password is not set")
+	case "cannot remove the last sign-in method":
+		h.respondError(c, http.StatusConflict, "last_sign_in_method", "Cannot remove the last sign-in method")
+	case "invalid personal access token scope":
+		h.respondError(c, http.StatusBadRequest, "invalid_scope", "Scopes must be api:read and/or api:write")
+	case "invalid personal access token expiry":
+		h.respondError(c, http.StatusBadRequest, "invalid_expiry", "Expiry must be in the future and within the allowed token lifetime")
+	case "personal access token limit reached":
+		h.respondError(c, http.StatusConflict, "token_limit_reached", "Personal access token limit reached, revoke unused tokens first")
+	case "personal access token not found":
+		h.respondError(c, http.StatusNotFound, "token_not_found", "Personal access token not found")
+	case "social login state not found":
+		h.respondError(c, http.StatusBadRequest, "social_login_state_invalid", "Sign-in request has expired or was already completed")
+	case "external sign-in failed":
+		h.respondError(c, http.StatusUnauthorized, "social_login_failed", "External sign-in failed")
+	case "external account has no email address":
+		h.respondError(c, http.StatusBadRequest, "external_email_required", "The provider did not share an email address")
+	case "an account with this email already exists, sign in and link the provider":
+		h.respondError(c, http.StatusConflict, "account_exists", "An account with this email already exists, sign in and link the provider")
+	case "external account is linked to another user":
+		h.respondError(c, http.StatusConflict, "identity_linked_to_another_user", "This provider account is linked to another user")
+	case "user identity already exists":
+		h.respondError(c, http.StatusConflict, "identity_exists", "A provider account is already linked")
+	case "user identity not found":
+		h.respondError(c, http.StatusNotFound, "identity_not_found", "Linked provider not found")
+	case "invalid role type":
+		h.respondError(c, http.StatusBadRequest, "invalid_role", "Role is not defined")
+	case "user role already exists":
+		h.respondError(c, http.StatusConflict, "role_already_assigned", "User already has this role")
+	case "user role not found":
+		h.respondError(c, http.StatusNotFound, "user_role_not_found", "User does not have this role")
+	case "role grants permissions the actor does not have":
+		h.respondError(c, http.StatusForbidden, "role_not_grantable", "Role grants permissions you do not have")
+	case "role expiry must be in the future":
+		h.respondError(c, http.StatusBadRequest, "invalid_role_expiry", "Role expiry must be in the future")
+	case "user is already suspended":
+		h.respondError(c, http.StatusConflict, "already_suspended", "User is already suspended")
+	case "user is not suspended":
+		h.respondError(c, http.StatusConflict, "not_suspended", "User is not suspended")
+	case "cannot suspend your own account":
+		h.respondError(c, http.StatusBadRequest, "cannot_suspend_self", "You cannot suspend your own account")
+	case "user has permissions the actor does not have":
+		h.respondError(c, http.StatusForbidden, "actor_lacks_permissions", "User has permissions you do not have")
+	case "cannot impersonate your own account":
+		h.respondError(c, http.StatusBadRequest, "cannot_impersonate_self", "You cannot impersonate your own account")
+	case "suspension end must be in the future":
+		h.respondError(c, http.StatusBadRequest, "invalid_suspension_end", "Suspension end must be in the future")
+	case "invalid cursor":
+		h.respondError(c, http.StatusBadRequest, "invalid_cursor", "Invalid cursor")
+	case "role not found":
+		h.respondError(c, http.StatusNotFound, "role_not_found", "Role not found")
+	case "role already exists":
+		h.respondError(c, http.StatusConflict, "role_exists", "Role with this name already exists")
+	case "invalid role name":
+		h.respondError(c, http.StatusBadRequest, "invalid_role_name", "Role name must be 2-50 lowercase letters, digits, - or _")
+	case "invalid permission":
+		h.respondError(c, http.StatusBadRequest, "invalid_permission", "Permissions must look like resource.action")
+	case "system roles cannot be deleted":
+		h.respondError(c, http.StatusConflict, "system_role", "System roles cannot be deleted")
+	case "role is assigned to users":
+		h.respondError(c, http.StatusConflict, "role_in_use", "Role is assigned to users, revoke it first")
 	default:
-		h.logger.Error("Unhandled service error", logger.Error(err))
+		h.logger.WithContext(c.Request.Context()).Error("Unhandled service error", logger.Error(err))
 		h.respondError(c, http.StatusInternalServerError, "internal_error", "Internal server error")
 	}
 }
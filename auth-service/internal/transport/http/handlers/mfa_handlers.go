@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generate a TOTP secret and otpauth URI. Two-factor authentication is enabled only after confirmation with the first code
+// @Tags 2fa
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.EnrollTOTPResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/2fa/enroll [post]
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	secret, uri, err := h.authService.EnrollTOTP(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EnrollTOTPResponse{
+		Secret:     secret,
+		OTPAuthURI: uri,
+	})
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enable two-factor authentication with the first code from the authenticator app. Recovery codes are returned only once
+// @Tags 2fa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.ConfirmTOTPRequest true "First TOTP code"
+// @Success 200 {object} dto.ConfirmTOTPResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/2fa/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	recoveryCodes, err := h.authService.ConfirmTOTP(c.Request.Context(), userID.(uuid.UUID), req.Code)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ConfirmTOTPResponse{
+		Message:       "Two-factor authentication enabled",
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// DisableTOTP godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication. Requires the password and a TOTP or recovery code
+// @Tags 2fa
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.DisableTOTPRequest true "Password and second factor"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/2fa/disable [post]
+func (h *AuthHandler) DisableTOTP(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.DisableTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	if err := h.authService.DisableTOTP(c.Request.Context(), userID.(uuid.UUID), req.Password, req.Code); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// VerifyMFA godoc
+// @Summary Complete login with second factor
+// @Description Exchange the mfa_token from /auth/login and a TOTP or recovery code for tokens
+// @Tags 2fa
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyMFARequest true "Challenge token and code"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts; see the Retry-After header"
+// @Router /auth/2fa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	claims, err := h.jwtService.ValidateMFAChallengeToken(req.MFAToken)
+	if err != nil {
+		h.respondError(c, http.StatusUnauthorized, "invalid_mfa_token", "Invalid or expired MFA token")
+		return
+	}
+
+	if err := h.authService.CompleteMFAChallenge(c.Request.Context(), claims, req.Code); err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn("Second factor verification failed",
+			logger.String("user_id", claims.UserID.String()),
+			logger.String("client_ip", c.ClientIP()),
+			logger.Error(err),
+		)
+		h.handleServiceError(c, err)
+		return
+	}
+
+	user, err := h.authService.GetUserByID(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	if !user.IsActive() {
+		h.respondError(c, http.StatusForbidden, "account_inactive", "User account is inactive")
+		return
+	}
+
+	h.completeLogin(c, user, req.DeviceName)
+}
+
+// respondMFAChallenge отвечает challenge токеном вместо пары токенов
+func (h *AuthHandler) respondMFAChallenge(c *gin.Context, user *domain.User) {
+	mfaToken, err := h.jwtService.GenerateMFAChallengeToken(user.ID())
+	if err != nil {
+		h.respondError(c, http.StatusInternalServerError, "token_generation_error", "Failed to generate MFA token")
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Password accepted, second factor required",
+		logger.String("user_id", user.ID().String()),
+		logger.String("client_ip", c.ClientIP()),
+	)
+
+	c.JSON(http.StatusAccepted, dto.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresIn:   int64(h.jwtService.MFAChallengeTTL().Seconds()),
+	})
+}
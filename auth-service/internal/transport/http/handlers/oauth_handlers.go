@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OAuthHandler struct {
+	oauthService *service.OAuthService
+	logger       logger.Logger
+}
+
+func NewOAuthHandler(oauthService *service.OAuthService, logger logger.Logger) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+}
+
+// Introspect проверяет access или refresh токен (RFC 7662). Маршрут вне /api,
+// поэтому не попадает в swagger.
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	var req dto.IntrospectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	client, ok := h.authenticateClient(c, req.ClientID, req.ClientSecret)
+	if !ok {
+		return
+	}
+
+	result, err := h.oauthService.IntrospectToken(c.Request.Context(), client, req.Token, req.TokenTypeHint)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	response := dto.IntrospectResponse{
+		Active:      result.Active,
+		Scope:       result.Scope,
+		Username:    result.Username,
+		TokenType:   result.TokenType,
+		Exp:         result.ExpiresAt,
+		Iat:         result.IssuedAt,
+		Sub:         result.Subject,
+		Iss:         result.Issuer,
+		SessionID:   result.SessionID,
+		ClientID:    result.ClientID,
+		Roles:       result.Roles,
+		Permissions: result.Permissions,
+	}
+	if result.Actor != "" {
+		response.Act = &dto.IntrospectActor{Sub: result.Actor}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// Revoke отзывает refresh токен вместе с сессией или access токен (RFC 7009)
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	var req dto.RevokeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	client, ok := h.authenticateClient(c, req.ClientID, req.ClientSecret)
+	if !ok {
+		return
+	}
+
+	if err := h.oauthService.RevokeToken(c.Request.Context(), client, req.Token, req.TokenTypeHint); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// authenticateClient берет учетные данные клиента из HTTP Basic или из полей формы.
+// При ошибке ответ уже записан.
+func (h *OAuthHandler) authenticateClient(c *gin.Context, formClientID, formClientSecret string) (*domain.OAuthClient, bool) {
+	clientID, clientSecret, hasBasic := c.Request.BasicAuth()
+	if !hasBasic {
+		clientID, clientSecret = formClientID, formClientSecret
+	}
+
+	client, err := h.oauthService.AuthenticateClient(c.Request.Context(), clientID, clientSecret)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidClient) {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			h.respondError(c, http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+			return nil, false
+		}
+		h.handleServiceError(c, err)
+		return nil, false
+	}
+
+	return client, true
+}
+
+func (h *OAuthHandler) handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrClientScopeNotAllowed):
+		h.respondError(c, http.StatusForbidden, "insufficient_scope", err.Error())
+	default:
+		h.logger.WithContext(c.Request.Context()).Error("OAuth request failed", logger.Error(err))
+		h.respondError(c, http.StatusInternalServerError, "server_error", "Internal server error")
+	}
+}
+
+func (h *OAuthHandler) respondError(c *gin.Context, statusCode int, code, description string) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(statusCode, dto.OAuthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/internal/transport/http/middleware"
+	"social-network/auth-service/pkg/logger"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCHandler обслуживает endpoint'ы сервера авторизации OpenID Connect. Протокольные
+// маршруты (/oauth/authorize, /oauth/token, /oauth/userinfo) находятся вне /api и не попадают
+// в swagger; в swagger описано только завершение авторизации страницей входа.
+type OIDCHandler struct {
+	oidcService  *service.OIDCService
+	oauthService *service.OAuthService
+	logger       logger.Logger
+}
+
+func NewOIDCHandler(oidcService *service.OIDCService, oauthService *service.OAuthService, logger logger.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService:  oidcService,
+		oauthService: oauthService,
+		logger:       logger,
+	}
+}
+
+// Authorize проверяет запрос авторизации и перенаправляет браузер на страницу входа
+// с исходными параметрами. Ошибки клиента возвращаются на его redirect_uri, кроме
+// неизвестного клиента и незарегистрированного адреса возврата.
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	var req dto.AuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorInvalidRequest, "response_type, client_id, redirect_uri and scope are required")
+		return
+	}
+
+	authReq := h.mapAuthorizeRequest(&req)
+	if _, _, err := h.oidcService.ValidateAuthorizationRequest(c.Request.Context(), authReq); err != nil {
+		var oauthErr *service.OAuthError
+		if errors.As(err, &oauthErr) {
+			c.Redirect(http.StatusFound, h.oidcService.ErrorRedirectURL(authReq, oauthErr))
+			return
+		}
+		h.handleAuthorizationError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, h.oidcService.LoginRedirectURL(c.Request.URL.RawQuery))
+}
+
+// CompleteAuthorization godoc
+// @Summary Complete OpenID Connect authorization
+// @Description Called by the login page after the user has signed in. Returns the client redirect with an authorization code, or consent_required when the user has to approve the requested scopes first; repeat the call with consent set to approve or deny
+// @Tags oauth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.AuthorizationDecisionRequest true "Original authorization request parameters and the consent decision"
+// @Success 200 {object} dto.AuthorizationRedirectResponse
+// @Success 202 {object} dto.ConsentRequiredResponse
+// @Failure 400 {object} dto.OAuthErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.OAuthErrorResponse
+// @Router /oauth/authorize [post]
+func (h *OIDCHandler) CompleteAuthorization(c *gin.Context) {
+	var req dto.AuthorizationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorInvalidRequest, err.Error())
+		return
+	}
+
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid token",
+		})
+		return
+	}
+
+	authReq := h.mapAuthorizeRequest(&req.AuthorizeRequest)
+	result, err := h.oidcService.Authorize(c.Request.Context(), claims, authReq, req.Consent)
+	if err != nil {
+		var oauthErr *service.OAuthError
+		if errors.As(err, &oauthErr) {
+			c.JSON(http.StatusOK, dto.AuthorizationRedirectResponse{
+				RedirectTo: h.oidcService.ErrorRedirectURL(authReq, oauthErr),
+			})
+			return
+		}
+		h.handleAuthorizationError(c, err)
+		return
+	}
+
+	if result.ConsentRequired {
+		c.JSON(http.StatusAccepted, dto.ConsentRequiredResponse{
+			ConsentRequired: true,
+			ClientID:        result.Client.ClientID(),
+			ClientName:      result.Client.Name(),
+			Scopes:          result.Scopes,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AuthorizationRedirectResponse{
+		RedirectTo: result.RedirectURI,
+	})
+}
+
+// Token выдает токены по коду авторизации или refresh токену клиента, а сервисам -
+// токен по client credentials
+func (h *OIDCHandler) Token(c *gin.Context) {
+	var req dto.TokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorInvalidRequest, "grant_type is required")
+		return
+	}
+
+	clientID, clientSecret, hasBasic := c.Request.BasicAuth()
+	if !hasBasic {
+		clientID, clientSecret = req.ClientID, req.ClientSecret
+	}
+
+	client, err := h.oidcService.AuthenticateClient(c.Request.Context(), clientID, clientSecret)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	info := domain.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	var tokens *service.TokenSet
+	switch req.GrantType {
+	case service.GrantTypeAuthorizationCode:
+		tokens, err = h.oidcService.ExchangeCode(c.Request.Context(), client, req.Code, req.RedirectURI, req.CodeVerifier, info)
+	case service.GrantTypeRefreshToken:
+		tokens, err = h.oidcService.Refresh(c.Request.Context(), client, req.RefreshToken, info)
+	case service.GrantTypeClientCredentials:
+		tokens, err = h.oauthService.IssueServiceToken(c.Request.Context(), client, req.Audience, req.Scope)
+	default:
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorUnsupportedGrantType, "grant_type is not supported")
+		return
+	}
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.TokenEndpointResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    tokens.ExpiresIn,
+		RefreshToken: tokens.RefreshToken,
+		IDToken:      tokens.IDToken,
+		Scope:        tokens.Scope,
+	})
+}
+
+// UserInfo возвращает claims пользователя по access токену со scope openid
+func (h *OIDCHandler) UserInfo(c *gin.Context) {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || token == "" {
+		c.Header("WWW-Authenticate", `Bearer realm="userinfo"`)
+		h.respondError(c, http.StatusUnauthorized, "invalid_token", "Missing access token")
+		return
+	}
+
+	info, err := h.oidcService.UserInfo(c.Request.Context(), token)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInsufficientPermissions):
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_scope", scope="openid"`)
+			h.respondError(c, http.StatusForbidden, "insufficient_scope", "The openid scope is required")
+		case errors.Is(err, service.ErrTokenInvalid), errors.Is(err, service.ErrTokenExpired), errors.Is(err, service.ErrTokenRevoked):
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			h.respondError(c, http.StatusUnauthorized, "invalid_token", "Access token is invalid or expired")
+		default:
+			h.handleServiceError(c, err)
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.UserInfoResponse{
+		Sub:               info.Subject,
+		Name:              info.Name,
+		PreferredUsername: info.PreferredUsername,
+		Email:             info.Email,
+		EmailVerified:     info.EmailVerified,
+	})
+}
+
+func (h *OIDCHandler) mapAuthorizeRequest(req *dto.AuthorizeRequest) *service.AuthorizationRequest {
+	return &service.AuthorizationRequest{
+		ResponseType:        req.ResponseType,
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		State:               req.State,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+	}
+}
+
+// handleAuthorizationError отвечает на ошибки запроса авторизации, которые нельзя
+// передать клиенту: клиент неизвестен или адрес возврата ему не принадлежит
+func (h *OIDCHandler) handleAuthorizationError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidClient) {
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorInvalidClient, "Unknown or inactive client")
+		return
+	}
+	h.handleServiceError(c, err)
+}
+
+func (h *OIDCHandler) handleServiceError(c *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if errors.As(err, &oauthErr) {
+		h.respondError(c, http.StatusBadRequest, oauthErr.Code, oauthErr.Description)
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrInvalidClient):
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		h.respondError(c, http.StatusUnauthorized, service.OAuthErrorInvalidClient, "Client authentication failed")
+	case errors.Is(err, service.ErrInvalidRedirectURI):
+		h.respondError(c, http.StatusBadRequest, service.OAuthErrorInvalidRequest, "redirect_uri is not registered for the client")
+	case errors.Is(err, service.ErrClientTokenNotAllowed):
+		h.respondError(c, http.StatusForbidden, service.OAuthErrorAccessDenied, err.Error())
+	case errors.Is(err, service.ErrUserInactive):
+		h.respondError(c, http.StatusForbidden, service.OAuthErrorAccessDenied, "User account is inactive")
+	default:
+		h.logger.WithContext(c.Request.Context()).Error("OpenID Connect request failed", logger.Error(err))
+		h.respondError(c, http.StatusInternalServerError, service.OAuthErrorServerError, "Internal server error")
+	}
+}
+
+func (h *OIDCHandler) respondError(c *gin.Context, statusCode int, code, description string) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(statusCode, dto.OAuthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BeginPasskeyRegistration godoc
+// @Summary Start passkey registration
+// @Description Create a registration ceremony. Pass options to navigator.credentials.create() and send the result to /auth/passkeys/register/finish with the session_id
+// @Tags passkeys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.BeginPasskeyRegistrationResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/passkeys/register/begin [post]
+func (h *AuthHandler) BeginPasskeyRegistration(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	sessionID, options, err := h.passkeyService.BeginRegistration(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BeginPasskeyRegistrationResponse{
+		SessionID: sessionID,
+		Options:   options,
+	})
+}
+
+// FinishPasskeyRegistration godoc
+// @Summary Finish passkey registration
+// @Description Verify the authenticator response and save the new passkey
+// @Tags passkeys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.FinishPasskeyRegistrationRequest true "Ceremony session and PublicKeyCredential JSON"
+// @Success 201 {object} dto.PasskeyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/passkeys/register/finish [post]
+func (h *AuthHandler) FinishPasskeyRegistration(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.FinishPasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	credential, err := h.passkeyService.FinishRegistration(c.Request.Context(), userID.(uuid.UUID), req.SessionID, req.Name, req.Credential)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapPasskeyToDTO(credential))
+}
+
+// BeginPasskeyLogin godoc
+// @Summary Start passkey login
+// @Description Create a login ceremony. Pass options to navigator.credentials.get(); the browser offers the passkeys saved for this site, so no email is needed
+// @Tags passkeys
+// @Produce json
+// @Success 200 {object} dto.BeginPasskeyLoginResponse
+// @Router /auth/passkeys/login/begin [post]
+func (h *AuthHandler) BeginPasskeyLogin(c *gin.Context) {
+	sessionID, options, err := h.passkeyService.BeginLogin(c.Request.Context())
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BeginPasskeyLoginResponse{
+		SessionID: sessionID,
+		Options:   options,
+	})
+}
+
+// FinishPasskeyLogin godoc
+// @Summary Finish passkey login
+// @Description Verify the assertion and return tokens. If two-factor authentication is enabled and the authenticator did not verify the user (PIN, biometrics), an mfa_required challenge is returned instead
+// @Tags passkeys
+// @Accept json
+// @Produce json
+// @Param request body dto.FinishPasskeyLoginRequest true "Ceremony session and PublicKeyCredential JSON"
+// @Success 200 {object} dto.LoginResponse
+// @Success 202 {object} dto.MFAChallengeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/passkeys/login/finish [post]
+func (h *AuthHandler) FinishPasskeyLogin(c *gin.Context) {
+	var req dto.FinishPasskeyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	user, userVerified, err := h.passkeyService.FinishLogin(c.Request.Context(), req.SessionID, req.Credential)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn("Passkey login failed",
+			logger.String("client_ip", c.ClientIP()),
+			logger.Error(err),
+		)
+		h.handleServiceError(c, err)
+		return
+	}
+
+	// Passkey с проверкой пользователя уже дает два фактора: владение ключом и PIN/биометрию
+	if !userVerified {
+		mfaEnabled, err := h.authService.IsMFAEnabled(c.Request.Context(), user.ID())
+		if err != nil {
+			h.handleServiceError(c, err)
+			return
+		}
+
+		if mfaEnabled {
+			h.respondMFAChallenge(c, user)
+			return
+		}
+	}
+
+	h.completeLogin(c, user, req.DeviceName)
+}
+
+// ListPasskeys godoc
+// @Summary List passkeys
+// @Description List passkeys registered by the current user
+// @Tags passkeys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListPasskeysResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/passkeys [get]
+func (h *AuthHandler) ListPasskeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	credentials, err := h.passkeyService.ListCredentials(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	passkeys := make([]dto.PasskeyResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		passkeys = append(passkeys, h.mapPasskeyToDTO(credential))
+	}
+
+	c.JSON(http.StatusOK, dto.ListPasskeysResponse{Passkeys: passkeys})
+}
+
+// DeletePasskey godoc
+// @Summary Delete passkey
+// @Description Delete one of the current user's passkeys. The last passkey of an account without a password or linked providers cannot be deleted
+// @Tags passkeys
+// @Security BearerAuth
+// @Produce json
+// @Param passkey_id path string true "Passkey ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/passkeys/{passkey_id} [delete]
+func (h *AuthHandler) DeletePasskey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	passkeyID, err := uuid.Parse(c.Param("passkey_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid passkey ID")
+		return
+	}
+
+	if err := h.passkeyService.DeleteCredential(c.Request.Context(), userID.(uuid.UUID), passkeyID); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Passkey deleted successfully",
+	})
+}
+
+// RemovePassword godoc
+// @Summary Remove password
+// @Description Remove the password so the account signs in with passkeys or linked providers only. Requires the current password and at least one passkey or linked provider
+// @Tags passkeys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.RemovePasswordRequest true "Current password"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/password [delete]
+func (h *AuthHandler) RemovePassword(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.RemovePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	if err := h.passkeyService.RemovePassword(c.Request.Context(), userID.(uuid.UUID), req.CurrentPassword); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Password removed successfully",
+	})
+}
+
+func (h *AuthHandler) mapPasskeyToDTO(credential *domain.WebAuthnCredential) dto.PasskeyResponse {
+	return dto.PasskeyResponse{
+		ID:           credential.ID(),
+		Name:         credential.Name(),
+		Transports:   credential.Transports(),
+		CloneWarning: credential.CloneWarning(),
+		LastUsedAt:   credential.LastUsedAt(),
+		CreatedAt:    credential.CreatedAt(),
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreatePersonalAccessToken godoc
+// @Summary Create personal access token
+// @Description Create a long-lived token for bots and integrations. Send it as "Authorization: Bearer <token>" instead of an access token. Scope api:read allows only GET requests, api:write allows any request. Tokens cannot manage passwords, sessions, two-factor authentication, passkeys, linked providers or other tokens. The token is shown only in this response
+// @Tags tokens
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreatePersonalAccessTokenRequest true "Token name, scopes and optional expiry"
+// @Success 201 {object} dto.CreatePersonalAccessTokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/tokens [post]
+func (h *AuthHandler) CreatePersonalAccessToken(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.CreatePersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	token, accessToken, err := h.personalAccessTokens.CreateToken(c.Request.Context(), userID.(uuid.UUID), req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, dto.CreatePersonalAccessTokenResponse{
+		Token:               token,
+		PersonalAccessToken: h.mapPersonalAccessTokenToDTO(accessToken),
+	})
+}
+
+// ListPersonalAccessTokens godoc
+// @Summary List personal access tokens
+// @Description List the current user's tokens that were not revoked, including expired ones
+// @Tags tokens
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListPersonalAccessTokensResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/tokens [get]
+func (h *AuthHandler) ListPersonalAccessTokens(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	accessTokens, err := h.personalAccessTokens.ListTokens(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	tokens := make([]dto.PersonalAccessTokenResponse, 0, len(accessTokens))
+	for _, accessToken := range accessTokens {
+		tokens = append(tokens, h.mapPersonalAccessTokenToDTO(accessToken))
+	}
+
+	c.JSON(http.StatusOK, dto.ListPersonalAccessTokensResponse{Tokens: tokens})
+}
+
+// RevokePersonalAccessToken godoc
+// @Summary Revoke personal access token
+// @Description Revoke one of the current user's tokens. Requests with it are rejected immediately
+// @Tags tokens
+// @Security BearerAuth
+// @Produce json
+// @Param token_id path string true "Token ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/tokens/{token_id} [delete]
+func (h *AuthHandler) RevokePersonalAccessToken(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	tokenID, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid token ID")
+		return
+	}
+
+	if err := h.personalAccessTokens.RevokeToken(c.Request.Context(), userID.(uuid.UUID), tokenID); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Personal access token revoked successfully",
+	})
+}
+
+func (h *AuthHandler) mapPersonalAccessTokenToDTO(accessToken *domain.PersonalAccessToken) dto.PersonalAccessTokenResponse {
+	return dto.PersonalAccessTokenResponse{
+		ID:         accessToken.ID(),
+		Name:       accessToken.Name(),
+		Prefix:     accessToken.Prefix(),
+		Scopes:     accessToken.Scopes(),
+		ExpiresAt:  accessToken.ExpiresAt(),
+		IsExpired:  accessToken.IsExpired(),
+		LastUsedAt: accessToken.LastUsedAt(),
+		LastUsedIP: accessToken.LastUsedIP(),
+		CreatedAt:  accessToken.CreatedAt(),
+	}
+}
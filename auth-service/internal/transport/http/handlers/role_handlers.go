@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRoles godoc
+// @Summary List roles
+// @Description List role definitions with their permissions
+// @Tags roles
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListRolesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/roles [get]
+func (h *AuthHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	response := make([]dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		response = append(response, h.mapRoleToDTO(role))
+	}
+
+	c.JSON(http.StatusOK, dto.ListRolesResponse{Roles: response})
+}
+
+// GetRole godoc
+// @Summary Get role
+// @Description Get a role definition with its permissions
+// @Tags roles
+// @Security BearerAuth
+// @Produce json
+// @Param role path string true "Role name"
+// @Success 200 {object} dto.RoleResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/roles/{role} [get]
+func (h *AuthHandler) GetRole(c *gin.Context) {
+	role, err := h.roleService.GetRole(c.Request.Context(), domain.UserRoleType(c.Param("role")))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapRoleToDTO(role))
+}
+
+// CreateRole godoc
+// @Summary Create role
+// @Description Define a new role as a set of permissions. Permissions look like resource.action; besides the known ones, other services may define their own
+// @Tags roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateRoleRequest true "Role definition"
+// @Success 201 {object} dto.RoleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/roles [post]
+func (h *AuthHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	role, err := h.roleService.CreateRole(c.Request.Context(), domain.UserRoleType(req.Name), req.Description, toPermissions(req.Permissions))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapRoleToDTO(role))
+}
+
+// UpdateRole godoc
+// @Summary Update role
+// @Description Replace the description and permissions of a role. Users get the new permissions when their access token is refreshed
+// @Tags roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param role path string true "Role name"
+// @Param request body dto.UpdateRoleRequest true "New description and permissions"
+// @Success 200 {object} dto.RoleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/roles/{role} [put]
+func (h *AuthHandler) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	role, err := h.roleService.UpdateRole(c.Request.Context(), domain.UserRoleType(c.Param("role")), req.Description, toPermissions(req.Permissions))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapRoleToDTO(role))
+}
+
+// DeleteRole godoc
+// @Summary Delete role
+// @Description Delete a role that is not assigned to anyone. System roles cannot be deleted
+// @Tags roles
+// @Security BearerAuth
+// @Produce json
+// @Param role path string true "Role name"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/roles/{role} [delete]
+func (h *AuthHandler) DeleteRole(c *gin.Context) {
+	if err := h.roleService.DeleteRole(c.Request.Context(), domain.UserRoleType(c.Param("role"))); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Role deleted successfully",
+	})
+}
+
+// ListPermissions godoc
+// @Summary List known permissions
+// @Description List permissions checked by the auth service and the default moderation permissions
+// @Tags roles
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListPermissionsResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/permissions [get]
+func (h *AuthHandler) ListPermissions(c *gin.Context) {
+	permissions := make([]string, len(domain.KnownPermissions))
+	for i, permission := range domain.KnownPermissions {
+		permissions[i] = string(permission)
+	}
+
+	c.JSON(http.StatusOK, dto.ListPermissionsResponse{Permissions: permissions})
+}
+
+func (h *AuthHandler) mapRoleToDTO(role *domain.Role) dto.RoleResponse {
+	permissions := make([]string, len(role.Permissions()))
+	for i, permission := range role.Permissions() {
+		permissions[i] = string(permission)
+	}
+
+	return dto.RoleResponse{
+		Name:        string(role.Name()),
+		Description: role.Description(),
+		Permissions: permissions,
+		IsSystem:    role.IsSystem(),
+		CreatedAt:   role.CreatedAt(),
+		UpdatedAt:   role.UpdatedAt(),
+	}
+}
+
+func toPermissions(values []string) []domain.Permission {
+	permissions := make([]domain.Permission, len(values))
+	for i, value := range values {
+		permissions[i] = domain.Permission(value)
+	}
+	return permissions
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListSocialProviders godoc
+// @Summary List social login providers
+// @Description List the external OpenID Connect providers configured for sign-in
+// @Tags social
+// @Produce json
+// @Success 200 {object} dto.ListSocialProvidersResponse
+// @Router /auth/social/providers [get]
+func (h *AuthHandler) ListSocialProviders(c *gin.Context) {
+	providers := h.socialLoginService.Providers()
+
+	response := make([]dto.SocialProviderResponse, 0, len(providers))
+	for _, provider := range providers {
+		response = append(response, dto.SocialProviderResponse{
+			Name:        provider.Name(),
+			DisplayName: provider.DisplayName(),
+		})
+	}
+
+	c.JSON(http.StatusOK, dto.ListSocialProvidersResponse{Providers: response})
+}
+
+// BeginSocialLogin godoc
+// @Summary Start social login
+// @Description Create a sign-in request to the provider. Keep the state, redirect the browser to authorization_url and send code and state from the provider redirect to /auth/social/{provider}/login/finish
+// @Tags social
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} dto.BeginSocialLoginResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /auth/social/{provider}/login/begin [post]
+func (h *AuthHandler) BeginSocialLogin(c *gin.Context) {
+	state, authURL, err := h.socialLoginService.BeginLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BeginSocialLoginResponse{
+		State:            state,
+		AuthorizationURL: authURL,
+	})
+}
+
+// FinishSocialLogin godoc
+// @Summary Finish social login
+// @Description Verify the provider response and return tokens. An unknown provider account is linked to the account with the same email only when both sides have verified it; otherwise a new account is created or 409 is returned. If two-factor authentication is enabled, an mfa_required challenge is returned instead
+// @Tags social
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param request body dto.FinishSocialLoginRequest true "Code and state from the provider redirect"
+// @Success 200 {object} dto.LoginResponse
+// @Success 202 {object} dto.MFAChallengeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/social/{provider}/login/finish [post]
+func (h *AuthHandler) FinishSocialLogin(c *gin.Context) {
+	var req dto.FinishSocialLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	user, err := h.socialLoginService.FinishLogin(c.Request.Context(), c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn("Social login failed",
+			logger.String("provider", c.Param("provider")),
+			logger.String("client_ip", c.ClientIP()),
+			logger.Error(err),
+		)
+		h.handleServiceError(c, err)
+		return
+	}
+
+	// Вход через провайдера заменяет только пароль, второй фактор по-прежнему нужен
+	mfaEnabled, err := h.authService.IsMFAEnabled(c.Request.Context(), user.ID())
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	if mfaEnabled {
+		h.respondMFAChallenge(c, user)
+		return
+	}
+
+	h.completeLogin(c, user, req.DeviceName)
+}
+
+// ListIdentities godoc
+// @Summary List linked providers
+// @Description List external provider accounts linked to the current user
+// @Tags social
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListUserIdentitiesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /auth/identities [get]
+func (h *AuthHandler) ListIdentities(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	identities, err := h.socialLoginService.ListIdentities(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	response := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		response = append(response, h.mapIdentityToDTO(identity))
+	}
+
+	c.JSON(http.StatusOK, dto.ListUserIdentitiesResponse{Identities: response})
+}
+
+// BeginIdentityLink godoc
+// @Summary Start linking a provider
+// @Description Create a request to link a provider account to the current user. Redirect the browser to authorization_url and send code and state to /auth/identities/{provider}/link/finish
+// @Tags social
+// @Security BearerAuth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} dto.BeginSocialLoginResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /auth/identities/{provider}/link/begin [post]
+func (h *AuthHandler) BeginIdentityLink(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	state, authURL, err := h.socialLoginService.BeginLink(c.Request.Context(), userID.(uuid.UUID), c.Param("provider"))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BeginSocialLoginResponse{
+		State:            state,
+		AuthorizationURL: authURL,
+	})
+}
+
+// FinishIdentityLink godoc
+// @Summary Finish linking a provider
+// @Description Verify the provider response and link the provider account to the current user
+// @Tags social
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param request body dto.FinishSocialLinkRequest true "Code and state from the provider redirect"
+// @Success 201 {object} dto.UserIdentityResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/identities/{provider}/link/finish [post]
+func (h *AuthHandler) FinishIdentityLink(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	var req dto.FinishSocialLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	identity, err := h.socialLoginService.FinishLink(c.Request.Context(), userID.(uuid.UUID), c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapIdentityToDTO(identity))
+}
+
+// UnlinkIdentity godoc
+// @Summary Unlink a provider
+// @Description Remove a linked provider account. The last linked provider of an account without a password or passkeys cannot be removed
+// @Tags social
+// @Security BearerAuth
+// @Produce json
+// @Param identity_id path string true "Identity ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/identities/{identity_id} [delete]
+func (h *AuthHandler) UnlinkIdentity(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	identityID, err := uuid.Parse(c.Param("identity_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid identity ID")
+		return
+	}
+
+	if err := h.socialLoginService.UnlinkIdentity(c.Request.Context(), userID.(uuid.UUID), identityID); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "Provider unlinked successfully",
+	})
+}
+
+func (h *AuthHandler) mapIdentityToDTO(identity *domain.UserIdentity) dto.UserIdentityResponse {
+	return dto.UserIdentityResponse{
+		ID:          identity.ID(),
+		Provider:    identity.Provider(),
+		Email:       identity.Email(),
+		LastLoginAt: identity.LastLoginAt(),
+		CreatedAt:   identity.CreatedAt(),
+	}
+}
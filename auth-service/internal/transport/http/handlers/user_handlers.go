@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/internal/transport/http/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListUsers godoc
+// @Summary List users
+// @Description Search users, newest first. Pass next_cursor as cursor to get the next page. Requires the users.read permission
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param email query string false "Exact email, case-insensitive"
+// @Param username query string false "Username prefix, case-insensitive"
+// @Param is_verified query bool false "Email verified"
+// @Param is_active query bool false "Account active (false lists suspended users)"
+// @Param created_from query string false "Registered at or after, RFC 3339"
+// @Param created_to query string false "Registered before, RFC 3339"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Success 200 {object} dto.ListUsersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /auth/users [get]
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	var req dto.UsersQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	query := service.UserQuery{
+		Email:          req.Email,
+		UsernamePrefix: req.UsernamePrefix,
+		IsVerified:     req.IsVerified,
+		IsActive:       req.IsActive,
+		Cursor:         req.Cursor,
+		Limit:          req.Limit,
+	}
+	if !req.CreatedFrom.IsZero() {
+		query.CreatedFrom = &req.CreatedFrom
+	}
+	if !req.CreatedTo.IsZero() {
+		query.CreatedTo = &req.CreatedTo
+	}
+
+	page, err := h.authService.ListUsers(c.Request.Context(), query)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	users := make([]dto.AdminUserResponse, len(page.Users))
+	for i, user := range page.Users {
+		users[i] = h.mapAdminUserToDTO(user)
+	}
+
+	c.JSON(http.StatusOK, dto.ListUsersResponse{
+		Users:      users,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// GetUser godoc
+// @Summary Get user
+// @Description Get a user with suspension details. Requires the users.read permission
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/users/{user_id} [get]
+func (h *AuthHandler) GetUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid user ID")
+		return
+	}
+
+	user, err := h.authService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapAdminUserToDTO(user))
+}
+
+// SuspendUser godoc
+// @Summary Suspend user
+// @Description Suspend a user and revoke all of their sessions. A suspension with until is lifted automatically when it ends. Requires the users.ban permission and every permission of the suspended user
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body dto.SuspendUserRequest true "Reason and optional end date"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/users/{user_id}/suspend [post]
+func (h *AuthHandler) SuspendUser(c *gin.Context) {
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid user ID")
+		return
+	}
+
+	var req dto.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	user, err := h.authService.SuspendUser(c.Request.Context(), service.UserSuspension{
+		UserID:  userID,
+		ActorID: claims.UserID,
+		Reason:  req.Reason,
+		Until:   req.Until,
+	})
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapAdminUserToDTO(user))
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate user
+// @Description Lift the suspension of a user. Sessions revoked on suspension are not restored. Requires the users.ban permission
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} dto.AdminUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /auth/users/{user_id}/reactivate [post]
+func (h *AuthHandler) ReactivateUser(c *gin.Context) {
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid user ID")
+		return
+	}
+
+	user, err := h.authService.ReactivateUser(c.Request.Context(), userID, claims.UserID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapAdminUserToDTO(user))
+}
+
+// ImpersonateUser godoc
+// @Summary Impersonate user
+// @Description Issue a short-lived access token to act as the user, for reproducing support issues. The token carries an act claim with the admin ID, cannot be refreshed and cannot change credentials, 2FA, sessions or roles. Requires the users.impersonate permission and every permission of the user
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body dto.ImpersonateUserRequest true "Reason recorded in the audit log"
+// @Success 200 {object} dto.ImpersonationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /auth/users/{user_id}/impersonate [post]
+func (h *AuthHandler) ImpersonateUser(c *gin.Context) {
+	value, _ := c.Get(middleware.ContextKeyAccessClaims)
+	claims, ok := value.(*service.AccessTokenClaims)
+	if !ok {
+		h.respondError(c, http.StatusUnauthorized, "unauthorized", "Invalid token")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", "Invalid user ID")
+		return
+	}
+
+	var req dto.ImpersonateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondError(c, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	impersonation, err := h.impersonation.Impersonate(c.Request.Context(), claims.UserID, userID, req.Reason)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ImpersonationResponse{
+		AccessToken: impersonation.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(impersonation.ExpiresAt).Seconds()),
+		User:        h.mapAdminUserToDTO(impersonation.User),
+	})
+}
+
+func (h *AuthHandler) mapAdminUserToDTO(user *domain.User) dto.AdminUserResponse {
+	response := dto.AdminUserResponse{UserResponse: h.mapUserToDTO(user)}
+	if suspension := user.Suspension(); suspension != nil {
+		response.Suspension = &dto.SuspensionResponse{
+			SuspendedBy: suspension.SuspendedBy,
+			Reason:      suspension.Reason,
+			SuspendedAt: suspension.SuspendedAt,
+			Until:       suspension.Until,
+		}
+	}
+	return response
+}
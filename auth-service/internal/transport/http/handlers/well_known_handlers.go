@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/internal/transport/http/dto"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksCacheMaxAge задает, как долго клиенты могут кешировать JWKS. Значение
+// должно быть заметно меньше grace period ключей, иначе клиенты не успеют
+// увидеть новый ключ до вывода старого из оборота.
+const jwksCacheMaxAge = "public, max-age=300"
+
+type WellKnownHandler struct {
+	jwtService *service.JWTService
+	publicURL  string
+}
+
+// NewWellKnownHandler создает обработчик well-known документов. publicURL - внешний адрес
+// сервиса, от которого строятся адреса endpoint'ов в discovery документе.
+func NewWellKnownHandler(jwtService *service.JWTService, publicURL string) *WellKnownHandler {
+	return &WellKnownHandler{
+		jwtService: jwtService,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// JWKS отдает открытые ключи для проверки access токенов (RFC 7517)
+func (h *WellKnownHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksCacheMaxAge)
+	c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
+
+// OpenIDConfiguration отдает discovery документ OpenID Connect
+func (h *WellKnownHandler) OpenIDConfiguration(c *gin.Context) {
+	c.Header("Cache-Control", jwksCacheMaxAge)
+	c.JSON(http.StatusOK, dto.OpenIDConfigurationResponse{
+		Issuer:                            h.jwtService.Issuer(),
+		AuthorizationEndpoint:             h.publicURL + "/oauth/authorize",
+		TokenEndpoint:                     h.publicURL + "/oauth/token",
+		UserInfoEndpoint:                  h.publicURL + "/oauth/userinfo",
+		JWKSURI:                           h.publicURL + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             h.publicURL + "/oauth/introspect",
+		RevocationEndpoint:                h.publicURL + "/oauth/revoke",
+		ScopesSupported:                   service.SupportedScopes,
+		ResponseTypesSupported:            []string{service.ResponseTypeCode},
+		GrantTypesSupported:               []string{service.GrantTypeAuthorizationCode, service.GrantTypeRefreshToken, service.GrantTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  h.jwtService.SigningAlgorithms(),
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{domain.CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "sid", "name", "preferred_username", "email", "email_verified"},
+	})
+}
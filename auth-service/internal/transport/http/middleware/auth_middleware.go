@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"net/http"
+	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/service"
 	"social-network/auth-service/internal/transport/http/dto"
+	"social-network/auth-service/pkg/requestctx"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,17 +13,23 @@ import (
 	"time"
 )
 
+// ContextKeyAccessClaims - ключ gin.Context, под которым хранятся claims проверенного access токена
+const ContextKeyAccessClaims = "access_claims"
+
 type AuthMiddleware struct {
-	jwtService *service.JWTService
+	tokenRevocation      *service.TokenRevocationService
+	personalAccessTokens *service.PersonalAccessTokenService
 }
 
-func NewAuthMiddleware(jwtService *service.JWTService) *AuthMiddleware {
+func NewAuthMiddleware(tokenRevocation *service.TokenRevocationService, personalAccessTokens *service.PersonalAccessTokenService) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService: jwtService,
+		tokenRevocation:      tokenRevocation,
+		personalAccessTokens: personalAccessTokens,
 	}
 }
 
-// RequireAuth проверяет наличие и валидность access token
+// RequireAuth проверяет наличие и валидность access token, включая список отзыва.
+// Вместо access токена можно передать персональный токен, если его scope разрешает запрос.
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := m.extractToken(c)
@@ -30,48 +38,57 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := m.jwtService.ValidateAccessToken(token)
+		claims, err := m.authenticate(c, token)
 		if err != nil {
 			m.respondUnauthorized(c, "Invalid or expired token")
 			return
 		}
 
+		if !m.scopeAllows(claims, c.Request.Method) {
+			m.respondForbidden(c, "Token scope does not allow this request")
+			return
+		}
+
 		// Сохраняем данные пользователя в контексте
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_username", claims.Username)
 		c.Set("user_roles", claims.Roles)
 		c.Set("user_verified", claims.IsVerified)
+		c.Set("session_id", claims.SessionID)
+		c.Set(ContextKeyAccessClaims, claims)
+		ctx := requestctx.WithUserID(c.Request.Context(), claims.UserID.String())
+		if claims.IsImpersonated() {
+			// Действия администратора от имени пользователя попадают в логи и аудит с его ID
+			ctx = requestctx.WithImpersonatorID(ctx, claims.Actor.Subject)
+		}
+		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 	}
 }
 
-// RequireRole проверяет наличие определенной роли
-func (m *AuthMiddleware) RequireRole(requiredRole string) gin.HandlerFunc {
+// RequireInteractiveSession отклоняет персональные токены: учетными данными и сессиями
+// управляют только после входа, чтобы утекший токен бота не давал захватить аккаунт
+func (m *AuthMiddleware) RequireInteractiveSession() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roles, exists := c.Get("user_roles")
-		if !exists {
-			m.respondForbidden(c, "No roles found")
-			return
-		}
-
-		userRoles, ok := roles.([]string)
-		if !ok {
-			m.respondForbidden(c, "Invalid roles format")
+		value, _ := c.Get(ContextKeyAccessClaims)
+		if claims, ok := value.(*service.AccessTokenClaims); ok && claims.IsPersonalAccessToken() {
+			m.respondForbidden(c, "Personal access tokens cannot be used for this operation")
 			return
 		}
 
-		hasRole := false
-		for _, role := range userRoles {
-			if role == requiredRole {
-				hasRole = true
-				break
-			}
-		}
+		c.Next()
+	}
+}
 
-		if !hasRole {
-			m.respondForbidden(c, "Insufficient permissions")
+// RejectImpersonation отклоняет токены имперсонации: администратор, вошедший от имени
+// пользователя, не может менять его пароль, 2FA, способы входа и роли
+func (m *AuthMiddleware) RejectImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get(ContextKeyAccessClaims)
+		if claims, ok := value.(*service.AccessTokenClaims); ok && claims.IsImpersonated() {
+			m.respondForbidden(c, "Impersonation tokens cannot be used for this operation")
 			return
 		}
 
@@ -79,35 +96,18 @@ func (m *AuthMiddleware) RequireRole(requiredRole string) gin.HandlerFunc {
 	}
 }
 
-// RequireAdmin проверяет права администратора
-func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
-	return m.RequireRole("admin")
-}
-
-// RequireModerator проверяет права модератора или выше
-func (m *AuthMiddleware) RequireModerator() gin.HandlerFunc {
+// RequirePermission проверяет, что роли пользователя дают право. Права берутся из токена,
+// поэтому middleware ставится после RequireAuth.
+func (m *AuthMiddleware) RequirePermission(permission domain.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roles, exists := c.Get("user_roles")
-		if !exists {
-			m.respondForbidden(c, "No roles found")
-			return
-		}
-
-		userRoles, ok := roles.([]string)
+		value, _ := c.Get(ContextKeyAccessClaims)
+		claims, ok := value.(*service.AccessTokenClaims)
 		if !ok {
-			m.respondForbidden(c, "Invalid roles format")
+			m.respondUnauthorized(c, "Missing authorization token")
 			return
 		}
 
-		hasPermission := false
-		for _, role := range userRoles {
-			if role == "admin" || role == "moderator" {
-				hasPermission = true
-				break
-			}
-		}
-
-		if !hasPermission {
+		if !claims.HasPermission(permission) {
 			m.respondForbidden(c, "Insufficient permissions")
 			return
 		}
@@ -144,8 +144,8 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := m.jwtService.ValidateAccessToken(token)
-		if err != nil {
+		claims, err := m.authenticate(c, token)
+		if err != nil || !m.scopeAllows(claims, c.Request.Method) {
 			c.Next()
 			return
 		}
@@ -156,11 +156,39 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		c.Set("user_username", claims.Username)
 		c.Set("user_roles", claims.Roles)
 		c.Set("user_verified", claims.IsVerified)
+		c.Set("session_id", claims.SessionID)
+		c.Set(ContextKeyAccessClaims, claims)
 
 		c.Next()
 	}
 }
 
+// authenticate проверяет персональный токен или JWT access токен
+func (m *AuthMiddleware) authenticate(c *gin.Context, token string) (*service.AccessTokenClaims, error) {
+	if service.IsPersonalAccessToken(token) {
+		return m.personalAccessTokens.Authenticate(c.Request.Context(), token, c.ClientIP())
+	}
+	return m.tokenRevocation.ValidateAccessToken(c.Request.Context(), token)
+}
+
+// scopeAllows проверяет scope персонального токена: api:read разрешает только чтение.
+// Access токены после входа ограничений по методам не имеют. Токены OAuth клиентов несут
+// scope OpenID Connect и к API не допускаются.
+func (m *AuthMiddleware) scopeAllows(claims *service.AccessTokenClaims, method string) bool {
+	if claims.IsClientToken() {
+		return false
+	}
+	if !claims.IsPersonalAccessToken() || claims.HasScope(service.ScopeAPIWrite) {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return claims.HasScope(service.ScopeAPIRead)
+	}
+	return false
+}
+
 func (m *AuthMiddleware) extractToken(c *gin.Context) string {
 	// Проверяем заголовок Authorization
 	authHeader := c.GetHeader("Authorization")
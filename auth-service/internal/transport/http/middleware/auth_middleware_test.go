@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"social-network/auth-service/internal/domain"
+	"social-network/auth-service/internal/infrastructure/keys"
+	"social-network/auth-service/internal/infrastructure/memory"
+	"social-network/auth-service/internal/service"
+	"social-network/auth-service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestAuthMiddleware_ScopeAllows(t *testing.T) {
+	m := &AuthMiddleware{}
+	pat := func(scope string) *service.AccessTokenClaims {
+		return &service.AccessTokenClaims{PersonalAccessTokenID: uuid.New(), Scope: scope}
+	}
+
+	tests := []struct {
+		name   string
+		claims *service.AccessTokenClaims
+		method string
+		want   bool
+	}{
+		{"access token may write", &service.AccessTokenClaims{}, http.MethodPost, true},
+		{"read token may GET", pat(service.ScopeAPIRead), http.MethodGet, true},
+		{"read token may HEAD", pat(service.ScopeAPIRead), http.MethodHead, true},
+		{"read token may not POST", pat(service.ScopeAPIRead), http.MethodPost, false},
+		{"read token may not DELETE", pat(service.ScopeAPIRead), http.MethodDelete, false},
+		{"write token may POST", pat(service.ScopeAPIWrite), http.MethodPost, true},
+		{"write token may GET", pat(service.ScopeAPIWrite), http.MethodGet, true},
+		{"token without scopes may not GET", pat(""), http.MethodGet, false},
+		{"oauth client token may not GET", &service.AccessTokenClaims{ClientID: "web-client", Scope: "openid profile"}, http.MethodGet, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.scopeAllows(tt.claims, tt.method); got != tt.want {
+				t.Fatalf("scopeAllows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireInteractiveSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := &AuthMiddleware{}
+
+	tests := []struct {
+		name   string
+		claims *service.AccessTokenClaims
+		want   int
+	}{
+		{"access token", &service.AccessTokenClaims{UserID: uuid.New()}, http.StatusNoContent},
+		{"personal access token", &service.AccessTokenClaims{UserID: uuid.New(), PersonalAccessTokenID: uuid.New(), Scope: service.ScopeAPIWrite}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/",
+				func(c *gin.Context) { c.Set(ContextKeyAccessClaims, tt.claims) },
+				m.RequireInteractiveSession(),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := &AuthMiddleware{}
+
+	tests := []struct {
+		name   string
+		claims *service.AccessTokenClaims
+		want   int
+	}{
+		{"with permission", &service.AccessTokenClaims{Permissions: "roles.manage roles.read"}, http.StatusNoContent},
+		{"without permission", &service.AccessTokenClaims{Permissions: "roles.read"}, http.StatusForbidden},
+		{"admin role without permission", &service.AccessTokenClaims{Roles: []domain.UserRoleType{domain.RoleAdmin}}, http.StatusForbidden},
+		{"no claims", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/",
+				func(c *gin.Context) {
+					if tt.claims != nil {
+						c.Set(ContextKeyAccessClaims, tt.claims)
+					}
+				},
+				m.RequirePermission(domain.PermissionRolesManage),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := &AuthMiddleware{}
+
+	tests := []struct {
+		name   string
+		claims *service.AccessTokenClaims
+		want   int
+	}{
+		{"access token", &service.AccessTokenClaims{UserID: uuid.New()}, http.StatusNoContent},
+		{"impersonation token", &service.AccessTokenClaims{UserID: uuid.New(), Actor: &service.ActorClaim{Subject: uuid.NewString()}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/",
+				func(c *gin.Context) { c.Set(ContextKeyAccessClaims, tt.claims) },
+				m.RejectImpersonation(),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireAuth_ClientToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	log := logger.NewCustomLogger("auth-service-test", "error", io.Discard)
+	keySet, err := keys.NewKeySet("", "", 0, log)
+	if err != nil {
+		t.Fatalf("create key set: %v", err)
+	}
+	jwtService := service.NewJWTService(keySet, []byte("test-refresh-secret"), "https://auth.test")
+	m := NewAuthMiddleware(service.NewTokenRevocationService(memory.NewTokenRevocationRepository(), jwtService, log), nil)
+
+	user := domain.NewUser("admin@example.com", "admin", "Admin")
+	access := &service.UserAccess{Roles: []domain.UserRoleType{domain.RoleAdmin}, Permissions: domain.KnownPermissions}
+	mustSign := func(clientID string) string {
+		t.Helper()
+		token, err := jwtService.SignAccessToken(jwtService.NewAccessTokenClaims(user, access, uuid.New(), service.ScopeOpenID, clientID))
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"first-party token", mustSign(""), http.StatusNoContent},
+		// Даже если в токене клиента оказались права администратора, API его не принимает
+		{"oauth client token", mustSign("web-client"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.DELETE("/admin/roles/support",
+				m.RequireAuth(),
+				m.RequirePermission(domain.PermissionRolesManage),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/roles/support", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
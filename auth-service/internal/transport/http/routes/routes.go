@@ -6,6 +6,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "social-network/auth-service/docs" // Импорт docs
+	"social-network/auth-service/internal/domain"
 	"social-network/auth-service/internal/transport/http/handlers"
 	"social-network/auth-service/internal/transport/http/middleware"
 )
@@ -32,6 +33,9 @@ import (
 func SetupRoutes(
 	router *gin.Engine,
 	authHandler *handlers.AuthHandler,
+	wellKnownHandler *handlers.WellKnownHandler,
+	oauthHandler *handlers.OAuthHandler,
+	oidcHandler *handlers.OIDCHandler,
 	authMiddleware *middleware.AuthMiddleware,
 ) {
 	// Debug endpoint
@@ -42,6 +46,13 @@ func SetupRoutes(
 			"routes": []string{
 				"GET /swagger/index.html",
 				"GET /health",
+				"GET /.well-known/jwks.json",
+				"GET /.well-known/openid-configuration",
+				"GET /oauth/authorize",
+				"POST /oauth/token",
+				"GET /oauth/userinfo",
+				"POST /oauth/introspect",
+				"POST /oauth/revoke",
 				"POST /api/auth/register",
 				"POST /api/auth/login",
 			},
@@ -69,6 +80,23 @@ func SetupRoutes(
 		c.JSON(200, gin.H{"status": "ready"})
 	})
 
+	// Открытые ключи для проверки access токенов другими сервисами
+	router.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+	router.GET("/.well-known/openid-configuration", wellKnownHandler.OpenIDConfiguration)
+
+	// OAuth endpoints для доверенных сервисов (client credentials)
+	oauth := router.Group("/oauth")
+	{
+		oauth.POST("/introspect", oauthHandler.Introspect)
+		oauth.POST("/revoke", oauthHandler.Revoke)
+
+		// OpenID Connect: authorization code с PKCE
+		oauth.GET("/authorize", oidcHandler.Authorize)
+		oauth.POST("/token", oidcHandler.Token)
+		oauth.GET("/userinfo", oidcHandler.UserInfo)
+		oauth.POST("/userinfo", oidcHandler.UserInfo)
+	}
+
 	// API routes
 	api := router.Group("/api")
 	{
@@ -80,27 +108,104 @@ func SetupRoutes(
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
 			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", authHandler.ResendVerificationEmail)
 			auth.POST("/reset-password", authHandler.InitiatePasswordReset)
 			auth.POST("/reset-password/confirm", authHandler.ResetPassword)
+			auth.POST("/magic-link", authHandler.RequestMagicLink)
+			auth.POST("/magic-link/verify", authHandler.MagicLinkLogin)
+			auth.POST("/2fa/verify", authHandler.VerifyMFA)
+			auth.POST("/passkeys/login/begin", authHandler.BeginPasskeyLogin)
+			auth.POST("/passkeys/login/finish", authHandler.FinishPasskeyLogin)
+			auth.GET("/social/providers", authHandler.ListSocialProviders)
+			auth.POST("/social/:provider/login/begin", authHandler.BeginSocialLogin)
+			auth.POST("/social/:provider/login/finish", authHandler.FinishSocialLogin)
 
 			// Protected endpoints
 			protected := auth.Group("")
 			protected.Use(authMiddleware.RequireAuth())
 			{
 				protected.GET("/me", authHandler.GetCurrentUser)
-				protected.PUT("/change-password", authHandler.ChangePassword)
-				protected.POST("/logout", authHandler.Logout)
 				protected.GET("/validate", authHandler.ValidateToken)
 			}
 
-			// Admin endpoints
+			// Управление учетными данными и сессиями недоступно персональным токенам, а смена
+			// способов входа и завершение чужих сессий - и токенам имперсонации
+			account := auth.Group("")
+			account.Use(authMiddleware.RequireAuth(), authMiddleware.RequireInteractiveSession())
+			{
+				account.PUT("/change-password", authMiddleware.RejectImpersonation(), authHandler.ChangePassword)
+				account.POST("/logout", authHandler.Logout)
+
+				// Session management
+				account.GET("/sessions", authHandler.ListSessions)
+				account.POST("/sessions/revoke-others", authMiddleware.RejectImpersonation(), authHandler.RevokeOtherSessions)
+				account.DELETE("/sessions/:session_id", authMiddleware.RejectImpersonation(), authHandler.RevokeSession)
+
+				// Two-factor authentication
+				account.POST("/2fa/enroll", authMiddleware.RejectImpersonation(), authHandler.EnrollTOTP)
+				account.POST("/2fa/confirm", authMiddleware.RejectImpersonation(), authHandler.ConfirmTOTP)
+				account.POST("/2fa/disable", authMiddleware.RejectImpersonation(), authHandler.DisableTOTP)
+
+				// Passkeys
+				account.GET("/passkeys", authHandler.ListPasskeys)
+				account.POST("/passkeys/register/begin", authMiddleware.RejectImpersonation(), authHandler.BeginPasskeyRegistration)
+				account.POST("/passkeys/register/finish", authMiddleware.RejectImpersonation(), authHandler.FinishPasskeyRegistration)
+				account.DELETE("/passkeys/:passkey_id", authMiddleware.RejectImpersonation(), authHandler.DeletePasskey)
+				account.DELETE("/password", authMiddleware.RejectImpersonation(), authHandler.RemovePassword)
+
+				// Linked social login providers
+				account.GET("/identities", authHandler.ListIdentities)
+				account.POST("/identities/:provider/link/begin", authMiddleware.RejectImpersonation(), authHandler.BeginIdentityLink)
+				account.POST("/identities/:provider/link/finish", authMiddleware.RejectImpersonation(), authHandler.FinishIdentityLink)
+				account.DELETE("/identities/:identity_id", authMiddleware.RejectImpersonation(), authHandler.UnlinkIdentity)
+
+				// Personal access tokens
+				account.GET("/tokens", authHandler.ListPersonalAccessTokens)
+				account.POST("/tokens", authMiddleware.RejectImpersonation(), authHandler.CreatePersonalAccessToken)
+				account.DELETE("/tokens/:token_id", authMiddleware.RejectImpersonation(), authHandler.RevokePersonalAccessToken)
+			}
+
+			// Admin endpoints: доступ определяется правами ролей; персональным токенам недоступны
 			admin := auth.Group("/users")
-			admin.Use(authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+			admin.Use(authMiddleware.RequireAuth(), authMiddleware.RequireInteractiveSession())
+			{
+				admin.GET("", authMiddleware.RequirePermission(domain.PermissionUsersRead), authHandler.ListUsers)
+				admin.GET("/:user_id", authMiddleware.RequirePermission(domain.PermissionUsersRead), authHandler.GetUser)
+				admin.POST("/:user_id/suspend", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionUsersBan), authHandler.SuspendUser)
+				admin.POST("/:user_id/reactivate", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionUsersBan), authHandler.ReactivateUser)
+				admin.POST("/:user_id/impersonate", authMiddleware.RequirePermission(domain.PermissionUsersImpersonate), authMiddleware.RejectImpersonation(), authHandler.ImpersonateUser)
+				admin.POST("/:user_id/roles", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionRolesAssign), authHandler.AssignRole)
+				admin.DELETE("/:user_id/roles/:role", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionRolesAssign), authHandler.RevokeRole)
+				admin.GET("/:user_id/roles", authMiddleware.RequirePermission(domain.PermissionRolesRead), authHandler.GetUserRoles)
+				admin.GET("/:user_id/roles/history", authMiddleware.RequirePermission(domain.PermissionRolesRead), authHandler.GetRoleHistory)
+			}
+
+			// Security audit log
+			audit := auth.Group("/audit")
+			audit.Use(authMiddleware.RequireAuth(), authMiddleware.RequireInteractiveSession(), authMiddleware.RequirePermission(domain.PermissionAuditRead))
+			{
+				audit.GET("/events", authHandler.ListAuditEvents)
+				audit.GET("/events/export", authHandler.ExportAuditEvents)
+			}
+
+			// Role definitions
+			roles := auth.Group("")
+			roles.Use(authMiddleware.RequireAuth(), authMiddleware.RequireInteractiveSession())
 			{
-				admin.POST("/:user_id/roles", authHandler.AssignRole)
-				admin.DELETE("/:user_id/roles/:role", authHandler.RevokeRole)
-				admin.GET("/:user_id/roles", authHandler.GetUserRoles)
+				roles.GET("/permissions", authMiddleware.RequirePermission(domain.PermissionRolesRead), authHandler.ListPermissions)
+				roles.GET("/roles", authMiddleware.RequirePermission(domain.PermissionRolesRead), authHandler.ListRoles)
+				roles.GET("/roles/:role", authMiddleware.RequirePermission(domain.PermissionRolesRead), authHandler.GetRole)
+				roles.POST("/roles", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionRolesManage), authHandler.CreateRole)
+				roles.PUT("/roles/:role", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionRolesManage), authHandler.UpdateRole)
+				roles.DELETE("/roles/:role", authMiddleware.RejectImpersonation(), authMiddleware.RequirePermission(domain.PermissionRolesManage), authHandler.DeleteRole)
 			}
 		}
+
+		// Завершение авторизации OpenID Connect страницей входа
+		oauthAPI := api.Group("/oauth")
+		oauthAPI.Use(authMiddleware.RequireAuth(), authMiddleware.RequireInteractiveSession(), authMiddleware.RejectImpersonation())
+		{
+			oauthAPI.POST("/authorize", oidcHandler.CompleteAuthorization)
+		}
 	}
 }
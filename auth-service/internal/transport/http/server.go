@@ -25,6 +25,15 @@ func NewServer(
 	cfg *config.Config,
 	authService *service.AuthService,
 	jwtService *service.JWTService,
+	tokenRevocation *service.TokenRevocationService,
+	oauthService *service.OAuthService,
+	oidcService *service.OIDCService,
+	passkeyService *service.PasskeyService,
+	socialLoginService *service.SocialLoginService,
+	personalAccessTokens *service.PersonalAccessTokenService,
+	roleService *service.RoleService,
+	impersonation *service.ImpersonationService,
+	auditService *service.AuditService,
 	validationService *service.ValidationService,
 	customLogger logger.Logger,
 	zapLogger *logger.ZapLogger,
@@ -36,8 +45,10 @@ func NewServer(
 	router := gin.New()
 
 	// Middleware
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(middleware.LoggingMiddleware(zapLogger))
 	router.Use(middleware.RecoveryMiddleware(zapLogger))
+	router.Use(middleware.TimeoutMiddleware(cfg.Server.HTTP.WriteTimeout))
 	router.Use(gin.Recovery())
 
 	// CORS middleware - ИСПРАВЛЕННАЯ ВЕРСИЯ
@@ -50,7 +61,8 @@ func NewServer(
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+		c.Header("Access-Control-Expose-Headers", "X-Request-ID, Retry-After")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400")
 
@@ -62,11 +74,14 @@ func NewServer(
 	})
 
 	// Handlers
-	authHandler := handlers.NewAuthHandler(authService, jwtService, validationService, customLogger)
-	authMiddleware := httpMiddleware.NewAuthMiddleware(jwtService)
+	authHandler := handlers.NewAuthHandler(authService, jwtService, passkeyService, socialLoginService, personalAccessTokens, roleService, impersonation, auditService, validationService, customLogger)
+	wellKnownHandler := handlers.NewWellKnownHandler(jwtService, cfg.OIDC.PublicURL)
+	oauthHandler := handlers.NewOAuthHandler(oauthService, customLogger)
+	oidcHandler := handlers.NewOIDCHandler(oidcService, oauthService, customLogger)
+	authMiddleware := httpMiddleware.NewAuthMiddleware(tokenRevocation, personalAccessTokens)
 
 	// Routes
-	routes.SetupRoutes(router, authHandler, authMiddleware)
+	routes.SetupRoutes(router, authHandler, wellKnownHandler, oauthHandler, oidcHandler, authMiddleware)
 
 	// HTTP Server
 	server := &http.Server{
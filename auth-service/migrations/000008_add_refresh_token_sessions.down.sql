@@ -0,0 +1,6 @@
+-- Drop device/session metadata from refresh_tokens
+DROP INDEX IF EXISTS idx_refresh_tokens_last_used_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS device_name;
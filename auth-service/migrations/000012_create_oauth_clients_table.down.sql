@@ -0,0 +1,2 @@
+-- Drop oauth_clients table
+DROP TABLE IF EXISTS oauth_clients;
@@ -0,0 +1,5 @@
+-- Drop token revocation tables
+DROP TABLE IF EXISTS session_revocation_exemptions;
+DROP TABLE IF EXISTS revoked_sessions;
+DROP TABLE IF EXISTS user_token_revocations;
+DROP TABLE IF EXISTS revoked_access_tokens;
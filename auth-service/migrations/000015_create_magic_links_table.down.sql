@@ -0,0 +1,2 @@
+-- Drop magic_links table
+DROP TABLE IF EXISTS magic_links;
@@ -0,0 +1,20 @@
+-- Create magic_links table
+CREATE TABLE IF NOT EXISTS magic_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    token VARCHAR(255) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    is_used BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_magic_links_user_id ON magic_links(user_id);
+CREATE INDEX IF NOT EXISTS idx_magic_links_token ON magic_links(token);
+CREATE INDEX IF NOT EXISTS idx_magic_links_expires_at ON magic_links(expires_at);
+CREATE INDEX IF NOT EXISTS idx_magic_links_created_at ON magic_links(created_at);
+
+-- Create partial index for active links
+CREATE INDEX IF NOT EXISTS idx_magic_links_active 
+ON magic_links(user_id, expires_at) WHERE is_used = FALSE;
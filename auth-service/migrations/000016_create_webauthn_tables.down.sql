@@ -0,0 +1,3 @@
+-- Drop webauthn tables
+DROP TABLE IF EXISTS webauthn_sessions;
+DROP TABLE IF EXISTS webauthn_credentials;
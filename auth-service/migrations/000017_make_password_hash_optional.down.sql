@@ -0,0 +1,3 @@
+-- Require a password hash again; passwordless accounts get an unusable empty hash
+UPDATE user_auth SET password_hash = '' WHERE password_hash IS NULL;
+ALTER TABLE user_auth ALTER COLUMN password_hash SET NOT NULL;
@@ -0,0 +1,2 @@
+-- Allow accounts without a password (passkey-only)
+ALTER TABLE user_auth ALTER COLUMN password_hash DROP NOT NULL;
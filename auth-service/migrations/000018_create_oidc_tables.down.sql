@@ -0,0 +1,8 @@
+-- Drop OIDC tables
+DROP TABLE IF EXISTS oauth_consents;
+DROP TABLE IF EXISTS oauth_authorization_codes;
+
+-- Drop authorization code flow settings from oauth_clients
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS skip_consent;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS is_public;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS redirect_uris;
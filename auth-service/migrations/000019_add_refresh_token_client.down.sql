@@ -0,0 +1,3 @@
+-- Drop OIDC client binding from refresh_tokens
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS scope;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS client_id;
@@ -0,0 +1,2 @@
+-- Drop client credentials audiences from oauth_clients
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS audiences;
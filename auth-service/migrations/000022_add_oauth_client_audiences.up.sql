@@ -0,0 +1,2 @@
+-- Add client credentials audiences to oauth_clients
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS audiences TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,8 @@
+-- Restore the fixed role list; assignments of custom roles are dropped
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS fk_user_roles_role;
+DELETE FROM user_roles WHERE role NOT IN ('user', 'moderator', 'admin');
+ALTER TABLE user_roles ALTER COLUMN role TYPE VARCHAR(20);
+ALTER TABLE user_roles ADD CONSTRAINT user_roles_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+
+-- Drop roles table
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,23 @@
+-- Create roles table
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Seed the roles that used to be hard-coded
+INSERT INTO roles (name, description, permissions, is_system) VALUES
+    ('user', 'Regular user', '{}', TRUE),
+    ('moderator', 'Removes posts and comments', '{comments.delete,posts.delete}', TRUE),
+    ('admin', 'Full access', '{comments.delete,posts.delete,roles.assign,roles.manage,roles.read,users.ban,users.read}', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Replace the fixed role list with a reference to roles
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_role_check;
+ALTER TABLE user_roles ALTER COLUMN role TYPE VARCHAR(50);
+ALTER TABLE user_roles ADD CONSTRAINT fk_user_roles_role
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE;
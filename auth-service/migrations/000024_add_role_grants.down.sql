@@ -0,0 +1,8 @@
+-- Drop role_changes table
+DROP TABLE IF EXISTS role_changes;
+
+-- Drop grant details from user_roles
+DROP INDEX IF EXISTS idx_user_roles_expires_at;
+ALTER TABLE user_roles DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE user_roles DROP COLUMN IF EXISTS reason;
+ALTER TABLE user_roles DROP COLUMN IF EXISTS granted_by;
@@ -0,0 +1,23 @@
+-- Record who granted a role, why, and until when
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS granted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS reason VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_expires_at
+ON user_roles(expires_at) WHERE is_active = TRUE AND expires_at IS NOT NULL;
+
+-- Create role_changes table
+CREATE TABLE IF NOT EXISTS role_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('granted', 'revoked', 'expired')),
+    actor_id UUID,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_role_changes_user_id ON role_changes(user_id, created_at DESC);
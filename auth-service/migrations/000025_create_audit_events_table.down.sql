@@ -0,0 +1,6 @@
+-- Drop audit permission
+UPDATE roles SET permissions = array_remove(permissions, 'audit.read'), updated_at = NOW();
+
+-- Drop audit_events table
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
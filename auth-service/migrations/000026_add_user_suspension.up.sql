@@ -0,0 +1,14 @@
+-- Record who suspended a user, why, and until when
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_users_suspended_until
+ON users(suspended_until) WHERE is_active = FALSE AND suspended_until IS NOT NULL;
+
+-- Indexes for the admin user search: exact email and username prefix, case-insensitive
+CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_users_username_lower_pattern ON users(LOWER(username) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Drop impersonation permission
+UPDATE roles SET permissions = array_remove(permissions, 'users.impersonate'), updated_at = NOW();
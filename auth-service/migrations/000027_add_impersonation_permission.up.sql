@@ -0,0 +1,4 @@
+-- Let admins impersonate users
+UPDATE roles
+SET permissions = array_append(permissions, 'users.impersonate'), updated_at = NOW()
+WHERE name = 'admin' AND NOT ('users.impersonate' = ANY(permissions));
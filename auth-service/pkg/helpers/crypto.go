@@ -2,25 +2,10 @@ package helpers
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword хеширует пароль с использованием bcrypt
-func HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
-	}
-	return string(hashedBytes), nil
-}
-
-// ComparePassword сравнивает пароль с хешем
-func ComparePassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
-}
-
 // GenerateSecureToken генерирует криптографически стойкий токен
 func GenerateSecureToken() string {
 	bytes := make([]byte, 32)
@@ -34,3 +19,10 @@ func GenerateSecureTokenWithLength(length int) string {
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
 }
+
+// HashToken хеширует высокоэнтропийный токен для хранения в БД.
+// Для паролей используйте HashPassword: SHA-256 не замедляет перебор.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -8,11 +8,15 @@ var TokenExpirationTimes = struct {
 	RefreshToken      time.Duration
 	EmailVerification time.Duration
 	PasswordReset     time.Duration
+	MagicLink         time.Duration
+	AuthorizationCode time.Duration
 }{
 	AccessToken:       15 * time.Minute,
 	RefreshToken:      7 * 24 * time.Hour,
 	EmailVerification: 24 * time.Hour,
 	PasswordReset:     1 * time.Hour,
+	MagicLink:         15 * time.Minute,
+	AuthorizationCode: 1 * time.Minute,
 }
 
 // GetExpirationTime возвращает время истечения для токена
@@ -28,6 +32,10 @@ func GetExpirationTime(tokenType string) time.Time {
 		return now.Add(TokenExpirationTimes.EmailVerification)
 	case "password_reset":
 		return now.Add(TokenExpirationTimes.PasswordReset)
+	case "magic_link":
+		return now.Add(TokenExpirationTimes.MagicLink)
+	case "authorization_code":
+		return now.Add(TokenExpirationTimes.AuthorizationCode)
 	default:
 		return now.Add(1 * time.Hour) // default 1 hour
 	}
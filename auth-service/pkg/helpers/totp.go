@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры TOTP (RFC 6238), совместимые с Google Authenticator и аналогами
+const (
+	TOTPPeriod     = 30
+	TOTPDigits     = 6
+	TOTPSecretSize = 20
+	// TOTPSkew - количество соседних 30-секундных интервалов, коды которых тоже принимаются
+	TOTPSkew = 1
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret генерирует случайный секрет TOTP в base32
+func GenerateTOTPSecret() string {
+	bytes := make([]byte, TOTPSecretSize)
+	rand.Read(bytes)
+	return base32NoPadding.EncodeToString(bytes)
+}
+
+// TOTPStep возвращает номер 30-секундного интервала для момента времени
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / TOTPPeriod
+}
+
+// GenerateTOTPCode вычисляет код для интервала (HOTP от номера интервала, RFC 4226)
+func GenerateTOTPCode(secret string, step int64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000), nil
+}
+
+// ValidateTOTPCode проверяет код с допуском TOTPSkew интервалов и возвращает
+// номер совпавшего интервала, чтобы вызывающий мог запретить повторное использование кода
+func ValidateTOTPCode(secret, code string, at time.Time) (int64, bool) {
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(at)
+	for step := current - TOTPSkew; step <= current+TOTPSkew; step++ {
+		expected, err := GenerateTOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// IsTOTPCode проверяет, похожа ли строка на TOTP код (ровно TOTPDigits цифр)
+func IsTOTPCode(code string) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildTOTPURI формирует otpauth:// URI для добавления секрета в приложение-аутентификатор
+func BuildTOTPURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(TOTPPeriod))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateRecoveryCode генерирует одноразовый код восстановления вида xxxxx-xxxxx
+func GenerateRecoveryCode() string {
+	bytes := make([]byte, 7)
+	rand.Read(bytes)
+	code := strings.ToLower(base32NoPadding.EncodeToString(bytes))[:10]
+	return code[:5] + "-" + code[5:]
+}
+
+// NormalizeRecoveryCode приводит введенный пользователем код восстановления к каноническому виду
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret - ключ "12345678901234567890" из тестовых векторов RFC 6238 в base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := GenerateTOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPCode_InvalidSecret(t *testing.T) {
+	if _, err := GenerateTOTPCode("not base32!", 1); err == nil {
+		t.Fatal("expected error for invalid secret")
+	}
+}
+
+func TestValidateTOTPCode_Window(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := TOTPStep(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		valid  bool
+	}{
+		{name: "two steps behind", offset: -2, valid: false},
+		{name: "previous step", offset: -1, valid: true},
+		{name: "current step", offset: 0, valid: true},
+		{name: "next step", offset: 1, valid: true},
+		{name: "two steps ahead", offset: 2, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateTOTPCode(rfc6238Secret, current+tt.offset)
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+
+			step, ok := ValidateTOTPCode(rfc6238Secret, code, now)
+			if ok != tt.valid {
+				t.Fatalf("expected valid=%v, got %v", tt.valid, ok)
+			}
+			// Номер интервала нужен вызывающему, чтобы не принять тот же код повторно
+			if ok && step != current+tt.offset {
+				t.Fatalf("expected step %d, got %d", current+tt.offset, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPCode_Malformed(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	for _, code := range []string{"", "00592", "0059244", "abcdef"} {
+		if _, ok := ValidateTOTPCode(rfc6238Secret, code, now); ok {
+			t.Fatalf("code %q must be rejected", code)
+		}
+	}
+	if _, ok := ValidateTOTPCode("not base32!", "005924", now); ok {
+		t.Fatal("code must be rejected for invalid secret")
+	}
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "123456", want: true},
+		{code: "000000", want: true},
+		{code: "12345", want: false},
+		{code: "1234567", want: false},
+		{code: "12345a", want: false},
+		{code: "abcde-fghij", want: false},
+		{code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTOTPCode(tt.code); got != tt.want {
+			t.Errorf("IsTOTPCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret := GenerateTOTPSecret()
+
+	key, err := base32NoPadding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret must be base32: %v", err)
+	}
+	if len(key) != TOTPSecretSize {
+		t.Fatalf("expected %d byte key, got %d", TOTPSecretSize, len(key))
+	}
+	if GenerateTOTPSecret() == secret {
+		t.Fatal("secrets must be random")
+	}
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code := GenerateRecoveryCode()
+		if len(code) != 11 || code[5] != '-' {
+			t.Fatalf("unexpected recovery code format %q", code)
+		}
+		if code != strings.ToLower(code) {
+			t.Fatalf("recovery code must be lower case, got %q", code)
+		}
+		// Код восстановления не должен приниматься за TOTP код
+		if IsTOTPCode(NormalizeRecoveryCode(code)) {
+			t.Fatalf("recovery code %q looks like a TOTP code", code)
+		}
+		if seen[code] {
+			t.Fatalf("duplicate recovery code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "abcde-fghij", want: "abcdefghij"},
+		{input: "ABCDE-FGHIJ", want: "abcdefghij"},
+		{input: "  abcde fghij\n", want: "abcdefghij"},
+		{input: "abcdefghij", want: "abcdefghij"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.input); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"runtime"
 	"time"
 
+	"social-network/auth-service/pkg/requestctx"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -202,15 +204,23 @@ func (l *CustomLogger) Fatal(msg string, fields ...Field) {
 }
 
 func (l *CustomLogger) With(fields ...Field) Logger {
+	// Копируем поля, чтобы производные логгеры не делили общий массив
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+
 	return &CustomLogger{
 		logger: l.logger,
-		fields: append(l.fields, fields...),
+		fields: merged,
 	}
 }
 
 func (l *CustomLogger) WithContext(ctx context.Context) Logger {
-	// Можно добавить извлечение данных из контекста (trace_id, user_id и т.д.)
-	return l
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
 }
 
 func (l *CustomLogger) log(level slog.Level, msg string, fields ...Field) {
@@ -253,7 +263,33 @@ func (z *ZapLogger) With(fields ...Field) Logger {
 }
 
 func (z *ZapLogger) WithContext(ctx context.Context) Logger {
-	return z
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return z
+	}
+	return z.With(fields...)
+}
+
+// contextFields извлекает из контекста значения, относящиеся к запросу
+func contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []Field
+	if requestID := requestctx.RequestID(ctx); requestID != "" {
+		fields = append(fields, String("request_id", requestID))
+	}
+	if userID := requestctx.UserID(ctx); userID != "" {
+		fields = append(fields, String("user_id", userID))
+	}
+	if impersonatorID := requestctx.ImpersonatorID(ctx); impersonatorID != "" {
+		fields = append(fields, String("impersonator_id", impersonatorID))
+	}
+	if clientID := requestctx.ClientID(ctx); clientID != "" {
+		fields = append(fields, String("client_id", clientID))
+	}
+	return fields
 }
 
 func (z *ZapLogger) convertFields(fields ...Field) []zap.Field {
@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"social-network/auth-service/pkg/logger"
+	"social-network/auth-service/pkg/requestctx"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,7 +32,8 @@ func LoggingMiddleware(zapLogger *logger.ZapLogger) gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
-		zapLogger.Info("HTTP Request",
+		reqLogger := zapLogger.WithContext(c.Request.Context())
+		reqLogger.Info("HTTP Request",
 			logger.String("method", method),
 			logger.String("path", path),
 			logger.Int("status", statusCode),
@@ -43,7 +46,7 @@ func LoggingMiddleware(zapLogger *logger.ZapLogger) gin.HandlerFunc {
 		// Логируем ошибки отдельно
 		if len(c.Errors) > 0 {
 			for _, err := range c.Errors {
-				zapLogger.Error("Request Error",
+				reqLogger.Error("Request Error",
 					logger.String("method", method),
 					logger.String("path", path),
 					logger.Error(err.Err),
@@ -54,10 +57,50 @@ func LoggingMiddleware(zapLogger *logger.ZapLogger) gin.HandlerFunc {
 	}
 }
 
+// RequestIDMiddleware берет идентификатор запроса из заголовка X-Request-ID или
+// генерирует новый, возвращает его в ответе и сохраняет в контексте запроса
+// вместе с языком клиента из Accept-Language
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestctx.HeaderRequestID)
+		if !requestctx.IsValidRequestID(requestID) {
+			requestID = requestctx.NewRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(requestctx.HeaderRequestID, requestID)
+		ctx := requestctx.WithRequestID(c.Request.Context(), requestID)
+		ctx = requestctx.WithClient(ctx, c.ClientIP(), c.Request.UserAgent())
+		if locale := requestctx.ParseAcceptLanguage(c.GetHeader("Accept-Language")); locale != "" {
+			ctx = requestctx.WithLocale(ctx, locale)
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// TimeoutMiddleware ограничивает время обработки запроса. Дедлайн передается
+// через контекст запроса до запросов в базу данных
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 // RecoveryMiddleware создает middleware для обработки паник
 func RecoveryMiddleware(zapLogger *logger.ZapLogger) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		zapLogger.Error("Panic recovered",
+		zapLogger.WithContext(c.Request.Context()).Error("Panic recovered",
 			logger.String("method", c.Request.Method),
 			logger.String("path", c.Request.URL.Path),
 			logger.String("client_ip", c.ClientIP()),
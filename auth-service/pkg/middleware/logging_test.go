@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"social-network/auth-service/pkg/requestctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{"client id is kept", "req-123", true},
+		{"missing id is generated", "", false},
+		{"id with spaces is replaced", "bad id", false},
+		{"too long id is replaced", strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+			router := gin.New()
+			router.Use(RequestIDMiddleware())
+			router.GET("/", func(c *gin.Context) {
+				fromContext = requestctx.RequestID(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestctx.HeaderRequestID, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			returned := rec.Header().Get(requestctx.HeaderRequestID)
+			if returned == "" || returned != fromContext {
+				t.Fatalf("response id %q must match context id %q", returned, fromContext)
+			}
+			if tt.wantSame && returned != tt.incoming {
+				t.Fatalf("request id = %q, want %q", returned, tt.incoming)
+			}
+			if !tt.wantSame && (returned == tt.incoming || !requestctx.IsValidRequestID(returned)) {
+				t.Fatalf("expected a freshly generated id, got %q", returned)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddleware_SetsDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var deadline time.Time
+	var hasDeadline bool
+	router := gin.New()
+	router.Use(TimeoutMiddleware(time.Second))
+	router.GET("/", func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+		c.Status(http.StatusNoContent)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !hasDeadline || time.Until(deadline) > time.Second {
+		t.Fatalf("expected request deadline within 1s, got %v (set: %v)", deadline, hasDeadline)
+	}
+}
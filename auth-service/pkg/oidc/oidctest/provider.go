@@ -0,0 +1,285 @@
+// Package oidctest запускает локальный провайдер OpenID Connect для тестов входа через
+// внешних провайдеров: discovery, token endpoint с PKCE, JWKS и userinfo. Страницу входа
+// провайдера заменяет Authorize, которая сразу выдает код для указанного пользователя.
+package oidctest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Регистрация клиента у провайдера
+const (
+	ClientID     = "auth-service"
+	ClientSecret = "provider-secret"
+	RedirectURL  = "https://auth.example/auth/social/mock/callback"
+
+	// KeyID - kid ключа, которым провайдер подписывает ID токены
+	KeyID = "mock-key"
+	// EncryptionKeyID - kid того же ключа с use=enc
+	EncryptionKeyID = "mock-enc-key"
+)
+
+// User - учетная запись пользователя у провайдера
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// grant - вход, подтвержденный провайдером и ожидающий обмена кода
+type grant struct {
+	user      User
+	challenge string
+	nonce     string
+}
+
+// Provider - запущенный провайдер. Поля настраиваются до первого запроса.
+type Provider struct {
+	URL string
+	// Issuer в discovery документе; по умолчанию совпадает с URL
+	Issuer string
+	// OmitEmail убирает email из ID токена, как делают провайдеры, отдающие его только через userinfo
+	OmitEmail bool
+
+	server *httptest.Server
+	key    *ecdsa.PrivateKey
+
+	mu           sync.Mutex
+	grants       map[string]grant
+	accessTokens map[string]User
+	jwksRequests int
+}
+
+// NewProvider запускает провайдер и останавливает его по завершении теста
+func NewProvider(t testing.TB) *Provider {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("oidctest: generate key: %v", err)
+	}
+
+	p := &Provider{
+		key:          key,
+		grants:       map[string]grant{},
+		accessTokens: map[string]User{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("POST /token", p.handleToken)
+	mux.HandleFunc("GET /jwks", p.handleJWKS)
+	mux.HandleFunc("GET /userinfo", p.handleUserInfo)
+
+	p.server = httptest.NewServer(mux)
+	p.URL = p.server.URL
+	p.Issuer = p.server.URL
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// Client возвращает HTTP клиент для запросов к провайдеру
+func (p *Provider) Client() *http.Client {
+	return p.server.Client()
+}
+
+// Key возвращает ключ подписи ID токенов
+func (p *Provider) Key() *ecdsa.PrivateKey {
+	return p.key
+}
+
+// JWKSRequests возвращает число запросов JWKS
+func (p *Provider) JWKSRequests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.jwksRequests
+}
+
+// Authorize имитирует вход user на странице провайдера и возвращает код авторизации
+func (p *Provider) Authorize(t testing.TB, authURL string, user User) string {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("oidctest: parse auth url: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("client_id") != ClientID || query.Get("redirect_uri") != RedirectURL ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("oidctest: unexpected authorization request %s", authURL)
+	}
+
+	code := randomToken()
+	p.mu.Lock()
+	p.grants[code] = grant{user: user, challenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+	p.mu.Unlock()
+	return code
+}
+
+// Claims возвращает claims ID токена, который провайдер выдал бы user для nonce
+func (p *Provider) Claims(user User, nonce string) jwt.MapClaims {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.URL,
+		"sub":   user.Subject,
+		"aud":   ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": nonce,
+	}
+	if user.Name != "" {
+		claims["name"] = user.Name
+	}
+	if user.PreferredUsername != "" {
+		claims["preferred_username"] = user.PreferredUsername
+	}
+	if user.Email != "" && !p.OmitEmail {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+	return claims
+}
+
+// IDToken подписывает claims ключом провайдера
+func (p *Provider) IDToken(claims jwt.MapClaims) string {
+	return SignIDToken(p.key, KeyID, claims)
+}
+
+// SignIDToken подписывает claims произвольным ключом ES256 с указанным kid
+func SignIDToken(key *ecdsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+func (p *Provider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 p.Issuer,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"userinfo_endpoint":      p.URL + "/userinfo",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+// handleToken обменивает код на токены. Код одноразовый и требует code_verifier.
+func (p *Provider) handleToken(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != ClientID || secret != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("redirect_uri") != RedirectURL {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	code := r.PostFormValue("code")
+	p.mu.Lock()
+	g, found := p.grants[code]
+	delete(p.grants, code)
+	p.mu.Unlock()
+
+	digest := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !found || base64.RawURLEncoding.EncodeToString(digest[:]) != g.challenge {
+		writeError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	accessToken := randomToken()
+	p.mu.Lock()
+	p.accessTokens[accessToken] = g.user
+	p.mu.Unlock()
+
+	writeJSON(w, map[string]string{
+		"access_token": accessToken,
+		"id_token":     p.IDToken(p.Claims(g.user, g.nonce)),
+		"token_type":   "Bearer",
+	})
+}
+
+func (p *Provider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.jwksRequests++
+	p.mu.Unlock()
+
+	point, err := p.key.PublicKey.ECDH()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	raw := point.Bytes()
+
+	x := base64.RawURLEncoding.EncodeToString(raw[1:33])
+	y := base64.RawURLEncoding.EncodeToString(raw[33:])
+
+	writeJSON(w, map[string]any{"keys": []map[string]string{
+		{"kty": "EC", "kid": KeyID, "use": "sig", "crv": "P-256", "x": x, "y": y},
+		// Тот же ключ, опубликованный для шифрования: подписи с этим kid клиент принимать не должен
+		{"kty": "EC", "kid": EncryptionKeyID, "use": "enc", "crv": "P-256", "x": x, "y": y},
+	}})
+}
+
+// handleUserInfo отдает claims пользователя; email_verified передается строкой, как у части провайдеров
+func (p *Provider) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	accessToken, bearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	p.mu.Lock()
+	user, found := p.accessTokens[accessToken]
+	p.mu.Unlock()
+
+	if !bearer || !found {
+		writeError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	emailVerified := "false"
+	if user.EmailVerified {
+		emailVerified = "true"
+	}
+	writeJSON(w, map[string]string{
+		"sub":                user.Subject,
+		"email":              user.Email,
+		"email_verified":     emailVerified,
+		"name":               user.Name,
+		"preferred_username": user.PreferredUsername,
+	})
+}
+
+func randomToken() string {
+	buf := make([]byte, 24)
+	rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
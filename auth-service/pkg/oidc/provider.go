@@ -0,0 +1,257 @@
+// Package oidc реализует клиентскую часть OpenID Connect (relying party) для входа через
+// внешних провайдеров: discovery, authorization code с PKCE и проверку ID токена по JWKS
+// провайдера. Поддерживаются только провайдеры с discovery документом; OAuth 2.0 без
+// OpenID Connect (например, GitHub) требует отдельного адаптера.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrProviderNotFound is returned when no provider with the given name is configured
+	ErrProviderNotFound = errors.New("oidc provider not found")
+
+	// ErrDiscoveryFailed is returned when the provider discovery document cannot be loaded
+	ErrDiscoveryFailed = errors.New("oidc discovery failed")
+
+	// ErrExchangeFailed is returned when the token endpoint rejects the authorization code
+	ErrExchangeFailed = errors.New("oidc code exchange failed")
+
+	// ErrInvalidIDToken is returned when the ID token signature, issuer, audience, expiry or nonce is invalid
+	ErrInvalidIDToken = errors.New("invalid oidc id token")
+)
+
+// maxResponseSize ограничивает размер ответов провайдера
+const maxResponseSize = 1 << 20
+
+// DefaultScopes - scope, которые запрашиваются, если в конфигурации провайдера они не заданы
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// Config - параметры подключения к провайдеру
+type Config struct {
+	// Name - ключ провайдера в URL и в привязках аккаунтов, например google
+	Name string
+	// DisplayName - название для кнопки входа
+	DisplayName string
+	// Issuer - адрес провайдера; discovery документ берется из {Issuer}/.well-known/openid-configuration
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL - адрес возврата, зарегистрированный у провайдера
+	RedirectURL string
+	Scopes      []string
+}
+
+// Tokens - ответ token endpoint провайдера
+type Tokens struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// metadata - используемая часть discovery документа
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider - подключение к одному провайдеру. Discovery документ и ключи загружаются
+// при первом обращении и кешируются.
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     *keyCache
+}
+
+func NewProvider(config Config, httpClient *http.Client) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultScopes
+	}
+	if config.DisplayName == "" {
+		config.DisplayName = config.Name
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+		keys:       newKeyCache(),
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+func (p *Provider) DisplayName() string {
+	return p.config.DisplayName
+}
+
+// AuthCodeURL возвращает адрес страницы входа провайдера для authorization code с PKCE
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", strings.Join(p.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return meta.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange обменивает код авторизации на токены провайдера
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Tokens, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	var tokens Tokens
+	if err := doJSON(p.httpClient, req, &tokens); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("%w: response has no id_token", ErrExchangeFailed)
+	}
+
+	return &tokens, nil
+}
+
+// UserInfo запрашивает claims пользователя у userinfo endpoint провайдера. Используется,
+// когда ID токен не содержит email.
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if meta.UserInfoEndpoint == "" {
+		return nil, fmt.Errorf("%w: provider has no userinfo endpoint", ErrDiscoveryFailed)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.UserInfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims Claims
+	if err := doJSON(p.httpClient, req, &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// discover загружает discovery документ. Неудачная загрузка не кешируется.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta metadata
+	if err := doJSON(p.httpClient, req, &meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscoveryFailed, err)
+	}
+
+	// Discovery документ должен принадлежать тому же issuer (OpenID Connect Discovery, раздел 4.3)
+	if meta.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match %q", ErrDiscoveryFailed, meta.Issuer, p.config.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: required endpoints are missing", ErrDiscoveryFailed)
+	}
+
+	p.metadata = &meta
+	return p.metadata, nil
+}
+
+// doJSON выполняет запрос к провайдеру и разбирает JSON ответ
+func doJSON(httpClient *http.Client, req *http.Request, target any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: unexpected status %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// NewCodeVerifier создает случайный code_verifier для PKCE (RFC 7636)
+func NewCodeVerifier() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallengeS256 вычисляет code_challenge = BASE64URL(SHA256(verifier))
+func CodeChallengeS256(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
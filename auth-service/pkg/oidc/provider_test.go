@@ -0,0 +1,359 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"social-network/auth-service/pkg/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testUser = oidctest.User{
+	Subject:       "provider-user-1",
+	Email:         "alice@provider.example",
+	EmailVerified: true,
+	Name:          "Alice",
+}
+
+func newTestProvider(mock *oidctest.Provider, clientSecret string) *Provider {
+	return NewProvider(Config{
+		Name:         "mock",
+		Issuer:       mock.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  oidctest.RedirectURL,
+	}, mock.Client())
+}
+
+func TestProvider_LoginFlow(t *testing.T) {
+	ctx := context.Background()
+	mock := oidctest.NewProvider(t)
+	provider := newTestProvider(mock, oidctest.ClientSecret)
+
+	verifier, err := NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("code verifier: %v", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", CodeChallengeS256(verifier))
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	code := mock.Authorize(t, authURL, testUser)
+
+	tokens, err := provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, tokens.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("verify id token: %v", err)
+	}
+	if claims.Subject != testUser.Subject || claims.Email != testUser.Email || !bool(claims.EmailVerified) {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	userInfo, err := provider.UserInfo(ctx, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("userinfo: %v", err)
+	}
+	// email_verified строкой тоже принимается
+	if userInfo.Subject != testUser.Subject || !bool(userInfo.EmailVerified) {
+		t.Fatalf("unexpected userinfo %+v", userInfo)
+	}
+
+	// Код одноразовый
+	if _, err := provider.Exchange(ctx, code, verifier); !errors.Is(err, ErrExchangeFailed) {
+		t.Fatalf("expected ErrExchangeFailed on code reuse, got %v", err)
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	mock := oidctest.NewProvider(t)
+
+	authURL, err := newTestProvider(mock, oidctest.ClientSecret).AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             oidctest.ClientID,
+		"redirect_uri":          oidctest.RedirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        "challenge-1",
+		"code_challenge_method": "S256",
+	}
+	for name, value := range want {
+		if got := parsed.Query().Get(name); got != value {
+			t.Errorf("%s: expected %q, got %q", name, value, got)
+		}
+	}
+	if parsed.Path != "/authorize" {
+		t.Errorf("unexpected authorization endpoint %s", authURL)
+	}
+}
+
+func TestProvider_ExchangeRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		secret   string
+	}{
+		{name: "wrong code verifier", verifier: "another-verifier", secret: oidctest.ClientSecret},
+		{name: "wrong client secret", verifier: "verifier", secret: "wrong"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mock := oidctest.NewProvider(t)
+			provider := newTestProvider(mock, tt.secret)
+
+			authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", CodeChallengeS256("verifier"))
+			if err != nil {
+				t.Fatalf("auth code url: %v", err)
+			}
+			code := mock.Authorize(t, authURL, testUser)
+
+			if _, err := provider.Exchange(ctx, code, tt.verifier); !errors.Is(err, ErrExchangeFailed) {
+				t.Fatalf("expected ErrExchangeFailed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	mock := oidctest.NewProvider(t)
+	mock.Issuer = "https://evil.example"
+
+	if _, err := newTestProvider(mock, oidctest.ClientSecret).AuthCodeURL(context.Background(), "state", "nonce", "challenge"); !errors.Is(err, ErrDiscoveryFailed) {
+		t.Fatalf("expected ErrDiscoveryFailed, got %v", err)
+	}
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		// token собирает ID токен из claims, выданных провайдером для nonce-1
+		token   func(mock *oidctest.Provider, claims jwt.MapClaims) string
+		wantErr bool
+	}{
+		{
+			name:  "valid token",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string { return mock.IDToken(claims) },
+		},
+		{
+			name: "several audiences with matching azp",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["aud"] = []string{oidctest.ClientID, "another-client"}
+				claims["azp"] = oidctest.ClientID
+				return mock.IDToken(claims)
+			},
+		},
+		{
+			name: "other issuer",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["iss"] = "https://evil.example"
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "issued to another client",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["aud"] = "another-client"
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "several audiences without azp",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["aud"] = []string{oidctest.ClientID, "another-client"}
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired beyond clock skew",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["exp"] = time.Now().Add(-2 * clockSkew).Unix()
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "without expiry",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				delete(claims, "exp")
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "nonce of another login",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["nonce"] = "nonce-2"
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty subject",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				claims["sub"] = ""
+				return mock.IDToken(claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed by a key not in jwks",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				return oidctest.SignIDToken(otherKey, oidctest.KeyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown key id",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				return oidctest.SignIDToken(mock.Key(), "rotated-key", claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "key published for encryption",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				return oidctest.SignIDToken(mock.Key(), oidctest.EncryptionKeyID, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "hmac signed with client secret",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				token.Header["kid"] = oidctest.KeyID
+				signed, _ := token.SignedString([]byte(oidctest.ClientSecret))
+				return signed
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsigned token",
+			token: func(mock *oidctest.Provider, claims jwt.MapClaims) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+				signed, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+				return signed
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := oidctest.NewProvider(t)
+
+			rawIDToken := tt.token(mock, mock.Claims(testUser, "nonce-1"))
+			claims, err := newTestProvider(mock, oidctest.ClientSecret).VerifyIDToken(context.Background(), rawIDToken, "nonce-1")
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if claims.Subject != testUser.Subject {
+					t.Fatalf("unexpected subject %q", claims.Subject)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Fatalf("expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProvider_JWKSRefetchIsThrottled(t *testing.T) {
+	ctx := context.Background()
+	mock := oidctest.NewProvider(t)
+	provider := newTestProvider(mock, oidctest.ClientSecret)
+
+	if _, err := provider.VerifyIDToken(ctx, mock.IDToken(mock.Claims(testUser, "nonce")), "nonce"); err != nil {
+		t.Fatalf("verify id token: %v", err)
+	}
+
+	// Токены с неизвестным kid не должны заставлять перечитывать JWKS на каждый запрос
+	for i := 0; i < 3; i++ {
+		token := oidctest.SignIDToken(mock.Key(), "rotated-key", mock.Claims(testUser, "nonce"))
+		if _, err := provider.VerifyIDToken(ctx, token, "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+			t.Fatalf("expected ErrInvalidIDToken, got %v", err)
+		}
+	}
+
+	if got := mock.JWKSRequests(); got != 1 {
+		t.Fatalf("expected one jwks request, got %d", got)
+	}
+}
+
+func TestFlexBool(t *testing.T) {
+	tests := []struct {
+		json string
+		want bool
+	}{
+		{json: `true`, want: true},
+		{json: `false`, want: false},
+		{json: `"true"`, want: true},
+		{json: `"false"`, want: false},
+		{json: `"yes"`, want: false},
+		{json: `1`, want: false},
+		{json: `null`, want: false},
+	}
+
+	for _, tt := range tests {
+		var value FlexBool
+		if err := json.Unmarshal([]byte(tt.json), &value); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.json, err)
+		}
+		if bool(value) != tt.want {
+			t.Errorf("FlexBool(%s) = %v, want %v", tt.json, value, tt.want)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	google := NewProvider(Config{Name: "google"}, nil)
+	gitlab := NewProvider(Config{Name: "gitlab", DisplayName: "GitLab"}, nil)
+	registry := NewRegistry(google, gitlab)
+
+	if provider, err := registry.Get("gitlab"); err != nil || provider != gitlab {
+		t.Fatalf("expected gitlab provider, got %v, %v", provider, err)
+	}
+	if _, err := registry.Get("github"); !errors.Is(err, ErrProviderNotFound) {
+		t.Fatalf("expected ErrProviderNotFound, got %v", err)
+	}
+	if providers := registry.Providers(); len(providers) != 2 || providers[0] != google {
+		t.Fatalf("providers must keep configuration order")
+	}
+	if google.DisplayName() != "google" || gitlab.DisplayName() != "GitLab" {
+		t.Fatalf("unexpected display names")
+	}
+}
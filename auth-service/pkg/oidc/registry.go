@@ -0,0 +1,30 @@
+package oidc
+
+// Registry - набор настроенных провайдеров в порядке конфигурации
+type Registry struct {
+	providers map[string]*Provider
+	order     []*Provider
+}
+
+func NewRegistry(providers ...*Provider) *Registry {
+	registry := &Registry{providers: make(map[string]*Provider, len(providers))}
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+		registry.order = append(registry.order, provider)
+	}
+	return registry
+}
+
+// Get возвращает провайдера по имени
+func (r *Registry) Get(name string) (*Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return provider, nil
+}
+
+// Providers возвращает провайдеров в порядке конфигурации
+func (r *Registry) Providers() []*Provider {
+	return r.order
+}
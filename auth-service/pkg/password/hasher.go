@@ -0,0 +1,72 @@
+// Package password хеширует пароли и проверяет хеши, созданные текущим или
+// устаревшими алгоритмами. Хеши хранятся в PHC формате
+// ($<алгоритм>$<версия>$<параметры>$<соль>$<хеш>), поэтому по строке хеша видно,
+// чем и с какими параметрами он создан.
+package password
+
+import "errors"
+
+var (
+	// ErrMismatch is returned when the password does not match the hash
+	ErrMismatch = errors.New("password does not match")
+
+	// ErrUnsupportedHash is returned when no configured algorithm recognizes the hash
+	ErrUnsupportedHash = errors.New("unsupported password hash")
+
+	// ErrMalformedHash is returned when the hash cannot be decoded
+	ErrMalformedHash = errors.New("malformed password hash")
+)
+
+// Algorithm - один алгоритм хеширования паролей
+type Algorithm interface {
+	// Hash создает хеш с текущими параметрами алгоритма
+	Hash(password string) (string, error)
+
+	// Verify проверяет пароль. Возвращает ErrMismatch, если пароль не подходит.
+	Verify(encodedHash, password string) error
+
+	// Recognizes сообщает, создан ли хеш этим алгоритмом
+	Recognizes(encodedHash string) bool
+
+	// Outdated сообщает, что хеш создан с параметрами, отличными от текущих
+	Outdated(encodedHash string) bool
+}
+
+// Hasher хеширует пароли текущим алгоритмом и проверяет хеши всех поддерживаемых
+type Hasher struct {
+	current    Algorithm
+	algorithms []Algorithm
+}
+
+// NewHasher создает Hasher. Новые хеши создаются алгоритмом current, а legacy
+// нужны только для проверки паролей, захешированных раньше.
+func NewHasher(current Algorithm, legacy ...Algorithm) *Hasher {
+	return &Hasher{
+		current:    current,
+		algorithms: append([]Algorithm{current}, legacy...),
+	}
+}
+
+// Hash хеширует пароль текущим алгоритмом
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify проверяет пароль алгоритмом, которым создан хеш
+func (h *Hasher) Verify(encodedHash, password string) error {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Recognizes(encodedHash) {
+			return algorithm.Verify(encodedHash, password)
+		}
+	}
+	return ErrUnsupportedHash
+}
+
+// NeedsRehash сообщает, что хеш создан другим алгоритмом или с устаревшими параметрами.
+// Вызывается только после успешной проверки пароля, пока он есть в открытом виде.
+func (h *Hasher) NeedsRehash(encodedHash string) bool {
+	if !h.current.Recognizes(encodedHash) {
+		return true
+	}
+	return h.current.Outdated(encodedHash)
+}
@@ -0,0 +1,202 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams - минимальные параметры, чтобы тесты не тратили время и память
+var testArgon2idParams = Argon2idParams{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func newTestHasher() *Hasher {
+	return NewHasher(NewArgon2id(testArgon2idParams), NewBcrypt(bcrypt.MinCost))
+}
+
+func TestArgon2id_HashFormat(t *testing.T) {
+	hash, err := NewArgon2id(testArgon2idParams).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != "v=19" || parts[3] != "m=64,t=1,p=1" {
+		t.Fatalf("unexpected PHC string %q", hash)
+	}
+
+	decoded, err := decodeArgon2id(hash)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.params != testArgon2idParams {
+		t.Fatalf("expected params %+v, got %+v", testArgon2idParams, decoded.params)
+	}
+}
+
+func TestArgon2id_VerifyUsesParamsFromHash(t *testing.T) {
+	older := testArgon2idParams
+	older.Iterations = 2
+	older.KeyLength = 24
+
+	hash, err := NewArgon2id(older).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	// Хеш со старыми параметрами должен проверяться, пока пароль не перехеширован
+	argon := NewArgon2id(testArgon2idParams)
+	if err := argon.Verify(hash, "correct horse"); err != nil {
+		t.Fatalf("hash with previous parameters must verify: %v", err)
+	}
+	if err := argon.Verify(hash, "battery staple"); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("expected ErrMismatch, got %v", err)
+	}
+}
+
+func TestDecodeArgon2id_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "empty", hash: ""},
+		{name: "other algorithm", hash: "$argon2i$v=19$m=64,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ"},
+		{name: "missing key", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ"},
+		{name: "bad version", hash: "$argon2id$v=x$m=64,t=1,p=1$c29tZXNhbHQ$c29tZWtleQ"},
+		{name: "bad params", hash: "$argon2id$v=19$m=64,t=1$c29tZXNhbHQ$c29tZWtleQ"},
+		{name: "bad salt", hash: "$argon2id$v=19$m=64,t=1,p=1$!!!$c29tZWtleQ"},
+		{name: "empty key", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ$"},
+		{name: "padded base64", hash: "$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ=$c29tZWtleQ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeArgon2id(tt.hash); !errors.Is(err, ErrMalformedHash) {
+				t.Fatalf("expected ErrMalformedHash, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHasher_Verify(t *testing.T) {
+	hasher := newTestHasher()
+
+	argonHash, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	bcryptHash, err := NewBcrypt(bcrypt.MinCost).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("bcrypt hash: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		wantErr  error
+	}{
+		{name: "argon2id match", hash: argonHash, password: "correct horse"},
+		{name: "argon2id mismatch", hash: argonHash, password: "battery staple", wantErr: ErrMismatch},
+		{name: "legacy bcrypt match", hash: bcryptHash, password: "correct horse"},
+		{name: "legacy bcrypt mismatch", hash: bcryptHash, password: "battery staple", wantErr: ErrMismatch},
+		{name: "2y bcrypt prefix", hash: "$2y$" + strings.TrimPrefix(bcryptHash, "$2a$"), password: "correct horse"},
+		{name: "truncated bcrypt", hash: bcryptHash[:20], password: "correct horse", wantErr: ErrMalformedHash},
+		{name: "truncated argon2id", hash: argonHash[:30], password: "correct horse", wantErr: ErrMalformedHash},
+		{name: "unknown algorithm", hash: "$scrypt$ln=15,r=8,p=1$c29tZXNhbHQ$c29tZWtleQ", password: "correct horse", wantErr: ErrUnsupportedHash},
+		{name: "plain text", hash: "correct horse", password: "correct horse", wantErr: ErrUnsupportedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := hasher.Verify(tt.hash, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	hasher := newTestHasher()
+
+	current, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	weakerArgon, err := NewArgon2id(testArgon2idParams).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	strongerHasher := NewHasher(NewArgon2id(stronger))
+
+	longerKey := testArgon2idParams
+	longerKey.KeyLength = 64
+	longerKeyHash, err := NewArgon2id(longerKey).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	bcryptHash, err := NewBcrypt(bcrypt.MinCost).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("bcrypt hash: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		hasher *Hasher
+		hash   string
+		want   bool
+	}{
+		{name: "current parameters", hasher: hasher, hash: current, want: false},
+		{name: "fewer iterations than configured", hasher: strongerHasher, hash: weakerArgon, want: true},
+		{name: "different key length", hasher: hasher, hash: longerKeyHash, want: true},
+		{name: "older argon2 version", hasher: hasher, hash: strings.Replace(current, "v=19", "v=16", 1), want: true},
+		{name: "legacy bcrypt", hasher: hasher, hash: bcryptHash, want: true},
+		{name: "malformed argon2id", hasher: hasher, hash: "$argon2id$v=19$garbage", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Fatalf("expected NeedsRehash=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBcrypt_Outdated(t *testing.T) {
+	hash, err := NewBcrypt(bcrypt.MinCost).Hash("correct horse")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cost int
+		want bool
+	}{
+		{name: "same cost", cost: bcrypt.MinCost, want: false},
+		{name: "higher configured cost", cost: bcrypt.MinCost + 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBcrypt(tt.cost).Outdated(hash); got != tt.want {
+				t.Fatalf("expected Outdated=%v, got %v", tt.want, got)
+			}
+		})
+	}
+	if !NewBcrypt(bcrypt.MinCost).Outdated("$2a$garbage") {
+		t.Fatal("malformed bcrypt hash must be reported as outdated")
+	}
+}
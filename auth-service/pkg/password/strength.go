@@ -0,0 +1,554 @@
+package password
+
+import (
+	_ "embed"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Оценка стойкости повторяет подход zxcvbn: пароль разбивается на известные шаблоны
+// (словарные слова, последовательности, ряды клавиатуры, повторы, даты), для каждого
+// шаблона оценивается число попыток перебора, и выбирается разбиение, которое
+// атакующему перебрать проще всего.
+
+// maxEstimatedLength ограничивает длину анализируемой части пароля. Хвост длиннее
+// только увеличивает стойкость, поэтому оценка остается заниженной, а не завышенной.
+const maxEstimatedLength = 100
+
+// Минимальное число попыток для части пароля (как в zxcvbn)
+const (
+	minSubmatchGuessesSingleChar = 10
+	minSubmatchGuessesMultiChar  = 50
+	minGuessesBeforeGrowing      = 10000
+)
+
+// Оценки стойкости от 0 (угадывается мгновенно) до 4 (стойкий)
+const (
+	ScoreTooGuessable = iota
+	ScoreVeryGuessable
+	ScoreSomewhatGuessable
+	ScoreSafelyUnguessable
+	ScoreVeryUnguessable
+)
+
+// Strength - результат оценки стойкости пароля
+type Strength struct {
+	Score        int
+	GuessesLog10 float64
+	Warning      string
+}
+
+//go:embed common_passwords.txt
+var commonPasswordsData string
+
+var commonPasswords = loadRankedDictionary(strings.Fields(commonPasswordsData))
+
+var keyboardRows = []string{
+	"qwertyuiop", "asdfghjkl", "zxcvbnm", "1234567890",
+	"йцукенгшщзхъ", "фывапролджэ", "ячсмитьбю",
+}
+
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'}, '6': {'g'}, '9': {'g'}, '1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'0': {'o'}, '$': {'s'}, '5': {'s'}, '+': {'t'}, '7': {'t'}, '%': {'x'}, '2': {'z'},
+}
+
+var (
+	yearRegex = regexp.MustCompile(`(?:19|20)\d{2}`)
+	dateRegex = regexp.MustCompile(`(\d{1,2})([./-]?)(\d{1,2})([./-]?)((?:19|20)\d{2})`)
+)
+
+type patternMatch struct {
+	i, j         int
+	guessesLog10 float64
+	pattern      string
+	warning      string
+}
+
+// EstimateStrength оценивает, сколько попыток нужно, чтобы угадать пароль.
+// userInputs - данные пользователя (email, имя), которые не должны помогать угадыванию.
+func EstimateStrength(password string, userInputs ...string) Strength {
+	runes := []rune(password)
+	if len(runes) > maxEstimatedLength {
+		runes = runes[:maxEstimatedLength]
+	}
+	if len(runes) == 0 {
+		return Strength{Score: ScoreTooGuessable, Warning: "Password is empty"}
+	}
+
+	matches := findPatterns(runes, userDictionary(userInputs))
+	guessesLog10, sequence := minimumGuesses(runes, matches)
+
+	return Strength{
+		Score:        scoreFromGuesses(guessesLog10),
+		GuessesLog10: guessesLog10,
+		Warning:      warningFor(sequence),
+	}
+}
+
+func scoreFromGuesses(guessesLog10 float64) int {
+	switch {
+	case guessesLog10 < 3:
+		return ScoreTooGuessable
+	case guessesLog10 < 6:
+		return ScoreVeryGuessable
+	case guessesLog10 < 8:
+		return ScoreSomewhatGuessable
+	case guessesLog10 < 10:
+		return ScoreSafelyUnguessable
+	default:
+		return ScoreVeryUnguessable
+	}
+}
+
+func findPatterns(runes []rune, userDict map[string]int) []patternMatch {
+	lower := []rune(strings.ToLower(string(runes)))
+
+	var matches []patternMatch
+	matches = append(matches, dictionaryMatches(runes, lower, userDict)...)
+	matches = append(matches, sequenceMatches(lower)...)
+	matches = append(matches, spatialMatches(lower)...)
+	matches = append(matches, repeatMatches(lower, userDict)...)
+	matches = append(matches, dateMatches(lower)...)
+
+	// Совпадение с частью пароля не может стоить меньше минимального перебора
+	for k := range matches {
+		length := matches[k].j - matches[k].i + 1
+		if length == len(runes) {
+			continue
+		}
+		minimum := math.Log10(minSubmatchGuessesMultiChar)
+		if length == 1 {
+			minimum = math.Log10(minSubmatchGuessesSingleChar)
+		}
+		matches[k].guessesLog10 = math.Max(matches[k].guessesLog10, minimum)
+	}
+
+	return matches
+}
+
+// dictionaryMatches ищет слова из словарей как есть, в обратном порядке и с l33t заменами
+func dictionaryMatches(runes, lower []rune, userDict map[string]int) []patternMatch {
+	var matches []patternMatch
+
+	lookup := func(word string) (int, string, string) {
+		if rank, ok := userDict[word]; ok {
+			return rank, "user_inputs", "Avoid using your name, username or email in the password"
+		}
+		if rank, ok := commonPasswords[word]; ok {
+			return rank, "dictionary", commonPasswordWarning(rank)
+		}
+		return 0, "", ""
+	}
+
+	variants := l33tVariants(lower)
+
+	for i := range lower {
+		for j := i + 2; j < len(lower); j++ {
+			original := runes[i : j+1]
+			upper := uppercaseVariationsLog10(original)
+
+			if rank, pattern, warning := lookup(string(lower[i : j+1])); rank > 0 {
+				matches = append(matches, patternMatch{i, j, math.Log10(float64(rank)) + upper, pattern, warning})
+			}
+
+			if rank, pattern, _ := lookup(reverseString(lower[i : j+1])); rank > 0 {
+				matches = append(matches, patternMatch{i, j, math.Log10(float64(rank)) + upper + math.Log10(2), pattern,
+					"Reversed words aren't much harder to guess"})
+			}
+
+			for _, variant := range variants {
+				substitutions := countDifferences(lower[i:j+1], variant[i:j+1])
+				if substitutions == 0 {
+					continue
+				}
+				if rank, pattern, _ := lookup(string(variant[i : j+1])); rank > 0 {
+					matches = append(matches, patternMatch{i, j,
+						math.Log10(float64(rank)) + upper + float64(substitutions)*math.Log10(2), pattern,
+						"Predictable substitutions like '@' instead of 'a' don't help very much"})
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// sequenceMatches ищет последовательности вида abc, 6543, aceg
+func sequenceMatches(lower []rune) []patternMatch {
+	var matches []patternMatch
+
+	i := 0
+	for i < len(lower)-2 {
+		delta := lower[i+1] - lower[i]
+		if delta == 0 || delta > 2 || delta < -2 {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j+1 < len(lower) && lower[j+1]-lower[j] == delta {
+			j++
+		}
+
+		if j-i+1 >= 3 {
+			base := 26.0
+			switch {
+			case strings.ContainsRune("az19", lower[i]):
+				base = 4
+			case unicode.IsDigit(lower[i]):
+				base = 10
+			}
+			guesses := base * float64(j-i+1)
+			if delta < 0 {
+				guesses *= 2
+			}
+			matches = append(matches, patternMatch{i, j, math.Log10(guesses), "sequence",
+				"Sequences like abc or 6543 are easy to guess"})
+			i = j
+			continue
+		}
+		i++
+	}
+
+	return matches
+}
+
+// spatialMatches ищет отрезки рядов клавиатуры в прямом и обратном порядке
+func spatialMatches(lower []rune) []patternMatch {
+	var matches []patternMatch
+
+	for i := range lower {
+		longest := 0
+		for j := i + 2; j < len(lower); j++ {
+			token := string(lower[i : j+1])
+			if !onKeyboardRow(token) {
+				break
+			}
+			longest = j
+		}
+		if longest > 0 {
+			length := float64(longest - i + 1)
+			matches = append(matches, patternMatch{i, longest, math.Log10(47 * 4 * (length - 1)), "spatial",
+				"Straight rows of keys are easy to guess"})
+		}
+	}
+
+	return matches
+}
+
+// repeatMatches ищет повторы блока: aaa, abcabc. Для каждого повтора берется
+// самый короткий блок, чтобы оценка блока не повторялась для всех его кратных.
+func repeatMatches(lower []rune, userDict map[string]int) []patternMatch {
+	var matches []patternMatch
+
+	i := 0
+	for i < len(lower) {
+		found := false
+		for size := 1; i+2*size <= len(lower); size++ {
+			block := lower[i : i+size]
+			count := 1
+			for next := i + size; next+size <= len(lower) && string(lower[next:next+size]) == string(block); next += size {
+				count++
+			}
+			if count < 2 || count*size < 3 {
+				continue
+			}
+
+			blockGuesses, _ := minimumGuesses(block, findPatterns(block, userDict))
+			warning := `Repeats like "abcabc" are only slightly harder to guess than "abc"`
+			if size == 1 {
+				warning = `Repeats like "aaa" are easy to guess`
+			}
+			matches = append(matches, patternMatch{i, i + count*size - 1, blockGuesses + math.Log10(float64(count)), "repeat", warning})
+
+			i += count * size
+			found = true
+			break
+		}
+		if !found {
+			i++
+		}
+	}
+
+	return matches
+}
+
+// dateMatches ищет годы и даты вида 01.02.1990 или 01021990
+func dateMatches(lower []rune) []patternMatch {
+	var matches []patternMatch
+
+	text := string(lower)
+	referenceYear := time.Now().Year()
+
+	for _, loc := range yearRegex.FindAllStringIndex(text, -1) {
+		year, _ := strconv.Atoi(text[loc[0]:loc[1]])
+		i, j := runeRange(text, loc)
+		matches = append(matches, patternMatch{i, j, math.Log10(yearSpace(year, referenceYear)), "year",
+			"Recent years are easy to guess"})
+	}
+
+	for _, loc := range dateRegex.FindAllStringSubmatchIndex(text, -1) {
+		first, _ := strconv.Atoi(text[loc[2]:loc[3]])
+		second, _ := strconv.Atoi(text[loc[6]:loc[7]])
+		year, _ := strconv.Atoi(text[loc[10]:loc[11]])
+
+		dayMonth := first >= 1 && first <= 31 && second >= 1 && second <= 12
+		monthDay := first >= 1 && first <= 12 && second >= 1 && second <= 31
+		if !dayMonth && !monthDay {
+			continue
+		}
+
+		guesses := 365 * yearSpace(year, referenceYear)
+		if loc[4] != loc[5] {
+			guesses *= 4
+		}
+		i, j := runeRange(text, loc[:2])
+		matches = append(matches, patternMatch{i, j, math.Log10(guesses), "date",
+			"Dates are often easy to guess"})
+	}
+
+	return matches
+}
+
+// minimumGuesses выбирает разбиение пароля на шаблоны с наименьшим числом попыток.
+// Непокрытые шаблонами участки считаются перебором по 10 вариантов на символ.
+func minimumGuesses(runes []rune, matches []patternMatch) (float64, []patternMatch) {
+	n := len(runes)
+	inf := math.Inf(1)
+
+	byEnd := make([][]patternMatch, n)
+	for _, m := range matches {
+		byEnd[m.j] = append(byEnd[m.j], m)
+	}
+
+	// best[k][l] - наименьший log10 произведения попыток для префикса [0..k] из l шаблонов
+	best := make([][]float64, n)
+	prev := make([][]patternMatch, n)
+	for k := range best {
+		best[k] = make([]float64, n+2)
+		prev[k] = make([]patternMatch, n+2)
+		for l := range best[k] {
+			best[k][l] = inf
+		}
+	}
+
+	consider := func(m patternMatch) {
+		if m.i == 0 {
+			if m.guessesLog10 < best[m.j][1] {
+				best[m.j][1] = m.guessesLog10
+				prev[m.j][1] = m
+			}
+			return
+		}
+		for l, value := range best[m.i-1] {
+			if math.IsInf(value, 1) || l+1 >= len(best[m.j]) {
+				continue
+			}
+			if candidate := value + m.guessesLog10; candidate < best[m.j][l+1] {
+				best[m.j][l+1] = candidate
+				prev[m.j][l+1] = m
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			consider(m)
+		}
+		for i := 0; i <= k; i++ {
+			consider(patternMatch{i: i, j: k, guessesLog10: float64(k - i + 1), pattern: "bruteforce"})
+		}
+	}
+
+	total, bestLength := inf, 0
+	for l, value := range best[n-1] {
+		if math.IsInf(value, 1) {
+			continue
+		}
+		// Атакующему нужно перебрать и порядок шаблонов, и их количество
+		lf, _ := math.Lgamma(float64(l) + 1)
+		guesses := logAdd(value+lf/math.Ln10, math.Log10(minGuessesBeforeGrowing)*float64(l-1))
+		if guesses < total {
+			total, bestLength = guesses, l
+		}
+	}
+
+	sequence := make([]patternMatch, 0, bestLength)
+	for k, l := n-1, bestLength; k >= 0 && l > 0; l-- {
+		m := prev[k][l]
+		sequence = append(sequence, m)
+		k = m.i - 1
+	}
+
+	return total, sequence
+}
+
+func warningFor(sequence []patternMatch) string {
+	var longest *patternMatch
+	for k := range sequence {
+		m := &sequence[k]
+		if m.pattern == "bruteforce" {
+			continue
+		}
+		if longest == nil || m.j-m.i > longest.j-longest.i {
+			longest = m
+		}
+	}
+
+	if longest == nil {
+		return ""
+	}
+	return longest.warning
+}
+
+func commonPasswordWarning(rank int) string {
+	switch {
+	case rank <= 10:
+		return "This is a top-10 common password"
+	case rank <= 100:
+		return "This is a top-100 common password"
+	default:
+		return "This is a very common password"
+	}
+}
+
+func loadRankedDictionary(words []string) map[string]int {
+	dictionary := make(map[string]int, len(words))
+	for index, word := range words {
+		word = strings.ToLower(word)
+		if _, exists := dictionary[word]; !exists {
+			dictionary[word] = index + 1
+		}
+	}
+	return dictionary
+}
+
+// userDictionary разбивает данные пользователя на слова: "ivan.petrov@mail.ru" дает
+// ivan, petrov, mail и исходную строку целиком
+func userDictionary(userInputs []string) map[string]int {
+	var words []string
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		words = append(words, input)
+		for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if utf8.RuneCountInString(part) >= 3 {
+				words = append(words, part)
+			}
+		}
+	}
+	return loadRankedDictionary(words)
+}
+
+// l33tVariants возвращает варианты пароля с обратными l33t заменами. Для неоднозначных
+// символов (1 -> i или l) строится отдельный вариант на каждую замену.
+func l33tVariants(lower []rune) [][]rune {
+	var variants [][]rune
+	for option := 0; option < 2; option++ {
+		variant := make([]rune, len(lower))
+		changed := false
+		for k, r := range lower {
+			variant[k] = r
+			if replacements, ok := l33tTable[r]; ok {
+				variant[k] = replacements[min(option, len(replacements)-1)]
+				changed = true
+			}
+		}
+		if changed {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+func onKeyboardRow(token string) bool {
+	for _, row := range keyboardRows {
+		if strings.Contains(row, token) || strings.Contains(row, reverseString([]rune(token))) {
+			return true
+		}
+	}
+	return false
+}
+
+// uppercaseVariationsLog10 оценивает, во сколько раз заглавные буквы усложняют слово
+func uppercaseVariationsLog10(word []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	if upper == 0 {
+		return 0
+	}
+
+	// Заглавная первая или последняя буква либо все заглавные - самые частые варианты
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(word[0]) || unicode.IsUpper(word[len(word)-1]))) {
+		return math.Log10(2)
+	}
+
+	variations := 0.0
+	for k := 1; k <= min(upper, lower); k++ {
+		variations += binomial(upper+lower, k)
+	}
+	return math.Log10(variations)
+}
+
+func yearSpace(year, referenceYear int) float64 {
+	return math.Max(math.Abs(float64(year-referenceYear)), 20)
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for d := 1; d <= k; d++ {
+		result = result * float64(n-k+d) / float64(d)
+	}
+	return result
+}
+
+// logAdd возвращает log10(10^a + 10^b)
+func logAdd(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log10(1+math.Pow(10, b-a))
+}
+
+func countDifferences(a, b []rune) int {
+	count := 0
+	for k := range a {
+		if a[k] != b[k] {
+			count++
+		}
+	}
+	return count
+}
+
+func reverseString(runes []rune) string {
+	reversed := make([]rune, len(runes))
+	for k, r := range runes {
+		reversed[len(runes)-1-k] = r
+	}
+	return string(reversed)
+}
+
+// runeRange переводит байтовые границы совпадения регулярного выражения в индексы рун
+func runeRange(text string, loc []int) (int, int) {
+	i := utf8.RuneCountInString(text[:loc[0]])
+	return i, i + utf8.RuneCountInString(text[loc[0]:loc[1]]) - 1
+}
@@ -0,0 +1,136 @@
+package requestctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// HeaderRequestID - заголовок HTTP и ключ gRPC metadata с идентификатором запроса
+const HeaderRequestID = "X-Request-ID"
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+	clientIDKey
+	localeKey
+	clientIPKey
+	userAgentKey
+	impersonatorIDKey
+)
+
+// WithRequestID сохраняет идентификатор запроса в контексте
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID возвращает идентификатор запроса из контекста
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithUserID сохраняет идентификатор аутентифицированного пользователя в контексте
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID возвращает идентификатор пользователя из контекста
+func UserID(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey).(string)
+	return userID
+}
+
+// WithImpersonatorID сохраняет идентификатор администратора, действующего от имени пользователя
+func WithImpersonatorID(ctx context.Context, impersonatorID string) context.Context {
+	return context.WithValue(ctx, impersonatorIDKey, impersonatorID)
+}
+
+// ImpersonatorID возвращает идентификатор администратора из контекста или пустую строку
+func ImpersonatorID(ctx context.Context) string {
+	impersonatorID, _ := ctx.Value(impersonatorIDKey).(string)
+	return impersonatorID
+}
+
+// WithClientID сохраняет идентификатор сервиса, вызвавшего RPC, в контексте
+func WithClientID(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, clientIDKey, clientID)
+}
+
+// ClientID возвращает идентификатор вызвавшего сервиса из контекста
+func ClientID(ctx context.Context) string {
+	clientID, _ := ctx.Value(clientIDKey).(string)
+	return clientID
+}
+
+// WithLocale сохраняет предпочитаемый язык клиента в контексте
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale возвращает предпочитаемый язык клиента из контекста
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey).(string)
+	return locale
+}
+
+// WithClient сохраняет IP адрес и User-Agent клиента в контексте
+func WithClient(ctx context.Context, ipAddress, userAgent string) context.Context {
+	ctx = context.WithValue(ctx, clientIPKey, ipAddress)
+	return context.WithValue(ctx, userAgentKey, userAgent)
+}
+
+// ClientIP возвращает IP адрес клиента из контекста
+func ClientIP(ctx context.Context) string {
+	ipAddress, _ := ctx.Value(clientIPKey).(string)
+	return ipAddress
+}
+
+// UserAgent возвращает User-Agent клиента из контекста
+func UserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey).(string)
+	return userAgent
+}
+
+// ParseAcceptLanguage возвращает основной язык из заголовка Accept-Language,
+// например "ru" для "ru-RU,ru;q=0.9,en;q=0.8"
+func ParseAcceptLanguage(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	lang, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	lang = strings.ToLower(lang)
+
+	if len(lang) < 2 || len(lang) > 3 {
+		return ""
+	}
+	for i := 0; i < len(lang); i++ {
+		if lang[i] < 'a' || lang[i] > 'z' {
+			return ""
+		}
+	}
+	return lang
+}
+
+// NewRequestID генерирует случайный идентификатор запроса
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// IsValidRequestID проверяет идентификатор, пришедший от клиента: не длиннее
+// 128 символов и только печатные ASCII символы без пробелов
+func IsValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
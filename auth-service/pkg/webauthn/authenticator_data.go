@@ -0,0 +1,88 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Флаги authenticator data (WebAuthn §6.1)
+const (
+	flagUserPresent            byte = 0x01
+	flagUserVerified           byte = 0x04
+	flagBackupEligible         byte = 0x08
+	flagBackedUp               byte = 0x10
+	flagAttestedCredentialData byte = 0x40
+)
+
+// maxCredentialIDLength - предельная длина credential ID по спецификации
+const maxCredentialIDLength = 1023
+
+// authenticatorData - разобранные данные аутентификатора
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+
+	// Заполняются только при регистрации (флаг AT)
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+func (d *authenticatorData) userPresent() bool {
+	return d.flags&flagUserPresent != 0
+}
+
+func (d *authenticatorData) userVerified() bool {
+	return d.flags&flagUserVerified != 0
+}
+
+func (d *authenticatorData) backupEligible() bool {
+	return d.flags&flagBackupEligible != 0
+}
+
+func (d *authenticatorData) backedUp() bool {
+	return d.flags&flagBackedUp != 0
+}
+
+// parseAuthenticatorData разбирает authenticator data: хеш RP ID, флаги, счетчик подписей
+// и, если есть, данные новой учетной записи с открытым ключом COSE
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	const headerLength = 32 + 1 + 4
+	if len(data) < headerLength {
+		return nil, fmt.Errorf("%w: authenticator data is too short", ErrMalformedData)
+	}
+
+	authData := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	if authData.flags&flagAttestedCredentialData == 0 {
+		return authData, nil
+	}
+
+	rest := data[headerLength:]
+	if len(rest) < 16+2 {
+		return nil, fmt.Errorf("%w: attested credential data is too short", ErrMalformedData)
+	}
+	authData.aaguid = rest[:16]
+
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLength == 0 || idLength > maxCredentialIDLength || idLength > len(rest) {
+		return nil, fmt.Errorf("%w: invalid credential id length", ErrMalformedData)
+	}
+	authData.credentialID = rest[:idLength]
+	rest = rest[idLength:]
+
+	// Открытый ключ - один элемент CBOR; после него могут идти расширения
+	_, afterKey, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, err
+	}
+	authData.publicKey = rest[:len(rest)-len(afterKey)]
+
+	return authData, nil
+}
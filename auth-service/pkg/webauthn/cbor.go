@@ -0,0 +1,211 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// cborMaxDepth ограничивает вложенность, чтобы специально собранные данные
+// не исчерпали стек
+const cborMaxDepth = 16
+
+// Основные типы CBOR (RFC 8949)
+const (
+	cborUnsigned   = 0
+	cborNegative   = 1
+	cborByteString = 2
+	cborTextString = 3
+	cborArray      = 4
+	cborMap        = 5
+	cborTag        = 6
+	cborSimple     = 7
+)
+
+// decodeCBOR разбирает один элемент CBOR и возвращает его вместе с оставшимися байтами.
+// Поддерживается подмножество, которое используют аутентификаторы (CTAP2 canonical CBOR):
+// целые числа возвращаются как int64, байтовые строки как []byte, текст как string,
+// массивы как []any, словари как map[any]any. Неопределенная длина не поддерживается.
+func decodeCBOR(data []byte) (any, []byte, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, fmt.Errorf("%w: cbor nesting too deep", ErrMalformedData)
+	}
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%w: unexpected end of cbor data", ErrMalformedData)
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+
+	// Простые значения и числа с плавающей точкой кодируют аргумент иначе
+	if major == cborSimple {
+		return decodeCBORSimple(data, info)
+	}
+
+	arg, rest, err := readCBORArgument(data, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: cbor integer overflow", ErrMalformedData)
+		}
+		return int64(arg), rest, nil
+
+	case cborNegative:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: cbor integer overflow", ErrMalformedData)
+		}
+		return -1 - int64(arg), rest, nil
+
+	case cborByteString, cborTextString:
+		if arg > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("%w: cbor string exceeds data", ErrMalformedData)
+		}
+		value := rest[:arg]
+		if major == cborTextString {
+			return string(value), rest[arg:], nil
+		}
+		return append([]byte(nil), value...), rest[arg:], nil
+
+	case cborArray:
+		// Каждый элемент занимает хотя бы байт, поэтому длину можно сверить с остатком
+		if arg > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("%w: cbor array exceeds data", ErrMalformedData)
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item any
+			item, rest, err = decodeCBORItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, rest, nil
+
+	case cborMap:
+		if arg > uint64(len(rest))/2 {
+			return nil, nil, fmt.Errorf("%w: cbor map exceeds data", ErrMalformedData)
+		}
+		items := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value any
+			key, rest, err = decodeCBORItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("%w: unsupported cbor map key", ErrMalformedData)
+			}
+			if _, exists := items[key]; exists {
+				return nil, nil, fmt.Errorf("%w: duplicate cbor map key", ErrMalformedData)
+			}
+			value, rest, err = decodeCBORItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items[key] = value
+		}
+		return items, rest, nil
+
+	default: // cborTag: семантика тегов не нужна, возвращаем помеченное значение
+		return decodeCBORItem(rest, depth+1)
+	}
+}
+
+// readCBORArgument читает аргумент заголовка: значение, длину или число элементов
+func readCBORArgument(data []byte, info byte) (uint64, []byte, error) {
+	rest := data[1:]
+
+	switch {
+	case info < 24:
+		return uint64(info), rest, nil
+	case info == 24:
+		if len(rest) < 1 {
+			break
+		}
+		return uint64(rest[0]), rest[1:], nil
+	case info == 25:
+		if len(rest) < 2 {
+			break
+		}
+		return uint64(binary.BigEndian.Uint16(rest)), rest[2:], nil
+	case info == 26:
+		if len(rest) < 4 {
+			break
+		}
+		return uint64(binary.BigEndian.Uint32(rest)), rest[4:], nil
+	case info == 27:
+		if len(rest) < 8 {
+			break
+		}
+		return binary.BigEndian.Uint64(rest), rest[8:], nil
+	default:
+		return 0, nil, fmt.Errorf("%w: indefinite-length cbor is not supported", ErrMalformedData)
+	}
+
+	return 0, nil, fmt.Errorf("%w: unexpected end of cbor data", ErrMalformedData)
+}
+
+// decodeCBORSimple разбирает false, true, null, undefined и числа с плавающей точкой
+func decodeCBORSimple(data []byte, info byte) (any, []byte, error) {
+	rest := data[1:]
+
+	switch info {
+	case 20:
+		return false, rest, nil
+	case 21:
+		return true, rest, nil
+	case 22, 23:
+		return nil, rest, nil
+	case 25:
+		if len(rest) < 2 {
+			break
+		}
+		return float64(float16ToFloat32(binary.BigEndian.Uint16(rest))), rest[2:], nil
+	case 26:
+		if len(rest) < 4 {
+			break
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(rest))), rest[4:], nil
+	case 27:
+		if len(rest) < 8 {
+			break
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(rest)), rest[8:], nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported cbor simple value", ErrMalformedData)
+	}
+
+	return nil, nil, fmt.Errorf("%w: unexpected end of cbor data", ErrMalformedData)
+}
+
+// float16ToFloat32 переводит число половинной точности (IEEE 754 binary16)
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0:
+		// Денормализованное число
+		value := float32(frac) / (1 << 24)
+		if sign != 0 {
+			return -value
+		}
+		return value
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+	}
+}
@@ -0,0 +1,118 @@
+package webauthn
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("decode hex %q: %v", s, err)
+	}
+	return data
+}
+
+// Примеры из приложения A RFC 8949
+func TestDecodeCBOR_RFC8949Examples(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want any
+	}{
+		{hex: "00", want: int64(0)},
+		{hex: "17", want: int64(23)},
+		{hex: "1818", want: int64(24)},
+		{hex: "1903e8", want: int64(1000)},
+		{hex: "1a000f4240", want: int64(1000000)},
+		{hex: "1b000000e8d4a51000", want: int64(1000000000000)},
+		{hex: "20", want: int64(-1)},
+		{hex: "3863", want: int64(-100)},
+		{hex: "3903e7", want: int64(-1000)},
+		{hex: "4401020304", want: []byte{1, 2, 3, 4}},
+		{hex: "60", want: ""},
+		{hex: "6449455446", want: "IETF"},
+		{hex: "62c3bc", want: "ü"},
+		{hex: "80", want: []any{}},
+		{hex: "83010203", want: []any{int64(1), int64(2), int64(3)}},
+		{hex: "8301820203820405", want: []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}},
+		{hex: "a0", want: map[any]any{}},
+		{hex: "a201020304", want: map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{hex: "a26161016162820203", want: map[any]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{hex: "f4", want: false},
+		{hex: "f5", want: true},
+		{hex: "f6", want: nil},
+		{hex: "f7", want: nil},
+		{hex: "f93c00", want: 1.0},
+		{hex: "f93e00", want: 1.5},
+		{hex: "f97bff", want: 65504.0},
+		{hex: "f90001", want: 5.960464477539063e-08},
+		{hex: "f9c400", want: -4.0},
+		{hex: "f97c00", want: math.Inf(1)},
+		{hex: "fa47c35000", want: 100000.0},
+		{hex: "fb3ff199999999999a", want: 1.1},
+		{hex: "c11a514b67b0", want: int64(1363896240)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			got, rest, err := decodeCBOR(mustHex(t, tt.hex))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(rest) != 0 {
+				t.Fatalf("unexpected trailing data %x", rest)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeCBOR_ReturnsRest(t *testing.T) {
+	got, rest, err := decodeCBOR([]byte{0x01, 0x02, 0x03})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got != int64(1) || !bytes.Equal(rest, []byte{0x02, 0x03}) {
+		t.Fatalf("expected 1 with rest 0203, got %v with rest %x", got, rest)
+	}
+}
+
+func TestDecodeCBOR_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "truncated argument", data: []byte{0x19, 0x03}},
+		{name: "unsigned overflow", data: []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "negative overflow", data: []byte{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "indefinite byte string", data: []byte{0x5f, 0x41, 0x01, 0xff}},
+		{name: "indefinite array", data: []byte{0x9f, 0x01, 0xff}},
+		{name: "string longer than data", data: []byte{0x64, 'I', 'E'}},
+		{name: "array longer than data", data: []byte{0x9a, 0xff, 0xff, 0xff, 0xff}},
+		{name: "missing array item", data: []byte{0x82, 0x01}},
+		{name: "map longer than data", data: []byte{0xa3, 0x01, 0x02}},
+		{name: "missing map value", data: []byte{0xa1, 0x01}},
+		{name: "byte string map key", data: []byte{0xa1, 0x41, 0x01, 0x02}},
+		{name: "duplicate map key", data: []byte{0xa2, 0x01, 0x02, 0x01, 0x03}},
+		{name: "unsupported simple value", data: []byte{0xf8, 0x20}},
+		{name: "truncated float", data: []byte{0xfa, 0x47, 0xc3}},
+		{name: "too deep", data: append(bytes.Repeat([]byte{0x81}, cborMaxDepth+1), 0x00)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeCBOR(tt.data); !errors.Is(err, ErrMalformedData) {
+				t.Fatalf("expected ErrMalformedData, got %v", err)
+			}
+		})
+	}
+}
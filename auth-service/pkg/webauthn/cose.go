@@ -0,0 +1,170 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// Алгоритмы COSE (RFC 9053), которые принимает сервис
+const (
+	AlgorithmES256 int64 = -7
+	AlgorithmEdDSA int64 = -8
+	AlgorithmRS256 int64 = -257
+)
+
+// SupportedAlgorithms - алгоритмы в порядке предпочтения для pubKeyCredParams
+var SupportedAlgorithms = []int64{AlgorithmES256, AlgorithmEdDSA, AlgorithmRS256}
+
+// Параметры COSE_Key (RFC 9052, RFC 9053)
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	// Параметры EC2 и OKP
+	coseKeyCurve = -1
+	coseKeyX     = -2
+	coseKeyY     = -3
+
+	// Параметры RSA
+	coseKeyN = -1
+	coseKeyE = -2
+)
+
+// rsaMinBits - минимальная длина RSA ключа аутентификатора
+const rsaMinBits = 2048
+
+// PublicKey - открытый ключ учетных данных, разобранный из COSE_Key
+type PublicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// ParsePublicKey разбирает открытый ключ в формате COSE_Key, как он хранится в базе
+func ParsePublicKey(coseKey []byte) (*PublicKey, error) {
+	decoded, rest, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing data after cose key", ErrMalformedData)
+	}
+
+	params, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: cose key is not a map", ErrMalformedData)
+	}
+
+	kty, _ := params[int64(coseKeyType)].(int64)
+	alg, _ := params[int64(coseKeyAlgorithm)].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgorithmES256:
+		return parseEC2Key(params)
+	case kty == coseKeyTypeOKP && alg == AlgorithmEdDSA:
+		return parseOKPKey(params)
+	case kty == coseKeyTypeRSA && alg == AlgorithmRS256:
+		return parseRSAKey(params)
+	default:
+		return nil, fmt.Errorf("%w: kty %d, alg %d", ErrUnsupportedAlgorithm, kty, alg)
+	}
+}
+
+// Algorithm возвращает идентификатор алгоритма COSE
+func (k *PublicKey) Algorithm() int64 {
+	return k.algorithm
+}
+
+// Verify проверяет подпись data. ES256 подпись ожидается в DER, как ее отдает аутентификатор.
+func (k *PublicKey) Verify(data, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	return nil
+}
+
+func parseEC2Key(params map[any]any) (*PublicKey, error) {
+	crv, _ := params[int64(coseKeyCurve)].(int64)
+	x, _ := params[int64(coseKeyX)].([]byte)
+	y, _ := params[int64(coseKeyY)].([]byte)
+	if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+		return nil, fmt.Errorf("%w: invalid ec2 key", ErrMalformedData)
+	}
+
+	// ecdh проверяет, что точка лежит на кривой
+	point := append(append([]byte{0x04}, x...), y...)
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, fmt.Errorf("%w: invalid ec2 point", ErrMalformedData)
+	}
+
+	return &PublicKey{
+		algorithm: AlgorithmES256,
+		key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		},
+	}, nil
+}
+
+func parseOKPKey(params map[any]any) (*PublicKey, error) {
+	crv, _ := params[int64(coseKeyCurve)].(int64)
+	x, _ := params[int64(coseKeyX)].([]byte)
+	if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: invalid okp key", ErrMalformedData)
+	}
+
+	return &PublicKey{algorithm: AlgorithmEdDSA, key: ed25519.PublicKey(x)}, nil
+}
+
+func parseRSAKey(params map[any]any) (*PublicKey, error) {
+	n, _ := params[int64(coseKeyN)].([]byte)
+	e, _ := params[int64(coseKeyE)].([]byte)
+	if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("%w: invalid rsa key", ErrMalformedData)
+	}
+
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < rsaMinBits {
+		return nil, fmt.Errorf("%w: rsa key is shorter than %d bits", ErrUnsupportedAlgorithm, rsaMinBits)
+	}
+
+	exponent := 0
+	for _, b := range e {
+		exponent = exponent<<8 | int(b)
+	}
+	if exponent < 3 || exponent%2 == 0 {
+		return nil, fmt.Errorf("%w: invalid rsa exponent", ErrMalformedData)
+	}
+
+	return &PublicKey{algorithm: AlgorithmRS256, key: &rsa.PublicKey{N: modulus, E: exponent}}, nil
+}
@@ -0,0 +1,156 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// Значения, которые браузер передает в clientDataJSON.type
+const (
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+)
+
+// Значения userVerification и residentKey
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+
+	residentKeyRequired = "required"
+)
+
+const publicKeyCredentialType = "public-key"
+
+// Base64URL - байты, которые в JSON передаются строкой base64url без дополнения,
+// как в PublicKeyCredential.toJSON()
+type Base64URL []byte
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return ErrMalformedData
+	}
+	*b = decoded
+	return nil
+}
+
+// CredentialCreationOptions - параметры navigator.credentials.create()
+type CredentialCreationOptions struct {
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Challenge              Base64URL              `json:"challenge"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout,omitempty"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials,omitempty"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// CredentialRequestOptions - параметры navigator.credentials.get()
+type CredentialRequestOptions struct {
+	Challenge        Base64URL              `json:"challenge"`
+	Timeout          int64                  `json:"timeout,omitempty"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string    `json:"type"`
+	ID         Base64URL `json:"id"`
+	Transports []string  `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// RegistrationCredential - результат navigator.credentials.create() в JSON виде
+type RegistrationCredential struct {
+	ID       string              `json:"id"`
+	RawID    Base64URL           `json:"rawId"`
+	Type     string              `json:"type"`
+	Response AttestationResponse `json:"response"`
+}
+
+type AttestationResponse struct {
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AttestationObject Base64URL `json:"attestationObject"`
+	Transports        []string  `json:"transports,omitempty"`
+}
+
+// AssertionCredential - результат navigator.credentials.get() в JSON виде
+type AssertionCredential struct {
+	ID       string            `json:"id"`
+	RawID    Base64URL         `json:"rawId"`
+	Type     string            `json:"type"`
+	Response AssertionResponse `json:"response"`
+}
+
+type AssertionResponse struct {
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AuthenticatorData Base64URL `json:"authenticatorData"`
+	Signature         Base64URL `json:"signature"`
+	UserHandle        Base64URL `json:"userHandle,omitempty"`
+}
+
+// collectedClientData - содержимое clientDataJSON
+type collectedClientData struct {
+	Type        string    `json:"type"`
+	Challenge   Base64URL `json:"challenge"`
+	Origin      string    `json:"origin"`
+	CrossOrigin bool      `json:"crossOrigin"`
+}
+
+// ParseRegistrationCredential разбирает ответ браузера на регистрацию
+func ParseRegistrationCredential(data []byte) (*RegistrationCredential, error) {
+	var credential RegistrationCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, ErrMalformedData
+	}
+	if credential.Type != publicKeyCredentialType || len(credential.RawID) == 0 {
+		return nil, ErrMalformedData
+	}
+	return &credential, nil
+}
+
+// ParseAssertionCredential разбирает ответ браузера на вход
+func ParseAssertionCredential(data []byte) (*AssertionCredential, error) {
+	var credential AssertionCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, ErrMalformedData
+	}
+	if credential.Type != publicKeyCredentialType || len(credential.RawID) == 0 {
+		return nil, ErrMalformedData
+	}
+	return &credential, nil
+}
@@ -0,0 +1,293 @@
+// Package webauthn реализует серверную часть WebAuthn (passkeys): формирует параметры
+// для navigator.credentials.create()/get() и проверяет ответы аутентификаторов.
+// Аттестация не проверяется: сервис запрашивает attestation "none" и не ограничивает
+// модели аутентификаторов, поэтому доверие к ключу строится на подтверждении владения
+// аккаунтом при регистрации.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrMalformedData is returned when the client response cannot be decoded
+	ErrMalformedData = errors.New("malformed webauthn data")
+
+	// ErrUnsupportedAlgorithm is returned when the credential public key uses an algorithm the server does not accept
+	ErrUnsupportedAlgorithm = errors.New("unsupported public key algorithm")
+
+	// ErrInvalidSignature is returned when the assertion signature does not verify
+	ErrInvalidSignature = errors.New("invalid webauthn signature")
+
+	// ErrCeremonyMismatch is returned when the client data type, challenge, origin or RP ID does not match the ceremony
+	ErrCeremonyMismatch = errors.New("webauthn ceremony mismatch")
+
+	// ErrUserNotPresent is returned when the authenticator did not confirm user presence
+	ErrUserNotPresent = errors.New("user presence not confirmed")
+
+	// ErrUserNotVerified is returned when user verification is required but was not performed
+	ErrUserNotVerified = errors.New("user verification not performed")
+)
+
+// challengeLength - длина случайного challenge в байтах
+const challengeLength = 32
+
+// Config - параметры проверяющей стороны (Relying Party)
+type Config struct {
+	// RPID - домен, к которому привязываются ключи (без схемы и порта)
+	RPID string
+	// RPName - название сервиса, которое показывает браузер
+	RPName string
+	// Origins - origin страниц, с которых разрешены церемонии
+	Origins []string
+	// Timeout - время на церемонию в браузере
+	Timeout time.Duration
+	// UserVerification - required, preferred или discouraged
+	UserVerification string
+}
+
+// RelyingParty формирует параметры церемоний и проверяет ответы аутентификаторов
+type RelyingParty struct {
+	config   Config
+	rpIDHash [32]byte
+}
+
+// Credential - учетные данные, проверенные при регистрации
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte
+	Algorithm      int64
+	SignCount      uint32
+	AAGUID         []byte
+	Transports     []string
+	UserVerified   bool
+	BackupEligible bool
+	BackedUp       bool
+}
+
+// Assertion - результат проверки входа
+type Assertion struct {
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+	UserVerified bool
+	BackedUp     bool
+}
+
+// NewRelyingParty проверяет конфигурацию и создает RelyingParty
+func NewRelyingParty(config Config) (*RelyingParty, error) {
+	if config.RPID == "" {
+		return nil, errors.New("webauthn: rp id is required")
+	}
+	if len(config.Origins) == 0 {
+		return nil, errors.New("webauthn: at least one origin is required")
+	}
+	if config.RPName == "" {
+		config.RPName = config.RPID
+	}
+
+	switch config.UserVerification {
+	case "":
+		config.UserVerification = UserVerificationPreferred
+	case UserVerificationRequired, UserVerificationPreferred, UserVerificationDiscouraged:
+	default:
+		return nil, fmt.Errorf("webauthn: unknown user verification requirement %q", config.UserVerification)
+	}
+
+	return &RelyingParty{
+		config:   config,
+		rpIDHash: sha256.Sum256([]byte(config.RPID)),
+	}, nil
+}
+
+// Timeout возвращает время на церемонию
+func (rp *RelyingParty) Timeout() time.Duration {
+	return rp.config.Timeout
+}
+
+// NewChallenge создает случайный challenge для одной церемонии
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// CreationOptions формирует параметры регистрации. Ключ создается как discoverable,
+// чтобы входить без ввода email; exclude не дает зарегистрировать один аутентификатор дважды.
+func (rp *RelyingParty) CreationOptions(challenge []byte, user UserEntity, exclude []CredentialDescriptor) *CredentialCreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: publicKeyCredentialType, Algorithm: alg})
+	}
+
+	return &CredentialCreationOptions{
+		RP:                 RelyingPartyEntity{ID: rp.config.RPID, Name: rp.config.RPName},
+		User:               user,
+		Challenge:          challenge,
+		PubKeyCredParams:   params,
+		Timeout:            rp.config.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        residentKeyRequired,
+			RequireResidentKey: true,
+			UserVerification:   rp.config.UserVerification,
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions формирует параметры входа. Пустой allow означает, что браузер
+// предложит любой discoverable ключ этого RP ID.
+func (rp *RelyingParty) RequestOptions(challenge []byte, allow []CredentialDescriptor) *CredentialRequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+
+	return &CredentialRequestOptions{
+		Challenge:        challenge,
+		Timeout:          rp.config.Timeout.Milliseconds(),
+		RPID:             rp.config.RPID,
+		AllowCredentials: allow,
+		UserVerification: rp.config.UserVerification,
+	}
+}
+
+// NewCredentialDescriptor описывает зарегистрированный ключ для exclude/allow списков
+func NewCredentialDescriptor(id []byte, transports []string) CredentialDescriptor {
+	return CredentialDescriptor{Type: publicKeyCredentialType, ID: id, Transports: transports}
+}
+
+// VerifyRegistration проверяет ответ на navigator.credentials.create() для выданного challenge
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, credential *RegistrationCredential) (*Credential, error) {
+	if err := rp.verifyClientData(credential.Response.ClientDataJSON, clientDataTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, rest, err := decodeCBOR(credential.Response.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: invalid attestation object", ErrMalformedData)
+	}
+
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: attestation object has no authData", ErrMalformedData)
+	}
+
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, fmt.Errorf("%w: no attested credential data", ErrMalformedData)
+	}
+	if !bytes.Equal(authData.credentialID, credential.RawID) {
+		return nil, fmt.Errorf("%w: credential id mismatch", ErrCeremonyMismatch)
+	}
+
+	publicKey, err := ParsePublicKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:             slices.Clone(authData.credentialID),
+		PublicKey:      slices.Clone(authData.publicKey),
+		Algorithm:      publicKey.Algorithm(),
+		SignCount:      authData.signCount,
+		AAGUID:         slices.Clone(authData.aaguid),
+		Transports:     credential.Response.Transports,
+		UserVerified:   authData.userVerified(),
+		BackupEligible: authData.backupEligible(),
+		BackedUp:       authData.backedUp(),
+	}, nil
+}
+
+// VerifyAssertion проверяет ответ на navigator.credentials.get() ключом publicKey
+// (COSE_Key, сохраненный при регистрации). Счетчик подписей сверяет вызывающий.
+func (rp *RelyingParty) VerifyAssertion(challenge []byte, credential *AssertionCredential, publicKey []byte) (*Assertion, error) {
+	if err := rp.verifyClientData(credential.Response.ClientDataJSON, clientDataTypeGet, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := rp.verifyAuthenticatorData(credential.Response.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Подписываются authenticatorData || SHA-256(clientDataJSON)
+	clientDataHash := sha256.Sum256(credential.Response.ClientDataJSON)
+	signed := append(slices.Clone([]byte(credential.Response.AuthenticatorData)), clientDataHash[:]...)
+	if err := key.Verify(signed, credential.Response.Signature); err != nil {
+		return nil, err
+	}
+
+	return &Assertion{
+		CredentialID: credential.RawID,
+		UserHandle:   credential.Response.UserHandle,
+		SignCount:    authData.signCount,
+		UserVerified: authData.userVerified(),
+		BackedUp:     authData.backedUp(),
+	}, nil
+}
+
+// verifyClientData сверяет тип церемонии, challenge и origin из clientDataJSON
+func (rp *RelyingParty) verifyClientData(raw []byte, ceremonyType string, challenge []byte) error {
+	var clientData collectedClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return fmt.Errorf("%w: invalid client data", ErrMalformedData)
+	}
+
+	if clientData.Type != ceremonyType {
+		return fmt.Errorf("%w: unexpected client data type %q", ErrCeremonyMismatch, clientData.Type)
+	}
+	if subtle.ConstantTimeCompare(clientData.Challenge, challenge) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrCeremonyMismatch)
+	}
+	if !slices.Contains(rp.config.Origins, clientData.Origin) {
+		return fmt.Errorf("%w: origin %q is not allowed", ErrCeremonyMismatch, clientData.Origin)
+	}
+	if clientData.CrossOrigin {
+		return fmt.Errorf("%w: cross-origin ceremony", ErrCeremonyMismatch)
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData разбирает authenticator data и проверяет RP ID и флаги пользователя
+func (rp *RelyingParty) verifyAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	authData, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rp.rpIDHash[:]) != 1 {
+		return nil, fmt.Errorf("%w: rp id hash mismatch", ErrCeremonyMismatch)
+	}
+	if !authData.userPresent() {
+		return nil, ErrUserNotPresent
+	}
+	if rp.config.UserVerification == UserVerificationRequired && !authData.userVerified() {
+		return nil, ErrUserNotVerified
+	}
+
+	return authData, nil
+}
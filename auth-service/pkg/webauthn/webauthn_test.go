@@ -0,0 +1,689 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+// cborPair - пара ключ-значение словаря CBOR; порядок пар сохраняется при кодировании
+type cborPair struct {
+	key   any
+	value any
+}
+
+type cborPairs []cborPair
+
+// encodeCBOR кодирует значения, из которых аутентификатор собирает свои ответы
+func encodeCBOR(value any) []byte {
+	switch v := value.(type) {
+	case int:
+		return encodeCBOR(int64(v))
+	case int64:
+		if v >= 0 {
+			return cborHeader(cborUnsigned, uint64(v))
+		}
+		return cborHeader(cborNegative, uint64(-1-v))
+	case []byte:
+		return append(cborHeader(cborByteString, uint64(len(v))), v...)
+	case string:
+		return append(cborHeader(cborTextString, uint64(len(v))), v...)
+	case cborPairs:
+		encoded := cborHeader(cborMap, uint64(len(v)))
+		for _, pair := range v {
+			encoded = append(encoded, encodeCBOR(pair.key)...)
+			encoded = append(encoded, encodeCBOR(pair.value)...)
+		}
+		return encoded
+	default:
+		panic("unsupported cbor value")
+	}
+}
+
+func cborHeader(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, arg)
+	}
+}
+
+// testAuthenticator - программный аутентификатор с одним ключом
+type testAuthenticator struct {
+	signer       crypto.Signer
+	credentialID []byte
+	coseKey      []byte
+}
+
+func newTestAuthenticator(t *testing.T, alg int64) *testAuthenticator {
+	t.Helper()
+
+	authenticator := &testAuthenticator{credentialID: make([]byte, 16)}
+	rand.Read(authenticator.credentialID)
+
+	switch alg {
+	case AlgorithmES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("generate ecdsa key: %v", err)
+		}
+		point, err := key.PublicKey.ECDH()
+		if err != nil {
+			t.Fatalf("ecdh key: %v", err)
+		}
+		raw := point.Bytes()
+		authenticator.signer = key
+		authenticator.coseKey = encodeCBOR(cborPairs{
+			{coseKeyType, coseKeyTypeEC2},
+			{coseKeyAlgorithm, AlgorithmES256},
+			{coseKeyCurve, coseCurveP256},
+			{coseKeyX, raw[1:33]},
+			{coseKeyY, raw[33:]},
+		})
+	case AlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generate ed25519 key: %v", err)
+		}
+		authenticator.signer = private
+		authenticator.coseKey = encodeCBOR(cborPairs{
+			{coseKeyType, coseKeyTypeOKP},
+			{coseKeyAlgorithm, AlgorithmEdDSA},
+			{coseKeyCurve, coseCurveEd25519},
+			{coseKeyX, []byte(public)},
+		})
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaMinBits)
+		if err != nil {
+			t.Fatalf("generate rsa key: %v", err)
+		}
+		authenticator.signer = key
+		authenticator.coseKey = rsaCOSEKey(key.N, key.E)
+	default:
+		t.Fatalf("unsupported algorithm %d", alg)
+	}
+
+	return authenticator
+}
+
+func rsaCOSEKey(n *big.Int, e int) []byte {
+	return encodeCBOR(cborPairs{
+		{coseKeyType, coseKeyTypeRSA},
+		{coseKeyAlgorithm, AlgorithmRS256},
+		{coseKeyN, n.Bytes()},
+		{coseKeyE, big.NewInt(int64(e)).Bytes()},
+	})
+}
+
+func (a *testAuthenticator) sign(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var (
+		signature []byte
+		err       error
+	)
+	switch key := a.signer.(type) {
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, data)
+	default:
+		digest := sha256.Sum256(data)
+		signature, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signature
+}
+
+// ceremony - данные, которые браузер и аутентификатор вкладывают в ответ.
+// Тест портит одно поле, чтобы проверить соответствующую проверку сервера.
+type ceremony struct {
+	clientDataType string
+	challenge      []byte
+	origin         string
+	crossOrigin    bool
+	rpID           string
+	flags          byte
+	signCount      uint32
+}
+
+func newCeremony(clientDataType string, challenge []byte) ceremony {
+	return ceremony{
+		clientDataType: clientDataType,
+		challenge:      challenge,
+		origin:         testOrigin,
+		rpID:           testRPID,
+		flags:          flagUserPresent | flagUserVerified,
+		signCount:      1,
+	}
+}
+
+func (c ceremony) clientDataJSON(t *testing.T) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(collectedClientData{
+		Type:        c.clientDataType,
+		Challenge:   c.challenge,
+		Origin:      c.origin,
+		CrossOrigin: c.crossOrigin,
+	})
+	if err != nil {
+		t.Fatalf("marshal client data: %v", err)
+	}
+	return data
+}
+
+func (c ceremony) authenticatorData(attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+	data := append(rpIDHash[:], c.flags)
+	data = binary.BigEndian.AppendUint32(data, c.signCount)
+	return append(data, attested...)
+}
+
+// register собирает ответ navigator.credentials.create() с attestation "none"
+func (a *testAuthenticator) register(t *testing.T, c ceremony) *RegistrationCredential {
+	t.Helper()
+
+	attested := make([]byte, 16) // AAGUID
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, a.coseKey...)
+
+	c.flags |= flagAttestedCredentialData
+	attestationObject := encodeCBOR(cborPairs{
+		{"fmt", "none"},
+		{"attStmt", cborPairs{}},
+		{"authData", c.authenticatorData(attested)},
+	})
+
+	return &RegistrationCredential{
+		RawID: a.credentialID,
+		Type:  publicKeyCredentialType,
+		Response: AttestationResponse{
+			ClientDataJSON:    c.clientDataJSON(t),
+			AttestationObject: attestationObject,
+			Transports:        []string{"internal"},
+		},
+	}
+}
+
+// assert собирает подписанный ответ navigator.credentials.get()
+func (a *testAuthenticator) assert(t *testing.T, c ceremony) *AssertionCredential {
+	t.Helper()
+
+	clientDataJSON := c.clientDataJSON(t)
+	authData := c.authenticatorData(nil)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+
+	return &AssertionCredential{
+		RawID: a.credentialID,
+		Type:  publicKeyCredentialType,
+		Response: AssertionResponse{
+			ClientDataJSON:    clientDataJSON,
+			AuthenticatorData: authData,
+			Signature:         a.sign(t, append(bytes.Clone(authData), clientDataHash[:]...)),
+			UserHandle:        []byte("user-handle"),
+		},
+	}
+}
+
+func newTestRelyingParty(t *testing.T, userVerification string) *RelyingParty {
+	t.Helper()
+
+	rp, err := NewRelyingParty(Config{
+		RPID:             testRPID,
+		Origins:          []string{testOrigin},
+		UserVerification: userVerification,
+	})
+	if err != nil {
+		t.Fatalf("new relying party: %v", err)
+	}
+	return rp
+}
+
+func TestVerifyRegistration_Algorithms(t *testing.T) {
+	for _, alg := range SupportedAlgorithms {
+		authenticator := newTestAuthenticator(t, alg)
+		rp := newTestRelyingParty(t, UserVerificationPreferred)
+		challenge, _ := NewChallenge()
+
+		response := authenticator.register(t, newCeremony(clientDataTypeCreate, challenge))
+
+		// Ответ проходит через JSON так же, как приходит от браузера
+		body, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("marshal credential: %v", err)
+		}
+		parsed, err := ParseRegistrationCredential(body)
+		if err != nil {
+			t.Fatalf("parse credential: %v", err)
+		}
+
+		credential, err := rp.VerifyRegistration(challenge, parsed)
+		if err != nil {
+			t.Fatalf("alg %d: verify registration: %v", alg, err)
+		}
+		if credential.Algorithm != alg || !bytes.Equal(credential.ID, authenticator.credentialID) ||
+			!bytes.Equal(credential.PublicKey, authenticator.coseKey) || !credential.UserVerified || credential.SignCount != 1 {
+			t.Fatalf("alg %d: unexpected credential %+v", alg, credential)
+		}
+	}
+}
+
+func TestVerifyRegistration_Rejects(t *testing.T) {
+	authenticator := newTestAuthenticator(t, AlgorithmES256)
+	challenge, _ := NewChallenge()
+	otherChallenge, _ := NewChallenge()
+
+	tests := []struct {
+		name             string
+		userVerification string
+		modify           func(c *ceremony)
+		modifyResponse   func(r *RegistrationCredential)
+		wantErr          error
+	}{
+		{
+			name:    "assertion client data type",
+			modify:  func(c *ceremony) { c.clientDataType = clientDataTypeGet },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "other challenge",
+			modify:  func(c *ceremony) { c.challenge = otherChallenge },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "foreign origin",
+			modify:  func(c *ceremony) { c.origin = "https://evil.example" },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "cross-origin iframe",
+			modify:  func(c *ceremony) { c.crossOrigin = true },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "other rp id",
+			modify:  func(c *ceremony) { c.rpID = "evil.example" },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "user not present",
+			modify:  func(c *ceremony) { c.flags &^= flagUserPresent },
+			wantErr: ErrUserNotPresent,
+		},
+		{
+			name:             "user verification required",
+			userVerification: UserVerificationRequired,
+			modify:           func(c *ceremony) { c.flags &^= flagUserVerified },
+			wantErr:          ErrUserNotVerified,
+		},
+		{
+			name:           "raw id differs from attested credential",
+			modifyResponse: func(r *RegistrationCredential) { r.RawID = []byte("other-credential") },
+			wantErr:        ErrCeremonyMismatch,
+		},
+		{
+			name:           "attestation object is not a map",
+			modifyResponse: func(r *RegistrationCredential) { r.Response.AttestationObject = encodeCBOR("none") },
+			wantErr:        ErrMalformedData,
+		},
+		{
+			name: "attestation object without attested credential",
+			modifyResponse: func(r *RegistrationCredential) {
+				r.Response.AttestationObject = encodeCBOR(cborPairs{
+					{"fmt", "none"},
+					{"authData", newCeremony(clientDataTypeCreate, challenge).authenticatorData(nil)},
+				})
+			},
+			wantErr: ErrMalformedData,
+		},
+		{
+			name: "trailing data after attestation object",
+			modifyResponse: func(r *RegistrationCredential) {
+				r.Response.AttestationObject = append(r.Response.AttestationObject, 0x00)
+			},
+			wantErr: ErrMalformedData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCeremony(clientDataTypeCreate, challenge)
+			if tt.modify != nil {
+				tt.modify(&c)
+			}
+			response := authenticator.register(t, c)
+			if tt.modifyResponse != nil {
+				tt.modifyResponse(response)
+			}
+
+			rp := newTestRelyingParty(t, tt.userVerification)
+			if _, err := rp.VerifyRegistration(challenge, response); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyAssertion_Algorithms(t *testing.T) {
+	for _, alg := range SupportedAlgorithms {
+		authenticator := newTestAuthenticator(t, alg)
+		rp := newTestRelyingParty(t, UserVerificationPreferred)
+		challenge, _ := NewChallenge()
+
+		c := newCeremony(clientDataTypeGet, challenge)
+		c.signCount = 42
+		response := authenticator.assert(t, c)
+
+		body, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("marshal credential: %v", err)
+		}
+		parsed, err := ParseAssertionCredential(body)
+		if err != nil {
+			t.Fatalf("parse credential: %v", err)
+		}
+
+		assertion, err := rp.VerifyAssertion(challenge, parsed, authenticator.coseKey)
+		if err != nil {
+			t.Fatalf("alg %d: verify assertion: %v", alg, err)
+		}
+		if assertion.SignCount != 42 || !assertion.UserVerified ||
+			!bytes.Equal(assertion.CredentialID, authenticator.credentialID) || string(assertion.UserHandle) != "user-handle" {
+			t.Fatalf("alg %d: unexpected assertion %+v", alg, assertion)
+		}
+	}
+}
+
+func TestVerifyAssertion_Rejects(t *testing.T) {
+	authenticator := newTestAuthenticator(t, AlgorithmES256)
+	otherAuthenticator := newTestAuthenticator(t, AlgorithmES256)
+	challenge, _ := NewChallenge()
+	otherChallenge, _ := NewChallenge()
+
+	tests := []struct {
+		name             string
+		userVerification string
+		modify           func(c *ceremony)
+		modifyResponse   func(t *testing.T, r *AssertionCredential)
+		storedKey        []byte
+		wantErr          error
+	}{
+		{
+			name:    "registration client data type",
+			modify:  func(c *ceremony) { c.clientDataType = clientDataTypeCreate },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "replayed assertion for another challenge",
+			modify:  func(c *ceremony) { c.challenge = otherChallenge },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "foreign origin",
+			modify:  func(c *ceremony) { c.origin = "https://evil.example" },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "other rp id",
+			modify:  func(c *ceremony) { c.rpID = "evil.example" },
+			wantErr: ErrCeremonyMismatch,
+		},
+		{
+			name:    "user not present",
+			modify:  func(c *ceremony) { c.flags &^= flagUserPresent },
+			wantErr: ErrUserNotPresent,
+		},
+		{
+			name:             "user verification required",
+			userVerification: UserVerificationRequired,
+			modify:           func(c *ceremony) { c.flags &^= flagUserVerified },
+			wantErr:          ErrUserNotVerified,
+		},
+		{
+			name:      "signed by another key",
+			storedKey: otherAuthenticator.coseKey,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name: "authenticator data changed after signing",
+			modifyResponse: func(t *testing.T, r *AssertionCredential) {
+				r.Response.AuthenticatorData[len(r.Response.AuthenticatorData)-1]++
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "client data changed after signing",
+			modifyResponse: func(t *testing.T, r *AssertionCredential) {
+				r.Response.ClientDataJSON = bytes.Replace(r.Response.ClientDataJSON, []byte(`"crossOrigin":false`), []byte(`"crossOrigin":false `), 1)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "truncated authenticator data",
+			modifyResponse: func(t *testing.T, r *AssertionCredential) {
+				r.Response.AuthenticatorData = r.Response.AuthenticatorData[:36]
+			},
+			wantErr: ErrMalformedData,
+		},
+		{
+			name:      "stored key uses unsupported algorithm",
+			storedKey: encodeCBOR(cborPairs{{coseKeyType, coseKeyTypeEC2}, {coseKeyAlgorithm, -35}}),
+			wantErr:   ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCeremony(clientDataTypeGet, challenge)
+			if tt.modify != nil {
+				tt.modify(&c)
+			}
+			response := authenticator.assert(t, c)
+			if tt.modifyResponse != nil {
+				tt.modifyResponse(t, response)
+			}
+
+			storedKey := authenticator.coseKey
+			if tt.storedKey != nil {
+				storedKey = tt.storedKey
+			}
+
+			rp := newTestRelyingParty(t, tt.userVerification)
+			if _, err := rp.VerifyAssertion(challenge, response, storedKey); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParsePublicKey_Rejects(t *testing.T) {
+	es256 := newTestAuthenticator(t, AlgorithmES256)
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	strongRSA := newTestAuthenticator(t, AlgorithmRS256).signer.(*rsa.PrivateKey)
+
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	y[31] = 1
+
+	tests := []struct {
+		name    string
+		key     []byte
+		wantErr error
+	}{
+		{
+			name:    "not a map",
+			key:     encodeCBOR("key"),
+			wantErr: ErrMalformedData,
+		},
+		{
+			name:    "trailing data",
+			key:     append(bytes.Clone(es256.coseKey), 0x00),
+			wantErr: ErrMalformedData,
+		},
+		{
+			name:    "ec2 key with eddsa algorithm",
+			key:     encodeCBOR(cborPairs{{coseKeyType, coseKeyTypeEC2}, {coseKeyAlgorithm, AlgorithmEdDSA}}),
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "ec2 point not on curve",
+			key: encodeCBOR(cborPairs{
+				{coseKeyType, coseKeyTypeEC2}, {coseKeyAlgorithm, AlgorithmES256},
+				{coseKeyCurve, coseCurveP256}, {coseKeyX, x}, {coseKeyY, y},
+			}),
+			wantErr: ErrMalformedData,
+		},
+		{
+			name: "ec2 key on other curve",
+			key: encodeCBOR(cborPairs{
+				{coseKeyType, coseKeyTypeEC2}, {coseKeyAlgorithm, AlgorithmES256},
+				{coseKeyCurve, 2}, {coseKeyX, x}, {coseKeyY, y},
+			}),
+			wantErr: ErrMalformedData,
+		},
+		{
+			name: "short okp key",
+			key: encodeCBOR(cborPairs{
+				{coseKeyType, coseKeyTypeOKP}, {coseKeyAlgorithm, AlgorithmEdDSA},
+				{coseKeyCurve, coseCurveEd25519}, {coseKeyX, x[:31]},
+			}),
+			wantErr: ErrMalformedData,
+		},
+		{
+			name:    "rsa key shorter than 2048 bits",
+			key:     rsaCOSEKey(weakRSA.N, weakRSA.E),
+			wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name:    "rsa even exponent",
+			key:     rsaCOSEKey(strongRSA.N, 65536),
+			wantErr: ErrMalformedData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePublicKey(tt.key); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseAuthenticatorData(t *testing.T) {
+	authenticator := newTestAuthenticator(t, AlgorithmEdDSA)
+	c := newCeremony(clientDataTypeCreate, nil)
+
+	attested := func(idLength uint16, id, tail []byte) []byte {
+		data := binary.BigEndian.AppendUint16(make([]byte, 16), idLength)
+		return append(append(data, id...), tail...)
+	}
+	withAttested := func(flags byte, attestedData []byte) []byte {
+		c := c
+		c.flags = flags | flagAttestedCredentialData
+		return c.authenticatorData(attestedData)
+	}
+
+	// Расширения (флаг ED) идут после ключа и не должны попасть в сохраненный ключ
+	extensions := encodeCBOR(cborPairs{{"credProtect", 2}})
+	withExtensions := withAttested(flagUserPresent|0x80, attested(uint16(len(authenticator.credentialID)), authenticator.credentialID, append(bytes.Clone(authenticator.coseKey), extensions...)))
+
+	t.Run("public key without extensions", func(t *testing.T) {
+		authData, err := parseAuthenticatorData(withExtensions)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if !bytes.Equal(authData.publicKey, authenticator.coseKey) || !bytes.Equal(authData.credentialID, authenticator.credentialID) {
+			t.Fatalf("unexpected attested credential data")
+		}
+	})
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "shorter than header", data: c.authenticatorData(nil)[:36]},
+		{name: "attested data too short", data: withAttested(flagUserPresent, make([]byte, 17))},
+		{name: "empty credential id", data: withAttested(flagUserPresent, attested(0, nil, authenticator.coseKey))},
+		{name: "credential id longer than data", data: withAttested(flagUserPresent, attested(64, authenticator.credentialID, nil))},
+		{name: "credential id over the limit", data: withAttested(flagUserPresent, attested(maxCredentialIDLength+1, make([]byte, maxCredentialIDLength+1), authenticator.coseKey))},
+		{name: "missing public key", data: withAttested(flagUserPresent, attested(uint16(len(authenticator.credentialID)), authenticator.credentialID, nil))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseAuthenticatorData(tt.data); !errors.Is(err, ErrMalformedData) {
+				t.Fatalf("expected ErrMalformedData, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseCredential_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "not json", body: "{"},
+		{name: "wrong type", body: `{"type":"password","rawId":"AQID"}`},
+		{name: "empty raw id", body: `{"type":"public-key","rawId":""}`},
+		{name: "raw id is not base64url", body: `{"type":"public-key","rawId":"***"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRegistrationCredential([]byte(tt.body)); !errors.Is(err, ErrMalformedData) {
+				t.Fatalf("registration: expected ErrMalformedData, got %v", err)
+			}
+			if _, err := ParseAssertionCredential([]byte(tt.body)); !errors.Is(err, ErrMalformedData) {
+				t.Fatalf("assertion: expected ErrMalformedData, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewRelyingParty_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "missing rp id", config: Config{Origins: []string{testOrigin}}},
+		{name: "missing origins", config: Config{RPID: testRPID}},
+		{name: "unknown user verification", config: Config{RPID: testRPID, Origins: []string{testOrigin}, UserVerification: "always"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRelyingParty(tt.config); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
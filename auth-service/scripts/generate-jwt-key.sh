@@ -0,0 +1,31 @@
+#!/bin/bash
+
+# Генерирует новый ключ подписи access токенов в каталоге JWT_KEYS_DIR.
+# Имя файла (kid) строится из даты, поэтому новый ключ автоматически
+# становится ключом подписи, а старые продолжают проверять токены.
+#
+# Использование: ./scripts/generate-jwt-key.sh [keys_dir] [ed25519|rsa]
+
+set -e
+
+KEYS_DIR=${1:-${JWT_KEYS_DIR:-./keys}}
+KEY_TYPE=${2:-ed25519}
+KID=$(date -u +%Y%m%dT%H%M%SZ)
+
+mkdir -p "$KEYS_DIR"
+
+case "$KEY_TYPE" in
+  ed25519)
+    openssl genpkey -algorithm ed25519 -out "$KEYS_DIR/$KID.pem"
+    ;;
+  rsa)
+    openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:3072 -out "$KEYS_DIR/$KID.pem"
+    ;;
+  *)
+    echo "Unknown key type: $KEY_TYPE (expected ed25519 or rsa)"
+    exit 1
+    ;;
+esac
+
+chmod 600 "$KEYS_DIR/$KID.pem"
+echo "✅ Generated $KEY_TYPE key $KEYS_DIR/$KID.pem (kid: $KID)"